@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/role"
+)
+
+// sama dengan binding RegisterRequest.Password (min=6)
+const minPasswordLength = 6
+
+var ErrPasswordTooShort = fmt.Errorf("password must be at least %d characters", minPasswordLength)
+
+type createUserInput struct {
+	Username string
+	Email    string
+	FullName string
+	Password string
+	Role     string
+}
+
+// createUser memakai service yang sama dengan API (Register + AssignRoleToUser)
+// sehingga validasi & hashing password identik.
+func createUser(
+	ctx context.Context,
+	authService auth.Service,
+	roleService role.Service,
+	in createUserInput,
+) (*auth.RegisterResponse, error) {
+	if len(in.Password) < minPasswordLength {
+		return nil, ErrPasswordTooShort
+	}
+
+	r, err := roleService.GetRoleByCode(ctx, in.Role)
+	if err != nil {
+		if errors.Is(err, role.ErrRoleNotFound) {
+			return nil, fmt.Errorf("role %q not found", in.Role)
+		}
+		return nil, err
+	}
+
+	fullName := in.FullName
+	if fullName == "" {
+		fullName = in.Username
+	}
+
+	user, err := authService.Register(ctx, auth.RegisterRequest{
+		Username: in.Username,
+		Email:    in.Email,
+		Password: in.Password,
+		FullName: fullName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// dibuat dari CLI: tidak ada actor di ctx, assigned_by NULL
+	if _, err := authService.AssignRoleToUser(ctx, user.ID, r.ID); err != nil {
+		return nil, fmt.Errorf("assign role %q: %w", in.Role, err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-mini-erp/internal/auth"
+	authmocks "go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/role"
+	rolemocks "go-mini-erp/internal/role/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// directTx menjalankan fn langsung; transaksi sebenarnya dibuka di run
+type directTx struct{}
+
+func (directTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	return fn(nil)
+}
+
+func newServices(ctrl *gomock.Controller) (*authmocks.MockRepository, *rolemocks.MockRepository, auth.Service, role.Service) {
+	authRepo := authmocks.NewMockRepository(ctrl)
+	roleRepo := rolemocks.NewMockRepository(ctrl)
+	return authRepo, roleRepo, auth.NewService(authRepo, directTx{}, nil, nil, "", nil, nil, nil), role.NewService(roleRepo, nil, nil)
+}
+
+func TestCreateUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authRepo, roleRepo, authService, roleService := newServices(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	roleID := uuid.New()
+
+	roleRepo.EXPECT().GetRoleByCode(ctx, "admin").
+		Return(db.Role{ID: roleID, Code: "admin", Name: "Administrator"}, nil)
+
+	authRepo.EXPECT().CheckUsernameExists(ctx, "ops").Return(false, nil)
+	authRepo.EXPECT().CheckEmailExists(ctx, "ops@example.com").Return(false, nil)
+	authRepo.EXPECT().WithQuerier(gomock.Any()).Return(authRepo)
+	authRepo.EXPECT().
+		CreateUser(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+			// password di-hash dengan bcrypt, bukan plaintext
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(arg.PasswordHash), []byte("s3cret!")))
+			assert.Equal(t, "ops", arg.FullName)
+			return db.CreateUserRow{
+				ID:        userID,
+				Username:  arg.Username,
+				Email:     arg.Email,
+				FullName:  arg.FullName,
+				IsActive:  arg.IsActive,
+				CreatedAt: dbutil.TimeToPgTime(time.Now()),
+			}, nil
+		})
+
+	authRepo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{ID: userID}, nil)
+	authRepo.EXPECT().GetRoleByID(ctx, roleID).Return(db.Role{ID: roleID, Code: "admin"}, nil)
+	authRepo.EXPECT().
+		AssignRoleToUser(ctx, db.AssignRoleToUserParams{
+			UserID:     userID,
+			RoleID:     roleID,
+			AssignedBy: dbutil.UUIDPtrToPgUUID(nil),
+		}).
+		Return(db.AssignRoleToUserRow{UserID: userID, RoleID: roleID}, nil)
+
+	user, err := createUser(ctx, authService, roleService, createUserInput{
+		Username: "ops",
+		Email:    "ops@example.com",
+		Password: "s3cret!",
+		Role:     "admin",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+}
+
+func TestCreateUser_RoleNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, roleRepo, authService, roleService := newServices(ctrl)
+
+	roleRepo.EXPECT().GetRoleByCode(gomock.Any(), "ghost").Return(db.Role{}, pgx.ErrNoRows)
+
+	user, err := createUser(context.Background(), authService, roleService, createUserInput{
+		Username: "ops",
+		Email:    "ops@example.com",
+		Password: "s3cret!",
+		Role:     "ghost",
+	})
+
+	assert.ErrorContains(t, err, `role "ghost" not found`)
+	assert.Nil(t, user)
+}
+
+func TestCreateUser_PasswordTooShort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, _, authService, roleService := newServices(ctrl)
+
+	_, err := createUser(context.Background(), authService, roleService, createUserInput{
+		Username: "ops",
+		Email:    "ops@example.com",
+		Password: "123",
+		Role:     "admin",
+	})
+
+	assert.ErrorIs(t, err, ErrPasswordTooShort)
+}
+
+func TestCreateUser_UsernameExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authRepo, roleRepo, authService, roleService := newServices(ctrl)
+
+	roleRepo.EXPECT().GetRoleByCode(gomock.Any(), "admin").Return(db.Role{ID: uuid.New()}, nil)
+	authRepo.EXPECT().WithQuerier(gomock.Any()).Return(authRepo)
+	authRepo.EXPECT().CheckUsernameExists(gomock.Any(), "ops").Return(true, nil)
+
+	_, err := createUser(context.Background(), authService, roleService, createUserInput{
+		Username: "ops",
+		Email:    "ops@example.com",
+		Password: "s3cret!",
+		Role:     "admin",
+	})
+
+	assert.ErrorIs(t, err, auth.ErrUsernameExists)
+}
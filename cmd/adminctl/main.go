@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"golang.org/x/term"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+const usage = `Usage: adminctl <command> [flags]
+
+Commands:
+  create-user --username <name> --email <email> [--full-name <name>] [--role <code>]
+              prompts for the password (read from stdin when not a terminal)`
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "create-user":
+		err = runCreateUser(os.Args[2:])
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runCreateUser(args []string) error {
+	fs := flag.NewFlagSet("create-user", flag.ExitOnError)
+	in := createUserInput{}
+	fs.StringVar(&in.Username, "username", "", "username (required)")
+	fs.StringVar(&in.Email, "email", "", "email (required)")
+	fs.StringVar(&in.FullName, "full-name", "", "full name (default: username)")
+	fs.StringVar(&in.Role, "role", "user", "role code to assign")
+	_ = fs.Parse(args)
+
+	if in.Username == "" || in.Email == "" {
+		fs.Usage()
+		return errors.New("--username and --email are required")
+	}
+
+	dbUrl := os.Getenv("DB_URL")
+	if dbUrl == "" {
+		return errors.New("DB_URL environment variable is required")
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+	in.Password = password
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, dbUrl)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	// user + role dibuat dalam satu transaksi
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	queries := db.New(tx)
+	// tanpa default role: role dipilih eksplisit lewat --role
+	authService := auth.NewService(auth.NewRepository(queries), database.NewDB(tx), nil, nil, "", nil, nil, nil)
+	roleService := role.NewService(role.NewRepository(queries), database.NewDB(tx), nil)
+
+	user, err := createUser(ctx, authService, roleService, in)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	log.Printf("User %q created (id=%s, role=%s)", user.Username, user.ID, in.Role)
+	return nil
+}
+
+// readPassword meminta password tanpa echo; jika stdin bukan terminal
+// (mis. di-pipe dari secret manager) baca satu baris apa adanya
+func readPassword() (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("read password: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	first, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+
+	fmt.Fprint(os.Stderr, "Confirm password: ")
+	second, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+
+	if string(first) != string(second) {
+		return "", errors.New("passwords do not match")
+	}
+	return string(first), nil
+}
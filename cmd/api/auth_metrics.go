@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go-mini-erp/internal/auth"
+)
+
+// authMetrics is the Prometheus implementation of auth.Metrics
+type authMetrics struct {
+	logins    *prometheus.CounterVec
+	refreshes *prometheus.CounterVec
+	lockouts  *prometheus.CounterVec
+}
+
+var _ auth.Metrics = (*authMetrics)(nil)
+
+// newAuthMetrics registers the auth_* counters with reg
+func newAuthMetrics(reg prometheus.Registerer) *authMetrics {
+	counter := func(name, help, label string) *prometheus.CounterVec {
+		c := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, []string{label})
+		reg.MustRegister(c)
+		return c
+	}
+
+	return &authMetrics{
+		logins:    counter("auth_login_total", "Login attempts by outcome.", "outcome"),
+		refreshes: counter("auth_token_refresh_total", "Refresh token exchanges by outcome.", "outcome"),
+		lockouts:  counter("auth_login_lockouts_total", "Login attempts rejected by the login throttle.", "scope"),
+	}
+}
+
+func (m *authMetrics) Login(outcome string) {
+	m.logins.WithLabelValues(outcome).Inc()
+}
+
+func (m *authMetrics) TokenRefresh(outcome string) {
+	m.refreshes.WithLabelValues(outcome).Inc()
+}
+
+func (m *authMetrics) Lockout(scope string) {
+	m.lockouts.WithLabelValues(scope).Inc()
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/auth"
+)
+
+func TestAuthMetrics_CountsByLabel(t *testing.T) {
+	m := newAuthMetrics(prometheus.NewRegistry())
+
+	m.Login(auth.OutcomeInvalidCredentials)
+	m.Login(auth.OutcomeInvalidCredentials)
+	m.Login(auth.OutcomeSuccess)
+	m.TokenRefresh(auth.OutcomeReused)
+	m.Lockout(auth.LockoutIP)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.logins.WithLabelValues(auth.OutcomeInvalidCredentials)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.logins.WithLabelValues(auth.OutcomeSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.refreshes.WithLabelValues(auth.OutcomeReused)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.lockouts.WithLabelValues(auth.LockoutIP)))
+}
@@ -3,76 +3,354 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/exaring/otelpgx"
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgxpool" // Gunakan pgxpool untuk performa lebih baik
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
 
 	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/category"
+	"go-mini-erp/internal/customer"
+	"go-mini-erp/internal/graph"
+	"go-mini-erp/internal/health"
+	"go-mini-erp/internal/inventory"
+	"go-mini-erp/internal/invoice"
+	"go-mini-erp/internal/notification"
+	"go-mini-erp/internal/product"
+	"go-mini-erp/internal/rbac"
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/shared/buildinfo"
+	"go-mini-erp/internal/shared/config"
+	"go-mini-erp/internal/shared/database"
 	dbgen "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/errreport"
 	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/ratelimit"
+	"go-mini-erp/internal/shared/telemetry"
+	"go-mini-erp/internal/shared/webhook"
+	"go-mini-erp/internal/subscription"
+	"go-mini-erp/internal/user"
 )
 
+// @title Go Mini ERP API
+// @version 1.0
+// @description REST API for the mini ERP system
+// @BasePath /api/v1
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the access token
 func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: .env file not found")
 	}
 
+	cfg := config.Load()
+	log.Printf("Starting %s", buildinfo.Get())
+
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	shutdownTracing, err := telemetry.InitTracing(ctx, cfg.Tracing)
+	if err != nil {
+		log.Fatal("Cannot initialize tracing:", err)
+	}
+
 	// 1. Database Connection (Menggunakan pgxpool)
-	dbUrl := os.Getenv("DB_URL")
-	if dbUrl == "" {
+	if cfg.Database.URL == "" {
 		log.Fatal("DB_URL environment variable is required")
 	}
 
-	dbPool, err := pgxpool.New(ctx, dbUrl)
+	poolCfg, err := database.NewPoolConfig(cfg.Database)
+	if err != nil {
+		log.Fatal("Invalid database config:", err)
+	}
+	log.Printf("Database pool: %s", database.PoolSummary(poolCfg))
+
+	if cfg.Tracing.Enabled {
+		// query tampil sebagai child span dari request
+		poolCfg.ConnConfig.Tracer = otelpgx.NewTracer()
+	}
+
+	dbPool, err := database.Connect(ctx, poolCfg, database.RetryConfig{
+		Attempts:  cfg.Database.ConnectAttempts,
+		BaseDelay: cfg.Database.ConnectBaseDelay,
+		OnRetry: func(attempt int, delay time.Duration, err error) {
+			log.Printf("Database not ready (attempt %d): %v, retrying in %s", attempt, err, delay)
+		},
+	})
+	if err != nil {
+		log.Fatal("Cannot connect to database:", err)
+	}
+
+	if os.Getenv("RUN_MIGRATIONS") == "true" {
+		if err := database.MigrateUp(cfg.Database.URL); err != nil {
+			log.Fatal("Database migration failed:", err)
+		}
+	}
+
+	// schema yang tertinggal dari migration di binary ini ditolak di sini,
+	// bukan lewat error query yang membingungkan
+	expectedSchema, err := database.ExpectedSchemaVersion()
+	if err != nil {
+		log.Fatal("Cannot read embedded migrations:", err)
+	}
+	schemaVersion := database.PgxSchemaVersion(dbPool)
+	actualSchema, err := database.CheckSchemaVersion(ctx, schemaVersion, expectedSchema)
+	log.Printf("Database schema: expected version %d, actual %s", expectedSchema, actualSchema)
 	if err != nil {
-		log.Fatal("Cannot connect to database pool:", err)
+		log.Fatalf("Refusing to start: %v (run migrations or set RUN_MIGRATIONS=true)", err)
+	}
+	if actualSchema.Version > expectedSchema {
+		log.Println("Warning: database schema is newer than this binary")
 	}
-	defer dbPool.Close()
 
-	// Ping database untuk memastikan koneksi aktif
-	if err := dbPool.Ping(ctx); err != nil {
-		log.Fatal("Database ping failed:", err)
+	// Redis opsional: dipakai untuk rate limit lintas instance
+	var redisClient *redis.Client
+	if cfg.Redis.URL != "" {
+		redisOpts, err := redis.ParseURL(cfg.Redis.URL)
+		if err != nil {
+			log.Fatal("Invalid REDIS_URL:", err)
+		}
+		redisClient = redis.NewClient(redisOpts)
 	}
 
-	// sqlc generator sekarang menggunakan dbPool
-	queries := dbgen.New(dbPool)
+	// sqlc generator sekarang menggunakan dbPool; tiap query dibatasi
+	// DB_QUERY_TIMEOUT, juga di dalam transaksi
+	queries := dbgen.New(database.WithQueryTimeout(dbPool, cfg.Database.QueryTimeout))
+	txDB := database.NewDB(dbPool).WithQueryTimeout(cfg.Database.QueryTimeout)
+
+	// Panic dilaporkan ke Sentry jika SENTRY_DSN di-set, selain itu ke log
+	var reporter errreport.Reporter = errreport.LogReporter{}
+	flushReporter := func() {}
+	if dsn := os.Getenv("SENTRY_DSN"); dsn != "" {
+		sentryReporter, err := errreport.NewSentryReporter(dsn, cfg.AppEnv, buildinfo.Version)
+		if err != nil {
+			log.Fatal("Cannot initialize Sentry:", err)
+		}
+		reporter = sentryReporter
+		flushReporter = func() { sentryReporter.Flush(2 * time.Second) }
+	}
 
 	// 2. Gin Setup
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// access log dan error log sebagai JSON, termasuk user_id/username
+	// untuk request yang lolos AuthMiddleware
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	middleware.SetErrorLogger(logger)
+
 	router := gin.New()
-	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
-	router.Use(middleware.CORSMiddleware())
-
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status": "ok",
-			"time":   time.Now().Format(time.RFC3339),
+	// tanpa ini gin percaya X-Forwarded-For dari siapa pun, dan client
+	// bisa mengganti header itu untuk mendapat bucket rate limit baru
+	if err := router.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.Use(middleware.RequestLogger(logger))
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.RecoveryMiddleware(reporter))
+	router.Use(middleware.TracingMiddleware())
+	if err := middleware.ValidateCORS(cfg.CORS); err != nil {
+		log.Fatalf("Invalid CORS config: %v (list the allowed origins or set CORS_ALLOW_CREDENTIALS=false)", err)
+	}
+	router.Use(middleware.CORSMiddleware(cfg.CORS))
+	router.Use(middleware.MaxBodyBytesWithOverrides(cfg.HTTP.MaxBodyBytes, bodyLimitOverrides(cfg.HTTP)))
+	// export CSV boleh menulis lebih lama dari HTTP_WRITE_TIMEOUT
+	router.Use(middleware.WriteTimeoutOverrides(writeTimeoutOverrides(cfg.HTTP)))
+
+	// maintenance: write ditolak 503 sebelum sempat memakai kuota rate limit
+	if sw := maintenanceSwitch(cfg.Maintenance, redisClient); sw != nil {
+		router.Use(middleware.MaintenanceMode(sw, cfg.Maintenance.RetryAfter))
+	}
+
+	// auth_* counter di /metrics (port admin) untuk monitoring keamanan
+	authMetrics := newAuthMetrics(prometheus.DefaultRegisterer)
+
+	// counter dipakai rate limit global, login throttle dan resend verification
+	var loginThrottle *auth.LoginThrottle
+	var limiter ratelimit.Counter = ratelimit.NewMemoryLimiter()
+	if redisClient != nil {
+		limiter = ratelimit.NewRedisLimiter(redisClient, "ratelimit:")
+	}
+	if cfg.RateLimit.Enabled {
+		if redisClient == nil {
+			log.Println("Warning: REDIS_URL not set, rate limits are per instance")
+		}
+		// login/export menghabiskan budget lebih cepat dari route biasa
+		costs := routeCosts(cfg.RateLimit, cfg.HTTP.APIBasePath)
+		if err := middleware.ValidateRouteCosts(costs, cfg.RateLimit.Requests); err != nil {
+			log.Fatalf("Invalid rate limit config: %v (raise RATE_LIMIT_REQUESTS or lower RATE_LIMIT_ROUTE_COSTS)", err)
+		}
+		router.Use(middleware.WeightedRateLimitMiddleware(limiter, cfg.RateLimit.Requests, cfg.RateLimit.Window,
+			middleware.ClientIPKey, costs))
+
+		loginThrottle = auth.NewLoginThrottle(limiter, auth.LoginThrottleConfig{
+			MaxFailuresPerIdentifier: cfg.RateLimit.LoginMaxFailures,
+			MaxFailuresPerIP:         cfg.RateLimit.LoginIPMaxFailures,
+			Window:                   cfg.RateLimit.LoginWindow,
+			Metrics:                  authMetrics,
 		})
-	})
-	jwtManager := auth.NewJWTManager(os.Getenv("JWT_SECRET"))
+	}
+
+	// Health check: /livez, /readyz (/health = alias readyz), /version
+	healthHandler := health.NewHandler(2 * time.Second)
+	healthHandler.AddCheck("database", dbPool.Ping)
+	healthHandler.AddCheck("schema", database.SchemaCheck(schemaVersion, expectedSchema))
+
+	// gauge db_pool_* di /metrics (port admin); goroutine berhenti saat ctx
+	// di-cancel
+	poolMetrics := database.NewPoolMetrics(prometheus.DefaultRegisterer, database.PgxPoolStats(dbPool))
+	go poolMetrics.Run(ctx, config.GetDuration("DB_POOL_METRICS_INTERVAL", 15*time.Second))
+	healthHandler.AddDetail("dbPool", func() any { return poolMetrics.Snapshot() })
+	if redisClient != nil {
+		healthHandler.AddCheck("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+	}
+
+	// API_BASE_PATH (default /api/v1) = path v1, v2 di sebelahnya; health
+	// ikut terdaftar di bawah v1
+	api := apiGroups(router, cfg.HTTP.APIBasePath, healthHandler)
+
+	registerSwagger(router, swaggerEnabled())
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if err := auth.ValidateJWTSecret(jwtSecret); err != nil {
+		if gin.Mode() == gin.ReleaseMode {
+			log.Fatalf("Refusing to start in release mode: %v (set JWT_SECRET to a random value of at least %d bytes)", err, auth.MinJWTSecretBytes)
+		}
+		log.Printf("Warning: %v, do not use this outside development", err)
+	}
+	middleware.SetJWTSecret(jwtSecret)
+	middleware.SetAccessTokenCookie(cfg.Auth.AccessTokenCookie)
+	jwtManager := auth.NewJWTManager(jwtSecret, cfg.Auth.AccessTokenTTL, nil)
+
+	// webhook dikirim dari background worker, berhenti saat ctx di-cancel.
+	// Subscriber = WEBHOOK_URLS + tabel webhooks.
+	webhookRepo := subscription.NewRepository(queries)
+	webhooks := webhook.NewDispatcher(webhook.Config(cfg.Webhook), subscription.NewStore(webhookRepo))
+	go webhooks.Run(ctx)
+
+	// grant role_menus untuk route dan untuk event stream SSE
+	rbacRepo := rbac.NewRepository(queries)
+	menuAccess := rbac.NewMenuAccess(rbacRepo, apiPrefixes(cfg.HTTP.APIBasePath), routeMenus, cfg.Auth.MenuCacheTTL, nil)
+	middleware.SetMenuAccess(menuAccess)
+
+	// event domain ke webhook + stream SSE dashboard
+	notifications := notification.NewHub(menuAccess)
+	events := webhook.Fanout{webhooks, notifications}
+
+	// role user dimuat dari database per request (di-cache), kecuali
+	// TRUST_ROLES_FROM_TOKEN; event role membuang cache yang terdampak
+	authRepo := auth.NewRepository(queries)
+	// token yang sudah logout ditolak di semua route, bukan hanya introspect;
+	// hasil cek di-cache TOKEN_REVOCATION_CACHE_TTL
+	middleware.SetTokenRevocations(auth.NewRevocationCache(authRepo, cfg.Auth.RevocationCacheTTL, nil))
+	if !cfg.Auth.TrustRolesFromToken {
+		roleCache := auth.NewRoleCache(authRepo, cfg.Auth.RoleCacheTTL, nil)
+		middleware.SetRoleSource(roleCache)
+		events = append(events, roleCacheEvents{cache: roleCache})
+	}
 
 	// 3. Routes Grouping
-	v1 := router.Group("/api/v1")
 	{
 		// Sesuai requirement Anda: sertakan penempatan folder/logic per module
-		authRepo := auth.NewRepository(queries)
-		authService := auth.NewService(authRepo, queries, jwtManager)
-		authHandler := auth.NewHandler(authService)
-		authHandler.RegisterRoutes(v1)
+		var activation *auth.AccountActivation
+		if cfg.Auth.ActivationRequired {
+			activationURL := cfg.Auth.ActivationURL
+			if activationURL == "" {
+				activationURL = cfg.HTTP.APIBasePath + "/auth/activate"
+			}
+			log.Println("Warning: ACCOUNT_ACTIVATION_REQUIRED without a mailer, activation links are logged")
+			activation = auth.NewAccountActivation(authRepo, cfg.Auth.ActivationTokenTTL, auth.LogActivationSender{URL: activationURL}, nil)
+		}
+
+		authService := auth.NewService(authRepo, txDB, jwtManager, events, cfg.Auth.DefaultRoleCode, nil, authMetrics, activation)
+		authHandler := auth.NewHandler(authService, loginThrottle)
+		authHandler.EnableGatewayEndpoints(cfg.Auth.GatewayAPIKeys)
+		if activation != nil {
+			authHandler.EnableAccountActivation(activation, auth.ResendLimit{
+				Limiter:  limiter,
+				PerIP:    cfg.RateLimit.ResendMaxPerIP,
+				PerEmail: cfg.RateLimit.ResendMaxPerEmail,
+				Window:   cfg.RateLimit.ResendWindow,
+			})
+		}
+		if cfg.Auth.AccountDeletionGrace > 0 {
+			accountDeletion := auth.NewAccountDeletion(authRepo, txDB, cfg.Auth.AccountDeletionGrace, nil)
+			authHandler.EnableAccountDeletion(accountDeletion)
+			go accountDeletion.Run(ctx, cfg.Auth.AccountDeletionInterval)
+		}
+		// profile v1 snake_case, v2 camelCase
+		api.RegisterVersioned(authHandler)
+
+		productRepo := product.NewRepository(queries)
+		productService := product.NewService(productRepo)
+		productHandler := product.NewHandler(productService)
+		api.Register(productHandler)
+
+		categoryRepo := category.NewRepository(queries)
+		categoryService := category.NewService(categoryRepo, txDB)
+		categoryHandler := category.NewHandler(categoryService)
+		api.Register(categoryHandler)
+
+		customerRepo := customer.NewRepository(queries)
+		customerService := customer.NewService(customerRepo)
+		customerHandler := customer.NewHandler(customerService)
+		api.Register(customerHandler)
+
+		inventoryRepo := inventory.NewRepository(queries)
+		inventoryService := inventory.NewService(inventoryRepo, txDB, events)
+		inventoryHandler := inventory.NewHandler(inventoryService)
+		api.Register(inventoryHandler)
+
+		invoiceRepo := invoice.NewRepository(queries)
+		invoiceService := invoice.NewService(invoiceRepo, txDB, events)
+		invoiceHandler := invoice.NewHandler(invoiceService)
+		api.Register(invoiceHandler)
+
+		roleRepo := role.NewRepository(queries)
+		roleService := role.NewService(roleRepo, txDB, events)
+		roleHandler := role.NewHandler(roleService)
+		api.Register(roleHandler)
+
+		userRepo := user.NewRepository(queries)
+		userService := user.NewService(userRepo, txDB, events)
+		userHandler := user.NewHandler(userService)
+		api.Register(userHandler)
+
+		rbacService := rbac.NewService(rbacRepo, txDB, menuAccess)
+		rbacHandler := rbac.NewHandler(rbacService)
+		api.Register(rbacHandler)
+
+		graphSchema, err := graph.NewSchema(graph.Resolvers{
+			Users:    userService,
+			Profiles: authService,
+			Roles:    roleService,
+			RBAC:     rbacService,
+		})
+		if err != nil {
+			log.Fatal("Invalid GraphQL schema:", err)
+		}
+		api.Register(graph.NewHandler(graphSchema))
+
+		webhookService := subscription.NewService(webhookRepo, webhooks)
+		webhookHandler := subscription.NewHandler(webhookService)
+		api.Register(webhookHandler)
+
+		notificationHandler := notification.NewHandler(notifications, config.GetDuration("SSE_HEARTBEAT", notification.DefaultHeartbeat))
+		api.Register(notificationHandler)
 	}
 
 	// 4. HTTP Server Setup
@@ -81,13 +359,9 @@ func main() {
 		port = "8080"
 	}
 
-	server := &http.Server{
-		Addr:         ":" + port,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	server := newServer(":"+port, router, cfg.HTTP)
+	// stream SSE tidak pernah idle; tanpa ini Shutdown menunggu sampai timeout
+	server.RegisterOnShutdown(notifications.Close)
 
 	// 5. Start Server with Graceful Shutdown
 	go func() {
@@ -97,6 +371,15 @@ func main() {
 		}
 	}()
 
+	// /metrics dan pprof hanya di port admin terpisah (default loopback)
+	adminServer := newAdminServer(config.GetString("PPROF_ADDR", "localhost:6060"), pprofEnabled())
+	go func() {
+		log.Printf("admin listener (metrics, pprof=%t) on %s", pprofEnabled(), adminServer.Addr)
+		if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("admin listen error: %v", err)
+		}
+	}()
+
 	// Menunggu signal interrupt
 	<-ctx.Done()
 
@@ -106,7 +389,21 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := server.Shutdown(shutdownCtx); err != nil {
+	// Server di-drain dulu, baru pool ditutup, terakhir flush span
+	flushTraces := func() {
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Printf("Tracing shutdown error: %v", err)
+		}
+	}
+	closeAdmin := func() {
+		_ = adminServer.Shutdown(shutdownCtx)
+	}
+	closeRedis := func() {
+		if redisClient != nil {
+			_ = redisClient.Close()
+		}
+	}
+	if err := shutdown(shutdownCtx, server, closeAdmin, dbPool.Close, closeRedis, flushTraces, flushReporter); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
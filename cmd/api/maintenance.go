@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/redis/go-redis/v9"
+
+	"go-mini-erp/internal/shared/config"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+// maintenanceSwitch picks MAINTENANCE_MODE when set, else the Redis key
+// when Redis is configured; nil = middleware maintenance tidak dipasang
+func maintenanceSwitch(cfg config.MaintenanceConfig, redisClient *redis.Client) middleware.MaintenanceSwitch {
+	switch {
+	case cfg.Enabled:
+		return middleware.StaticMaintenance(true)
+	case redisClient != nil && cfg.RedisKey != "":
+		return middleware.NewRedisMaintenance(redisClient, cfg.RedisKey)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// pprofEnabled reads PPROF_ENABLED; profiling is off unless explicitly
+// enabled, in every mode
+func pprofEnabled() bool {
+	return os.Getenv("PPROF_ENABLED") == "true"
+}
+
+// registerPprof mounts net/http/pprof under /debug/pprof/*
+func registerPprof(r gin.IRoutes, enabled bool) {
+	if !enabled {
+		return
+	}
+	r.GET("/debug/pprof/", gin.WrapF(pprof.Index))
+	r.GET("/debug/pprof/cmdline", gin.WrapF(pprof.Cmdline))
+	r.GET("/debug/pprof/profile", gin.WrapF(pprof.Profile))
+	r.POST("/debug/pprof/symbol", gin.WrapF(pprof.Symbol))
+	r.GET("/debug/pprof/symbol", gin.WrapF(pprof.Symbol))
+	r.GET("/debug/pprof/trace", gin.WrapF(pprof.Trace))
+	// heap, goroutine, allocs, block, mutex, threadcreate
+	r.GET("/debug/pprof/:profile", func(c *gin.Context) {
+		pprof.Handler(c.Param("profile")).ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// newAdminServer serves /metrics, plus pprof when withPprof, on a separate
+// (default loopback-only) port so neither is reachable through the public
+// listener: metrics memuat counter auth_* dan statistik runtime
+func newAdminServer(addr string, withPprof bool) *http.Server {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	registerPprof(router, withPprof)
+
+	return &http.Server{
+		Addr:        addr,
+		Handler:     router,
+		ReadTimeout: 15 * time.Second,
+		// profile/trace bisa berjalan lama (?seconds=30)
+		WriteTimeout: 2 * time.Minute,
+	}
+}
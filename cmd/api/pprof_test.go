@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPprof_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registerPprof(router, false)
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/cmdline"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestPprof_Enabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registerPprof(router, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "goroutine")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/debug/pprof/goroutine?debug=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/debug/pprof/cmdline", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPprofEnabled_Flag(t *testing.T) {
+	t.Setenv("PPROF_ENABLED", "")
+	assert.False(t, pprofEnabled())
+
+	t.Setenv("PPROF_ENABLED", "true")
+	assert.True(t, pprofEnabled())
+}
+
+func TestAdminServer_ServesMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, withPprof := range []bool{false, true} {
+		handler := newAdminServer("localhost:0", withPprof).Handler
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "go_goroutines")
+
+		want := http.StatusNotFound
+		if withPprof {
+			want = http.StatusOK
+		}
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/debug/pprof/", nil))
+		assert.Equal(t, want, w.Code)
+	}
+}
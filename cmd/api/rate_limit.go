@@ -0,0 +1,45 @@
+package main
+
+import (
+	"go-mini-erp/internal/shared/apiversion"
+	"go-mini-erp/internal/shared/config"
+)
+
+// defaultRouteCosts: berapa token dari budget RATE_LIMIT_REQUESTS yang
+// dipakai satu request; path relatif terhadap base path tiap versi. Route
+// lain = 1, health = 0 supaya probe load balancer tidak pernah kena 429.
+var defaultRouteCosts = map[string]int{
+	"/auth/login":    5,
+	"/auth/register": 5,
+	"/auth/refresh":  2,
+	"/users/export":  10,
+	"/roles/export":  10,
+	"/rbac/export":   10,
+	"/rbac/import":   10,
+	"/livez":         0,
+	"/readyz":        0,
+	"/health":        0,
+	"/version":       0,
+}
+
+// routeCosts maps every route template of every mounted version (dan root,
+// tempat health juga didaftarkan) to its cost; cfg.RouteCosts menimpa
+// default per route
+func routeCosts(cfg config.RateLimitConfig, basePath string) map[string]int {
+	relative := make(map[string]int, len(defaultRouteCosts)+len(cfg.RouteCosts))
+	for route, cost := range defaultRouteCosts {
+		relative[route] = cost
+	}
+	for route, cost := range cfg.RouteCosts {
+		relative[route] = cost
+	}
+
+	costs := make(map[string]int, len(relative)*(len(apiversion.Supported)+1))
+	for route, cost := range relative {
+		costs[route] = cost
+		for _, v := range apiversion.Supported {
+			costs[apiversion.Path(basePath, v)+route] = cost
+		}
+	}
+	return costs
+}
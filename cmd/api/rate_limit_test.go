@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/config"
+)
+
+func TestRouteCosts_EveryVersionWithOverrides(t *testing.T) {
+	costs := routeCosts(config.RateLimitConfig{
+		RouteCosts: map[string]int{"/auth/login": 8, "/products": 3},
+	}, "/erp/api/v1")
+
+	assert.Equal(t, 8, costs["/erp/api/v1/auth/login"])
+	assert.Equal(t, 8, costs["/erp/api/v2/auth/login"])
+	assert.Equal(t, 3, costs["/erp/api/v2/products"])
+	assert.Equal(t, 10, costs["/erp/api/v1/users/export"])
+
+	// health juga didaftarkan di root
+	assert.Equal(t, 0, costs["/livez"])
+	assert.Equal(t, 0, costs["/erp/api/v1/readyz"])
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+/*
+roleCacheEvents menghubungkan event role ke auth.RoleCache: role yang
+diubah (mis. dinonaktifkan) atau dihapus dan assignment baru atau yang
+dicabut langsung membuang cache user terkait, tidak menunggu ROLE_CACHE_TTL. Invalidasi
+sengaja sinkron supaya request admin berikutnya sudah melihat perubahan;
+biayanya satu query per 500 pemegang role.
+*/
+type roleCacheEvents struct {
+	cache *auth.RoleCache
+}
+
+func (e roleCacheEvents) Publish(ctx context.Context, event webhook.Event) {
+	switch event.Type {
+	case webhook.EventRoleUpdated, webhook.EventRoleDeleted:
+		if r, ok := event.Data.(*role.RoleResponse); ok {
+			e.cache.InvalidateRole(ctx, r.ID, r.Code)
+		}
+	case webhook.EventRoleAssigned, webhook.EventRoleRemoved:
+		if a, ok := event.Data.(role.RoleAssignedEvent); ok {
+			e.cache.Invalidate(a.UserIDs...)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/auth"
+	authmocks "go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/role"
+	rolemocks "go-mini-erp/internal/role/mocks"
+	"go-mini-erp/internal/shared/clock"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+func TestRoleCacheEvents_DeactivatedRoleSeenBeforeTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authRepo := authmocks.NewMockRepository(ctrl)
+	roleRepo := rolemocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(authRepo, time.Hour, clock.NewFake(time.Now()))
+	roles := role.NewService(roleRepo, directTx{}, roleCacheEvents{cache: cache})
+
+	ctx := context.Background()
+	userID, roleID := uuid.New(), uuid.New()
+
+	authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{Code: "sales"}}, nil)
+	codes, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sales"}, codes)
+
+	roleRepo.EXPECT().WithQuerier(gomock.Any()).Return(roleRepo)
+	roleRepo.EXPECT().GetRoleByID(gomock.Any(), roleID).Return(db.Role{ID: roleID, Code: "sales", Name: "Sales", IsActive: dbutil.BoolPtr(true)}, nil)
+	roleRepo.EXPECT().UpdateRole(gomock.Any(), gomock.Any()).Return(db.Role{ID: roleID, Code: "sales", Name: "Sales", IsActive: dbutil.BoolPtr(false)}, nil)
+	authRepo.EXPECT().ListUsersByRole(gomock.Any(), gomock.Any()).Return([]db.ListUsersByRoleRow{{ID: userID}}, nil)
+
+	_, err = roles.UpdateRole(ctx, roleID, nil, role.UpdateRoleRequest{IsActive: dbutil.BoolPtr(false)})
+	require.NoError(t, err)
+
+	// GetUserRoles hanya mengembalikan role aktif
+	authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil)
+	codes, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, codes)
+}
+
+func TestRoleCacheEvents_AssignmentEvictsUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authRepo := authmocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(authRepo, time.Hour, clock.NewFake(time.Now()))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	gomock.InOrder(
+		authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil),
+		authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{Code: "sales"}}, nil),
+	)
+	_, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+
+	roleCacheEvents{cache: cache}.Publish(ctx, webhook.NewEvent(webhook.EventRoleAssigned, role.RoleAssignedEvent{
+		RoleID:  uuid.New(),
+		UserIDs: []uuid.UUID{userID},
+	}))
+
+	codes, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sales"}, codes)
+}
+
+// directTx menjalankan fn langsung tanpa transaksi
+type directTx struct{}
+
+func (directTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	return fn(nil)
+}
+
+func TestRoleCacheEvents_RevokedAdminSeenBeforeTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authRepo := authmocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(authRepo, time.Hour, clock.NewFake(time.Now()))
+	service := auth.NewService(authRepo, directTx{}, nil, roleCacheEvents{cache: cache}, "", nil, nil, nil)
+
+	ctx := context.Background()
+	userID, adminRoleID := uuid.New(), uuid.New()
+
+	authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{ID: adminRoleID, Code: "admin"}}, nil)
+	codes, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin"}, codes)
+
+	// DELETE /users/:id/roles/:roleId
+	authRepo.EXPECT().WithQuerier(gomock.Any()).Return(authRepo)
+	authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{ID: adminRoleID, Code: "admin"}}, nil)
+	authRepo.EXPECT().LockAdminRole(gomock.Any()).Return(nil)
+	authRepo.EXPECT().CountActiveAdmins(gomock.Any(), userID).Return(db.CountActiveAdminsRow{IsAdmin: true, Remaining: 1}, nil)
+	authRepo.EXPECT().RemoveRoleFromUser(gomock.Any(), userID, adminRoleID).Return(nil)
+	require.NoError(t, service.RemoveRoleFromUser(ctx, userID, adminRoleID))
+
+	// cache dibuang: role dimuat ulang tanpa admin
+	authRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil)
+	codes, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, codes)
+}
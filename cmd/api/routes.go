@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/docs"
+	"go-mini-erp/internal/health"
+	"go-mini-erp/internal/shared/apiversion"
+)
+
+/*
+apiGroups creates one router group per supported API version; basePath
+adalah path v1, versi lain di sebelahnya (/api/v1 -> /api/v2). Health
+route tetap di root untuk probe orchestrator dan juga di bawah basePath
+supaya bisa dicek lewat proxy; spec swagger ikut memakai basePath.
+*/
+func apiGroups(router *gin.Engine, basePath string, healthHandler *health.Handler) apiversion.Groups {
+	docs.SwaggerInfo.BasePath = basePath
+
+	healthHandler.RegisterRoutes(router)
+
+	groups := apiversion.NewGroups(router, basePath, apiversion.Supported...)
+	healthHandler.RegisterRoutes(groups.Group(apiversion.V1))
+
+	return groups
+}
+
+// apiPrefixes returns the base path of every mounted version, untuk
+// rbac.MenuAccess yang mencocokkan route tanpa prefix API
+func apiPrefixes(basePath string) []string {
+	prefixes := make([]string, 0, len(apiversion.Supported))
+	for _, v := range apiversion.Supported {
+		prefixes = append(prefixes, apiversion.Path(basePath, v))
+	}
+	return prefixes
+}
+
+// routeMenus maps every route group guarded by RequireMenuForPath to its
+// menu code. Route yang tidak ada di sini ditolak, jadi group baru yang
+// memakai RequireMenuForPath harus ditambahkan.
+var routeMenus = map[string]string{
+	"/products":   "products",
+	"/categories": "categories",
+	"/customers":  "customers",
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/docs"
+	"go-mini-erp/internal/health"
+	"go-mini-erp/internal/product"
+	"go-mini-erp/internal/shared/config"
+)
+
+func TestAPIGroups_CustomBasePath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Cleanup(func() { docs.SwaggerInfo.BasePath = config.DefaultAPIBasePath })
+
+	router := gin.New()
+	groups := apiGroups(router, "/erp/api/v1", health.NewHandler(time.Second))
+	groups.Register(product.NewHandler(nil))
+
+	routes := map[string]bool{}
+	for _, r := range router.Routes() {
+		routes[r.Method+" "+r.Path] = true
+	}
+	assert.True(t, routes["GET /erp/api/v1/products"])
+	assert.True(t, routes["GET /erp/api/v2/products"])
+	assert.False(t, routes["GET /api/v1/products"])
+
+	for _, path := range []string{"/livez", "/erp/api/v1/livez"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+
+	assert.Equal(t, "/erp/api/v1", docs.SwaggerInfo.BasePath)
+}
+
+func TestNormalizeBasePath(t *testing.T) {
+	tests := map[string]string{
+		"":              "/api/v1",
+		"/":             "/api/v1",
+		"/erp/api/v1":   "/erp/api/v1",
+		"erp/api/v1/":   "/erp/api/v1",
+		" /erp/api/v2 ": "/erp/api/v2",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, config.NormalizeBasePath(in), in)
+	}
+}
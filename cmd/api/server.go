@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"go-mini-erp/internal/shared/apiversion"
+	"go-mini-erp/internal/shared/config"
+)
+
+// streamingRoutes write their response for longer than the server
+// WriteTimeout allows; path relatif terhadap base path tiap versi
+var streamingRoutes = []string{
+	"/users/export",
+	"/roles/export",
+	"/rbac/export",
+}
+
+// uploadRoutes accept bodies above HTTP_MAX_BODY_BYTES; path relatif
+// terhadap base path tiap versi
+var uploadRoutes = []string{
+	"/rbac/import",
+}
+
+// newServer builds the public http.Server with the timeouts from cfg
+func newServer(addr string, handler http.Handler, cfg config.HTTPConfig) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+}
+
+// writeTimeoutOverrides maps every streaming route template of every
+// mounted version to cfg.StreamWriteTimeout
+func writeTimeoutOverrides(cfg config.HTTPConfig) map[string]time.Duration {
+	overrides := make(map[string]time.Duration, len(streamingRoutes)*len(apiversion.Supported))
+	for _, v := range apiversion.Supported {
+		base := apiversion.Path(cfg.APIBasePath, v)
+		for _, route := range streamingRoutes {
+			overrides[base+route] = cfg.StreamWriteTimeout
+		}
+	}
+	return overrides
+}
+
+// bodyLimitOverrides maps every upload route template of every mounted
+// version to cfg.ImportMaxBodyBytes
+func bodyLimitOverrides(cfg config.HTTPConfig) map[string]int64 {
+	overrides := make(map[string]int64, len(uploadRoutes)*len(apiversion.Supported))
+	for _, v := range apiversion.Supported {
+		base := apiversion.Path(cfg.APIBasePath, v)
+		for _, route := range uploadRoutes {
+			overrides[base+route] = cfg.ImportMaxBodyBytes
+		}
+	}
+	return overrides
+}
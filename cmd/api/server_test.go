@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/config"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func TestNewServer_TimeoutsFromEnv(t *testing.T) {
+	t.Setenv("HTTP_READ_TIMEOUT", "20s")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "45s")
+	t.Setenv("HTTP_IDLE_TIMEOUT", "2m")
+
+	server := newServer(":8080", http.NotFoundHandler(), config.Load().HTTP)
+
+	assert.Equal(t, ":8080", server.Addr)
+	assert.Equal(t, 20*time.Second, server.ReadTimeout)
+	assert.Equal(t, 45*time.Second, server.WriteTimeout)
+	assert.Equal(t, 2*time.Minute, server.IdleTimeout)
+}
+
+func TestNewServer_DefaultsPerEnv(t *testing.T) {
+	tests := []struct {
+		env          string
+		read, write  time.Duration
+		idle, stream time.Duration
+	}{
+		{"production", 15 * time.Second, 15 * time.Second, time.Minute, 10 * time.Minute},
+		{"development", time.Minute, time.Minute, time.Minute, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("APP_ENV", tt.env)
+			cfg := config.Load().HTTP
+
+			server := newServer(":8080", http.NotFoundHandler(), cfg)
+
+			assert.Equal(t, tt.read, server.ReadTimeout)
+			assert.Equal(t, tt.write, server.WriteTimeout)
+			assert.Equal(t, tt.idle, server.IdleTimeout)
+			assert.Equal(t, tt.stream, cfg.StreamWriteTimeout)
+		})
+	}
+}
+
+func TestWriteTimeoutOverrides_EveryVersion(t *testing.T) {
+	overrides := writeTimeoutOverrides(config.HTTPConfig{
+		APIBasePath:        "/erp/api/v1",
+		StreamWriteTimeout: 5 * time.Minute,
+	})
+
+	assert.Equal(t, 5*time.Minute, overrides["/erp/api/v1/users/export"])
+	assert.Equal(t, 5*time.Minute, overrides["/erp/api/v2/rbac/export"])
+	assert.Len(t, overrides, 2*len(streamingRoutes))
+}
+
+func TestBodyLimitOverrides_ImportAcceptsLargerBody(t *testing.T) {
+	cfg := config.HTTPConfig{
+		APIBasePath:        "/erp/api/v1",
+		MaxBodyBytes:       1 << 10,
+		ImportMaxBodyBytes: 1 << 20,
+	}
+	overrides := bodyLimitOverrides(cfg)
+	assert.Equal(t, int64(1<<20), overrides["/erp/api/v2/rbac/import"])
+	assert.Len(t, overrides, len(uploadRoutes)*2)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.MaxBodyBytesWithOverrides(cfg.MaxBodyBytes, overrides))
+	readBody := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+	router.POST("/erp/api/v1/rbac/import", readBody)
+	router.POST("/erp/api/v1/products", readBody)
+
+	post := func(path string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewReader(make([]byte, 64<<10))))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, post("/erp/api/v1/rbac/import"))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("/erp/api/v1/products"))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// shutdown drains the HTTP server first and only then runs closers
+// (db pool, redis, ...) in the given order, so in-flight requests
+// never hit a closed pool. Everything is bounded by ctx.
+func shutdown(ctx context.Context, server shutdowner, closers ...func()) error {
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("server shutdown failed: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, closeFn := range closers {
+			closeFn()
+		}
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("closing resources timed out: %w", ctx.Err())
+	}
+}
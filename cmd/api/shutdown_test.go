@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type serverStub struct {
+	calls *[]string
+	err   error
+}
+
+func (s serverStub) Shutdown(ctx context.Context) error {
+	*s.calls = append(*s.calls, "server")
+	return s.err
+}
+
+func TestShutdown_ServerDrainsBeforePoolCloses(t *testing.T) {
+	var calls []string
+
+	err := shutdown(
+		context.Background(),
+		serverStub{calls: &calls},
+		func() { calls = append(calls, "db") },
+		func() { calls = append(calls, "redis") },
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"server", "db", "redis"}, calls)
+}
+
+func TestShutdown_ServerErrorSkipsClosers(t *testing.T) {
+	var calls []string
+
+	err := shutdown(
+		context.Background(),
+		serverStub{calls: &calls, err: errors.New("boom")},
+		func() { calls = append(calls, "db") },
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"server"}, calls)
+}
+
+func TestShutdown_CloserTimeout(t *testing.T) {
+	var calls []string
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	err := shutdown(ctx, serverStub{calls: &calls}, func() { <-block })
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
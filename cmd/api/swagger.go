@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	_ "go-mini-erp/docs"
+)
+
+// swaggerEnabled reads SWAGGER_ENABLED; when unset swagger is on
+// in dev and off in release mode
+func swaggerEnabled() bool {
+	if v := os.Getenv("SWAGGER_ENABLED"); v != "" {
+		return v == "true"
+	}
+	return gin.Mode() != gin.ReleaseMode
+}
+
+// registerSwagger serves swagger-ui and the generated spec at /swagger/*any
+func registerSwagger(r gin.IRoutes, enabled bool) {
+	if !enabled {
+		return
+	}
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(
+		swaggerFiles.Handler,
+		ginSwagger.PersistAuthorization(true),
+	))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwagger_Enabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registerSwagger(router, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/index.html", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/doc.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "BearerAuth")
+}
+
+func TestSwagger_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registerSwagger(router, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/index.html", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSwaggerEnabled_Flag(t *testing.T) {
+	t.Setenv("SWAGGER_ENABLED", "false")
+	assert.False(t, swaggerEnabled())
+
+	t.Setenv("SWAGGER_ENABLED", "true")
+	assert.True(t, swaggerEnabled())
+
+	t.Setenv("SWAGGER_ENABLED", "")
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(gin.TestMode)
+	assert.False(t, swaggerEnabled())
+}
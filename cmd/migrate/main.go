@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/joho/godotenv"
+
+	"go-mini-erp/internal/shared/database"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up              apply all pending migrations
+  down [steps]    roll back migrations (default 1 step)
+  version         print current schema version
+  force <version> set version without running migrations (fix dirty state)`
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	dbUrl := os.Getenv("DB_URL")
+	if dbUrl == "" {
+		log.Fatal("DB_URL environment variable is required")
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err := run(dbUrl, os.Args[1], os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dbUrl, command string, args []string) error {
+	if command == "up" {
+		return database.MigrateUp(dbUrl)
+	}
+
+	m, err := database.NewMigrator(dbUrl)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch command {
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps %q", args[0])
+			}
+		}
+		if err := m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("migrate down failed: %w", err)
+		}
+	case "force":
+		if len(args) < 1 {
+			return errors.New("force requires a version")
+		}
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		if err := m.Force(version); err != nil {
+			return fmt.Errorf("migrate force failed: %w", err)
+		}
+	case "version":
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		log.Println("Schema version: none")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Schema version: %d (dirty=%v)", version, dirty)
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+
+	"go-mini-erp/internal/seed"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// seed membuat role default, menu + grant, dan admin awal.
+// Aman dijalankan berulang kali.
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	dbUrl := os.Getenv("DB_URL")
+	if dbUrl == "" {
+		log.Fatal("DB_URL environment variable is required")
+	}
+
+	admin := seed.AdminConfig{
+		Username: os.Getenv("SEED_ADMIN_USERNAME"),
+		Email:    os.Getenv("SEED_ADMIN_EMAIL"),
+		Password: os.Getenv("SEED_ADMIN_PASSWORD"),
+		FullName: os.Getenv("SEED_ADMIN_FULL_NAME"),
+	}
+
+	if err := run(context.Background(), dbUrl, admin); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, dbUrl string, admin seed.AdminConfig) error {
+	pool, err := pgxpool.New(ctx, dbUrl)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	// satu transaksi: seed gagal di tengah tidak meninggalkan data setengah jadi
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := seed.Run(ctx, db.New(tx), admin)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	log.Printf("Seeded %d roles, %d menus", result.Roles, result.Menus)
+	if result.AdminCreated {
+		log.Printf("Admin user %q created", admin.Username)
+	} else {
+		log.Printf("Admin user %q already exists, skipped", admin.Username)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+-- =====================================================
+-- Rollback: Mini ERP System
+-- Drop in reverse dependency order
+-- =====================================================
+
+-- Audit & System
+DROP TABLE IF EXISTS audit_logs;
+
+-- Finance Module
+DROP TABLE IF EXISTS payments;
+
+-- Inventory/Warehouse Module
+DROP TABLE IF EXISTS stock_adjustment_lines;
+DROP TABLE IF EXISTS stock_adjustments;
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS stock_balances;
+DROP TABLE IF EXISTS stock_locations;
+
+-- Sales Module
+DROP TABLE IF EXISTS customer_invoices;
+DROP TABLE IF EXISTS sales_order_lines;
+DROP TABLE IF EXISTS sales_orders;
+DROP TABLE IF EXISTS quotation_lines;
+DROP TABLE IF EXISTS quotations;
+
+-- Procurement Module
+DROP TABLE IF EXISTS supplier_bills;
+DROP TABLE IF EXISTS goods_receipt_lines;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+
+-- Master Data
+DROP TABLE IF EXISTS customers;
+DROP TABLE IF EXISTS suppliers;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS units_of_measure;
+DROP TABLE IF EXISTS categories;
+
+-- RBAC & Authentication
+DROP TABLE IF EXISTS role_menus;
+DROP TABLE IF EXISTS menus;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS roles;
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
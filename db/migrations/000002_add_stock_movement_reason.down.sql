@@ -0,0 +1,3 @@
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS reference,
+    DROP COLUMN IF EXISTS reason;
@@ -0,0 +1,3 @@
+ALTER TABLE stock_movements
+    ADD COLUMN reason VARCHAR(255),
+    ADD COLUMN reference VARCHAR(100);
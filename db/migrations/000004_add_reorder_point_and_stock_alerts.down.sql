@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS stock_alerts;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS reorder_point;
@@ -0,0 +1,15 @@
+-- NULL = produk tidak dipantau
+ALTER TABLE products
+    ADD COLUMN reorder_point DECIMAL(15,3);
+
+CREATE TABLE stock_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    on_hand DECIMAL(15,3) NOT NULL,
+    reorder_point DECIMAL(15,3) NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- paling banyak satu alert terbuka per produk
+CREATE UNIQUE INDEX idx_stock_alerts_open ON stock_alerts(product_id) WHERE resolved_at IS NULL;
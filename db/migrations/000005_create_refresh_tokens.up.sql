@@ -0,0 +1,12 @@
+-- satu row per refresh token yang diterbitkan (id = klaim jti).
+-- Token yang sudah di-rotate tetap disimpan supaya pemakaian ulang terdeteksi.
+CREATE TABLE refresh_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    replaced_by UUID,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_refresh_tokens_user ON refresh_tokens(user_id);
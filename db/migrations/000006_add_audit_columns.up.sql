@@ -0,0 +1,12 @@
+-- siapa yang membuat / terakhir mengubah row (NULL = sistem, seed, atau self-register)
+ALTER TABLE roles
+    ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE users
+    ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE products
+    ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
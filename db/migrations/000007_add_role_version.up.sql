@@ -0,0 +1,3 @@
+-- optimistic concurrency: tiap update role menaikkan version (dipakai sebagai ETag)
+ALTER TABLE roles
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
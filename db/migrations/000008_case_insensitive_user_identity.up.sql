@@ -0,0 +1,7 @@
+-- email disimpan lowercase; username tetap apa adanya tapi unik tanpa
+-- membedakan huruf besar/kecil. Gagal jika data lama sudah punya duplikat
+-- beda-case: bereskan dulu sebelum migrate.
+UPDATE users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+CREATE UNIQUE INDEX users_username_lower_key ON users (lower(username));
+CREATE UNIQUE INDEX users_email_lower_key ON users (lower(email));
@@ -0,0 +1,11 @@
+-- deny-list access token (jti) yang dicabut sebelum expired, mis. saat logout.
+-- Verifier lain sync lewat GET /auth/revoked?since=; row boleh dihapus
+-- setelah expires_at karena token-nya sudah tidak valid.
+CREATE TABLE revoked_tokens (
+    jti UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_tokens_revoked_at ON revoked_tokens(revoked_at);
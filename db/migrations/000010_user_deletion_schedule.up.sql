@@ -0,0 +1,6 @@
+-- Penghapusan akun oleh user sendiri: deletion_due_at terisi selama masa
+-- tenggang, worker menganonimkan user setelah waktunya lewat.
+ALTER TABLE users ADD COLUMN deletion_due_at TIMESTAMPTZ;
+
+CREATE INDEX users_deletion_due_at_idx ON users (deletion_due_at)
+    WHERE deletion_due_at IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS user_roles_bump_rbac_version ON user_roles;
+DROP TRIGGER IF EXISTS role_menus_bump_rbac_version ON role_menus;
+DROP TRIGGER IF EXISTS menus_bump_rbac_version ON menus;
+DROP TRIGGER IF EXISTS roles_bump_rbac_version ON roles;
+DROP FUNCTION IF EXISTS bump_rbac_version();
+DROP TABLE IF EXISTS rbac_version;
@@ -0,0 +1,33 @@
+-- Satu counter untuk semua perubahan role/menu/assignment; dipakai ETag
+-- GET /auth/profile supaya cek 304 tidak perlu menyusun ulang menu.
+-- Trigger per statement, bukan per baris, jadi import RBAC besar hanya
+-- menaikkan version sekali per statement.
+CREATE TABLE rbac_version (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    version BIGINT NOT NULL DEFAULT 1
+);
+
+INSERT INTO rbac_version (id) VALUES (TRUE);
+
+CREATE FUNCTION bump_rbac_version() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE rbac_version SET version = version + 1;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER roles_bump_rbac_version
+    AFTER INSERT OR UPDATE OR DELETE ON roles
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_rbac_version();
+
+CREATE TRIGGER menus_bump_rbac_version
+    AFTER INSERT OR UPDATE OR DELETE ON menus
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_rbac_version();
+
+CREATE TRIGGER role_menus_bump_rbac_version
+    AFTER INSERT OR UPDATE OR DELETE ON role_menus
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_rbac_version();
+
+CREATE TRIGGER user_roles_bump_rbac_version
+    AFTER INSERT OR UPDATE OR DELETE ON user_roles
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_rbac_version();
@@ -0,0 +1,9 @@
+-- Token aktivasi akun (ACCOUNT_ACTIVATION_REQUIRED): user hasil register
+-- dibuat is_active = false dan punya satu row di sini sampai link di email
+-- dibuka. Hanya hash token yang disimpan; row dihapus saat dipakai.
+CREATE TABLE user_activations (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
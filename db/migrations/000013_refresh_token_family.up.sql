@@ -0,0 +1,8 @@
+-- family_id mengelompokkan refresh token satu sesi login: token hasil
+-- rotasi mewarisi family token sebelumnya, dan logout menghapus satu family.
+-- Token lama menjadi family-nya sendiri.
+ALTER TABLE refresh_tokens ADD COLUMN family_id UUID;
+UPDATE refresh_tokens SET family_id = id WHERE family_id IS NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX idx_refresh_tokens_family ON refresh_tokens(family_id);
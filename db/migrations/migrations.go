@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migration files so the binary
+// can apply them without shipping the db/ directory alongside it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
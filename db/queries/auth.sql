@@ -10,7 +10,7 @@ SELECT
     created_at,
     updated_at
 FROM users
-WHERE username = $1 
+WHERE lower(username) = lower(sqlc.arg(username)) 
     AND deleted_at IS NULL
 LIMIT 1;
 
@@ -38,10 +38,11 @@ SELECT
     full_name,
     is_active,
     last_login_at,
+    deletion_due_at,
     created_at,
     updated_at
 FROM users
-WHERE email = $1 
+WHERE lower(email) = lower(sqlc.arg(email)) 
     AND deleted_at IS NULL
 LIMIT 1;
 
@@ -51,17 +52,19 @@ INSERT INTO users (
     email,
     password_hash,
     full_name,
-    is_active
+    is_active,
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $6
 )
 RETURNING id, username, email, full_name, is_active, created_at;
 
 -- name: UpdateUserLastLogin :exec
 UPDATE users
-SET last_login_at = NOW(),
+SET last_login_at = sqlc.arg(last_login_at),
     updated_at = NOW()
-WHERE id = $1;
+WHERE id = sqlc.arg(id);
 
 -- name: GetUserRoles :many
 SELECT 
@@ -76,27 +79,71 @@ WHERE ur.user_id = $1
     AND r.is_active = true
 ORDER BY r.name;
 
--- name: GetUserMenus :many
-SELECT 
-    m.id,
-    m.parent_id,
-    m.code,
-    m.name,
-    m.path,
-    m.icon,
-    m.sort_order,
-    -- Cast hasil MAX ke boolean agar SQLC men-generate tipe bool
-    (MAX(rm.can_create::int) > 0)::BOOLEAN as can_create,
-    (MAX(rm.can_read::int) > 0)::BOOLEAN as can_read,
-    (MAX(rm.can_update::int) > 0)::BOOLEAN as can_update,
-    (MAX(rm.can_delete::int) > 0)::BOOLEAN as can_delete
-FROM menus m
-INNER JOIN role_menus rm ON m.id = rm.menu_id
-INNER JOIN user_roles ur ON rm.role_id = ur.role_id
-WHERE ur.user_id = $1 
-    AND m.is_active = true
-GROUP BY m.id, m.parent_id, m.code, m.name, m.path, m.icon, m.sort_order
-ORDER BY m.sort_order, m.name;
+-- name: GetUserProfile :one
+-- User, role dan menu dalam satu round-trip untuk GetProfile. Role dan
+-- menu dikirim sebagai array JSON (di-decode di auth_mapper.go);
+-- user tanpa role/menu mendapat '[]'. Urutan menu dibuat total (root
+-- dulu, lalu sort_order, name, code) supaya sidebar tidak berubah-ubah
+-- antar request.
+SELECT
+    u.id,
+    u.username,
+    u.email,
+    u.full_name,
+    u.is_active,
+    u.last_login_at,
+    u.created_at,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object(
+            'id', r.id,
+            'code', r.code,
+            'name', r.name,
+            'description', r.description,
+            'assigned_at', ur.assigned_at
+        ) ORDER BY r.name)
+        FROM roles r
+        INNER JOIN user_roles ur ON r.id = ur.role_id
+        WHERE ur.user_id = u.id
+            AND r.is_active = true
+    ), '[]'::jsonb)::jsonb AS roles,
+    COALESCE((
+        SELECT jsonb_agg(to_jsonb(um) ORDER BY um.parent_id NULLS FIRST, um.sort_order, um.name, um.code)
+        FROM (
+            SELECT
+                m.id,
+                m.parent_id,
+                m.code,
+                m.name,
+                m.path,
+                m.icon,
+                m.sort_order,
+                (MAX(rm.can_create::int) > 0) AS can_create,
+                (MAX(rm.can_read::int) > 0) AS can_read,
+                (MAX(rm.can_update::int) > 0) AS can_update,
+                (MAX(rm.can_delete::int) > 0) AS can_delete
+            FROM menus m
+            INNER JOIN role_menus rm ON m.id = rm.menu_id
+            INNER JOIN user_roles ur ON rm.role_id = ur.role_id
+            WHERE ur.user_id = u.id
+                AND m.is_active = true
+            GROUP BY m.id, m.parent_id, m.code, m.name, m.path, m.icon, m.sort_order
+        ) um
+    ), '[]'::jsonb)::jsonb AS menus
+FROM users u
+WHERE u.id = $1
+    AND u.deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetProfileVersion :one
+-- Bahan ETag GetProfile: updated_at user ditambah counter rbac_version
+-- yang naik setiap role/menu/assignment berubah.
+SELECT
+    u.updated_at,
+    v.version AS rbac_version
+FROM users u
+CROSS JOIN rbac_version v
+WHERE u.id = $1
+    AND u.deleted_at IS NULL;
 
 -- name: AssignRoleToUser :one
 INSERT INTO user_roles (
@@ -111,17 +158,190 @@ RETURNING id, user_id, role_id, assigned_at;
 -- name: CheckUsernameExists :one
 SELECT EXISTS(
     SELECT 1 FROM users 
-    WHERE username = $1 
+    WHERE lower(username) = lower(sqlc.arg(username)) 
     AND deleted_at IS NULL
 ) as exists;
 
 -- name: CheckEmailExists :one
 SELECT EXISTS(
     SELECT 1 FROM users 
-    WHERE email = $1 
+    WHERE lower(email) = lower(sqlc.arg(email)) 
     AND deleted_at IS NULL
 ) as exists;
 
 -- name: RemoveRoleFromUser :exec
 DELETE FROM user_roles
-WHERE user_id = $1 AND role_id = $2;
\ No newline at end of file
+WHERE user_id = $1 AND role_id = $2;
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (
+    id,
+    user_id,
+    family_id,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: GetRefreshToken :one
+SELECT id, user_id, family_id, expires_at, revoked_at, replaced_by
+FROM refresh_tokens
+WHERE id = $1
+LIMIT 1;
+
+-- name: RotateRefreshToken :execrows
+-- hanya token yang belum di-revoke; 0 row = sudah dipakai (reuse/race)
+UPDATE refresh_tokens
+SET revoked_at = NOW(),
+    replaced_by = sqlc.arg(replaced_by)
+WHERE id = sqlc.arg(id)
+    AND revoked_at IS NULL;
+
+-- name: DeleteRefreshTokenFamily :exec
+-- logout: semua refresh token sesi ini (termasuk yang sudah di-rotate)
+-- dihapus, jadi dipakai lagi = invalid token, bukan reuse
+DELETE FROM refresh_tokens
+WHERE family_id = $1
+    AND user_id = $2;
+
+-- name: RevokeAccessToken :exec
+INSERT INTO revoked_tokens (
+    jti,
+    user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsAccessTokenRevoked :one
+SELECT EXISTS (
+    SELECT 1 FROM revoked_tokens WHERE jti = $1
+);
+
+-- name: ListRevokedTokens :many
+SELECT jti, expires_at, revoked_at
+FROM revoked_tokens
+WHERE revoked_at > sqlc.arg(since)
+ORDER BY revoked_at, jti
+LIMIT sqlc.arg(limit_count)
+OFFSET sqlc.arg(offset_count);
+
+-- name: CountRevokedTokens :one
+SELECT COUNT(*)
+FROM revoked_tokens
+WHERE revoked_at > sqlc.arg(since);
+
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (
+    user_id,
+    table_name,
+    record_id,
+    action,
+    new_values
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: GetUserPasswordHash :one
+SELECT password_hash
+FROM users
+WHERE id = $1
+    AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: LockAdminRole :exec
+-- Dipanggil di awal transaksi yang bisa menghabiskan admin aktif (hapus
+-- akun) supaya dua transaksi tidak sama-sama melihat admin lain tersisa.
+SELECT id FROM roles
+WHERE code = 'admin'
+FOR UPDATE;
+
+-- name: ScheduleUserDeletion :one
+-- Jadwal yang sudah ada tidak digeser: mengulang DELETE /auth/account
+-- mengembalikan tanggal yang sama.
+UPDATE users
+SET deletion_due_at = COALESCE(deletion_due_at, sqlc.arg(due_at)),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND deleted_at IS NULL
+RETURNING deletion_due_at;
+
+-- name: CancelUserDeletion :execrows
+-- 0 row = user tidak sedang dijadwalkan untuk dihapus
+UPDATE users
+SET deletion_due_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NULL
+    AND deletion_due_at IS NOT NULL;
+
+-- name: ListUsersDueForDeletion :many
+SELECT id
+FROM users
+WHERE deletion_due_at <= sqlc.arg(now)
+    AND deleted_at IS NULL
+ORDER BY deletion_due_at, id
+LIMIT sqlc.arg(limit_count);
+
+-- name: AnonymizeUser :execrows
+-- Baris user tetap ada karena direferensikan created_by/updated_by di
+-- tabel lain; data pribadinya diganti. Cek deletion_due_at diulang di
+-- sini supaya cancel yang datang bersamaan dengan worker tetap menang.
+UPDATE users
+SET username = 'deleted-' || replace(id::text, '-', ''),
+    email = 'deleted-' || id::text || '@invalid',
+    password_hash = '',
+    full_name = 'Deleted user',
+    is_active = false,
+    last_login_at = NULL,
+    deletion_due_at = NULL,
+    deleted_at = sqlc.arg(now),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND deleted_at IS NULL
+    AND deletion_due_at <= sqlc.arg(now);
+
+-- name: DeleteUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = $1;
+
+-- name: DeleteUserRefreshTokens :exec
+DELETE FROM refresh_tokens
+WHERE user_id = $1;
+
+-- name: CreateUserActivation :exec
+-- register ulang tidak mungkin (email unik), tapi token baru menggantikan
+-- yang lama kalau suatu saat dikirim ulang
+INSERT INTO user_activations (
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW();
+
+-- name: HasPendingActivation :one
+SELECT EXISTS (
+    SELECT 1 FROM user_activations WHERE user_id = $1
+);
+
+-- name: ActivateUserByToken :one
+-- token dipakai sekali: row aktivasi dihapus dan user diaktifkan dalam satu
+-- statement; no rows = token tidak dikenal, sudah dipakai, atau expired
+WITH consumed AS (
+    DELETE FROM user_activations
+    WHERE token_hash = sqlc.arg(token_hash)
+        AND expires_at > sqlc.arg(now)
+    RETURNING user_id
+)
+UPDATE users
+SET is_active = true,
+    updated_at = NOW()
+FROM consumed
+WHERE users.id = consumed.user_id
+    AND users.deleted_at IS NULL
+RETURNING users.id;
@@ -0,0 +1,49 @@
+-- name: CheckCategoryCodeExists :one
+SELECT EXISTS(
+    SELECT 1 FROM categories
+    WHERE code = $1
+) as exists;
+
+-- name: ListCategories :many
+SELECT
+    id,
+    code,
+    name,
+    description,
+    parent_id,
+    is_active,
+    created_at,
+    updated_at
+FROM categories
+ORDER BY name;
+
+-- name: UpdateCategory :execrows
+UPDATE categories
+SET name = $2,
+    description = $3,
+    parent_id = $4,
+    is_active = $5,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories
+WHERE id = $1;
+
+-- name: GetCategoryAncestorIDs :many
+-- UNION (bukan UNION ALL) supaya data yang sudah terlanjur cyclic tidak loop
+WITH RECURSIVE ancestors AS (
+    SELECT c.id, c.parent_id
+    FROM categories c
+    WHERE c.id = $1
+    UNION
+    SELECT p.id, p.parent_id
+    FROM categories p
+    INNER JOIN ancestors a ON p.id = a.parent_id
+)
+SELECT id FROM ancestors;
+
+-- name: LockCategoryTree :exec
+-- Dipanggil di awal transaksi yang memindahkan parent category supaya dua
+-- perpindahan paralel tidak sama-sama lolos cek cycle (A->B dan B->A).
+SELECT pg_advisory_xact_lock(hashtext('categories.parent_id'));
@@ -0,0 +1,68 @@
+-- name: CheckCustomerCodeExists :one
+-- termasuk yang sudah di-soft delete: constraint UNIQUE berlaku untuk semua row
+SELECT EXISTS(
+    SELECT 1 FROM customers
+    WHERE code = $1
+) as exists;
+
+-- name: ListCustomers :many
+SELECT
+    id,
+    code,
+    name,
+    contact_person,
+    email,
+    phone,
+    address,
+    tax_id,
+    credit_limit,
+    payment_terms,
+    is_active,
+    created_at,
+    updated_at,
+    deleted_at
+FROM customers
+WHERE (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+    AND (
+        sqlc.narg(search)::text IS NULL
+        OR name ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR code ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+    )
+ORDER BY name
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountCustomers :one
+SELECT COUNT(*)
+FROM customers
+WHERE (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+    AND (
+        sqlc.narg(search)::text IS NULL
+        OR name ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR code ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+    );
+
+-- Soft delete mengikuti pola internal/shared/softdelete; customer adalah
+-- implementasi referensinya.
+
+-- name: SoftDeleteCustomer :execrows
+UPDATE customers
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NULL;
+
+-- name: RestoreCustomer :execrows
+UPDATE customers
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NOT NULL;
+
+-- name: HardDeleteCustomer :execrows
+-- hanya customer yang sudah di-soft delete; yang masih dipakai sales
+-- order/invoice ditolak FK
+DELETE FROM customers
+WHERE id = $1
+    AND deleted_at IS NOT NULL;
@@ -73,9 +73,11 @@ INSERT INTO products (
     sale_price,
     min_stock,
     max_stock,
-    is_active
+    is_active,
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12
 )
 RETURNING id, code, name, created_at;
 
@@ -97,7 +99,9 @@ SELECT
     p.max_stock,
     p.is_active,
     p.created_at,
-    p.updated_at
+    p.updated_at,
+    p.created_by,
+    p.updated_by
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 INNER JOIN units_of_measure u ON p.uom_id = u.id
@@ -150,6 +154,7 @@ SET name = $2,
     min_stock = $7,
     max_stock = $8,
     is_active = $9,
+    updated_by = $10,
     updated_at = NOW()
 WHERE id = $1 
     AND deleted_at IS NULL;
@@ -367,4 +372,117 @@ INNER JOIN stock_locations sl ON sa.location_id = sl.id
 WHERE ($1::uuid IS NULL OR sa.location_id = $1)
     AND ($2::varchar IS NULL OR sa.status = $2)
 ORDER BY sa.adjustment_date DESC, sa.adjustment_number DESC
-LIMIT $3 OFFSET $4;
\ No newline at end of file
+LIMIT $3 OFFSET $4;
+-- name: LockStockBalance :one
+SELECT quantity
+FROM stock_balances
+WHERE product_id = $1
+    AND location_id = $2
+FOR UPDATE;
+
+-- name: RecordStockMovement :one
+INSERT INTO stock_movements (
+    movement_number,
+    product_id,
+    location_id,
+    movement_type,
+    quantity,
+    reason,
+    reference,
+    movement_date,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, NOW(), $8
+)
+RETURNING *;
+
+-- name: ListProductStockBalances :many
+SELECT
+    sb.location_id,
+    sl.code as location_code,
+    sl.name as location_name,
+    sb.quantity,
+    sb.reserved_qty,
+    sb.available_qty,
+    sb.last_updated
+FROM stock_balances sb
+INNER JOIN stock_locations sl ON sb.location_id = sl.id
+WHERE sb.product_id = $1
+ORDER BY sl.name;
+
+-- name: ListProductStockMovements :many
+SELECT
+    sm.id,
+    sm.movement_number,
+    sm.location_id,
+    sl.name as location_name,
+    sm.movement_type,
+    sm.quantity,
+    sm.reason,
+    sm.reference,
+    sm.movement_date,
+    sm.created_by
+FROM stock_movements sm
+INNER JOIN stock_locations sl ON sm.location_id = sl.id
+WHERE sm.product_id = sqlc.arg(product_id)
+ORDER BY sm.movement_date DESC, sm.created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountProductStockMovements :one
+SELECT COUNT(*)
+FROM stock_movements
+WHERE product_id = $1;
+
+-- name: GetProductReorderPoint :one
+SELECT reorder_point
+FROM products
+WHERE id = $1
+    AND deleted_at IS NULL;
+
+-- name: SetProductReorderPoint :execrows
+UPDATE products
+SET reorder_point = sqlc.narg(reorder_point),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND deleted_at IS NULL;
+
+-- name: SumProductOnHand :one
+SELECT COALESCE(SUM(quantity), 0)::numeric AS on_hand
+FROM stock_balances
+WHERE product_id = $1;
+
+-- name: OpenStockAlert :execrows
+-- 0 row = alert untuk produk ini masih terbuka
+INSERT INTO stock_alerts (
+    product_id,
+    on_hand,
+    reorder_point
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (product_id) WHERE resolved_at IS NULL DO NOTHING;
+
+-- name: ResolveStockAlert :execrows
+UPDATE stock_alerts
+SET resolved_at = NOW()
+WHERE product_id = $1
+    AND resolved_at IS NULL;
+
+-- name: ListOpenStockAlerts :many
+SELECT
+    a.id,
+    a.product_id,
+    p.code AS product_code,
+    p.name AS product_name,
+    a.reorder_point,
+    (
+        SELECT COALESCE(SUM(sb.quantity), 0)
+        FROM stock_balances sb
+        WHERE sb.product_id = a.product_id
+    )::numeric AS on_hand,
+    a.created_at
+FROM stock_alerts a
+INNER JOIN products p ON a.product_id = p.id
+WHERE a.resolved_at IS NULL
+    AND p.deleted_at IS NULL
+ORDER BY a.created_at DESC;
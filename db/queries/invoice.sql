@@ -0,0 +1,38 @@
+-- name: LockCustomerInvoice :one
+SELECT
+    id,
+    customer_id,
+    total_amount,
+    paid_amount,
+    status
+FROM customer_invoices
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ApplyCustomerInvoicePayment :one
+UPDATE customer_invoices
+SET paid_amount = paid_amount + sqlc.arg(amount),
+    status = CASE
+        WHEN paid_amount + sqlc.arg(amount) >= total_amount THEN 'paid'
+        WHEN paid_amount + sqlc.arg(amount) > 0 THEN 'partial'
+        ELSE 'unpaid'
+    END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING total_amount, paid_amount, status;
+
+-- name: ListInvoicePayments :many
+SELECT
+    id,
+    payment_number,
+    payment_date,
+    amount,
+    payment_method,
+    reference,
+    notes,
+    created_by,
+    created_at
+FROM payments
+WHERE invoice_id = $1
+    AND payment_type = 'receivable'
+ORDER BY payment_date, created_at;
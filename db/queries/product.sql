@@ -0,0 +1,42 @@
+-- name: ListProducts :many
+SELECT
+    p.id,
+    p.code,
+    p.name,
+    p.description,
+    p.category_id,
+    p.uom_id,
+    p.product_type,
+    p.cost_price,
+    p.sale_price,
+    p.is_active,
+    p.created_at,
+    p.updated_at,
+    p.created_by,
+    p.updated_by
+FROM products p
+WHERE p.deleted_at IS NULL
+    AND (
+        sqlc.narg(search)::text IS NULL
+        OR p.name ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR p.code ILIKE '%' || sqlc.narg(search)::text || '%'
+    )
+ORDER BY p.name
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountProducts :one
+SELECT COUNT(*)
+FROM products p
+WHERE p.deleted_at IS NULL
+    AND (
+        sqlc.narg(search)::text IS NULL
+        OR p.name ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR p.code ILIKE '%' || sqlc.narg(search)::text || '%'
+    );
+
+-- name: SoftDeleteProduct :execrows
+UPDATE products
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NULL;
@@ -0,0 +1,108 @@
+-- Query untuk GET /rbac/export dan POST /rbac/import.
+-- Semua relasi direferensikan lewat code supaya portable antar environment.
+
+-- name: ListRBACRoles :many
+SELECT code, name, description, is_active
+FROM roles
+ORDER BY code;
+
+-- name: ListRBACMenus :many
+SELECT
+    m.code,
+    p.code AS parent_code,
+    m.name,
+    m.path,
+    m.icon,
+    m.sort_order,
+    m.is_active
+FROM menus m
+LEFT JOIN menus p ON m.parent_id = p.id
+ORDER BY m.code;
+
+-- name: ListRBACGrants :many
+SELECT
+    r.code AS role_code,
+    m.code AS menu_code,
+    rm.can_create,
+    rm.can_read,
+    rm.can_update,
+    rm.can_delete
+FROM role_menus rm
+INNER JOIN roles r ON rm.role_id = r.id
+INNER JOIN menus m ON rm.menu_id = m.id
+ORDER BY r.code, m.code;
+
+-- name: ImportRole :one
+INSERT INTO roles (
+    code,
+    name,
+    description,
+    is_active
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (code) DO UPDATE SET
+    name = EXCLUDED.name,
+    description = EXCLUDED.description,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id;
+
+-- name: ImportMenu :one
+INSERT INTO menus (
+    parent_id,
+    code,
+    name,
+    path,
+    icon,
+    sort_order,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (code) DO UPDATE SET
+    parent_id = EXCLUDED.parent_id,
+    name = EXCLUDED.name,
+    path = EXCLUDED.path,
+    icon = EXCLUDED.icon,
+    sort_order = EXCLUDED.sort_order,
+    is_active = EXCLUDED.is_active
+RETURNING id;
+
+-- name: ImportRoleMenu :exec
+INSERT INTO role_menus (
+    role_id,
+    menu_id,
+    can_create,
+    can_read,
+    can_update,
+    can_delete
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (role_id, menu_id) DO UPDATE SET
+    can_create = EXCLUDED.can_create,
+    can_read = EXCLUDED.can_read,
+    can_update = EXCLUDED.can_update,
+    can_delete = EXCLUDED.can_delete;
+
+-- name: HasMenuPermission :one
+-- Dipakai middleware RequireMenu: true jika salah satu role aktif
+-- punya permission tersebut di menu aktif.
+SELECT EXISTS (
+    SELECT 1
+    FROM role_menus rm
+    INNER JOIN roles r ON rm.role_id = r.id
+    INNER JOIN menus m ON rm.menu_id = m.id
+    WHERE r.code = ANY(sqlc.arg(role_codes)::text[])
+        AND r.is_active = true
+        AND m.is_active = true
+        AND m.code = sqlc.arg(menu_code)
+        AND CASE sqlc.arg(permission)::text
+            WHEN 'create' THEN rm.can_create
+            WHEN 'read' THEN rm.can_read
+            WHEN 'update' THEN rm.can_update
+            WHEN 'delete' THEN rm.can_delete
+            ELSE false
+        END
+);
@@ -2,9 +2,11 @@
 INSERT INTO roles (
     code,
     name,
-    description
+    description,
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $4
 ) RETURNING *;
 
 -- name: GetRoleByID :one
@@ -15,25 +17,105 @@ WHERE id = $1 LIMIT 1;
 SELECT * FROM roles
 WHERE code = $1 LIMIT 1;
 
+-- name: GetRolesByCodes :many
+-- Code yang tidak ditemukan tidak muncul; caller membandingkan code-nya.
+SELECT * FROM roles
+WHERE code = ANY(sqlc.arg(codes)::text[])
+ORDER BY code;
+
 -- name: UpdateRole :one
+-- no rows = role tidak ada atau version sudah berubah (update bersamaan)
 UPDATE roles
 SET 
     name = $2,
     description = $3,
     is_active = $4,
+    updated_by = $5,
+    version = version + 1,
     updated_at = NOW()
 WHERE id = $1
+    AND version = $6
 RETURNING *;
 
 -- name: DeleteRole :exec
 DELETE FROM roles
 WHERE id = $1;
 
+-- name: CountRoleUsers :one
+SELECT COUNT(*) FROM user_roles
+WHERE role_id = $1;
+
+-- name: ListUsersByRole :many
+-- User yang di-soft delete tidak ikut; urut dari assignment terlama.
+SELECT
+    u.id,
+    u.username,
+    u.email,
+    u.full_name,
+    u.is_active,
+    ur.assigned_at
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+WHERE ur.role_id = sqlc.arg(role_id)
+  AND u.deleted_at IS NULL
+ORDER BY ur.assigned_at, u.username
+LIMIT sqlc.arg(limit_count)
+OFFSET sqlc.arg(offset_count);
+
+-- name: CountUsersByRole :one
+SELECT COUNT(*)
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+WHERE ur.role_id = $1
+  AND u.deleted_at IS NULL;
+
 -- name: ListRoles :many
+-- limit_count NULL = semua row
 SELECT * FROM roles
-ORDER BY created_at DESC;
+WHERE (
+        sqlc.narg(search)::text IS NULL
+        OR code ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR name ILIKE '%' || sqlc.narg(search)::text || '%'
+    )
+    AND (sqlc.narg(is_active)::boolean IS NULL OR is_active = sqlc.narg(is_active)::boolean)
+ORDER BY created_at DESC, id
+LIMIT sqlc.narg(limit_count)::int OFFSET sqlc.arg(offset_count)::int;
 
 -- name: UpdateRoleStatus :exec
 UPDATE roles
-SET is_active = $2, updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+SET is_active = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1;
+-- name: CopyRoleMenus :exec
+INSERT INTO role_menus (
+    role_id,
+    menu_id,
+    can_create,
+    can_read,
+    can_update,
+    can_delete
+)
+SELECT
+    sqlc.arg(target_role_id)::uuid,
+    rm.menu_id,
+    rm.can_create,
+    rm.can_read,
+    rm.can_update,
+    rm.can_delete
+FROM role_menus rm
+WHERE rm.role_id = sqlc.arg(source_role_id)::uuid;
+
+-- name: ListExistingUserIDs :many
+SELECT id FROM users
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+    AND deleted_at IS NULL;
+
+-- name: AssignRoleIfMissing :execrows
+-- 0 row = user sudah punya role ini
+INSERT INTO user_roles (
+    user_id,
+    role_id,
+    assigned_by
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, role_id) DO NOTHING;
@@ -0,0 +1,50 @@
+-- Query untuk cmd/seed: semuanya idempotent (aman dijalankan berulang)
+
+-- name: UpsertRole :one
+-- DO UPDATE no-op supaya RETURNING tetap mengembalikan row yang sudah ada
+INSERT INTO roles (
+    code,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (code) DO UPDATE SET code = EXCLUDED.code
+RETURNING *;
+
+-- name: UpsertMenu :one
+INSERT INTO menus (
+    parent_id,
+    code,
+    name,
+    path,
+    icon,
+    sort_order
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (code) DO UPDATE SET code = EXCLUDED.code
+RETURNING *;
+
+-- name: GrantRoleMenu :exec
+INSERT INTO role_menus (
+    role_id,
+    menu_id,
+    can_create,
+    can_read,
+    can_update,
+    can_delete
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (role_id, menu_id) DO NOTHING;
+
+-- name: AssignRoleToUserIfMissing :exec
+INSERT INTO user_roles (
+    user_id,
+    role_id,
+    assigned_by
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, role_id) DO NOTHING;
@@ -0,0 +1,64 @@
+-- name: ListUsersForExport :many
+-- keyset pagination by username supaya export tidak perlu OFFSET
+SELECT
+    u.id,
+    u.username,
+    u.email,
+    u.full_name,
+    u.is_active,
+    u.last_login_at,
+    COALESCE(
+        array_agg(r.code ORDER BY r.code) FILTER (WHERE r.id IS NOT NULL),
+        '{}'
+    )::text[] as role_codes
+FROM users u
+LEFT JOIN user_roles ur ON ur.user_id = u.id
+LEFT JOIN roles r ON r.id = ur.role_id
+WHERE u.deleted_at IS NULL
+    AND (sqlc.narg(is_active)::boolean IS NULL OR u.is_active = sqlc.narg(is_active)::boolean)
+    AND (
+        sqlc.narg(role_code)::text IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM user_roles fur
+            INNER JOIN roles fr ON fr.id = fur.role_id
+            WHERE fur.user_id = u.id
+                AND fr.code = sqlc.narg(role_code)::text
+        )
+    )
+    AND (sqlc.narg(after_username)::text IS NULL OR u.username > sqlc.narg(after_username)::text)
+GROUP BY u.id
+ORDER BY u.username
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetUserForUpdate :one
+SELECT id, username, email, full_name, is_active, last_login_at, created_at, updated_at
+FROM users
+WHERE id = $1
+    AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: UpdateUser :one
+UPDATE users
+SET email = sqlc.arg(email),
+    full_name = sqlc.arg(full_name),
+    is_active = sqlc.narg(is_active),
+    updated_by = sqlc.narg(updated_by),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND deleted_at IS NULL
+RETURNING id, username, email, full_name, is_active, last_login_at, created_at, updated_at, created_by, updated_by;
+
+-- name: CountActiveAdmins :one
+-- admin = user aktif yang memegang role 'admin' (seed.RoleAdmin) yang aktif.
+-- remaining: admin selain user_id; is_admin: user_id sendiri admin aktif.
+SELECT
+    COUNT(DISTINCT u.id) FILTER (WHERE u.id <> sqlc.arg(user_id)::uuid) AS remaining,
+    COALESCE(bool_or(u.id = sqlc.arg(user_id)::uuid), false)::boolean AS is_admin
+FROM users u
+INNER JOIN user_roles ur ON ur.user_id = u.id
+INNER JOIN roles r ON r.id = ur.role_id
+WHERE r.code = 'admin'
+    AND r.is_active = true
+    AND u.is_active = true
+    AND u.deleted_at IS NULL;
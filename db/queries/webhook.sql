@@ -0,0 +1,75 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+    url,
+    secret,
+    event_types,
+    is_active
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+ORDER BY created_at DESC, id;
+
+-- name: ListActiveWebhooks :many
+-- pencocokan event type dilakukan di Go (webhook.Matches)
+SELECT * FROM webhooks
+WHERE is_active = true;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks
+SET url = $2,
+    event_types = $3,
+    is_active = $4,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE id = $1;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    webhook_id,
+    url,
+    event_id,
+    event_type,
+    attempt,
+    status_code,
+    error,
+    succeeded,
+    duration_ms
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE webhook_id = $1;
+
+-- name: CreateWebhookDeadLetter :exec
+INSERT INTO webhook_dead_letters (
+    webhook_id,
+    url,
+    event_id,
+    event_type,
+    payload,
+    attempts,
+    last_error
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"go-mini-erp/internal/shared/clock"
+	"go-mini-erp/internal/shared/ratelimit"
+	"go-mini-erp/internal/shared/validation"
+)
+
+// ActivationSender delivers the activation token of a newly registered
+// user, biasanya lewat email
+type ActivationSender interface {
+	SendActivation(ctx context.Context, email, token string) error
+}
+
+// LogActivationSender writes the activation link to the log. Repo ini
+// belum punya mailer; dipakai sampai ada sender email sungguhan, jangan
+// di production karena siapa pun yang membaca log bisa mengaktifkan akun.
+type LogActivationSender struct {
+	// URL is the page the link points to; token ditambahkan sebagai ?token=
+	URL string
+}
+
+func (s LogActivationSender) SendActivation(_ context.Context, email, token string) error {
+	log.Printf("auth: activation link for %s: %s", email, activationLink(s.URL, token))
+	return nil
+}
+
+func activationLink(base, token string) string {
+	return base + "?token=" + url.QueryEscape(token)
+}
+
+/*
+AccountActivation mewajibkan konfirmasi email sebelum user hasil Register
+bisa login. Register membuat user is_active = false beserta token aktivasi
+(hanya hash-nya yang disimpan) lalu mengirim token lewat sender; Login
+mengembalikan ErrAccountNotActivated selama token belum dipakai, dan
+Activate menghapus token sekaligus menyalakan is_active.
+*/
+type AccountActivation struct {
+	repo   Repository
+	ttl    time.Duration
+	sender ActivationSender
+	clock  clock.Clock
+}
+
+// NewAccountActivation: ttl = masa berlaku token, clk nil = wall clock
+func NewAccountActivation(repo Repository, ttl time.Duration, sender ActivationSender, clk clock.Clock) *AccountActivation {
+	return &AccountActivation{
+		repo:   repo,
+		ttl:    ttl,
+		sender: sender,
+		clock:  clock.OrReal(clk),
+	}
+}
+
+// issue stores a new token for userID through repo (yang bisa terikat ke
+// transaksi Register) and returns the raw token for send
+func (a *AccountActivation) issue(ctx context.Context, repo Repository, userID uuid.UUID) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate activation token failed: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	if err := repo.CreateUserActivation(ctx, userID, hashActivationToken(token), a.clock.Now().Add(a.ttl)); err != nil {
+		return "", fmt.Errorf("create activation failed: %w", err)
+	}
+	return token, nil
+}
+
+// send is called after Register commits; user sudah tersimpan, jadi
+// kegagalan kirim hanya di-log
+func (a *AccountActivation) send(ctx context.Context, email, token string) {
+	if err := a.sender.SendActivation(ctx, email, token); err != nil {
+		log.Printf("auth: send activation to %s failed: %v", email, err)
+	}
+}
+
+// pending reports whether userID registered but has not activated yet
+func (a *AccountActivation) pending(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return a.repo.HasPendingActivation(ctx, userID)
+}
+
+// Activate consumes token and activates its user; token tidak dikenal,
+// sudah dipakai, atau expired = ErrActivationTokenInvalid
+func (a *AccountActivation) Activate(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrActivationTokenInvalid
+	}
+
+	_, err := a.repo.ActivateUserByToken(ctx, hashActivationToken(token), a.clock.Now())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrActivationTokenInvalid
+		}
+		return err
+	}
+	return nil
+}
+
+// Resend issues a new token for email and sends it, replacing the old one,
+// but only while the account is still waiting for activation. Email tidak
+// dikenal atau akun yang sudah aktif tidak mengubah apa pun dan tidak
+// mengembalikan error, supaya response tidak membocorkan akun mana yang ada.
+func (a *AccountActivation) Resend(ctx context.Context, email string) error {
+	user, err := a.repo.GetUserByEmail(ctx, validation.NormalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	pending, err := a.pending(ctx, user.ID)
+	if err != nil || !pending {
+		return err
+	}
+
+	token, err := a.issue(ctx, a.repo, user.ID)
+	if err != nil {
+		return err
+	}
+	a.send(ctx, user.Email, token)
+	return nil
+}
+
+// ResendLimit caps POST /auth/resend-verification. PerIP yang terlampaui
+// dijawab 429; PerEmail yang terlampaui tetap 200 tapi email tidak dikirim,
+// supaya inbox korban tidak dibanjiri dan respons tetap seragam.
+type ResendLimit struct {
+	Limiter  ratelimit.RateLimiter
+	PerIP    int
+	PerEmail int
+	Window   time.Duration
+}
+
+// allowEmail reports whether another resend to email fits the quota;
+// backend error = boleh, sama seperti RateLimitMiddleware
+func (l ResendLimit) allowEmail(ctx context.Context, email string) bool {
+	res, err := l.Limiter.Allow(ctx, "resend:email:"+validation.NormalizeEmail(email), l.PerEmail, l.Window)
+	if err != nil {
+		log.Printf("resend limiter unavailable, allowing resend: %v", err)
+		return true
+	}
+	return res.Allowed
+}
+
+func hashActivationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
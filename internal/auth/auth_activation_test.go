@@ -0,0 +1,291 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-mini-erp/internal/auth"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/ratelimit"
+)
+
+// activationStore menyimpan satu user dan token aktivasinya di memori
+type activationStore struct {
+	auth.Repository // method lain tidak dipakai Register/Login/Activate
+
+	user      db.GetUserByEmailRow
+	tokenHash string
+	expiresAt time.Time
+}
+
+func (s *activationStore) WithQuerier(q db.Querier) auth.Repository { return s }
+
+func (s *activationStore) CheckUsernameExists(ctx context.Context, username string) (bool, error) {
+	return false, nil
+}
+
+func (s *activationStore) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	return false, nil
+}
+
+func (s *activationStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+	s.user = db.GetUserByEmailRow{
+		ID:           uuid.New(),
+		Username:     arg.Username,
+		Email:        arg.Email,
+		PasswordHash: arg.PasswordHash,
+		IsActive:     arg.IsActive,
+	}
+	return db.CreateUserRow{ID: s.user.ID, Username: arg.Username, Email: arg.Email, IsActive: arg.IsActive}, nil
+}
+
+func (s *activationStore) GetUserByEmail(ctx context.Context, email string) (db.GetUserByEmailRow, error) {
+	if email != s.user.Email {
+		return db.GetUserByEmailRow{}, pgx.ErrNoRows
+	}
+	return s.user, nil
+}
+
+func (s *activationStore) CreateUserActivation(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	s.tokenHash = tokenHash
+	s.expiresAt = expiresAt
+	return nil
+}
+
+func (s *activationStore) HasPendingActivation(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return userID == s.user.ID && s.tokenHash != "", nil
+}
+
+func (s *activationStore) ActivateUserByToken(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	if tokenHash == "" || tokenHash != s.tokenHash || !now.Before(s.expiresAt) {
+		return uuid.Nil, pgx.ErrNoRows
+	}
+	s.tokenHash = ""
+	active := true
+	s.user.IsActive = &active
+	return s.user.ID, nil
+}
+
+func (s *activationStore) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]db.GetUserRolesRow, error) {
+	return nil, nil
+}
+
+func (s *activationStore) CreateRefreshToken(ctx context.Context, arg db.CreateRefreshTokenParams) error {
+	return nil
+}
+
+func (s *activationStore) UpdateUserLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+// tokenOutbox menangkap token yang dikirim setelah Register
+type tokenOutbox struct {
+	email, token string
+}
+
+func (o *tokenOutbox) SendActivation(ctx context.Context, email, token string) error {
+	o.email, o.token = email, token
+	return nil
+}
+
+func newActivationFixture(t *testing.T) (auth.Service, *gin.Engine, *activationStore, *tokenOutbox) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := &activationStore{}
+	outbox := &tokenOutbox{}
+	activation := auth.NewAccountActivation(store, time.Hour, outbox, nil)
+	service := auth.NewService(store, directTx{}, &jwtManagerStub{}, nil, "", nil, nil, activation)
+
+	handler := auth.NewHandler(service, nil)
+	handler.EnableAccountActivation(activation, auth.ResendLimit{
+		Limiter:  ratelimit.NewMemoryLimiter(),
+		PerIP:    5,
+		PerEmail: 2,
+		Window:   time.Hour,
+	})
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+
+	return service, router, store, outbox
+}
+
+func activate(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/activate?token="+token, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func resendVerification(router *gin.Engine, email string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/resend-verification",
+		bytes.NewBufferString(`{"email":"`+email+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func registerNewbie(t *testing.T, service auth.Service) {
+	t.Helper()
+	_, err := service.Register(context.Background(), auth.RegisterRequest{
+		Username: "newbie",
+		Email:    "newbie@example.com",
+		Password: "password123",
+		FullName: "New User",
+	})
+	require.NoError(t, err)
+}
+
+func TestActivation_LoginBlockedUntilActivated(t *testing.T) {
+	service, router, store, outbox := newActivationFixture(t)
+	ctx := context.Background()
+
+	_, err := service.Register(ctx, auth.RegisterRequest{
+		Username: "newbie",
+		Email:    "Newbie@Example.com",
+		Password: "password123",
+		FullName: "New User",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, store.user.IsActive)
+	assert.False(t, *store.user.IsActive)
+	assert.Equal(t, "newbie@example.com", outbox.email)
+	require.NotEmpty(t, outbox.token)
+	assert.NotEqual(t, outbox.token, store.tokenHash, "only the hash is stored")
+
+	login := auth.LoginRequest{Email: "newbie@example.com", Password: "password123"}
+	_, err = service.Login(ctx, login)
+	assert.ErrorIs(t, err, auth.ErrAccountNotActivated)
+
+	w := activate(router, outbox.token)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	res, err := service.Login(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", res.AccessToken)
+
+	// token hanya bisa dipakai sekali
+	w = activate(router, outbox.token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestActivation_LoginHandlerReturns403(t *testing.T) {
+	service, router, _, _ := newActivationFixture(t)
+	registerNewbie(t, service)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+		bytes.NewBufferString(`{"email":"newbie@example.com","password":"password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "account_not_activated")
+}
+
+func TestActivation_InvalidToken(t *testing.T) {
+	_, router, _, _ := newActivationFixture(t)
+
+	assert.Equal(t, http.StatusBadRequest, activate(router, "").Code)
+	assert.Equal(t, http.StatusBadRequest, activate(router, "unknown").Code)
+}
+
+func TestActivation_DeactivatedUserStaysInactive(t *testing.T) {
+	service, _, store, _ := newActivationFixture(t)
+
+	// user lama yang dinonaktifkan admin tidak punya token aktivasi
+	inactive := false
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	store.user = db.GetUserByEmailRow{ID: uuid.New(), Email: "old@example.com", PasswordHash: string(hash), IsActive: &inactive}
+
+	_, err = service.Login(context.Background(), auth.LoginRequest{Email: "old@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, auth.ErrUserInactive)
+
+	_, err = service.Login(context.Background(), auth.LoginRequest{Email: "old@example.com", Password: "wrong-password"})
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+}
+
+func TestActivation_WrongPasswordHidesPendingStatus(t *testing.T) {
+	service, _, _, _ := newActivationFixture(t)
+	registerNewbie(t, service)
+
+	// status akun baru terlihat setelah password cocok
+	_, err := service.Login(context.Background(), auth.LoginRequest{Email: "newbie@example.com", Password: "wrong-password"})
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	assert.NotErrorIs(t, err, auth.ErrAccountNotActivated)
+}
+
+func TestResendVerification_RegeneratesToken(t *testing.T) {
+	service, router, store, outbox := newActivationFixture(t)
+	registerNewbie(t, service)
+	oldToken, oldHash := outbox.token, store.tokenHash
+
+	w := resendVerification(router, "Newbie@Example.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	require.NotEmpty(t, outbox.token)
+	assert.NotEqual(t, oldToken, outbox.token)
+	assert.NotEqual(t, oldHash, store.tokenHash)
+
+	// token lama tidak berlaku lagi, yang baru mengaktifkan akun
+	assert.Equal(t, http.StatusBadRequest, activate(router, oldToken).Code)
+	assert.Equal(t, http.StatusOK, activate(router, outbox.token).Code)
+}
+
+func TestResendVerification_NoOpStill200(t *testing.T) {
+	service, router, _, outbox := newActivationFixture(t)
+	registerNewbie(t, service)
+	require.Equal(t, http.StatusOK, activate(router, outbox.token).Code)
+	*outbox = tokenOutbox{}
+
+	tests := []struct {
+		name  string
+		email string
+	}{
+		{"already activated", "newbie@example.com"},
+		{"unknown email", "nobody@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := resendVerification(router, tt.email)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, outbox.token, "nothing is sent")
+		})
+	}
+}
+
+func TestResendVerification_RateLimited(t *testing.T) {
+	service, router, _, outbox := newActivationFixture(t)
+	registerNewbie(t, service)
+
+	// PerEmail = 2: kiriman ketiga diam-diam dilewati, respons tetap 200
+	for range 2 {
+		require.Equal(t, http.StatusOK, resendVerification(router, "newbie@example.com").Code)
+	}
+	sent := outbox.token
+	assert.Equal(t, http.StatusOK, resendVerification(router, "newbie@example.com").Code)
+	assert.Equal(t, sent, outbox.token, "per-email quota reached, no new email")
+
+	// PerIP = 5: request keenam dari IP yang sama ditolak
+	for range 2 {
+		require.Equal(t, http.StatusOK, resendVerification(router, "other@example.com").Code)
+	}
+	w := resendVerification(router, "other@example.com")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
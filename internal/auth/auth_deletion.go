@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-mini-erp/internal/shared/clock"
+	"go-mini-erp/internal/shared/database"
+	dbgen "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// AuditAccountDeleted is the audit_logs.action written when a user is anonymized
+const AuditAccountDeleted = "account_deleted"
+
+// purgeBatchSize: jumlah user yang dianonimkan per query list
+const purgeBatchSize = 100
+
+/*
+AccountDeletion menjadwalkan penghapusan akun oleh user sendiri.
+Schedule hanya mengisi deletion_due_at; selama masa tenggang user masih
+bisa login (LoginResponse.PendingDeletionAt terisi) dan Cancel
+mengembalikan akunnya. Setelah lewat, PurgeDue (dipanggil Run secara
+berkala) mengganti data pribadi user dan mencabut role serta refresh
+token-nya. Baris users tidak dihapus karena direferensikan tabel lain.
+*/
+type AccountDeletion struct {
+	repo  Repository
+	tx    database.Transactor
+	grace time.Duration
+	clock clock.Clock
+}
+
+// NewAccountDeletion: clk nil = wall clock
+func NewAccountDeletion(repo Repository, tx database.Transactor, grace time.Duration, clk clock.Clock) *AccountDeletion {
+	return &AccountDeletion{
+		repo:  repo,
+		tx:    tx,
+		grace: grace,
+		clock: clock.OrReal(clk),
+	}
+}
+
+// Schedule checks password and sets the account to be deleted after the
+// grace period. Calling it again keeps the original due date. The last
+// active admin gets ErrLastAdmin.
+func (d *AccountDeletion) Schedule(ctx context.Context, userID uuid.UUID, password string) (*AccountDeletionResponse, error) {
+	hash, err := d.repo.GetUserPasswordHash(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return nil, ErrPasswordConfirmation
+	}
+
+	var due time.Time
+	err = d.tx.WithTx(ctx, func(q dbgen.Querier) error {
+		repo := d.repo.WithQuerier(q)
+
+		if err := guardLastAdmin(ctx, repo, userID); err != nil {
+			return err
+		}
+
+		due, err = repo.ScheduleUserDeletion(ctx, userID, d.clock.Now().Add(d.grace))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountDeletionResponse{DeletionDueAt: due}, nil
+}
+
+// guardLastAdmin returns ErrLastAdmin when userID is the only active
+// admin; admin yang sedang menunggu penghapusan masih dihitung, karena
+// PurgeDue mengecek ulang sebelum menganonimkan
+func guardLastAdmin(ctx context.Context, repo Repository, userID uuid.UUID) error {
+	if err := repo.LockAdminRole(ctx); err != nil {
+		return err
+	}
+	admins, err := repo.CountActiveAdmins(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if admins.IsAdmin && admins.Remaining == 0 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// Cancel restores an account still in its grace period
+func (d *AccountDeletion) Cancel(ctx context.Context, userID uuid.UUID) error {
+	n, err := d.repo.CancelUserDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDeletionNotScheduled
+	}
+	return nil
+}
+
+// PurgeDue anonymizes every user whose grace period has passed and
+// returns how many were anonymized; admin aktif terakhir dilewati
+func (d *AccountDeletion) PurgeDue(ctx context.Context) (int, error) {
+	now := d.clock.Now()
+	purged := 0
+
+	for {
+		ids, err := d.repo.ListUsersDueForDeletion(ctx, now, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		batch := 0
+		for _, id := range ids {
+			ok, err := d.anonymize(ctx, id, now)
+			if errors.Is(err, ErrLastAdmin) {
+				// tetap terjadwal; dianonimkan begitu ada admin lain
+				log.Printf("auth: keeping account %s scheduled for deletion, it is the last active admin", id)
+				continue
+			}
+			if err != nil {
+				return purged, fmt.Errorf("anonymize user %s: %w", id, err)
+			}
+			if ok {
+				batch++
+			}
+		}
+		purged += batch
+
+		// batch penuh berisi admin terakhir saja akan terus kembali
+		if len(ids) < purgeBatchSize || batch == 0 {
+			return purged, nil
+		}
+	}
+}
+
+// anonymize returns false if the deletion was cancelled in the meantime
+func (d *AccountDeletion) anonymize(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	anonymized := false
+
+	err := d.tx.WithTx(ctx, func(q dbgen.Querier) error {
+		repo := d.repo.WithQuerier(q)
+
+		if err := guardLastAdmin(ctx, repo, userID); err != nil {
+			return err
+		}
+
+		n, err := repo.AnonymizeUser(ctx, userID, now)
+		if err != nil || n == 0 {
+			return err
+		}
+		anonymized = true
+
+		if err := repo.DeleteUserRoles(ctx, userID); err != nil {
+			return err
+		}
+		if err := repo.DeleteUserRefreshTokens(ctx, userID); err != nil {
+			return err
+		}
+
+		return repo.CreateAuditLog(ctx, dbgen.CreateAuditLogParams{
+			UserID:    dbutil.UUIDPtrToPgUUID(&userID),
+			TableName: "users",
+			RecordID:  userID,
+			Action:    AuditAccountDeleted,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return anonymized, nil
+}
+
+// Run calls PurgeDue every interval until ctx is cancelled
+func (d *AccountDeletion) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *AccountDeletion) purge(ctx context.Context) {
+	n, err := d.PurgeDue(ctx)
+	if err != nil {
+		log.Printf("auth: account deletion purge failed after %d users: %v", n, err)
+		return
+	}
+	if n > 0 {
+		log.Printf("auth: anonymized %d deleted accounts", n)
+	}
+}
@@ -0,0 +1,395 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/shared/clock"
+	"go-mini-erp/internal/shared/ctxkeys"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/ratelimit"
+)
+
+const deletionGrace = 30 * 24 * time.Hour
+
+// deletionUser adalah baris users yang relevan untuk penghapusan akun
+type deletionUser struct {
+	username     string
+	passwordHash string
+	dueAt        *time.Time
+	deletedAt    *time.Time
+	roles        int
+	tokens       int
+	admin        bool
+}
+
+// deletionStore meniru query penghapusan akun di atas map, termasuk
+// guard deletion_due_at <= now di AnonymizeUser
+type deletionStore struct {
+	auth.Repository // method lain tidak dipakai AccountDeletion
+
+	users  map[uuid.UUID]*deletionUser
+	audits []db.CreateAuditLogParams
+}
+
+func newDeletionStore() *deletionStore {
+	return &deletionStore{users: map[uuid.UUID]*deletionUser{}}
+}
+
+func (s *deletionStore) addUser(t *testing.T, username, password string) uuid.UUID {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	id := uuid.New()
+	s.users[id] = &deletionUser{username: username, passwordHash: string(hash), roles: 1, tokens: 2}
+	return id
+}
+
+func (s *deletionStore) active(id uuid.UUID) (*deletionUser, bool) {
+	u, ok := s.users[id]
+	if !ok || u.deletedAt != nil {
+		return nil, false
+	}
+	return u, true
+}
+
+func (s *deletionStore) WithQuerier(q db.Querier) auth.Repository { return s }
+
+func (s *deletionStore) GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
+	u, ok := s.active(id)
+	if !ok {
+		return "", pgx.ErrNoRows
+	}
+	return u.passwordHash, nil
+}
+
+func (s *deletionStore) ScheduleUserDeletion(ctx context.Context, id uuid.UUID, dueAt time.Time) (time.Time, error) {
+	u, ok := s.active(id)
+	if !ok {
+		return time.Time{}, pgx.ErrNoRows
+	}
+	if u.dueAt == nil {
+		u.dueAt = &dueAt
+	}
+	return *u.dueAt, nil
+}
+
+func (s *deletionStore) CancelUserDeletion(ctx context.Context, id uuid.UUID) (int64, error) {
+	u, ok := s.active(id)
+	if !ok || u.dueAt == nil {
+		return 0, nil
+	}
+	u.dueAt = nil
+	return 1, nil
+}
+
+func (s *deletionStore) ListUsersDueForDeletion(ctx context.Context, now time.Time, limit int32) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, u := range s.users {
+		if u.deletedAt == nil && u.dueAt != nil && !u.dueAt.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	if len(ids) > int(limit) {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (s *deletionStore) AnonymizeUser(ctx context.Context, id uuid.UUID, now time.Time) (int64, error) {
+	u, ok := s.active(id)
+	if !ok || u.dueAt == nil || u.dueAt.After(now) {
+		return 0, nil
+	}
+	u.username = "deleted-" + id.String()
+	u.passwordHash = ""
+	u.dueAt = nil
+	u.deletedAt = &now
+	return 1, nil
+}
+
+func (s *deletionStore) LockAdminRole(ctx context.Context) error { return nil }
+
+func (s *deletionStore) CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error) {
+	var row db.CountActiveAdminsRow
+	for id, u := range s.users {
+		if !u.admin || u.deletedAt != nil {
+			continue
+		}
+		if id == userID {
+			row.IsAdmin = true
+		} else {
+			row.Remaining++
+		}
+	}
+	return row, nil
+}
+
+func (s *deletionStore) DeleteUserRoles(ctx context.Context, userID uuid.UUID) error {
+	s.users[userID].roles = 0
+	s.users[userID].admin = false
+	return nil
+}
+
+func (s *deletionStore) DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	s.users[userID].tokens = 0
+	return nil
+}
+
+func (s *deletionStore) CreateAuditLog(ctx context.Context, arg db.CreateAuditLogParams) error {
+	s.audits = append(s.audits, arg)
+	return nil
+}
+
+func newAccountDeletion(store *deletionStore) (*auth.AccountDeletion, *clock.FakeClock) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	return auth.NewAccountDeletion(store, &fakeTx{}, deletionGrace, clk), clk
+}
+
+func TestAccountDeletion_Schedule(t *testing.T) {
+	store := newDeletionStore()
+	deletion, clk := newAccountDeletion(store)
+	userID := store.addUser(t, "alice", "secret123")
+	ctx := context.Background()
+
+	result, err := deletion.Schedule(ctx, userID, "secret123")
+	require.NoError(t, err)
+
+	want := clk.Now().Add(deletionGrace)
+	assert.Equal(t, want, result.DeletionDueAt)
+	assert.Equal(t, want, *store.users[userID].dueAt)
+
+	// request ulang tidak memperpanjang masa tenggang
+	clk.Advance(24 * time.Hour)
+	result, err = deletion.Schedule(ctx, userID, "secret123")
+	require.NoError(t, err)
+	assert.Equal(t, want, result.DeletionDueAt)
+}
+
+func TestAccountDeletion_ScheduleWrongPassword(t *testing.T) {
+	store := newDeletionStore()
+	deletion, _ := newAccountDeletion(store)
+	userID := store.addUser(t, "alice", "secret123")
+
+	result, err := deletion.Schedule(context.Background(), userID, "wrong")
+
+	assert.ErrorIs(t, err, auth.ErrPasswordConfirmation)
+	assert.Nil(t, result)
+	assert.Nil(t, store.users[userID].dueAt)
+}
+
+func TestAccountDeletion_Cancel(t *testing.T) {
+	store := newDeletionStore()
+	deletion, clk := newAccountDeletion(store)
+	userID := store.addUser(t, "alice", "secret123")
+	ctx := context.Background()
+
+	_, err := deletion.Schedule(ctx, userID, "secret123")
+	require.NoError(t, err)
+
+	require.NoError(t, deletion.Cancel(ctx, userID))
+	assert.Nil(t, store.users[userID].dueAt)
+	assert.ErrorIs(t, deletion.Cancel(ctx, userID), auth.ErrDeletionNotScheduled)
+
+	// setelah dibatalkan, lewat masa tenggang pun akun tidak disentuh
+	clk.Advance(deletionGrace + time.Hour)
+	n, err := deletion.PurgeDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, "alice", store.users[userID].username)
+}
+
+func TestAccountDeletion_PurgeDue(t *testing.T) {
+	store := newDeletionStore()
+	deletion, clk := newAccountDeletion(store)
+	ctx := context.Background()
+
+	early := store.addUser(t, "alice", "secret123")
+	late := store.addUser(t, "bob", "secret456")
+	untouched := store.addUser(t, "carol", "secret789")
+
+	_, err := deletion.Schedule(ctx, early, "secret123")
+	require.NoError(t, err)
+	clk.Advance(48 * time.Hour)
+	_, err = deletion.Schedule(ctx, late, "secret456")
+	require.NoError(t, err)
+
+	// masa tenggang alice habis, bob masih 47 jam lagi
+	clk.Advance(deletionGrace - 47*time.Hour)
+	n, err := deletion.PurgeDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	anonymized := store.users[early]
+	assert.Equal(t, "deleted-"+early.String(), anonymized.username)
+	assert.Empty(t, anonymized.passwordHash)
+	assert.Equal(t, clk.Now(), *anonymized.deletedAt)
+	assert.Zero(t, anonymized.roles)
+	assert.Zero(t, anonymized.tokens)
+
+	require.Len(t, store.audits, 1)
+	assert.Equal(t, early, store.audits[0].RecordID)
+	assert.Equal(t, auth.AuditAccountDeleted, store.audits[0].Action)
+
+	assert.Equal(t, "bob", store.users[late].username)
+	assert.NotNil(t, store.users[late].dueAt)
+
+	clk.Advance(48 * time.Hour)
+	n, err = deletion.PurgeDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NotNil(t, store.users[late].deletedAt)
+
+	assert.Equal(t, "carol", store.users[untouched].username)
+	assert.Nil(t, store.users[untouched].deletedAt)
+}
+
+func TestAccountDeletion_LastAdmin(t *testing.T) {
+	store := newDeletionStore()
+	deletion, clk := newAccountDeletion(store)
+	ctx := context.Background()
+
+	alice := store.addUser(t, "alice", "secret123")
+	bob := store.addUser(t, "bob", "secret456")
+	store.users[alice].admin = true
+
+	// satu-satunya admin tidak bisa menjadwalkan penghapusan akunnya
+	_, err := deletion.Schedule(ctx, alice, "secret123")
+	require.ErrorIs(t, err, auth.ErrLastAdmin)
+	assert.Nil(t, store.users[alice].dueAt)
+
+	// dengan admin kedua keduanya bisa menjadwalkan...
+	store.users[bob].admin = true
+	_, err = deletion.Schedule(ctx, alice, "secret123")
+	require.NoError(t, err)
+	_, err = deletion.Schedule(ctx, bob, "secret456")
+	require.NoError(t, err)
+
+	// ...tapi purge menyisakan satu admin aktif
+	clk.Advance(deletionGrace + time.Hour)
+	n, err := deletion.PurgeDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	var kept []uuid.UUID
+	for _, id := range []uuid.UUID{alice, bob} {
+		if store.users[id].deletedAt == nil {
+			kept = append(kept, id)
+		}
+	}
+	require.Len(t, kept, 1)
+	assert.True(t, store.users[kept[0]].admin)
+	assert.NotNil(t, store.users[kept[0]].dueAt, "stays scheduled until another admin exists")
+}
+
+func newDeletionRouter(t *testing.T, userID uuid.UUID, deletion *auth.AccountDeletion, throttle *auth.LoginThrottle) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler := auth.NewHandler(nil, throttle)
+	handler.EnableAccountDeletion(deletion)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ctxkeys.SetUserID(c, userID.String())
+		c.Next()
+	})
+	router.DELETE("/auth/account", handler.DeleteAccount)
+	router.POST("/auth/account/restore", handler.RestoreAccount)
+	return router
+}
+
+func TestDeleteAccountHandler(t *testing.T) {
+	store := newDeletionStore()
+	deletion, clk := newAccountDeletion(store)
+	userID := store.addUser(t, "alice", "secret123")
+	router := newDeletionRouter(t, userID, deletion, nil)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodDelete, "/auth/account", `{"password":"wrong"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = send(http.MethodDelete, "/auth/account", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send(http.MethodDelete, "/auth/account", `{"password":"secret123"}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var resp auth.AccountDeletionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, clk.Now().Add(deletionGrace).Equal(resp.DeletionDueAt))
+
+	w = send(http.MethodPost, "/auth/account/restore", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = send(http.MethodPost, "/auth/account/restore", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestDeleteAccountHandler_LastAdminIs409(t *testing.T) {
+	store := newDeletionStore()
+	deletion, _ := newAccountDeletion(store)
+	userID := store.addUser(t, "alice", "secret123")
+	store.users[userID].admin = true
+	router := newDeletionRouter(t, userID, deletion, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/auth/account", bytes.NewBufferString(`{"password":"secret123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Nil(t, store.users[userID].dueAt)
+}
+
+func TestDeleteAccountHandler_PasswordGuessesAreThrottled(t *testing.T) {
+	store := newDeletionStore()
+	deletion, _ := newAccountDeletion(store)
+	userID := store.addUser(t, "alice", "secret123")
+	throttle := auth.NewLoginThrottle(ratelimit.NewMemoryLimiter(), auth.LoginThrottleConfig{
+		MaxFailuresPerIdentifier: 2,
+		MaxFailuresPerIP:         100,
+		Window:                   time.Minute,
+	})
+	router := newDeletionRouter(t, userID, deletion, throttle)
+
+	send := func(password string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/auth/account", bytes.NewBufferString(`{"password":"`+password+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, send("wrong-1").Code)
+	assert.Equal(t, http.StatusForbidden, send("wrong-2").Code)
+
+	// budget habis: password benar pun ditolak sampai window lewat
+	w := send("secret123")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Nil(t, store.users[userID].dueAt)
+}
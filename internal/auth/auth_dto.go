@@ -4,12 +4,27 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/pagination"
 )
 
 // Request/Response DTOs
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// TokenDelivery: "cookie" (default, httpOnly) atau "body" untuk client
+	// native yang tidak bisa memakai cookie; "cookie_only" juga mengirim
+	// access token sebagai cookie dan tidak ada token di body
+	TokenDelivery string `json:"tokenDelivery" binding:"omitempty,oneof=cookie body cookie_only" enums:"cookie,body,cookie_only"`
+}
+
+// RefreshTokenRequest is the optional body of /auth/refresh; kosong =
+// refresh token dibaca dari cookie
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+	// TokenDelivery "cookie_only" untuk client cookie yang login dengan
+	// mode itu; diabaikan kalau refreshToken dikirim di body
+	TokenDelivery string `json:"tokenDelivery" binding:"omitempty,oneof=cookie cookie_only" enums:"cookie,cookie_only"`
 }
 
 type RegisterRequest struct {
@@ -20,11 +35,60 @@ type RegisterRequest struct {
 }
 
 type LoginResponse struct {
-	AccessToken  string   `json:"accessToken"`
-	RefreshToken string   `json:"refreshToken"`
+	AccessToken  string   `json:"accessToken,omitempty"`
+	RefreshToken string   `json:"refreshToken,omitempty"`
 	TokenType    string   `json:"tokenType"`
 	ExpiresIn    int      `json:"expiresIn"`
 	User         UserInfo `json:"user"`
+
+	// PendingDeletionAt is set while the account is scheduled for
+	// deletion; POST /auth/account/restore membatalkannya
+	PendingDeletionAt *time.Time `json:"pendingDeletionAt,omitempty"`
+}
+
+// IntrospectRequest follows RFC 7662: form-encoded token, JSON juga diterima
+type IntrospectRequest struct {
+	Token         string `form:"token" json:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint" json:"token_type_hint"`
+}
+
+// IntrospectionResponse follows RFC 7662; an inactive token only carries
+// active=false. Scope berisi role code dipisah spasi.
+type IntrospectionResponse struct {
+	Active   bool     `json:"active"`
+	Sub      string   `json:"sub,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Roles    []string `json:"roles,omitempty"`
+	Exp      int64    `json:"exp,omitempty"`
+	Scope    string   `json:"scope,omitempty"`
+}
+
+// RevokedToken is one deny-list entry of GET /auth/revoked
+type RevokedToken struct {
+	JTI       uuid.UUID `json:"jti"`
+	RevokedAt time.Time `json:"revokedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ListRevokedTokensResponse is pagination.Response[RevokedToken] (untuk swagger)
+type ListRevokedTokensResponse struct {
+	Data []RevokedToken `json:"data"`
+	Meta struct {
+		Total      int64 `json:"total"`
+		Page       int   `json:"page"`
+		PageSize   int   `json:"pageSize"`
+		TotalPages int   `json:"totalPages"`
+	} `json:"meta"`
+	Links *pagination.Links `json:"links,omitempty"`
+}
+
+// WhoAmIResponse is read straight from the access token claims
+type WhoAmIResponse struct {
+	UserID    uuid.UUID `json:"userId"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
 type RegisterResponse struct {
@@ -36,8 +100,8 @@ type RegisterResponse struct {
 }
 
 type TokenResponse struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 	TokenType    string `json:"tokenType"`
 	ExpiresIn    int    `json:"expiresIn"`
 }
@@ -56,6 +120,12 @@ type RoleInfo struct {
 	Name string    `json:"name"`
 }
 
+// AssignRoleRequest is the body of POST /users/:id/roles; assigned_by
+// diisi dari user yang login, bukan dari body
+type AssignRoleRequest struct {
+	RoleID string `json:"roleId" binding:"required,uuid"`
+}
+
 type RoleAssignmentResponse struct {
 	ID         uuid.UUID `json:"id"`
 	UserID     uuid.UUID `json:"userId"`
@@ -87,3 +157,47 @@ type MenuInfo struct {
 	CanUpdate bool       `json:"canUpdate"`
 	CanDelete bool       `json:"canDelete"`
 }
+
+// UserProfileV1 is UserProfile with the snake_case field names API v1
+// keeps; v2 mengirim UserProfile (camelCase)
+type UserProfileV1 struct {
+	ID          uuid.UUID    `json:"id"`
+	Username    string       `json:"username"`
+	Email       string       `json:"email"`
+	FullName    string       `json:"full_name"`
+	IsActive    bool         `json:"is_active"`
+	LastLoginAt *time.Time   `json:"last_login_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Roles       []RoleInfo   `json:"roles"`
+	Menus       []MenuInfoV1 `json:"menus"`
+}
+
+// MenuInfoV1 is MenuInfo in snake_case for UserProfileV1
+type MenuInfoV1 struct {
+	ID        uuid.UUID  `json:"id"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	Path      *string    `json:"path"`
+	Icon      *string    `json:"icon"`
+	CanCreate bool       `json:"can_create"`
+	CanRead   bool       `json:"can_read"`
+	CanUpdate bool       `json:"can_update"`
+	CanDelete bool       `json:"can_delete"`
+}
+
+// ResendVerificationRequest is the body of POST /auth/resend-verification
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// DeleteAccountRequest is the body of DELETE /auth/account; password
+// diminta ulang walau token valid
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// AccountDeletionResponse tells the user when the account will be anonymized
+type AccountDeletionResponse struct {
+	DeletionDueAt time.Time `json:"deletionDueAt"`
+}
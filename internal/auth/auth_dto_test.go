@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/auth"
+)
+
+// jsonKeys returns the top-level keys v marshals to, sorted
+func jsonKeys(t *testing.T, v any) []string {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw, &m))
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/*
+Nama field JSON auth dikunci di sini: semua camelCase, kecuali
+IntrospectRequest/IntrospectionResponse yang mengikuti RFC 7662. Kalau
+test ini gagal, perubahannya breaking untuk client.
+*/
+func TestAuthDTO_JSONFieldNames(t *testing.T) {
+	parentID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		dto  any
+		keys []string
+	}{
+		{"LoginRequest", auth.LoginRequest{}, []string{"email", "password", "tokenDelivery"}},
+		{"RefreshTokenRequest", auth.RefreshTokenRequest{}, []string{"refreshToken", "tokenDelivery"}},
+		{"RegisterRequest", auth.RegisterRequest{}, []string{"email", "fullName", "password", "username"}},
+		{"LoginResponse", auth.LoginResponse{AccessToken: "a", RefreshToken: "r", PendingDeletionAt: &now}, []string{"accessToken", "expiresIn", "pendingDeletionAt", "refreshToken", "tokenType", "user"}},
+		{"TokenResponse", auth.TokenResponse{AccessToken: "a", RefreshToken: "r"}, []string{"accessToken", "expiresIn", "refreshToken", "tokenType"}},
+		{"UserInfo", auth.UserInfo{}, []string{"email", "fullName", "id", "roles", "username"}},
+		{"RoleInfo", auth.RoleInfo{}, []string{"code", "id", "name"}},
+		{"RegisterResponse", auth.RegisterResponse{}, []string{"createdAt", "email", "fullName", "id", "username"}},
+		{"WhoAmIResponse", auth.WhoAmIResponse{}, []string{"email", "expiresAt", "roles", "userId", "username"}},
+		{"AssignRoleRequest", auth.AssignRoleRequest{}, []string{"roleId"}},
+		{"DeleteAccountRequest", auth.DeleteAccountRequest{}, []string{"password"}},
+		{"AccountDeletionResponse", auth.AccountDeletionResponse{}, []string{"deletionDueAt"}},
+		{"RoleAssignmentResponse", auth.RoleAssignmentResponse{}, []string{"assignedAt", "id", "roleId", "userId"}},
+		{"UserProfile", auth.UserProfile{}, []string{"createdAt", "email", "fullName", "id", "isActive", "lastLoginAt", "menus", "roles", "username"}},
+		{"MenuInfo", auth.MenuInfo{ParentID: &parentID}, []string{"canCreate", "canDelete", "canRead", "canUpdate", "code", "icon", "id", "name", "parentId", "path"}},
+		{"RevokedToken", auth.RevokedToken{}, []string{"expiresAt", "jti", "revokedAt"}},
+		{"ListRevokedTokensResponse", auth.ListRevokedTokensResponse{}, []string{"data", "meta"}},
+		{"IntrospectRequest", auth.IntrospectRequest{}, []string{"token", "token_type_hint"}},
+		{"IntrospectionResponse", auth.IntrospectionResponse{Active: true, Sub: "s", Username: "u", Roles: []string{"r"}, Exp: 1, Scope: "r"}, []string{"active", "exp", "roles", "scope", "sub", "username"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.keys, jsonKeys(t, tt.dto))
+		})
+	}
+}
+
+func TestListRevokedTokensResponse_MetaFieldNames(t *testing.T) {
+	var resp auth.ListRevokedTokensResponse
+
+	assert.Equal(t, []string{"page", "pageSize", "total", "totalPages"}, jsonKeys(t, resp.Meta))
+}
@@ -10,4 +10,23 @@ var (
 	ErrEmailExists        = errors.New("email already exists")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenExpired       = errors.New("token expired")
+	ErrLastAdmin          = errors.New("cannot remove the admin role from the last active admin")
+
+	// ErrRoleAlreadyAssigned: pasangan user-role sudah ada (UNIQUE user_roles)
+	ErrRoleAlreadyAssigned = errors.New("role already assigned to user")
+	ErrRoleNotFound        = errors.New("role not found")
+
+	// ErrPasswordConfirmation: password yang dikirim ulang untuk aksi
+	// sensitif (mis. hapus akun) tidak cocok
+	ErrPasswordConfirmation = errors.New("password confirmation failed")
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+
+	// ErrRefreshTokenReused: refresh token yang sudah di-rotate dipakai lagi,
+	// kemungkinan token dicuri. Client harus login ulang.
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+
+	// ErrAccountNotActivated: user belum membuka link aktivasi; beda dengan
+	// ErrUserInactive yang berarti dinonaktifkan admin
+	ErrAccountNotActivated    = errors.New("account is not activated")
+	ErrActivationTokenInvalid = errors.New("invalid or expired activation token")
 )
@@ -2,31 +2,120 @@ package auth
 
 import (
 	"errors"
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/apiversion"
+	"go-mini-erp/internal/shared/httpcache"
 	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/validation"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// Cara refresh token dikirim ke client, dipilih per login
+const (
+	TokenDeliveryCookie = "cookie"
+	TokenDeliveryBody   = "body"
+	// TokenDeliveryCookieOnly also puts the access token in a short-lived
+	// httpOnly cookie and leaves both tokens out of the body (web app).
+	// Butuh ACCESS_TOKEN_COOKIE agar AuthMiddleware membaca cookie itu.
+	TokenDeliveryCookieOnly = "cookie_only"
+)
+
+const refreshCookieName = "refresh_token"
+
 type Handler struct {
-	service Service
+	service     Service
+	throttle    *LoginThrottle
+	gatewayKeys []string
+	deletion    *AccountDeletion
+	activation  *AccountActivation
+	resend      ResendLimit
 }
 
-func NewHandler(service Service) *Handler {
+// NewHandler creates auth handler; throttle nil = login tidak di-throttle
+func NewHandler(service Service, throttle *LoginThrottle) *Handler {
 	return &Handler{
-		service: service,
+		service:  service,
+		throttle: throttle,
 	}
 }
 
+// EnableGatewayEndpoints registers POST /auth/introspect and GET
+// /auth/revoked for clients sending one of apiKeys; tanpa key kedua
+// endpoint tidak didaftarkan
+func (h *Handler) EnableGatewayEndpoints(apiKeys []string) {
+	h.gatewayKeys = apiKeys
+}
+
+// EnableAccountDeletion registers DELETE /auth/account and POST
+// /auth/account/restore; nil = user tidak bisa menghapus akunnya sendiri
+func (h *Handler) EnableAccountDeletion(d *AccountDeletion) {
+	h.deletion = d
+}
+
+// EnableAccountActivation registers GET /auth/activate and POST
+// /auth/resend-verification; pasangkan dengan activation yang sama di
+// NewService
+func (h *Handler) EnableAccountActivation(a *AccountActivation, resend ResendLimit) {
+	h.activation = a
+	h.resend = resend
+}
+
+// RegisterVersionRoutes mounts the auth routes of API version v. Hanya
+// GET /auth/profile yang berbeda: v1 snake_case, v2 camelCase.
+func (h *Handler) RegisterVersionRoutes(v apiversion.Version, r *gin.RouterGroup) {
+	profile := h.GetProfile
+	if v == apiversion.V1 {
+		profile = h.GetProfileV1
+	}
+	h.registerRoutes(r, profile)
+}
+
+// RegisterRoutes mounts the routes of the latest version (camelCase)
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	h.registerRoutes(r, h.GetProfile)
+}
+
+func (h *Handler) registerRoutes(r *gin.RouterGroup, profile gin.HandlerFunc) {
 	auth := r.Group("/auth")
 	{
 		auth.POST("/login", h.Login)
 		auth.POST("/register", h.Register)
 		auth.POST("/refresh", h.RefreshToken)
 		auth.POST("/logout", middleware.AuthMiddleware(), h.Logout)
-		auth.GET("/profile", middleware.AuthMiddleware(), h.GetProfile)
+		auth.GET("/profile", middleware.AuthMiddleware(), profile)
+		auth.GET("/whoami", middleware.AuthMiddleware(), h.WhoAmI)
+
+		if h.activation != nil {
+			auth.GET("/activate", h.Activate)
+			auth.POST("/resend-verification",
+				middleware.RateLimitMiddleware(h.resend.Limiter, h.resend.PerIP, h.resend.Window, resendIPKey),
+				h.ResendVerification)
+		}
+
+		if h.deletion != nil {
+			auth.DELETE("/account", middleware.AuthMiddleware(), h.DeleteAccount)
+			auth.POST("/account/restore", middleware.AuthMiddleware(), h.RestoreAccount)
+		}
+
+		if len(h.gatewayKeys) > 0 {
+			gateway := auth.Group("", middleware.RequireAPIKey(h.gatewayKeys))
+			gateway.POST("/introspect", h.Introspect)
+			gateway.GET("/revoked", h.ListRevokedTokens)
+		}
+	}
+
+	// role user diatur admin; path di bawah /users, sama seperti user.Handler
+	userRoles := r.Group("/users/:id/roles", middleware.AuthMiddleware(), middleware.RequireRole(seed.RoleAdmin))
+	{
+		userRoles.GET("", h.GetUserRoles)
+		userRoles.POST("", h.AssignRoleToUser)
+		userRoles.DELETE("/:roleId", h.RemoveRoleFromUser)
 	}
 }
 
@@ -37,31 +126,54 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 // @Produce json
 // @Param request body LoginRequest true "Login credentials"
 // @Success 200 {object} LoginResponse
+// @Failure 400 {object} validation.ErrorResponse
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} middleware.RateLimitedResponse
 // @Router /auth/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, validation.Response(err))
 		return
 	}
 
-	result, err := h.service.Login(c.Request.Context(), req)
+	if !accessCookieAvailable(c, req.TokenDelivery) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if h.throttle != nil {
+		if res := h.throttle.Check(ctx, c.ClientIP(), req.Email); !res.Allowed {
+			middleware.RespondRateLimited(c, "Too many failed login attempts", res, h.throttle.Window())
+			return
+		}
+	}
+
+	result, err := h.service.Login(ctx, req)
 	if err != nil {
+		// attempt sudah dipesan oleh Check; hanya kredensial salah yang tetap dihitung
+		if h.throttle != nil && !errors.Is(err, ErrInvalidCredentials) {
+			h.throttle.Refund(ctx, c.ClientIP(), req.Email)
+		}
 		handleServiceError(c, err)
 		return
 	}
 
-	// Set refresh token as httpOnly cookie
-	c.SetCookie(
-		"refresh_token",
-		result.RefreshToken,
-		7*24*60*60, // 7 days
-		"/",
-		"",
-		false, // Set to true in production with HTTPS
-		true,  // httpOnly
-	)
+	if h.throttle != nil {
+		h.throttle.Succeeded(ctx, c.ClientIP(), req.Email)
+	}
+
+	if req.TokenDelivery != TokenDeliveryBody {
+		if err := setRefreshCookie(c, result.RefreshToken); err != nil {
+			middleware.InternalError(c, err)
+			return
+		}
+		result.RefreshToken = ""
+	}
+	if req.TokenDelivery == TokenDeliveryCookieOnly {
+		setAccessCookie(c, result.AccessToken, result.ExpiresIn)
+		result.AccessToken = ""
+	}
 
 	c.JSON(http.StatusOK, result)
 }
@@ -73,13 +185,13 @@ func (h *Handler) Login(c *gin.Context) {
 // @Produce json
 // @Param request body RegisterRequest true "Registration data"
 // @Success 201 {object} RegisterResponse
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} validation.ErrorResponse
 // @Failure 409 {object} map[string]string
 // @Router /auth/register [post]
 func (h *Handler) Register(c *gin.Context) {
 	var req RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := validation.ShouldBindStrictJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
 		return
 	}
 
@@ -92,64 +204,276 @@ func (h *Handler) Register(c *gin.Context) {
 	c.JSON(http.StatusCreated, result)
 }
 
+// Activate godoc
+// @Summary Activate a registered account
+// @Description Consumes the token sent after registration; only mounted
+// @Description when ACCOUNT_ACTIVATION_REQUIRED is on.
+// @Tags auth
+// @Produce json
+// @Param token query string true "Activation token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /auth/activate [get]
+func (h *Handler) Activate(c *gin.Context) {
+	if err := h.activation.Activate(c.Request.Context(), c.Query("token")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account activated"})
+}
+
+// ResendVerification godoc
+// @Summary Resend the account activation email
+// @Description Sends a new activation token if the email belongs to an
+// @Description account that is not activated yet; the old token stops
+// @Description working. Always 200 so the response does not reveal which
+// @Description emails are registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResendVerificationRequest true "Registered email"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} validation.ErrorResponse
+// @Failure 429 {object} middleware.RateLimitedResponse
+// @Router /auth/resend-verification [post]
+func (h *Handler) ResendVerification(c *gin.Context) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if h.resend.allowEmail(ctx, req.Email) {
+		if err := h.activation.Resend(ctx, req.Email); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the account is awaiting activation, a new email has been sent"})
+}
+
+// resendIPKey: kuota per IP terpisah dari rate limit global
+func resendIPKey(c *gin.Context) string {
+	return "resend:ip:" + c.ClientIP()
+}
+
 // RefreshToken godoc
 // @Summary Refresh access token
+// @Description Rotates the refresh token. Cookie clients send the refresh_token cookie and get the new one as a cookie; body clients (tokenDelivery=body at login) send {"refreshToken"} and get the new one in the response. cookie_only clients send {"tokenDelivery":"cookie_only"} and get both tokens as cookies only. Cookie clients must also echo the csrf_token cookie in the X-CSRF-Token header, otherwise 403 with code "csrf_failed". Presenting an already-rotated token returns 401 with code "refresh_reuse_detected"; the client must log in again.
 // @Tags auth
+// @Accept json
 // @Produce json
+// @Param request body RefreshTokenRequest false "Refresh token for body delivery"
+// @Param X-CSRF-Token header string false "Value of the csrf_token cookie; required for cookie delivery"
 // @Success 200 {object} TokenResponse
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /auth/refresh [post]
 func (h *Handler) RefreshToken(c *gin.Context) {
-	// Get refresh token from cookie
-	refreshToken, err := c.Cookie("refresh_token")
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token not found"})
-		return
+	// body opsional: client cookie biasanya tidak mengirim body sama sekali
+	var req RefreshTokenRequest
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, validation.Response(err))
+			return
+		}
+	}
+
+	delivery := TokenDeliveryBody
+	refreshToken := req.RefreshToken
+	if refreshToken == "" {
+		delivery = TokenDeliveryCookie
+		if req.TokenDelivery == TokenDeliveryCookieOnly {
+			if !accessCookieAvailable(c, req.TokenDelivery) {
+				return
+			}
+			delivery = TokenDeliveryCookieOnly
+		}
+
+		var err error
+		if refreshToken, err = c.Cookie(refreshCookieName); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token not found"})
+			return
+		}
+		// cookie ikut terkirim otomatis, jadi buktikan request datang dari
+		// app sendiri lewat double-submit CSRF
+		if !middleware.ValidCSRF(c) {
+			middleware.RespondCSRFFailed(c)
+			return
+		}
 	}
 
 	result, err := h.service.RefreshToken(c.Request.Context(), refreshToken)
 	if err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) && delivery != TokenDeliveryBody {
+			// paksa login ulang: cookie lama tidak berguna lagi
+			clearAuthCookies(c)
+		}
 		handleServiceError(c, err)
 		return
 	}
 
-	// Set new refresh token
-	c.SetCookie(
-		"refresh_token",
-		result.RefreshToken,
-		7*24*60*60,
-		"/",
-		"",
-		false,
-		true,
-	)
+	if delivery != TokenDeliveryBody {
+		if err := setRefreshCookie(c, result.RefreshToken); err != nil {
+			middleware.InternalError(c, err)
+			return
+		}
+		result.RefreshToken = ""
+	}
+	if delivery == TokenDeliveryCookieOnly {
+		setAccessCookie(c, result.AccessToken, result.ExpiresIn)
+		result.AccessToken = ""
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// WhoAmI godoc
+// @Summary Current token claims
+// @Description Decoded access token claims without a database lookup. Use /auth/profile for current roles and menus.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} WhoAmIResponse
+// @Failure 401 {object} map[string]string
+// @Router /auth/whoami [get]
+func (h *Handler) WhoAmI(c *gin.Context) {
+	claims := middleware.GetClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+		return
+	}
+
+	res := WhoAmIResponse{
+		UserID:   userID,
+		Username: claims.Username,
+		Email:    claims.Email,
+		Roles:    claims.Roles,
+	}
+	if claims.ExpiresAt != nil {
+		res.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	c.JSON(http.StatusOK, res)
+}
 
+// Introspect godoc
+// @Summary Token introspection (RFC 7662)
+// @Description For trusted gateways (X-API-Key). Expired, invalid and refresh tokens return {"active":false} with status 200.
+// @Tags auth
+// @Accept x-www-form-urlencoded,json
+// @Produce json
+// @Param X-API-Key header string true "Introspection client key"
+// @Param token formData string true "Access token"
+// @Success 200 {object} IntrospectionResponse
+// @Failure 400 {object} validation.ErrorResponse
+// @Failure 401 {object} map[string]string
+// @Router /auth/introspect [post]
+func (h *Handler) Introspect(c *gin.Context) {
+	var req IntrospectRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.Introspect(c.Request.Context(), req.Token))
+}
+
+// ListRevokedTokens godoc
+// @Summary Revoked access tokens
+// @Description For verifiers that check tokens locally (X-API-Key): jtis revoked after since, oldest first, to keep a local deny-list in sync.
+// @Tags auth
+// @Produce json
+// @Param X-API-Key header string true "Gateway client key"
+// @Param since query string false "RFC 3339 timestamp, exclusive (default: all)"
+// @Param page query int false "Page (default 1)"
+// @Param pageSize query int false "Page size (default 10, max 100)"
+// @Param links query bool false "Include next/prev/first/last links"
+// @Success 200 {object} ListRevokedTokensResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /auth/revoked [get]
+func (h *Handler) ListRevokedTokens(c *gin.Context) {
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+	}
+
+	result, err := h.service.ListRevokedTokens(c.Request.Context(), since, pagination.ParsePagination(c))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	result.AddLinks(c)
 	c.JSON(http.StatusOK, result)
 }
 
 // GetProfile godoc
 // @Summary Get user profile
+// @Description Sends an ETag; a request with a matching If-None-Match gets 304 without the profile being rebuilt. v1 answers with snake_case fields; /api/v2 sends the same profile in camelCase (auth.UserProfile).
 // @Tags auth
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} UserProfile
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} UserProfileV1
+// @Success 304 "Profile unchanged"
 // @Failure 401 {object} map[string]string
 // @Router /auth/profile [get]
 func (h *Handler) GetProfile(c *gin.Context) {
+	if result, ok := h.profile(c); ok {
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetProfileV1 is GetProfile for API v1, answering with UserProfileV1
+func (h *Handler) GetProfileV1(c *gin.Context) {
+	if result, ok := h.profile(c); ok {
+		c.JSON(http.StatusOK, mapProfileV1(result))
+	}
+}
+
+// profile loads the caller's profile; ok = false when a response (304
+// atau error) sudah dikirim
+func (h *Handler) profile(c *gin.Context) (*UserProfile, bool) {
 	userIDStr := middleware.GetUserID(c)
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
-		return
+		return nil, false
+	}
+
+	// ETag dihitung sebelum profile: kalau profile berubah di antaranya,
+	// ETag lama hanya membuat request berikutnya mengambil ulang
+	etag, err := h.service.ProfileETag(c.Request.Context(), userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return nil, false
+	}
+	if httpcache.NotModifiedETag(c, etag) {
+		return nil, false
 	}
 
 	result, err := h.service.GetProfile(c.Request.Context(), userID)
 	if err != nil {
 		handleServiceError(c, err)
-		return
+		return nil, false
 	}
 
-	c.JSON(http.StatusOK, result)
+	return result, true
 }
 
 // Logout godoc
@@ -167,119 +491,291 @@ func (h *Handler) Logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Logout(c.Request.Context(), userID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+	// access token ini masuk deny-list sampai expired, refresh token
+	// sesinya dihapus
+	var tokenID, sessionID uuid.UUID
+	var expiresAt time.Time
+	if claims := middleware.GetClaims(c); claims != nil {
+		tokenID, _ = uuid.Parse(claims.ID)
+		sessionID, _ = uuid.Parse(claims.SessionID)
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+	}
+
+	if err := h.service.Logout(c.Request.Context(), userID, tokenID, sessionID, expiresAt); err != nil {
+		handleServiceError(c, err)
 		return
 	}
 
-	// Clear refresh token cookie
-	c.SetCookie(
-		"refresh_token",
-		"",
-		-1,
-		"/",
-		"",
-		false,
-		true,
-	)
+	clearAuthCookies(c)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
-// GET /users/:id/roles
-func (h *Handler) GetUserRoles(c *gin.Context) {
-	userIDParam := c.Param("id")
-	userID, err := uuid.Parse(userIDParam)
+// DeleteAccount godoc
+// @Summary Schedule deletion of the caller's account
+// @Description The account is anonymized after the grace period unless
+// @Description POST /auth/account/restore is called first. The last
+// @Description active admin gets 409.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DeleteAccountRequest true "Current password"
+// @Success 202 {object} AccountDeletionResponse
+// @Failure 400 {object} validation.ErrorResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} middleware.RateLimitedResponse
+// @Router /auth/account [delete]
+func (h *Handler) DeleteAccount(c *gin.Context) {
+	userID, err := uuid.Parse(middleware.GetUserID(c))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
-	roles, err := h.service.GetUserRoles(c.Request.Context(), userID)
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	// konfirmasi password memakai budget login yang sama: token curian
+	// tidak bisa dipakai untuk menebak password tanpa batas
+	ctx := c.Request.Context()
+	identifier := userID.String()
+	if claims := middleware.GetClaims(c); claims != nil && claims.Email != "" {
+		identifier = claims.Email
+	}
+	if h.throttle != nil {
+		if res := h.throttle.Check(ctx, c.ClientIP(), identifier); !res.Allowed {
+			middleware.RespondRateLimited(c, "Too many failed password attempts", res, h.throttle.Window())
+			return
+		}
+	}
+
+	result, err := h.deletion.Schedule(ctx, userID, req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if h.throttle != nil && !errors.Is(err, ErrPasswordConfirmation) {
+			h.throttle.Refund(ctx, c.ClientIP(), identifier)
+		}
+		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
+	if h.throttle != nil {
+		h.throttle.Succeeded(ctx, c.ClientIP(), identifier)
+	}
+
+	c.JSON(http.StatusAccepted, result)
 }
 
-// POST /users/:id/roles
-type AssignRoleRequest struct {
-	RoleID     string `json:"roleId" binding:"required,uuid"`
-	AssignedBy string `json:"assignedBy" binding:"required,uuid"`
+// RestoreAccount godoc
+// @Summary Cancel a scheduled account deletion
+// @Tags auth
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/account/restore [post]
+func (h *Handler) RestoreAccount(c *gin.Context) {
+	userID, err := uuid.Parse(middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.deletion.Cancel(c.Request.Context(), userID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
 
-func (h *Handler) AssignRoleToUser(c *gin.Context) {
-	userIDParam := c.Param("id")
-	userID, err := uuid.Parse(userIDParam)
+// GetUserRoles godoc
+// @Summary List a user's roles
+// @Description Admin only.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {array} RoleInfo
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /users/{id}/roles [get]
+func (h *Handler) GetUserRoles(c *gin.Context) {
+	userID, ok := validation.ParamUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	roles, err := h.service.GetUserRoles(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// AssignRoleToUser godoc
+// @Summary Assign a role to a user
+// @Description Admin only. Assigning a role the user already has returns 409.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body AssignRoleRequest true "Role to assign"
+// @Success 201 {object} RoleAssignmentResponse
+// @Failure 400 {object} validation.ErrorResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/{id}/roles [post]
+func (h *Handler) AssignRoleToUser(c *gin.Context) {
+	userID, ok := validation.ParamUUID(c, "id")
+	if !ok {
 		return
 	}
 
 	var req AssignRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, validation.Response(err))
 		return
 	}
 
+	// binding uuid sudah menolak roleId yang tidak valid
 	roleID, _ := uuid.Parse(req.RoleID)
-	assignedBy, _ := uuid.Parse(req.AssignedBy)
 
-	res, err := h.service.AssignRoleToUser(c.Request.Context(), userID, roleID, assignedBy)
+	res, err := h.service.AssignRoleToUser(c.Request.Context(), userID, roleID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, res)
 }
 
-// DELETE /users/:id/roles/:roleId
+// RemoveRoleFromUser godoc
+// @Summary Remove a role from a user
+// @Description Admin only. Taking the admin role from the last active admin returns 409.
+// @Tags users
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param roleId path string true "Role ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/{id}/roles/{roleId} [delete]
 func (h *Handler) RemoveRoleFromUser(c *gin.Context) {
-	userIDParam := c.Param("id")
-	roleIDParam := c.Param("roleId")
-
-	userID, err := uuid.Parse(userIDParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+	userID, ok := validation.ParamUUID(c, "id")
+	if !ok {
 		return
 	}
 
-	roleID, err := uuid.Parse(roleIDParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role id"})
+	roleID, ok := validation.ParamUUID(c, "roleId")
+	if !ok {
 		return
 	}
 
-	err = h.service.RemoveRoleFromUser(c.Request.Context(), userID, roleID)
+	err := h.service.RemoveRoleFromUser(c.Request.Context(), userID, roleID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleServiceError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// setRefreshCookie stores the refresh token in an httpOnly cookie, Secure
+// whenever the request came in over HTTPS (langsung atau lewat proxy),
+// plus the CSRF cookie the next cookie-authenticated write must echo
+func setRefreshCookie(c *gin.Context, token string) error {
+	csrf, err := middleware.NewCSRFToken()
+	if err != nil {
+		return err
+	}
+	setAuthCookie(c, refreshCookieName, token, int(RefreshTokenTTL.Seconds()), true)
+	// sengaja tidak httpOnly: JS membacanya untuk header X-CSRF-Token
+	setAuthCookie(c, middleware.CSRFCookieName, csrf, int(RefreshTokenTTL.Seconds()), false)
+	return nil
+}
+
+// setAccessCookie stores the access token under the AuthMiddleware cookie
+// name, dengan umur sama seperti token itu sendiri
+func setAccessCookie(c *gin.Context, token string, expiresIn int) {
+	setAuthCookie(c, middleware.AccessTokenCookie(), token, expiresIn, true)
+}
+
+// clearAuthCookies removes the refresh and CSRF cookies and, when enabled,
+// the access token cookie
+func clearAuthCookies(c *gin.Context) {
+	setAuthCookie(c, refreshCookieName, "", -1, true)
+	setAuthCookie(c, middleware.CSRFCookieName, "", -1, false)
+	if name := middleware.AccessTokenCookie(); name != "" {
+		setAuthCookie(c, name, "", -1, true)
+	}
+}
+
+// setAuthCookie: semua cookie auth SameSite=Strict, jadi browser tidak
+// mengirimnya pada request yang dipicu situs lain
+func setAuthCookie(c *gin.Context, name, value string, maxAge int, httpOnly bool) {
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(name, value, maxAge, "/", "", isHTTPS(c), httpOnly)
+}
+
+// accessCookieAvailable rejects cookie_only delivery with 400 while the
+// access token cookie is disabled; middleware tidak akan membacanya
+func accessCookieAvailable(c *gin.Context, delivery string) bool {
+	if delivery == TokenDeliveryCookieOnly && middleware.AccessTokenCookie() == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cookie_only token delivery is not enabled"})
+		return false
+	}
+	return true
+}
+
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
 // handleServiceError maps service errors to HTTP status codes
 func handleServiceError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, ErrInvalidCredentials):
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-	case errors.Is(err, ErrUserNotFound):
+	case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrRoleNotFound):
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 	case errors.Is(err, ErrUserInactive):
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrAccountNotActivated):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "account_not_activated"})
+	case errors.Is(err, ErrActivationTokenInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 	case errors.Is(err, ErrUsernameExists):
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 	case errors.Is(err, ErrEmailExists):
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrRoleAlreadyAssigned):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrPasswordConfirmation):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrDeletionNotScheduled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error(), "code": "refresh_reuse_detected"})
 	case errors.Is(err, ErrInvalidToken):
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 	case errors.Is(err, ErrTokenExpired):
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 	default:
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		middleware.InternalError(c, err)
 	}
 }
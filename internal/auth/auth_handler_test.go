@@ -2,19 +2,31 @@ package auth_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"go-mini-erp/internal/auth"
 	"go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/shared/apiversion"
+	"go-mini-erp/internal/shared/ctxkeys"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/validation"
 )
 
 // Test Login - Success
@@ -24,7 +36,7 @@ func TestLoginHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/login", handler.Login)
@@ -60,7 +72,7 @@ func TestLoginHandler_Success(t *testing.T) {
 
 	// Create request
 	body := map[string]string{
-		"username": "testuser",
+		"email":    "test@example.com",
 		"password": "password123",
 	}
 	jsonBody, _ := json.Marshal(body)
@@ -88,7 +100,7 @@ func TestLoginHandler_InvalidCredentials(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/login", handler.Login)
@@ -103,7 +115,7 @@ func TestLoginHandler_InvalidCredentials(t *testing.T) {
 
 	// Create request
 	body := map[string]string{
-		"username": "wronguser",
+		"email":    "test@example.com",
 		"password": "wrongpass",
 	}
 	jsonBody, _ := json.Marshal(body)
@@ -130,7 +142,7 @@ func TestLoginHandler_BadRequest(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/login", handler.Login)
@@ -158,7 +170,7 @@ func TestRegisterHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/register", handler.Register)
@@ -184,10 +196,10 @@ func TestRegisterHandler_Success(t *testing.T) {
 
 	// Create request
 	body := map[string]string{
-		"username":  "newuser",
-		"email":     "new@example.com",
-		"password":  "password123",
-		"full_name": "New User",
+		"username": "newuser",
+		"email":    "new@example.com",
+		"password": "password123",
+		"fullName": "New User",
 	}
 	jsonBody, _ := json.Marshal(body)
 	req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonBody))
@@ -213,7 +225,7 @@ func TestRegisterHandler_UsernameExists(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/register", handler.Register)
@@ -225,10 +237,10 @@ func TestRegisterHandler_UsernameExists(t *testing.T) {
 
 	// Create request
 	body := map[string]string{
-		"username":  "existinguser",
-		"email":     "new@example.com",
-		"password":  "password123",
-		"full_name": "New User",
+		"username": "existinguser",
+		"email":    "new@example.com",
+		"password": "password123",
+		"fullName": "New User",
 	}
 	jsonBody, _ := json.Marshal(body)
 	req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonBody))
@@ -242,6 +254,47 @@ func TestRegisterHandler_UsernameExists(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, w.Code)
 }
 
+// Test Register - Password Too Short
+func TestRegisterHandler_FieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	router := gin.Default()
+	router.POST("/auth/register", handler.Register)
+
+	// Create request
+	body := map[string]string{
+		"username": "newuser",
+		"email":    "new@example.com",
+		"password": "abc",
+		"fullName": "New User",
+	}
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute request
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response validation.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation failed", response.Error)
+	assert.Equal(t, []validation.FieldError{{
+		Field:   "password",
+		Rule:    "min",
+		Message: "password must be at least 6 characters",
+	}}, response.Fields)
+}
+
 // Test RefreshToken - Success
 func TestRefreshTokenHandler_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -249,7 +302,7 @@ func TestRefreshTokenHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/refresh", handler.RefreshToken)
@@ -272,6 +325,7 @@ func TestRefreshTokenHandler_Success(t *testing.T) {
 		Name:  "refresh_token",
 		Value: "old-refresh-token",
 	})
+	addCSRF(req)
 
 	// Execute request
 	w := httptest.NewRecorder()
@@ -293,7 +347,7 @@ func TestRefreshTokenHandler_MissingCookie(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/refresh", handler.RefreshToken)
@@ -316,7 +370,7 @@ func TestRefreshTokenHandler_InvalidToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 	router.POST("/auth/refresh", handler.RefreshToken)
@@ -332,6 +386,7 @@ func TestRefreshTokenHandler_InvalidToken(t *testing.T) {
 		Name:  "refresh_token",
 		Value: "invalid-token",
 	})
+	addCSRF(req)
 
 	// Execute request
 	w := httptest.NewRecorder()
@@ -341,6 +396,107 @@ func TestRefreshTokenHandler_InvalidToken(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+// Test RefreshToken - Reused Token
+func TestRefreshTokenHandler_ReuseDetected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	router := gin.Default()
+	router.POST("/auth/refresh", handler.RefreshToken)
+
+	mockService.EXPECT().
+		RefreshToken(gomock.Any(), "rotated-token").
+		Return(nil, auth.ErrRefreshTokenReused).
+		Times(1)
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{
+		Name:  "refresh_token",
+		Value: "rotated-token",
+	})
+	addCSRF(req)
+
+	// Execute request
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh_reuse_detected", response["code"])
+	assert.Contains(t, w.Header().Get("Set-Cookie"), "refresh_token=;")
+}
+
+// Test WhoAmI - Claims Without Service Call
+func TestWhoAmIHandler_ReturnsClaims(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// tanpa EXPECT: panggilan ke service akan menggagalkan test
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	router := gin.Default()
+	router.GET("/auth/whoami", middleware.AuthMiddleware(), handler.WhoAmI)
+
+	// secret sama dengan yang dipakai AuthMiddleware
+	userID := uuid.New()
+	token, err := auth.NewJWTManager("your-secret-key", 0, nil).
+		GenerateAccessToken(userID, uuid.New(), "testuser", "test@example.com", []string{"admin", "sales"})
+	assert.NoError(t, err)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// Execute request
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response auth.WhoAmIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, response.UserID)
+	assert.Equal(t, "testuser", response.Username)
+	assert.Equal(t, "test@example.com", response.Email)
+	assert.Equal(t, []string{"admin", "sales"}, response.Roles)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), response.ExpiresAt, time.Minute)
+}
+
+// Refresh token ditandatangani secret yang sama tapi bukan access token
+func TestWhoAmIHandler_RefreshTokenIs401(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := auth.NewHandler(mocks.NewMockService(ctrl), nil)
+	router := gin.New()
+	router.GET("/auth/whoami", middleware.AuthMiddleware(), handler.WhoAmI)
+
+	token, err := auth.NewJWTManager("your-secret-key", 0, nil).
+		GenerateRefreshToken(uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 // Test GetProfile - Success
 func TestGetProfileHandler_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -348,13 +504,13 @@ func TestGetProfileHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 
 	// Mock auth middleware - set user_id in context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", "f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+		ctxkeys.SetUserID(c, "f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
 		c.Next()
 	})
 
@@ -388,6 +544,9 @@ func TestGetProfileHandler_Success(t *testing.T) {
 		},
 	}
 
+	mockService.EXPECT().
+		ProfileETag(gomock.Any(), userID).
+		Return(`"1-1"`, nil)
 	mockService.EXPECT().
 		GetProfile(gomock.Any(), userID).
 		Return(expectedResponse, nil).
@@ -418,13 +577,13 @@ func TestGetProfileHandler_InvalidUserID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 
 	// Mock auth middleware - set invalid user_id
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", "invalid-uuid")
+		ctxkeys.SetUserID(c, "invalid-uuid")
 		c.Next()
 	})
 
@@ -448,13 +607,13 @@ func TestLogoutHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := auth.NewHandler(mockService)
+	handler := auth.NewHandler(mockService, nil)
 
 	router := gin.Default()
 
 	// Mock auth middleware
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", "f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+		ctxkeys.SetUserID(c, "f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
 		c.Next()
 	})
 
@@ -463,7 +622,7 @@ func TestLogoutHandler_Success(t *testing.T) {
 	userID := uuid.MustParse("f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
 
 	mockService.EXPECT().
-		Logout(gomock.Any(), userID).
+		Logout(gomock.Any(), userID, uuid.Nil, uuid.Nil, time.Time{}).
 		Return(nil).
 		Times(1)
 
@@ -493,3 +652,701 @@ func TestLogoutHandler_Success(t *testing.T) {
 	}
 	assert.True(t, found, "refresh_token cookie should be set to expire")
 }
+
+const introspectSecret = "introspection-test-secret-at-least-32-bytes"
+
+// revocationStore menyimpan deny-list di memori
+type revocationStore struct {
+	auth.Repository // method lain tidak dipakai
+
+	revoked         []db.ListRevokedTokensRow
+	deletedFamilies []uuid.UUID
+}
+
+func (s *revocationStore) DeleteRefreshTokenFamily(ctx context.Context, familyID, userID uuid.UUID) error {
+	s.deletedFamilies = append(s.deletedFamilies, familyID)
+	return nil
+}
+
+func (s *revocationStore) RevokeAccessToken(ctx context.Context, arg db.RevokeAccessTokenParams) error {
+	s.revoked = append(s.revoked, db.ListRevokedTokensRow{
+		Jti:       arg.Jti,
+		ExpiresAt: arg.ExpiresAt,
+		RevokedAt: dbutil.TimeToPgTime(time.Now()),
+	})
+	return nil
+}
+
+func (s *revocationStore) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	for _, r := range s.revoked {
+		if r.Jti == jti {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *revocationStore) ListRevokedTokens(ctx context.Context, arg db.ListRevokedTokensParams) ([]db.ListRevokedTokensRow, error) {
+	var rows []db.ListRevokedTokensRow
+	for _, r := range s.revoked {
+		if r.RevokedAt.Time.After(arg.Since.Time) {
+			rows = append(rows, r)
+		}
+	}
+	return rows, nil
+}
+
+func (s *revocationStore) CountRevokedTokens(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+	rows, _ := s.ListRevokedTokens(ctx, db.ListRevokedTokensParams{Since: since})
+	return int64(len(rows)), nil
+}
+
+// newIntrospectRouter memakai service, JWTManager dan AuthMiddleware asli
+func newIntrospectRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	middleware.SetJWTSecret(introspectSecret)
+
+	service := auth.NewService(&revocationStore{}, nil, auth.NewJWTManager(introspectSecret, 0, nil), nil, "", nil, nil, nil)
+	handler := auth.NewHandler(service, nil)
+	handler.EnableGatewayEndpoints([]string{"gateway-key"})
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group(""))
+	return router
+}
+
+func introspect(router *gin.Engine, apiKey, token string) *httptest.ResponseRecorder {
+	form := url.Values{"token": {token}}
+	req := httptest.NewRequest(http.MethodPost, "/auth/introspect", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(middleware.APIKeyHeader, apiKey)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIntrospectHandler_ActiveToken(t *testing.T) {
+	router := newIntrospectRouter(t)
+
+	userID := uuid.New()
+	token, err := auth.NewJWTManager(introspectSecret, 0, nil).
+		GenerateAccessToken(userID, uuid.New(), "testuser", "test@example.com", []string{"admin", "sales"})
+	require.NoError(t, err)
+
+	w := introspect(router, "gateway-key", token)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res auth.IntrospectionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.True(t, res.Active)
+	assert.Equal(t, userID.String(), res.Sub)
+	assert.Equal(t, "testuser", res.Username)
+	assert.Equal(t, []string{"admin", "sales"}, res.Roles)
+	assert.Equal(t, "admin sales", res.Scope)
+	assert.Greater(t, res.Exp, time.Now().Unix())
+}
+
+func TestIntrospectHandler_ExpiredToken(t *testing.T) {
+	router := newIntrospectRouter(t)
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		UserID:   uuid.NewString(),
+		Username: "testuser",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	}).SignedString([]byte(introspectSecret))
+	require.NoError(t, err)
+
+	w := introspect(router, "gateway-key", expired)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"active":false}`, w.Body.String())
+}
+
+func TestIntrospectHandler_RequiresAPIKey(t *testing.T) {
+	router := newIntrospectRouter(t)
+
+	w := introspect(router, "wrong-key", "any-token")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestListRevokedTokensHandler_AfterLogout(t *testing.T) {
+	router := newIntrospectRouter(t)
+	since := time.Now().Add(-time.Second)
+
+	token, err := auth.NewJWTManager(introspectSecret, 0, nil).
+		GenerateAccessToken(uuid.New(), uuid.New(), "testuser", "test@example.com", []string{"admin"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/auth/revoked?since="+url.QueryEscape(since.Format(time.RFC3339)), nil)
+	req.Header.Set(middleware.APIKeyHeader, "gateway-key")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res auth.ListRevokedTokensResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Data, 1)
+
+	claims := &auth.Claims{}
+	_, _, err = jwt.NewParser().ParseUnverified(token, claims)
+	require.NoError(t, err)
+	assert.Equal(t, claims.ID, res.Data[0].JTI.String())
+	assert.Equal(t, int64(1), res.Meta.Total)
+
+	// token yang sudah logout juga tidak aktif lagi di introspection
+	assert.JSONEq(t, `{"active":false}`, introspect(router, "gateway-key", token).Body.String())
+}
+
+func TestLogout_TokenRejectedOnProtectedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	middleware.SetJWTSecret(introspectSecret)
+	store := &revocationStore{}
+	// ttl 0: token aktif tidak di-cache, logout langsung terlihat
+	middleware.SetTokenRevocations(auth.NewRevocationCache(store, 0, nil))
+	t.Cleanup(func() { middleware.SetTokenRevocations(nil) })
+
+	service := auth.NewService(store, nil, auth.NewJWTManager(introspectSecret, 0, nil), nil, "", nil, nil, nil)
+	router := gin.New()
+	auth.NewHandler(service, nil).RegisterRoutes(router.Group(""))
+
+	sessionID := uuid.New()
+	token, err := auth.NewJWTManager(introspectSecret, 0, nil).
+		GenerateAccessToken(uuid.New(), sessionID, "testuser", "test@example.com", []string{"admin"})
+	require.NoError(t, err)
+
+	call := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, call(http.MethodGet, "/auth/whoami"))
+	require.Equal(t, http.StatusOK, call(http.MethodPost, "/auth/logout"))
+
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/auth/whoami"))
+	// refresh token family sesi ini ikut dihapus
+	assert.Equal(t, []uuid.UUID{sessionID}, store.deletedFamilies)
+}
+
+func TestListRevokedTokensHandler_InvalidSince(t *testing.T) {
+	router := newIntrospectRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/revoked?since=yesterday", nil)
+	req.Header.Set(middleware.APIKeyHeader, "gateway-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func newDeliveryRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	router := gin.New()
+	router.POST("/auth/login", handler.Login)
+	router.POST("/auth/refresh", handler.RefreshToken)
+	return router, mockService
+}
+
+func refreshCookie(w *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == "refresh_token" {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestTokenDelivery_Cookie(t *testing.T) {
+	router, mockService := newDeliveryRouter(t)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(&auth.LoginResponse{AccessToken: "access-1", RefreshToken: "refresh-1"}, nil)
+	mockService.EXPECT().
+		RefreshToken(gomock.Any(), "refresh-1").
+		Return(&auth.TokenResponse{AccessToken: "access-2", RefreshToken: "refresh-2"}, nil)
+
+	// login tanpa tokenDelivery = cookie
+	req := httptest.NewRequest(http.MethodPost, "/auth/login",
+		strings.NewReader(`{"email":"test@example.com","password":"password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "refresh-1")
+	cookie := refreshCookie(w)
+	require.NotNil(t, cookie)
+	assert.Equal(t, "refresh-1", cookie.Value)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+
+	csrf := responseCookie(w, middleware.CSRFCookieName)
+	require.NotNil(t, csrf)
+	assert.False(t, csrf.HttpOnly, "the app reads it to fill X-CSRF-Token")
+	assert.Equal(t, http.SameSiteStrictMode, csrf.SameSite)
+
+	req = httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: cookie.Value})
+	req.AddCookie(csrf)
+	req.Header.Set(middleware.CSRFHeader, csrf.Value)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"accessToken":"access-2","tokenType":"","expiresIn":0}`, w.Body.String())
+	require.NotNil(t, refreshCookie(w))
+	assert.Equal(t, "refresh-2", refreshCookie(w).Value)
+}
+
+// addCSRF sends a matching CSRF cookie and header, seperti app web
+func addCSRF(req *http.Request) {
+	req.AddCookie(&http.Cookie{Name: middleware.CSRFCookieName, Value: "csrf-1"})
+	req.Header.Set(middleware.CSRFHeader, "csrf-1")
+}
+
+func TestTokenDelivery_CookieRefreshRequiresCSRF(t *testing.T) {
+	router, _ := newDeliveryRouter(t)
+
+	tests := []struct {
+		name   string
+		cookie string
+		header string
+	}{
+		{"no csrf", "", ""},
+		{"cookie without header", "csrf-1", ""},
+		{"header mismatch", "csrf-1", "csrf-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+			req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh-1"})
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: middleware.CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set(middleware.CSRFHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// service tidak dipanggil: mock tanpa EXPECT akan gagal kalau iya
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Contains(t, w.Body.String(), "csrf_failed")
+		})
+	}
+}
+
+func TestTokenDelivery_Body(t *testing.T) {
+	router, mockService := newDeliveryRouter(t)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(&auth.LoginResponse{AccessToken: "access-1", RefreshToken: "refresh-1"}, nil)
+	mockService.EXPECT().
+		RefreshToken(gomock.Any(), "refresh-1").
+		Return(&auth.TokenResponse{AccessToken: "access-2", RefreshToken: "refresh-2"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login",
+		strings.NewReader(`{"email":"test@example.com","password":"password123","tokenDelivery":"body"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, refreshCookie(w))
+
+	var login auth.LoginResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &login))
+	assert.Equal(t, "refresh-1", login.RefreshToken)
+
+	req = httptest.NewRequest(http.MethodPost, "/auth/refresh",
+		strings.NewReader(`{"refreshToken":"`+login.RefreshToken+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, refreshCookie(w))
+
+	var refreshed auth.TokenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+	assert.Equal(t, "refresh-2", refreshed.RefreshToken)
+}
+
+func TestTokenDelivery_InvalidMode(t *testing.T) {
+	router, _ := newDeliveryRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login",
+		strings.NewReader(`{"email":"test@example.com","password":"password123","tokenDelivery":"header"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// profile yang sama: v1 snake_case, v2 camelCase, lewat route yang dipasang
+// RegisterVersioned seperti di main
+func TestGetProfileHandler_BothVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	middleware.SetJWTSecret(introspectSecret)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	userID := uuid.MustParse("f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	lastLogin := time.Date(2026, 3, 2, 9, 15, 0, 0, time.UTC)
+	mockService.EXPECT().
+		ProfileETag(gomock.Any(), gomock.Any()).
+		Return(`"1-1"`, nil).
+		Times(len(apiversion.Supported))
+	mockService.EXPECT().
+		GetProfile(gomock.Any(), gomock.Any()).
+		Return(&auth.UserProfile{
+			ID:          userID,
+			Username:    "testuser",
+			FullName:    "Test User",
+			IsActive:    true,
+			LastLoginAt: &lastLogin,
+			Menus:       []auth.MenuInfo{{ID: uuid.New(), Code: "dashboard", CanRead: true}},
+		}, nil).
+		Times(len(apiversion.Supported))
+
+	router := gin.New()
+	apiversion.NewGroups(router, "/api/v1", apiversion.Supported...).RegisterVersioned(handler)
+
+	get := func(path string) map[string]any {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", bearerFor(t, "user"))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, path)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	v1 := get("/api/v1/auth/profile")
+	assert.Equal(t, "Test User", v1["full_name"])
+	assert.Equal(t, true, v1["is_active"])
+	assert.Equal(t, "2026-03-02T09:15:00Z", v1["last_login_at"])
+	assert.Equal(t, true, v1["menus"].([]any)[0].(map[string]any)["can_read"])
+	assert.NotContains(t, v1, "fullName")
+
+	v2 := get("/api/v2/auth/profile")
+	assert.Equal(t, "Test User", v2["fullName"])
+	assert.Equal(t, true, v2["isActive"])
+	assert.Equal(t, "2026-03-02T09:15:00Z", v2["lastLoginAt"])
+	assert.Equal(t, true, v2["menus"].([]any)[0].(map[string]any)["canRead"])
+	assert.NotContains(t, v2, "full_name")
+}
+
+// typo field ditolak dengan nama field-nya, bukan "fullName is required"
+func TestRegisterHandler_UnknownField(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	router := gin.New()
+	router.POST("/auth/register", handler.Register)
+
+	body := `{"username":"newuser","email":"new@example.com","password":"secret123","fulname":"New User"}`
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response validation.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, `unknown field "fulname"`, response.Error)
+	assert.Equal(t, []validation.FieldError{{
+		Field:   "fulname",
+		Rule:    "unknown",
+		Message: "fulname is not a recognized field",
+	}}, response.Fields)
+}
+
+// newUserRolesRouter mounts the auth routes as main does, termasuk
+// AuthMiddleware dan guard admin di /users/:id/roles
+func newUserRolesRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	middleware.SetJWTSecret(introspectSecret)
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	router := gin.New()
+	auth.NewHandler(mockService, nil).RegisterRoutes(router.Group("/api/v1"))
+	return router, mockService
+}
+
+func bearerFor(t *testing.T, roles ...string) string {
+	t.Helper()
+	token, err := auth.NewJWTManager(introspectSecret, 0, nil).
+		GenerateAccessToken(uuid.New(), uuid.New(), "someone", "someone@example.com", roles)
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+func TestUserRolesRoutes_AdminOnly(t *testing.T) {
+	router, _ := newUserRolesRouter(t)
+	base := "/api/v1/users/" + uuid.NewString() + "/roles"
+
+	routes := []struct {
+		method, path string
+	}{
+		{http.MethodGet, base},
+		{http.MethodPost, base},
+		{http.MethodDelete, base + "/" + uuid.NewString()},
+	}
+	for _, rt := range routes {
+		t.Run(rt.method, func(t *testing.T) {
+			req := httptest.NewRequest(rt.method, rt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+			req = httptest.NewRequest(rt.method, rt.path, nil)
+			req.Header.Set("Authorization", bearerFor(t, "staff"))
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
+
+func TestUserRolesRoutes_InvalidUUID(t *testing.T) {
+	// mock tanpa EXPECT: service tidak boleh dipanggil
+	router, _ := newUserRolesRouter(t)
+	valid := uuid.NewString()
+
+	tests := []struct {
+		name, method, path string
+	}{
+		{"list, bad user id", http.MethodGet, "/api/v1/users/not-a-uuid/roles"},
+		{"assign, bad user id", http.MethodPost, "/api/v1/users/not-a-uuid/roles"},
+		{"remove, bad user id", http.MethodDelete, "/api/v1/users/not-a-uuid/roles/" + valid},
+		{"remove, bad role id", http.MethodDelete, "/api/v1/users/" + valid + "/roles/not-a-uuid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", bearerFor(t, "admin"))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestRemoveRoleFromUserHandler_LastAdmin(t *testing.T) {
+	router, mockService := newUserRolesRouter(t)
+	userID, roleID := uuid.New(), uuid.New()
+
+	mockService.EXPECT().
+		RemoveRoleFromUser(gomock.Any(), userID, roleID).
+		Return(auth.ErrLastAdmin)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+userID.String()+"/roles/"+roleID.String(), nil)
+	req.Header.Set("Authorization", bearerFor(t, "admin"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), auth.ErrLastAdmin.Error())
+}
+
+func TestAssignRoleToUserHandler_AlreadyAssigned(t *testing.T) {
+	router, mockService := newUserRolesRouter(t)
+
+	userID := uuid.New()
+	roleID := uuid.New()
+
+	// assignment kedua untuk pasangan yang sama
+	mockService.EXPECT().
+		AssignRoleToUser(gomock.Any(), userID, roleID).
+		Return(nil, auth.ErrRoleAlreadyAssigned)
+
+	body := `{"roleId":"` + roleID.String() + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+userID.String()+"/roles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearerFor(t, "admin"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), auth.ErrRoleAlreadyAssigned.Error())
+}
+
+func TestAssignRoleToUserHandler_RoleNotFound(t *testing.T) {
+	router, mockService := newUserRolesRouter(t)
+
+	userID := uuid.New()
+	roleID := uuid.New()
+
+	mockService.EXPECT().
+		AssignRoleToUser(gomock.Any(), userID, roleID).
+		Return(nil, auth.ErrRoleNotFound)
+
+	body := `{"roleId":"` + roleID.String() + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+userID.String()+"/roles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearerFor(t, "admin"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), auth.ErrRoleNotFound.Error())
+}
+
+func TestTokenDelivery_CookieOnly(t *testing.T) {
+	router, mockService := newDeliveryRouter(t)
+	middleware.SetAccessTokenCookie("access_token")
+	t.Cleanup(func() { middleware.SetAccessTokenCookie("") })
+
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(&auth.LoginResponse{AccessToken: "access-1", RefreshToken: "refresh-1", TokenType: "Bearer", ExpiresIn: 900}, nil)
+	mockService.EXPECT().
+		RefreshToken(gomock.Any(), "refresh-1").
+		Return(&auth.TokenResponse{AccessToken: "access-2", RefreshToken: "refresh-2", TokenType: "Bearer", ExpiresIn: 900}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login",
+		strings.NewReader(`{"email":"test@example.com","password":"password123","tokenDelivery":"cookie_only"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "access-1")
+	assert.NotContains(t, w.Body.String(), "refresh-1")
+	require.NotNil(t, refreshCookie(w))
+	assert.Equal(t, "refresh-1", refreshCookie(w).Value)
+
+	access := responseCookie(w, "access_token")
+	require.NotNil(t, access)
+	assert.Equal(t, "access-1", access.Value)
+	assert.Equal(t, 900, access.MaxAge)
+	assert.True(t, access.HttpOnly)
+
+	req = httptest.NewRequest(http.MethodPost, "/auth/refresh",
+		strings.NewReader(`{"tokenDelivery":"cookie_only"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh-1"})
+	addCSRF(req)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"tokenType":"Bearer","expiresIn":900}`, w.Body.String())
+	require.NotNil(t, responseCookie(w, "access_token"))
+	assert.Equal(t, "access-2", responseCookie(w, "access_token").Value)
+	assert.Equal(t, "refresh-2", refreshCookie(w).Value)
+}
+
+// tanpa ACCESS_TOKEN_COOKIE middleware tidak membaca cookie, jadi mode ini ditolak
+func TestTokenDelivery_CookieOnlyDisabled(t *testing.T) {
+	router, _ := newDeliveryRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login",
+		strings.NewReader(`{"email":"test@example.com","password":"password123","tokenDelivery":"cookie_only"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func responseCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestGetProfileHandler_ETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := auth.NewHandler(mockService, nil)
+
+	userID := uuid.New()
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ctxkeys.SetUserID(c, userID.String())
+		c.Next()
+	})
+	router.GET("/auth/profile", handler.GetProfile)
+
+	getProfile := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/auth/profile", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	gomock.InOrder(
+		mockService.EXPECT().ProfileETag(gomock.Any(), userID).Return(`"100-7"`, nil),
+		mockService.EXPECT().GetProfile(gomock.Any(), userID).
+			Return(&auth.UserProfile{ID: userID, FullName: "Test User"}, nil),
+
+		// profile belum berubah: GetProfile tidak dipanggil sama sekali
+		mockService.EXPECT().ProfileETag(gomock.Any(), userID).Return(`"100-7"`, nil),
+
+		// role user berubah, rbac_version naik
+		mockService.EXPECT().ProfileETag(gomock.Any(), userID).Return(`"100-8"`, nil),
+		mockService.EXPECT().GetProfile(gomock.Any(), userID).
+			Return(&auth.UserProfile{ID: userID, FullName: "Test User Renamed"}, nil),
+	)
+
+	w := getProfile("")
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, `"100-7"`, etag)
+
+	w = getProfile(etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = getProfile(etag)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"100-8"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "Test User Renamed")
+}
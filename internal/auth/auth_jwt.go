@@ -2,55 +2,128 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/clock"
+)
+
+// DefaultAccessTokenTTL is the access token lifetime when none is configured
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+// RefreshTokenTTL is the lifetime of a refresh token (JWT exp and DB row)
+const RefreshTokenTTL = 7 * 24 * time.Hour
+
+// MinJWTSecretBytes is the shortest JWT_SECRET accepted in release mode
+const MinJWTSecretBytes = 32
+
+// defaultJWTSecrets are placeholder secrets that ship with the code/docs
+var defaultJWTSecrets = []string{
+	"your-secret-key",
+	"your-secret-key-change-in-production",
+}
+
+var (
+	ErrJWTSecretEmpty    = errors.New("JWT_SECRET is empty")
+	ErrJWTSecretDefault  = errors.New("JWT_SECRET is a built-in default")
+	ErrJWTSecretTooShort = fmt.Errorf("JWT_SECRET is shorter than %d bytes", MinJWTSecretBytes)
 )
 
+// ValidateJWTSecret checks the signing secret used at startup. Main
+// refuses to boot in release mode on error, dev hanya warning.
+func ValidateJWTSecret(secret string) error {
+	if secret == "" {
+		return ErrJWTSecretEmpty
+	}
+	for _, d := range defaultJWTSecrets {
+		if secret == d {
+			return ErrJWTSecretDefault
+		}
+	}
+	if len(secret) < MinJWTSecretBytes {
+		return ErrJWTSecretTooShort
+	}
+	return nil
+}
+
 // Claims is JWT payload used across auth
 type Claims struct {
 	UserID   string   `json:"user_id"`
 	Username string   `json:"username,omitempty"`
 	Email    string   `json:"email,omitempty"`
 	Roles    []string `json:"roles,omitempty"`
+	// TokenType membedakan access dan refresh token; keduanya punya jti
+	TokenType string `json:"typ,omitempty"`
+	// SessionID is the refresh token family the access token was issued
+	// with; logout menghapus family ini
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
 // JWTManager defines JWT operations (easy to mock)
 type JWTManager interface {
-	GenerateAccessToken(userID uuid.UUID, username, email string, roles []string) (string, error)
-	GenerateRefreshToken(userID uuid.UUID) (string, error)
+	GenerateAccessToken(userID, sessionID uuid.UUID, username, email string, roles []string) (string, error)
+	GenerateRefreshToken(userID, tokenID uuid.UUID) (string, error)
+	ParseAccessToken(token string) (*Claims, error)
 	ParseRefreshToken(token string) (*Claims, error)
+	// AccessTokenTTL is the exp - iat of every access token; sumber
+	// expiresIn di LoginResponse/TokenResponse
+	AccessTokenTTL() time.Duration
 }
 
 // jwtManager is concrete implementation
 type jwtManager struct {
-	secret []byte
+	secret    []byte
+	accessTTL time.Duration
+	clock     clock.Clock
 }
 
-// NewJWTManager creates JWT manager with secret
-func NewJWTManager(secret string) JWTManager {
+// NewJWTManager creates JWT manager with secret; accessTTL 0 =
+// DefaultAccessTokenTTL, clk nil = wall clock. clk dipakai untuk iat/exp
+// saat generate dan cek exp saat parse.
+func NewJWTManager(secret string, accessTTL time.Duration, clk clock.Clock) JWTManager {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
 	return &jwtManager{
-		secret: []byte(secret),
+		secret:    []byte(secret),
+		accessTTL: accessTTL,
+		clock:     clock.OrReal(clk),
 	}
 }
 
+func (j *jwtManager) AccessTokenTTL() time.Duration {
+	return j.accessTTL
+}
+
 // GenerateAccessToken creates short-lived access token
 func (j *jwtManager) GenerateAccessToken(
-	userID uuid.UUID,
+	userID, sessionID uuid.UUID,
 	username, email string,
 	roles []string,
 ) (string, error) {
 
+	now := j.clock.Now()
 	claims := Claims{
-		UserID:   userID.String(),
-		Username: username,
-		Email:    email,
-		Roles:    roles,
+		UserID:    userID.String(),
+		Username:  username,
+		Email:     email,
+		Roles:     roles,
+		TokenType: tokenTypeAccess,
+		SessionID: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.accessTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
@@ -58,13 +131,17 @@ func (j *jwtManager) GenerateAccessToken(
 		SignedString(j.secret)
 }
 
-// GenerateRefreshToken creates long-lived refresh token
-func (j *jwtManager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
+// GenerateRefreshToken creates long-lived refresh token; tokenID becomes
+// the jti claim so the token can be tracked for rotation
+func (j *jwtManager) GenerateRefreshToken(userID, tokenID uuid.UUID) (string, error) {
+	now := j.clock.Now()
 	claims := Claims{
-		UserID: userID.String(),
+		UserID:    userID.String(),
+		TokenType: tokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        tokenID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(RefreshTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
@@ -72,21 +149,58 @@ func (j *jwtManager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 		SignedString(j.secret)
 }
 
+// validMethods: hanya HS256 yang kita terbitkan; "none" dan RS/ES ditolak
+var validMethods = []string{jwt.SigningMethodHS256.Alg()}
+
+// keyFunc hands out the HMAC secret only for HMAC-signed tokens, so a
+// token claiming another algorithm can never be verified with it
+func (j *jwtManager) keyFunc(t *jwt.Token) (interface{}, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method %q", t.Header["alg"])
+	}
+	return j.secret, nil
+}
+
+func (j *jwtManager) parse(token string) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(token, &Claims{}, j.keyFunc,
+		jwt.WithValidMethods(validMethods),
+		jwt.WithTimeFunc(j.clock.Now),
+	)
+}
+
+// ParseAccessToken validates an access token; refresh tokens ditolak
+// supaya tidak bisa dipakai sebagai access token. Access token lama tanpa
+// typ juga tidak punya jti, jadi jti tanpa typ access = refresh token.
+func (j *jwtManager) ParseAccessToken(token string) (*Claims, error) {
+	parsed, err := j.parse(token)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrTokenExpired
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := parsed.Claims.(*Claims)
+	if !ok || !parsed.Valid || (claims.ID != "" && claims.TokenType != tokenTypeAccess) {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
 // ParseRefreshToken validates and parses refresh token
 func (j *jwtManager) ParseRefreshToken(token string) (*Claims, error) {
-	parsed, err := jwt.ParseWithClaims(token, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		return j.secret, nil
-	})
+	parsed, err := j.parse(token)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
 	claims, ok := parsed.Claims.(*Claims)
-	if !ok || !parsed.Valid {
+	if !ok || !parsed.Valid || claims.TokenType == tokenTypeAccess {
 		return nil, ErrInvalidToken
 	}
 
-	if claims.ExpiresAt.Before(time.Now()) {
+	if claims.ExpiresAt.Before(j.clock.Now()) {
 		return nil, ErrTokenExpired
 	}
 
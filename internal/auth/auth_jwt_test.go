@@ -0,0 +1,162 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/shared/clock"
+)
+
+func TestValidateJWTSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr error
+	}{
+		{"empty", "", auth.ErrJWTSecretEmpty},
+		{"middleware default", "your-secret-key", auth.ErrJWTSecretDefault},
+		{"documented default", "your-secret-key-change-in-production", auth.ErrJWTSecretDefault},
+		{"too short", strings.Repeat("k", auth.MinJWTSecretBytes-1), auth.ErrJWTSecretTooShort},
+		{"minimum length", strings.Repeat("k", auth.MinJWTSecretBytes), nil},
+		{"long random", "3f9c2a7e1b5d48c0a6e2f1d9b7c4a8e03f9c2a7e1b5d48c0", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := auth.ValidateJWTSecret(tt.secret)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+const jwtTestSecret = "jwt-test-secret-that-is-long-enough-32"
+
+// forgedTokens berisi claims valid yang ditandatangani dengan algoritma
+// selain HS256
+func forgedTokens(t *testing.T, claims auth.Claims) map[string]string {
+	t.Helper()
+
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rs256, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(t, err)
+
+	hs512, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString([]byte(jwtTestSecret))
+	require.NoError(t, err)
+
+	return map[string]string{"alg none": none, "RS256": rs256, "HS512": hs512}
+}
+
+func TestParseAccessToken_RejectsOtherAlgorithms(t *testing.T) {
+	manager := auth.NewJWTManager(jwtTestSecret, 0, nil)
+
+	claims := auth.Claims{
+		UserID:    uuid.NewString(),
+		TokenType: "access",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	for name, token := range forgedTokens(t, claims) {
+		t.Run(name, func(t *testing.T) {
+			_, err := manager.ParseAccessToken(token)
+			assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		})
+	}
+}
+
+func TestParseRefreshToken_RejectsOtherAlgorithms(t *testing.T) {
+	manager := auth.NewJWTManager(jwtTestSecret, 0, nil)
+
+	claims := auth.Claims{
+		UserID:    uuid.NewString(),
+		TokenType: "refresh",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	for name, token := range forgedTokens(t, claims) {
+		t.Run(name, func(t *testing.T) {
+			_, err := manager.ParseRefreshToken(token)
+			assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		})
+	}
+}
+
+func TestParseTokens_AcceptHS256(t *testing.T) {
+	manager := auth.NewJWTManager(jwtTestSecret, 0, nil)
+	userID := uuid.New()
+
+	access, err := manager.GenerateAccessToken(userID, uuid.New(), "testuser", "test@example.com", nil)
+	require.NoError(t, err)
+	claims, err := manager.ParseAccessToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), claims.UserID)
+
+	refresh, err := manager.GenerateRefreshToken(userID, uuid.New())
+	require.NoError(t, err)
+	_, err = manager.ParseRefreshToken(refresh)
+	assert.NoError(t, err)
+}
+
+func TestParseTokens_ExpireWithClock(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := auth.NewJWTManager(jwtTestSecret, 0, clk)
+	userID := uuid.New()
+
+	access, err := manager.GenerateAccessToken(userID, uuid.New(), "testuser", "test@example.com", nil)
+	require.NoError(t, err)
+	refresh, err := manager.GenerateRefreshToken(userID, uuid.New())
+	require.NoError(t, err)
+
+	clk.Advance(14 * time.Minute)
+	_, err = manager.ParseAccessToken(access)
+	assert.NoError(t, err)
+
+	clk.Advance(2 * time.Minute)
+	_, err = manager.ParseAccessToken(access)
+	assert.ErrorIs(t, err, auth.ErrTokenExpired)
+
+	_, err = manager.ParseRefreshToken(refresh)
+	assert.NoError(t, err)
+
+	clk.Advance(auth.RefreshTokenTTL)
+	_, err = manager.ParseRefreshToken(refresh)
+	assert.Error(t, err)
+}
+
+func TestGenerateAccessToken_ConfiguredTTL(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := auth.NewJWTManager(jwtTestSecret, 5*time.Minute, clk)
+	assert.Equal(t, 5*time.Minute, manager.AccessTokenTTL())
+
+	access, err := manager.GenerateAccessToken(uuid.New(), uuid.New(), "testuser", "test@example.com", nil)
+	require.NoError(t, err)
+
+	clk.Advance(6 * time.Minute)
+	_, err = manager.ParseAccessToken(access)
+	assert.ErrorIs(t, err, auth.ErrTokenExpired)
+
+	assert.Equal(t, auth.DefaultAccessTokenTTL, auth.NewJWTManager(jwtTestSecret, 0, nil).AccessTokenTTL())
+}
@@ -1,23 +1,14 @@
 package auth
 
 import (
-	db "go-mini-erp/internal/shared/database/sqlc"
+	"encoding/json"
+	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgtype"
-)
 
-/*
-uuidFromPg mengubah pgtype.UUID (nullable)
-menjadi *uuid.UUID untuk DTO / response layer
-*/
-func uuidFromPg(u pgtype.UUID) *uuid.UUID {
-	if !u.Valid {
-		return nil
-	}
-	id := uuid.UUID(u.Bytes)
-	return &id
-}
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
 
 /*
 mapRoles mengubah hasil query role
@@ -37,27 +28,155 @@ func mapRoles(rows []db.GetUserRolesRow) []RoleInfo {
 	return roles
 }
 
+// profileRole and profileMenu are the elements of the roles/menus JSON
+// arrays returned by GetUserProfile
+type profileRole struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+}
+
+type profileMenu struct {
+	ID        uuid.UUID  `json:"id"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	Path      *string    `json:"path"`
+	Icon      *string    `json:"icon"`
+	CanCreate bool       `json:"can_create"`
+	CanRead   bool       `json:"can_read"`
+	CanUpdate bool       `json:"can_update"`
+	CanDelete bool       `json:"can_delete"`
+}
+
 /*
-mapMenus mengubah hasil query menu
-nullable UUID dipetakan via helper, *string langsung dipakai
+mapProfile men-decode array JSON roles dan menus
+dari GetUserProfile lalu membangun UserProfile
 */
-func mapMenus(rows []db.GetUserMenusRow) []MenuInfo {
+func mapProfile(row db.GetUserProfileRow) (*UserProfile, error) {
+	var roles []profileRole
+	if err := json.Unmarshal(row.Roles, &roles); err != nil {
+		return nil, fmt.Errorf("decode profile roles: %w", err)
+	}
+
+	var menus []profileMenu
+	if err := json.Unmarshal(row.Menus, &menus); err != nil {
+		return nil, fmt.Errorf("decode profile menus: %w", err)
+	}
+
+	roleInfos := make([]RoleInfo, 0, len(roles))
+	for _, r := range roles {
+		roleInfos = append(roleInfos, RoleInfo(r))
+	}
+
+	return &UserProfile{
+		ID:          row.ID,
+		Username:    row.Username,
+		Email:       row.Email,
+		FullName:    row.FullName,
+		IsActive:    row.IsActive != nil && *row.IsActive,
+		LastLoginAt: dbutil.PgTimeToTimePtr(row.LastLoginAt),
+		CreatedAt:   row.CreatedAt.Time,
+		Roles:       roleInfos,
+		Menus:       mapMenus(menus),
+	}, nil
+}
+
+// mapProfileV1 renames the fields of p for API v1
+func mapProfileV1(p *UserProfile) UserProfileV1 {
+	menus := make([]MenuInfoV1, 0, len(p.Menus))
+	for _, m := range p.Menus {
+		menus = append(menus, MenuInfoV1(m))
+	}
+
+	return UserProfileV1{
+		ID:          p.ID,
+		Username:    p.Username,
+		Email:       p.Email,
+		FullName:    p.FullName,
+		IsActive:    p.IsActive,
+		LastLoginAt: p.LastLoginAt,
+		CreatedAt:   p.CreatedAt,
+		Roles:       p.Roles,
+		Menus:       menus,
+	}
+}
+
+/*
+mapMenus mengubah menu profile ke MenuInfo.
+Menu yang sama dari beberapa role digabung per ID,
+flag permission di-OR sehingga gabungan grant yang berlaku.
+Menu tanpa permission sama sekali dibuang (lihat accessibleMenus).
+*/
+func mapMenus(rows []profileMenu) []MenuInfo {
 	menus := make([]MenuInfo, 0, len(rows))
+	index := make(map[uuid.UUID]int, len(rows))
 
 	for _, m := range rows {
-		menus = append(menus, MenuInfo{
-			ID:        m.ID,
-			ParentID:  uuidFromPg(m.ParentID),
-			Code:      m.Code,
-			Name:      m.Name,
-			Path:      m.Path,
-			Icon:      m.Icon,
-			CanCreate: m.CanCreate,
-			CanRead:   m.CanRead,
-			CanUpdate: m.CanUpdate,
-			CanDelete: m.CanDelete,
+		if i, ok := index[m.ID]; ok {
+			menus[i].CanCreate = menus[i].CanCreate || m.CanCreate
+			menus[i].CanRead = menus[i].CanRead || m.CanRead
+			menus[i].CanUpdate = menus[i].CanUpdate || m.CanUpdate
+			menus[i].CanDelete = menus[i].CanDelete || m.CanDelete
+			continue
+		}
+
+		index[m.ID] = len(menus)
+		menus = append(menus, MenuInfo(m))
+	}
+
+	return accessibleMenus(menus)
+}
+
+/*
+accessibleMenus drops menus whose four flags are all false. Parent
+tanpa permission tetap dikirim selama ada turunan yang bisa diakses,
+supaya sidebar masih bisa menyusun tree-nya.
+*/
+func accessibleMenus(menus []MenuInfo) []MenuInfo {
+	index := make(map[uuid.UUID]int, len(menus))
+	for i, m := range menus {
+		index[m.ID] = i
+	}
+
+	keep := make([]bool, len(menus))
+	for i, m := range menus {
+		if !m.CanCreate && !m.CanRead && !m.CanUpdate && !m.CanDelete {
+			continue
+		}
+		// tandai menu ini dan semua ancestor-nya yang ikut di-return
+		for j := i; !keep[j]; {
+			keep[j] = true
+			if menus[j].ParentID == nil {
+				break
+			}
+			parent, ok := index[*menus[j].ParentID]
+			if !ok {
+				break
+			}
+			j = parent
+		}
+	}
+
+	out := make([]MenuInfo, 0, len(menus))
+	for i, m := range menus {
+		if keep[i] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func mapRevokedTokens(rows []db.ListRevokedTokensRow) []RevokedToken {
+	tokens := make([]RevokedToken, 0, len(rows))
+
+	for _, r := range rows {
+		tokens = append(tokens, RevokedToken{
+			JTI:       r.Jti,
+			RevokedAt: r.RevokedAt.Time,
+			ExpiresAt: r.ExpiresAt.Time,
 		})
 	}
 
-	return menus
+	return tokens
 }
@@ -0,0 +1,55 @@
+package auth
+
+import "errors"
+
+// Outcome label values untuk Metrics
+const (
+	OutcomeSuccess            = "success"
+	OutcomeInvalidCredentials = "invalid_credentials"
+	OutcomeInactive           = "inactive"
+	OutcomeInvalidToken       = "invalid_token"
+	OutcomeExpired            = "expired"
+	OutcomeReused             = "reused"
+	OutcomeError              = "error"
+)
+
+// Lockout scopes: key throttle mana yang menolak percobaan login
+const (
+	LockoutIdentifier = "identifier"
+	LockoutIP         = "ip"
+)
+
+// Metrics receives auth outcomes for security monitoring. Package ini
+// tidak bergantung ke Prometheus; adapter-nya dipasang di cmd/api.
+type Metrics interface {
+	Login(outcome string)
+	TokenRefresh(outcome string)
+	// Lockout counts a login attempt rejected by LoginThrottle
+	Lockout(scope string)
+}
+
+// NopMetrics discards everything; dipakai kalau metrics nil
+type NopMetrics struct{}
+
+func (NopMetrics) Login(string)        {}
+func (NopMetrics) TokenRefresh(string) {}
+func (NopMetrics) Lockout(string)      {}
+
+// outcomeOf maps a Login/RefreshToken error to its outcome label
+func outcomeOf(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, ErrInvalidCredentials):
+		return OutcomeInvalidCredentials
+	case errors.Is(err, ErrUserInactive), errors.Is(err, ErrAccountNotActivated):
+		return OutcomeInactive
+	case errors.Is(err, ErrRefreshTokenReused):
+		return OutcomeReused
+	case errors.Is(err, ErrTokenExpired):
+		return OutcomeExpired
+	case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrUserNotFound):
+		return OutcomeInvalidToken
+	}
+	return OutcomeError
+}
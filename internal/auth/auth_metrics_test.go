@@ -0,0 +1,87 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/auth/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/ratelimit"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// metricsRecorder mencatat setiap outcome yang dilaporkan
+type metricsRecorder struct {
+	logins    []string
+	refreshes []string
+	lockouts  []string
+}
+
+func (m *metricsRecorder) Login(outcome string)        { m.logins = append(m.logins, outcome) }
+func (m *metricsRecorder) TokenRefresh(outcome string) { m.refreshes = append(m.refreshes, outcome) }
+func (m *metricsRecorder) Lockout(scope string)        { m.lockouts = append(m.lockouts, scope) }
+
+func TestLoginMetrics_FailureThenSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	metrics := &metricsRecorder{}
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, metrics, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	repo.EXPECT().GetUserByEmail(ctx, "test@example.com").Return(db.GetUserByEmailRow{
+		ID:           userID,
+		Email:        "test@example.com",
+		PasswordHash: string(hashed),
+		IsActive:     dbutil.BoolPtr(true),
+	}, nil).Times(2)
+	repo.EXPECT().GetUserRoles(ctx, userID).Return(nil, nil)
+	repo.EXPECT().CreateRefreshToken(ctx, gomock.Any()).Return(nil)
+	repo.EXPECT().UpdateUserLastLogin(ctx, userID, gomock.Any()).Return(nil)
+
+	_, err := service.Login(ctx, auth.LoginRequest{Email: "test@example.com", Password: "wrong"})
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	assert.Equal(t, []string{auth.OutcomeInvalidCredentials}, metrics.logins)
+
+	_, err = service.Login(ctx, auth.LoginRequest{Email: "test@example.com", Password: "password123"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{auth.OutcomeInvalidCredentials, auth.OutcomeSuccess}, metrics.logins)
+	assert.Empty(t, metrics.refreshes)
+}
+
+func TestRefreshMetrics_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	metrics := &metricsRecorder{}
+	service := auth.NewService(mocks.NewMockRepository(ctrl), nil, &jwtManagerStub{}, nil, "", nil, metrics, nil)
+
+	_, err := service.RefreshToken(context.Background(), "garbage")
+	assert.Error(t, err)
+	assert.Equal(t, []string{auth.OutcomeInvalidToken}, metrics.refreshes)
+}
+
+func TestLoginThrottle_RecordsLockoutScope(t *testing.T) {
+	metrics := &metricsRecorder{}
+	throttle := auth.NewLoginThrottle(ratelimit.NewMemoryLimiter(), auth.LoginThrottleConfig{
+		MaxFailuresPerIdentifier: 2,
+		MaxFailuresPerIP:         100,
+		Window:                   time.Minute,
+		Metrics:                  metrics,
+	})
+	ctx := context.Background()
+
+	// dua attempt yang dipesan Check dan tidak di-refund = dua login gagal
+	assert.True(t, throttle.Check(ctx, "10.0.0.1", "victim@example.com").Allowed)
+	assert.True(t, throttle.Check(ctx, "10.0.0.1", "victim@example.com").Allowed)
+	assert.False(t, throttle.Check(ctx, "10.0.0.2", "victim@example.com").Allowed)
+
+	assert.Equal(t, []string{auth.LockoutIdentifier}, metrics.lockouts)
+}
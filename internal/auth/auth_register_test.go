@@ -0,0 +1,130 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// directTx seperti fakeTx tapi tanpa state, aman dipakai paralel
+type directTx struct{}
+
+func (directTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	return fn(nil)
+}
+
+// uniqueUserStore meniru constraint UNIQUE(username) tabel users.
+// Cek existence baru selesai setelah semua request sudah mengecek,
+// jadi semuanya lolos cek dan bertabrakan di insert.
+type uniqueUserStore struct {
+	auth.Repository // method lain tidak dipakai Register
+
+	mu        sync.Mutex
+	usernames map[string]bool
+	checked   sync.WaitGroup
+}
+
+func (s *uniqueUserStore) WithQuerier(q db.Querier) auth.Repository { return s }
+
+func (s *uniqueUserStore) CheckUsernameExists(ctx context.Context, username string) (bool, error) {
+	s.mu.Lock()
+	exists := s.usernames[username]
+	s.mu.Unlock()
+
+	s.checked.Done()
+	s.checked.Wait()
+	return exists, nil
+}
+
+func (s *uniqueUserStore) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	return false, nil
+}
+
+func (s *uniqueUserStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.usernames[arg.Username] {
+		return db.CreateUserRow{}, &pgconn.PgError{Code: "23505", ConstraintName: "users_username_key"}
+	}
+	s.usernames[arg.Username] = true
+	return db.CreateUserRow{ID: uuid.New(), Username: arg.Username, Email: arg.Email}, nil
+}
+
+// register menjalankan n Register bersamaan dengan username yang sama
+func register(service auth.Service, n int) []error {
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = service.Register(context.Background(), auth.RegisterRequest{
+				Username: "racer",
+				Email:    fmt.Sprintf("racer%d@example.com", i),
+				Password: "password123",
+				FullName: "Racer",
+			})
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func assertOneWinner(t *testing.T, errs []error) {
+	t.Helper()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, auth.ErrUsernameExists)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestRegister_ConcurrentSameUsername(t *testing.T) {
+	store := &uniqueUserStore{usernames: map[string]bool{}}
+	store.checked.Add(2)
+	service := auth.NewService(store, directTx{}, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	assertOneWinner(t, register(service, 2))
+}
+
+// Butuh database yang sudah di-migrate: TEST_DB_URL=postgres://...
+func TestRegister_ConcurrentSameUsername_Postgres(t *testing.T) {
+	dbURL := os.Getenv("TEST_DB_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DB_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE username = 'racer'")
+	})
+
+	repo := auth.NewRepository(db.New(pool))
+	service := auth.NewService(repo, database.NewDB(pool), &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	assertOneWinner(t, register(service, 2))
+}
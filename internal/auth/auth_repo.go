@@ -2,10 +2,13 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 //go:generate mockgen -source=auth_repo.go -destination=mocks/auth_repository_mock.go -package=mocks
@@ -17,16 +20,54 @@ type Repository interface {
 	GetUserByEmail(ctx context.Context, email string) (db.GetUserByEmailRow, error)
 
 	CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error)
-	UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error
+	UpdateUserLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
 
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]db.GetUserRolesRow, error)
-	GetUserMenus(ctx context.Context, userID uuid.UUID) ([]db.GetUserMenusRow, error)
+	ListUsersByRole(ctx context.Context, arg db.ListUsersByRoleParams) ([]db.ListUsersByRoleRow, error)
+	// GetUserProfile returns the user with roles and menus as JSON arrays
+	GetUserProfile(ctx context.Context, userID uuid.UUID) (db.GetUserProfileRow, error)
+	// GetProfileVersion returns what GetUserProfile's ETag is derived from
+	GetProfileVersion(ctx context.Context, userID uuid.UUID) (db.GetProfileVersionRow, error)
 
 	AssignRoleToUser(ctx context.Context, arg db.AssignRoleToUserParams) (db.AssignRoleToUserRow, error)
 	RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error
+	GetRoleByID(ctx context.Context, id uuid.UUID) (db.Role, error)
+	GetRoleByCode(ctx context.Context, code string) (db.Role, error)
+	CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error)
+	// LockAdminRole serializes last-admin checks; hanya berarti di dalam transaksi
+	LockAdminRole(ctx context.Context) error
 
 	CheckUsernameExists(ctx context.Context, username string) (bool, error)
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
+
+	CreateRefreshToken(ctx context.Context, arg db.CreateRefreshTokenParams) error
+	GetRefreshToken(ctx context.Context, id uuid.UUID) (db.GetRefreshTokenRow, error)
+	RotateRefreshToken(ctx context.Context, arg db.RotateRefreshTokenParams) (int64, error)
+	DeleteRefreshTokenFamily(ctx context.Context, familyID, userID uuid.UUID) error
+
+	RevokeAccessToken(ctx context.Context, arg db.RevokeAccessTokenParams) error
+	IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+	ListRevokedTokens(ctx context.Context, arg db.ListRevokedTokensParams) ([]db.ListRevokedTokensRow, error)
+	CountRevokedTokens(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+
+	CreateAuditLog(ctx context.Context, arg db.CreateAuditLogParams) error
+
+	GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error)
+	// ScheduleUserDeletion returns the effective due date; jadwal lama dipertahankan
+	ScheduleUserDeletion(ctx context.Context, id uuid.UUID, dueAt time.Time) (time.Time, error)
+	CancelUserDeletion(ctx context.Context, id uuid.UUID) (int64, error)
+	ListUsersDueForDeletion(ctx context.Context, now time.Time, limit int32) ([]uuid.UUID, error)
+	AnonymizeUser(ctx context.Context, id uuid.UUID, now time.Time) (int64, error)
+	DeleteUserRoles(ctx context.Context, userID uuid.UUID) error
+	DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+
+	CreateUserActivation(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error
+	HasPendingActivation(ctx context.Context, userID uuid.UUID) (bool, error)
+	// ActivateUserByToken returns pgx.ErrNoRows for an unknown, used or expired token
+	ActivateUserByToken(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error)
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
 }
 
 // repository is concrete implementation
@@ -77,8 +118,12 @@ func (r *repository) CreateUser(
 func (r *repository) UpdateUserLastLogin(
 	ctx context.Context,
 	id uuid.UUID,
+	at time.Time,
 ) error {
-	return r.q.UpdateUserLastLogin(ctx, id)
+	return r.q.UpdateUserLastLogin(ctx, db.UpdateUserLastLoginParams{
+		ID:          id,
+		LastLoginAt: dbutil.TimeToPgTime(at),
+	})
 }
 
 // ==========================
@@ -92,11 +137,25 @@ func (r *repository) GetUserRoles(
 	return r.q.GetUserRoles(ctx, userID)
 }
 
-func (r *repository) GetUserMenus(
+func (r *repository) ListUsersByRole(
+	ctx context.Context,
+	arg db.ListUsersByRoleParams,
+) ([]db.ListUsersByRoleRow, error) {
+	return r.q.ListUsersByRole(ctx, arg)
+}
+
+func (r *repository) GetUserProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+) (db.GetUserProfileRow, error) {
+	return r.q.GetUserProfile(ctx, userID)
+}
+
+func (r *repository) GetProfileVersion(
 	ctx context.Context,
 	userID uuid.UUID,
-) ([]db.GetUserMenusRow, error) {
-	return r.q.GetUserMenus(ctx, userID)
+) (db.GetProfileVersionRow, error) {
+	return r.q.GetProfileVersion(ctx, userID)
 }
 
 func (r *repository) AssignRoleToUser(
@@ -114,6 +173,22 @@ func (r *repository) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid
 	return r.q.RemoveRoleFromUser(ctx, arg)
 }
 
+func (r *repository) GetRoleByID(ctx context.Context, id uuid.UUID) (db.Role, error) {
+	return r.q.GetRoleByID(ctx, id)
+}
+
+func (r *repository) GetRoleByCode(ctx context.Context, code string) (db.Role, error) {
+	return r.q.GetRoleByCode(ctx, code)
+}
+
+func (r *repository) CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error) {
+	return r.q.CountActiveAdmins(ctx, userID)
+}
+
+func (r *repository) LockAdminRole(ctx context.Context) error {
+	return r.q.LockAdminRole(ctx)
+}
+
 // ==========================
 // Validation helpers
 // ==========================
@@ -131,3 +206,149 @@ func (r *repository) CheckEmailExists(
 ) (bool, error) {
 	return r.q.CheckEmailExists(ctx, email)
 }
+
+// ==========================
+// Refresh token & audit
+// ==========================
+
+func (r *repository) CreateRefreshToken(
+	ctx context.Context,
+	arg db.CreateRefreshTokenParams,
+) error {
+	return r.q.CreateRefreshToken(ctx, arg)
+}
+
+func (r *repository) DeleteRefreshTokenFamily(ctx context.Context, familyID, userID uuid.UUID) error {
+	return r.q.DeleteRefreshTokenFamily(ctx, db.DeleteRefreshTokenFamilyParams{
+		FamilyID: familyID,
+		UserID:   userID,
+	})
+}
+
+func (r *repository) GetRefreshToken(
+	ctx context.Context,
+	id uuid.UUID,
+) (db.GetRefreshTokenRow, error) {
+	return r.q.GetRefreshToken(ctx, id)
+}
+
+func (r *repository) RotateRefreshToken(
+	ctx context.Context,
+	arg db.RotateRefreshTokenParams,
+) (int64, error) {
+	return r.q.RotateRefreshToken(ctx, arg)
+}
+
+func (r *repository) RevokeAccessToken(
+	ctx context.Context,
+	arg db.RevokeAccessTokenParams,
+) error {
+	return r.q.RevokeAccessToken(ctx, arg)
+}
+
+func (r *repository) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	return r.q.IsAccessTokenRevoked(ctx, jti)
+}
+
+func (r *repository) ListRevokedTokens(
+	ctx context.Context,
+	arg db.ListRevokedTokensParams,
+) ([]db.ListRevokedTokensRow, error) {
+	return r.q.ListRevokedTokens(ctx, arg)
+}
+
+func (r *repository) CountRevokedTokens(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+	return r.q.CountRevokedTokens(ctx, since)
+}
+
+func (r *repository) CreateAuditLog(
+	ctx context.Context,
+	arg db.CreateAuditLogParams,
+) error {
+	return r.q.CreateAuditLog(ctx, arg)
+}
+
+// ==========================
+// Account deletion
+// ==========================
+
+func (r *repository) GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
+	return r.q.GetUserPasswordHash(ctx, id)
+}
+
+func (r *repository) ScheduleUserDeletion(
+	ctx context.Context,
+	id uuid.UUID,
+	dueAt time.Time,
+) (time.Time, error) {
+	due, err := r.q.ScheduleUserDeletion(ctx, db.ScheduleUserDeletionParams{
+		ID:    id,
+		DueAt: dbutil.TimeToPgTime(dueAt),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dbutil.PgTimeValue(due), nil
+}
+
+func (r *repository) CancelUserDeletion(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.CancelUserDeletion(ctx, id)
+}
+
+func (r *repository) ListUsersDueForDeletion(
+	ctx context.Context,
+	now time.Time,
+	limit int32,
+) ([]uuid.UUID, error) {
+	return r.q.ListUsersDueForDeletion(ctx, db.ListUsersDueForDeletionParams{
+		Now:        dbutil.TimeToPgTime(now),
+		LimitCount: limit,
+	})
+}
+
+func (r *repository) AnonymizeUser(ctx context.Context, id uuid.UUID, now time.Time) (int64, error) {
+	return r.q.AnonymizeUser(ctx, db.AnonymizeUserParams{
+		ID:  id,
+		Now: dbutil.TimeToPgTime(now),
+	})
+}
+
+func (r *repository) DeleteUserRoles(ctx context.Context, userID uuid.UUID) error {
+	return r.q.DeleteUserRoles(ctx, userID)
+}
+
+func (r *repository) DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	return r.q.DeleteUserRefreshTokens(ctx, userID)
+}
+
+// ==========================
+// Activation
+// ==========================
+
+func (r *repository) CreateUserActivation(
+	ctx context.Context,
+	userID uuid.UUID,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	return r.q.CreateUserActivation(ctx, db.CreateUserActivationParams{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: dbutil.TimeToPgTime(expiresAt),
+	})
+}
+
+func (r *repository) HasPendingActivation(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.q.HasPendingActivation(ctx, userID)
+}
+
+func (r *repository) ActivateUserByToken(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	return r.q.ActivateUserByToken(ctx, db.ActivateUserByTokenParams{
+		TokenHash: tokenHash,
+		Now:       dbutil.TimeToPgTime(now),
+	})
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
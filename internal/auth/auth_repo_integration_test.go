@@ -0,0 +1,293 @@
+//go:build integration
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/shared/database"
+	"go-mini-erp/internal/shared/database/dbtest"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func TestMain(m *testing.M) { dbtest.Main(m) }
+
+func createUser(t *testing.T, repo auth.Repository, username string) db.CreateUserRow {
+	t.Helper()
+
+	user, err := repo.CreateUser(context.Background(), db.CreateUserParams{
+		Username:     username,
+		Email:        username + "@Example.com",
+		PasswordHash: "hash",
+		FullName:     "Test " + username,
+		IsActive:     dbutil.BoolPtr(true),
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestRepositoryIntegration_CreateAndFetchUser(t *testing.T) {
+	pool := dbtest.New(t)
+	repo := auth.NewRepository(db.New(pool))
+	ctx := context.Background()
+
+	created := createUser(t, repo, "budi")
+
+	byEmail, err := repo.GetUserByEmail(ctx, "BUDI@example.COM")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, byEmail.ID)
+	assert.Equal(t, "hash", byEmail.PasswordHash)
+
+	byID, err := repo.GetUserByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "budi", byID.Username)
+	assert.False(t, byID.LastLoginAt.Valid)
+
+	loginAt := time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.UpdateUserLastLogin(ctx, created.ID, loginAt))
+
+	byID, err = repo.GetUserByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, loginAt.Equal(byID.LastLoginAt.Time))
+
+	_, err = repo.CreateUser(ctx, db.CreateUserParams{
+		Username:     "BUDI",
+		Email:        "other@example.com",
+		PasswordHash: "hash",
+		FullName:     "Duplicate",
+	})
+	assert.Error(t, err, "username unik tanpa memandang huruf besar/kecil")
+}
+
+// grantMenu membuat menu (kalau belum ada) dan memberi role akses baca
+func grantMenu(t *testing.T, pool *pgxpool.Pool, roleID uuid.UUID, code string, canUpdate bool) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO menus (code, name, path, sort_order) VALUES ($1::text, $1::text, '/' || $1::text, 1)
+		ON CONFLICT (code) DO NOTHING`, code)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO role_menus (role_id, menu_id, can_read, can_update)
+		SELECT $1, id, true, $3 FROM menus WHERE code = $2`, roleID, code, canUpdate)
+	require.NoError(t, err)
+}
+
+func TestRepositoryIntegration_RolesAndProfile(t *testing.T) {
+	pool := dbtest.New(t)
+	queries := db.New(pool)
+	repo := auth.NewRepository(queries)
+	ctx := context.Background()
+
+	user := createUser(t, repo, "sari")
+
+	sales, err := queries.CreateRole(ctx, db.CreateRoleParams{Code: "sales", Name: "Sales"})
+	require.NoError(t, err)
+	warehouse, err := queries.CreateRole(ctx, db.CreateRoleParams{Code: "warehouse", Name: "Warehouse"})
+	require.NoError(t, err)
+
+	for _, roleID := range []uuid.UUID{sales.ID, warehouse.ID} {
+		_, err := repo.AssignRoleToUser(ctx, db.AssignRoleToUserParams{UserID: user.ID, RoleID: roleID})
+		require.NoError(t, err)
+	}
+
+	roles, err := repo.GetUserRoles(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, "sales", roles[0].Code)
+	assert.Equal(t, "warehouse", roles[1].Code)
+
+	// products diberikan ke dua role: permission digabung
+	grantMenu(t, pool, sales.ID, "products", false)
+	grantMenu(t, pool, warehouse.ID, "products", true)
+	grantMenu(t, pool, warehouse.ID, "inventory", false)
+
+	service := auth.NewService(repo, database.NewDB(pool), nil, nil, "", nil, nil, nil)
+	profile, err := service.GetProfile(ctx, user.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sari", profile.Username)
+	assert.Len(t, profile.Roles, 2)
+	require.Len(t, profile.Menus, 2)
+
+	menus := map[string]auth.MenuInfo{}
+	for _, m := range profile.Menus {
+		menus[m.Code] = m
+	}
+	assert.True(t, menus["products"].CanRead)
+	assert.True(t, menus["products"].CanUpdate)
+	assert.Equal(t, "/products", *menus["products"].Path)
+	assert.False(t, menus["inventory"].CanUpdate)
+}
+
+func TestRepositoryIntegration_ProfileMenuOrder(t *testing.T) {
+	pool := dbtest.New(t)
+	queries := db.New(pool)
+	repo := auth.NewRepository(queries)
+	ctx := context.Background()
+
+	user := createUser(t, repo, "wati")
+	role, err := queries.CreateRole(ctx, db.CreateRoleParams{Code: "ops", Name: "Ops"})
+	require.NoError(t, err)
+	_, err = repo.AssignRoleToUser(ctx, db.AssignRoleToUserParams{UserID: user.ID, RoleID: role.ID})
+	require.NoError(t, err)
+
+	// sort_order dan name sama semua kecuali child; urutan hanya
+	// ditentukan parent, lalu code sebagai tie-breaker
+	for _, code := range []string{"zeta", "alpha", "mid"} {
+		_, err := pool.Exec(ctx, `
+			INSERT INTO menus (code, name, sort_order) VALUES ($1, 'Master', 5)`, code)
+		require.NoError(t, err)
+	}
+	_, err = pool.Exec(ctx, `
+		INSERT INTO menus (code, name, sort_order, parent_id)
+		SELECT 'child', 'Anak', 0, id FROM menus WHERE code = 'mid'`)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `
+		INSERT INTO role_menus (role_id, menu_id, can_read)
+		SELECT $1, id, true FROM menus WHERE code IN ('zeta', 'alpha', 'mid', 'child')`, role.ID)
+	require.NoError(t, err)
+
+	service := auth.NewService(repo, database.NewDB(pool), nil, nil, "", nil, nil, nil)
+	codes := func() []string {
+		profile, err := service.GetProfile(ctx, user.ID)
+		require.NoError(t, err)
+
+		out := make([]string, 0, len(profile.Menus))
+		for _, m := range profile.Menus {
+			out = append(out, m.Code)
+		}
+		return out
+	}
+
+	first := codes()
+	assert.Equal(t, []string{"alpha", "mid", "zeta", "child"}, first)
+	assert.Equal(t, first, codes())
+}
+
+func TestRepositoryIntegration_ProfileWithoutRoles(t *testing.T) {
+	pool := dbtest.New(t)
+	repo := auth.NewRepository(db.New(pool))
+	ctx := context.Background()
+
+	user := createUser(t, repo, "tono")
+
+	profile, err := auth.NewService(repo, database.NewDB(pool), nil, nil, "", nil, nil, nil).GetProfile(ctx, user.ID)
+	require.NoError(t, err)
+
+	assert.Empty(t, profile.Roles)
+	assert.Empty(t, profile.Menus)
+}
+
+func TestRepositoryIntegration_AccountDeletion(t *testing.T) {
+	pool := dbtest.New(t)
+	repo := auth.NewRepository(db.New(pool))
+	ctx := context.Background()
+
+	user := createUser(t, repo, "sari")
+	scheduledAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := scheduledAt.Add(30 * 24 * time.Hour)
+
+	got, err := repo.ScheduleUserDeletion(ctx, user.ID, due)
+	require.NoError(t, err)
+	assert.True(t, due.Equal(got))
+
+	// jadwal kedua tidak menggeser tanggal
+	got, err = repo.ScheduleUserDeletion(ctx, user.ID, due.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, due.Equal(got))
+
+	byEmail, err := repo.GetUserByEmail(ctx, "sari@example.com")
+	require.NoError(t, err)
+	assert.True(t, due.Equal(byEmail.DeletionDueAt.Time))
+
+	ids, err := repo.ListUsersDueForDeletion(ctx, due.Add(-time.Second), 10)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	n, err := repo.AnonymizeUser(ctx, user.ID, due.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, n, "belum jatuh tempo")
+
+	ids, err = repo.ListUsersDueForDeletion(ctx, due, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{user.ID}, ids)
+
+	n, err = repo.AnonymizeUser(ctx, user.ID, due)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	_, err = repo.GetUserByEmail(ctx, "sari@example.com")
+	assert.Error(t, err)
+
+	var username, email string
+	require.NoError(t, pool.QueryRow(ctx, "SELECT username, email FROM users WHERE id = $1", user.ID).Scan(&username, &email))
+	assert.NotContains(t, username, "sari")
+	assert.NotContains(t, email, "sari")
+
+	// username dan email lama bisa dipakai lagi
+	createUser(t, repo, "sari")
+}
+
+func TestRepositoryIntegration_CancelUserDeletion(t *testing.T) {
+	pool := dbtest.New(t)
+	repo := auth.NewRepository(db.New(pool))
+	ctx := context.Background()
+
+	user := createUser(t, repo, "tono")
+
+	n, err := repo.CancelUserDeletion(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	_, err = repo.ScheduleUserDeletion(ctx, user.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	n, err = repo.CancelUserDeletion(ctx, user.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	n, err = repo.AnonymizeUser(ctx, user.ID, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestRepositoryIntegration_ProfileETag(t *testing.T) {
+	pool := dbtest.New(t)
+	queries := db.New(pool)
+	repo := auth.NewRepository(queries)
+	service := auth.NewService(repo, database.NewDB(pool), nil, nil, "", nil, nil, nil)
+	ctx := context.Background()
+
+	user := createUser(t, repo, "etag")
+	first, err := service.ProfileETag(ctx, user.ID)
+	require.NoError(t, err)
+
+	again, err := service.ProfileETag(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	// assignment role menaikkan rbac_version lewat trigger
+	sales, err := queries.CreateRole(ctx, db.CreateRoleParams{Code: "sales", Name: "Sales"})
+	require.NoError(t, err)
+	_, err = repo.AssignRoleToUser(ctx, db.AssignRoleToUserParams{UserID: user.ID, RoleID: sales.ID})
+	require.NoError(t, err)
+
+	afterAssign, err := service.ProfileETag(ctx, user.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, afterAssign)
+
+	_, err = service.ProfileETag(ctx, uuid.New())
+	assert.ErrorIs(t, err, auth.ErrUserNotFound)
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/clock"
+)
+
+/*
+RevocationCache menyimpan hasil cek deny-list access token untuk
+AuthMiddleware, supaya setiap request dan heartbeat SSE tidak query
+database. Token yang belum di-revoke disimpan selama ttl, jadi logout
+berlaku di request lain paling lambat setelah ttl; token yang sudah
+di-revoke disimpan sampai expired. Tidak ada entry yang hidup melewati
+exp token, dan paling sering sekali per ttl entry kedaluwarsa disapu.
+ttl <= 0 = tanpa cache untuk token yang belum di-revoke.
+*/
+type RevocationCache struct {
+	repo  Repository
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu        sync.Mutex
+	entries   map[uuid.UUID]cachedRevocation
+	nextSweep time.Time
+}
+
+type cachedRevocation struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// NewRevocationCache: clk nil = wall clock
+func NewRevocationCache(repo Repository, ttl time.Duration, clk clock.Clock) *RevocationCache {
+	return &RevocationCache{
+		repo:    repo,
+		ttl:     ttl,
+		clock:   clock.OrReal(clk),
+		entries: map[uuid.UUID]cachedRevocation{},
+	}
+}
+
+// IsAccessTokenRevoked reports whether jti is on the deny-list, from
+// cache while fresh. expiresAt is the token's exp and bounds the entry.
+func (c *RevocationCache) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID, expiresAt time.Time) (bool, error) {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[jti]
+	if ok && now.Before(entry.expiresAt) {
+		c.mu.Unlock()
+		return entry.revoked, nil
+	}
+	delete(c.entries, jti)
+	c.mu.Unlock()
+
+	revoked, err := c.repo.IsAccessTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	until := expiresAt
+	if !revoked {
+		if c.ttl <= 0 {
+			return false, nil
+		}
+		if limit := now.Add(c.ttl); limit.Before(until) {
+			until = limit
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweepExpired(now)
+	if now.Before(until) {
+		c.entries[jti] = cachedRevocation{revoked: revoked, expiresAt: until}
+	}
+
+	return revoked, nil
+}
+
+// sweepExpired membuang entry token yang tidak dipakai lagi; dipanggil
+// dengan mu terkunci, paling sering sekali per ttl
+func (c *RevocationCache) sweepExpired(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	for jti, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, jti)
+		}
+	}
+	c.nextSweep = now.Add(max(c.ttl, time.Minute))
+}
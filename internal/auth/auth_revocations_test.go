@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/shared/clock"
+)
+
+func TestRevocationCache_ActiveTokenCachedForTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := auth.NewRevocationCache(repo, 5*time.Second, clk)
+
+	ctx := context.Background()
+	jti := uuid.New()
+	exp := clk.Now().Add(15 * time.Minute)
+
+	gomock.InOrder(
+		repo.EXPECT().IsAccessTokenRevoked(ctx, jti).Return(false, nil),
+		// logout setelah cek pertama, terlihat setelah ttl
+		repo.EXPECT().IsAccessTokenRevoked(ctx, jti).Return(true, nil),
+	)
+
+	for i := 0; i < 3; i++ {
+		revoked, err := cache.IsAccessTokenRevoked(ctx, jti, exp)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	}
+
+	clk.Advance(6 * time.Second)
+
+	// revoked di-cache sampai exp, tidak query lagi
+	for i := 0; i < 3; i++ {
+		revoked, err := cache.IsAccessTokenRevoked(ctx, jti, exp)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+		clk.Advance(time.Minute)
+	}
+}
+
+func TestRevocationCache_EntryBoundedByTokenExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := auth.NewRevocationCache(repo, time.Minute, clk)
+
+	ctx := context.Background()
+	jti := uuid.New()
+	exp := clk.Now().Add(2 * time.Second)
+
+	repo.EXPECT().IsAccessTokenRevoked(ctx, jti).Return(false, nil).Times(2)
+
+	_, err := cache.IsAccessTokenRevoked(ctx, jti, exp)
+	require.NoError(t, err)
+
+	// ttl belum lewat, tapi exp token sudah
+	clk.Advance(3 * time.Second)
+	_, err = cache.IsAccessTokenRevoked(ctx, jti, exp)
+	require.NoError(t, err)
+}
+
+func TestRevocationCache_ErrorNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	cache := auth.NewRevocationCache(repo, time.Minute, nil)
+
+	ctx := context.Background()
+	jti := uuid.New()
+	dbErr := errors.New("db down")
+
+	gomock.InOrder(
+		repo.EXPECT().IsAccessTokenRevoked(ctx, jti).Return(false, dbErr),
+		repo.EXPECT().IsAccessTokenRevoked(ctx, jti).Return(false, nil),
+	)
+
+	_, err := cache.IsAccessTokenRevoked(ctx, jti, time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, dbErr)
+
+	revoked, err := cache.IsAccessTokenRevoked(ctx, jti, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
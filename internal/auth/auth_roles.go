@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/clock"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// roleHoldersPageSize: ukuran halaman ListUsersByRole saat InvalidateRole
+const roleHoldersPageSize = 500
+
+/*
+RoleCache memuat role code user dari database untuk AuthMiddleware
+(mode TrustRolesFromToken = false). Hasil disimpan per user selama ttl,
+jadi perubahan role oleh admin berlaku paling lambat setelah ttl,
+bukan setelah access token habis. Entry yang kedaluwarsa dibuang saat
+dibaca, dan paling sering sekali per ttl semua entry kedaluwarsa disapu,
+jadi map hanya berisi user yang aktif dalam kira-kira dua ttl terakhir.
+
+Invalidate yang terjadi selama UserRoles membaca database menaikkan
+generasi user itu; hasil baca yang dimulai sebelumnya (mungkin role lama)
+tidak disimpan, jadi tidak tertahan sampai ttl.
+*/
+type RoleCache struct {
+	repo  Repository
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu        sync.Mutex
+	entries   map[uuid.UUID]cachedRoles
+	nextSweep time.Time
+
+	// gen naik setiap invalidasi; invalidated[user] = gen saat user itu
+	// terakhir di-invalidate, flushed = gen saat seluruh cache dikosongkan.
+	// loads = jumlah UserRoles yang sedang membaca database.
+	gen         uint64
+	invalidated map[uuid.UUID]uint64
+	flushed     uint64
+	loads       int
+}
+
+type cachedRoles struct {
+	codes     []string
+	expiresAt time.Time
+}
+
+// NewRoleCache: clk nil = wall clock
+func NewRoleCache(repo Repository, ttl time.Duration, clk clock.Clock) *RoleCache {
+	return &RoleCache{
+		repo:        repo,
+		ttl:         ttl,
+		clock:       clock.OrReal(clk),
+		entries:     map[uuid.UUID]cachedRoles{},
+		invalidated: map[uuid.UUID]uint64{},
+	}
+}
+
+// UserRoles returns the user's current role codes, from cache while fresh
+func (c *RoleCache) UserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	fresh := ok && now.Before(entry.expiresAt)
+	if ok && !fresh {
+		delete(c.entries, userID)
+	}
+	if fresh {
+		c.mu.Unlock()
+		return entry.codes, nil
+	}
+	started := c.gen
+	c.loads++
+	c.mu.Unlock()
+
+	rows, err := c.repo.GetUserRoles(ctx, userID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loads--
+
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(rows))
+	for _, r := range rows {
+		codes = append(codes, r.Code)
+	}
+
+	// di-invalidate selagi query berjalan: hasil ini mungkin sudah basi,
+	// dipakai untuk request ini saja
+	stale := c.invalidated[userID] > started || c.flushed > started
+
+	c.sweepExpired(now)
+	if !stale {
+		c.entries[userID] = cachedRoles{codes: codes, expiresAt: now.Add(c.ttl)}
+	}
+
+	return codes, nil
+}
+
+// sweepExpired membuang entry user yang tidak kembali lagi; dipanggil
+// dengan mu terkunci, paling sering sekali per ttl
+func (c *RoleCache) sweepExpired(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	for id, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+	// generasi hanya dibandingkan oleh load yang sedang berjalan
+	if c.loads == 0 {
+		clear(c.invalidated)
+	}
+	c.nextSweep = now.Add(c.ttl)
+}
+
+// invalidate membuang entry id dan menandai load yang sedang berjalan
+// untuk id sebagai basi; dipanggil dengan mu terkunci
+func (c *RoleCache) invalidate(id uuid.UUID) {
+	delete(c.entries, id)
+	if c.loads > 0 {
+		c.invalidated[id] = c.gen
+	}
+}
+
+// Invalidate drops the cached roles of userIDs; request berikutnya memuat
+// ulang dari database
+func (c *RoleCache) Invalidate(userIDs ...uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for _, id := range userIDs {
+		c.invalidate(id)
+	}
+}
+
+/*
+InvalidateRole drops every entry affected by a change to role roleID:
+entry yang menyimpan code (role dinonaktifkan) dan semua user yang
+memegang role itu menurut ListUsersByRole (role diaktifkan lagi, jadi
+code-nya belum ada di cache). Kalau daftar user gagal dimuat, seluruh
+cache dikosongkan supaya tidak ada yang tertinggal sampai TTL.
+*/
+func (c *RoleCache) InvalidateRole(ctx context.Context, roleID uuid.UUID, code string) {
+	holders, err := c.roleHolders(ctx, roleID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if err != nil {
+		log.Printf("auth: list users of role %s failed, flushing role cache: %v", code, err)
+		clear(c.entries)
+		c.flushed = c.gen
+		return
+	}
+
+	for _, id := range holders {
+		c.invalidate(id)
+	}
+	for id, entry := range c.entries {
+		if slices.Contains(entry.codes, code) {
+			c.invalidate(id)
+		}
+	}
+}
+
+func (c *RoleCache) roleHolders(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for offset := int32(0); ; offset += roleHoldersPageSize {
+		rows, err := c.repo.ListUsersByRole(ctx, db.ListUsersByRoleParams{
+			RoleID:      roleID,
+			LimitCount:  roleHoldersPageSize,
+			OffsetCount: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range rows {
+			ids = append(ids, r.ID)
+		}
+		if len(rows) < roleHoldersPageSize {
+			return ids, nil
+		}
+	}
+}
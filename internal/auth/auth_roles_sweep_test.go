@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/clock"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// userRolesRepo hanya mengimplementasikan GetUserRoles
+type userRolesRepo struct {
+	Repository
+}
+
+func (userRolesRepo) GetUserRoles(context.Context, uuid.UUID) ([]db.GetUserRolesRow, error) {
+	return []db.GetUserRolesRow{{Code: "user"}}, nil
+}
+
+func TestRoleCache_ExpiredEntriesAreSwept(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := NewRoleCache(userRolesRepo{}, 30*time.Second, clk)
+	ctx := context.Background()
+
+	// user yang datang sekali lalu tidak pernah kembali
+	for i := 0; i < 100; i++ {
+		_, err := cache.UserRoles(ctx, uuid.New())
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.entries, 100)
+
+	clk.Advance(31 * time.Second)
+
+	active := uuid.New()
+	_, err := cache.UserRoles(ctx, active)
+	require.NoError(t, err)
+	assert.Len(t, cache.entries, 1)
+	assert.Contains(t, cache.entries, active)
+}
+
+func TestRoleCache_ExpiredEntryDroppedOnRead(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	repo := &failingRolesRepo{}
+	cache := NewRoleCache(repo, 30*time.Second, clk)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	_, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+
+	// reload gagal: entry lama tetap tidak tertinggal di map
+	clk.Advance(31 * time.Second)
+	repo.err = assert.AnError
+	_, err = cache.UserRoles(ctx, userID)
+	require.Error(t, err)
+	assert.Empty(t, cache.entries)
+}
+
+type failingRolesRepo struct {
+	Repository
+	err error
+}
+
+func (r *failingRolesRepo) GetUserRoles(context.Context, uuid.UUID) ([]db.GetUserRolesRow, error) {
+	return nil, r.err
+}
+
+func TestRoleCache_InvalidatedGenerationsAreSwept(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := NewRoleCache(userRolesRepo{}, 30*time.Second, clk)
+
+	// tanpa load yang berjalan tidak ada yang perlu ditandai
+	cache.Invalidate(uuid.New())
+	assert.Empty(t, cache.invalidated)
+
+	cache.loads = 1
+	cache.Invalidate(uuid.New())
+	assert.Len(t, cache.invalidated, 1)
+
+	cache.loads = 0
+	clk.Advance(31 * time.Second)
+	_, err := cache.UserRoles(context.Background(), uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, cache.invalidated)
+}
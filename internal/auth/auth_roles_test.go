@@ -0,0 +1,213 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/shared/clock"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+func TestRoleCache_RoleChangedMidSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := auth.NewRoleCache(repo, 30*time.Second, clk)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	gomock.InOrder(
+		repo.EXPECT().
+			GetUserRoles(gomock.Any(), userID).
+			Return([]db.GetUserRolesRow{{Code: "admin"}}, nil),
+		repo.EXPECT().
+			GetUserRoles(gomock.Any(), userID).
+			Return([]db.GetUserRolesRow{{Code: "user"}}, nil),
+	)
+
+	roles, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, roles)
+
+	// masih di dalam TTL: dari cache, tanpa query
+	roles, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, roles)
+
+	clk.Advance(31 * time.Second)
+
+	roles, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user"}, roles)
+}
+
+func TestRoleCache_InvalidateRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := auth.NewRoleCache(repo, time.Hour, clk)
+	ctx := context.Background()
+
+	salesID := uuid.New()
+	seller, reactivated, other := uuid.New(), uuid.New(), uuid.New()
+
+	// seller punya "sales" di cache; reactivated memegang role itu saat
+	// masih nonaktif jadi code-nya belum ada di cache
+	repo.EXPECT().GetUserRoles(gomock.Any(), seller).Return([]db.GetUserRolesRow{{Code: "sales"}}, nil)
+	repo.EXPECT().GetUserRoles(gomock.Any(), reactivated).Return(nil, nil)
+	repo.EXPECT().GetUserRoles(gomock.Any(), other).Return([]db.GetUserRolesRow{{Code: "finance"}}, nil)
+	for _, id := range []uuid.UUID{seller, reactivated, other} {
+		_, err := cache.UserRoles(ctx, id)
+		require.NoError(t, err)
+	}
+
+	repo.EXPECT().
+		ListUsersByRole(gomock.Any(), db.ListUsersByRoleParams{RoleID: salesID, LimitCount: 500}).
+		Return([]db.ListUsersByRoleRow{{ID: reactivated}}, nil)
+	cache.InvalidateRole(ctx, salesID, "sales")
+
+	// tanpa Advance: dua user terdampak dimuat ulang, other tetap dari cache
+	repo.EXPECT().GetUserRoles(gomock.Any(), seller).Return(nil, nil)
+	repo.EXPECT().GetUserRoles(gomock.Any(), reactivated).Return([]db.GetUserRolesRow{{Code: "sales"}}, nil)
+
+	roles, err := cache.UserRoles(ctx, seller)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	roles, err = cache.UserRoles(ctx, reactivated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sales"}, roles)
+
+	roles, err = cache.UserRoles(ctx, other)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"finance"}, roles)
+}
+
+func TestRoleCache_InvalidateRoleLookupFailsFlushesAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(repo, time.Hour, clock.NewFake(time.Now()))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{Code: "finance"}}, nil).Times(2)
+	_, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+
+	repo.EXPECT().ListUsersByRole(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+	cache.InvalidateRole(ctx, uuid.New(), "sales")
+
+	_, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+}
+
+func TestRoleCache_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(repo, time.Hour, clock.NewFake(time.Now()))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	gomock.InOrder(
+		repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil),
+		repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{Code: "sales"}}, nil),
+	)
+
+	_, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+
+	cache.Invalidate(userID)
+
+	roles, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sales"}, roles)
+}
+
+func TestRoleCache_InvalidateDuringLoadSkipsStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(repo, time.Hour, clock.NewFake(time.Now()))
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+
+	gomock.InOrder(
+		// admin mencabut role selagi query ini berjalan: hasilnya sudah basi
+		repo.EXPECT().GetUserRoles(gomock.Any(), userID).
+			DoAndReturn(func(context.Context, uuid.UUID) ([]db.GetUserRolesRow, error) {
+				cache.Invalidate(userID)
+				return []db.GetUserRolesRow{{Code: "admin"}}, nil
+			}),
+		repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{Code: "user"}}, nil),
+	)
+
+	roles, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, roles, "the in-flight request still gets what it read")
+
+	// tidak disimpan: request berikutnya membaca ulang
+	roles, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user"}, roles)
+
+	// invalidasi userID selagi otherID dimuat tidak membuang hasil otherID
+	repo.EXPECT().GetUserRoles(gomock.Any(), otherID).
+		DoAndReturn(func(context.Context, uuid.UUID) ([]db.GetUserRolesRow, error) {
+			cache.Invalidate(userID)
+			return nil, nil
+		})
+	repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]db.GetUserRolesRow{{Code: "user"}}, nil)
+
+	_, err = cache.UserRoles(ctx, otherID)
+	require.NoError(t, err)
+	_, err = cache.UserRoles(ctx, otherID)
+	require.NoError(t, err)
+	_, err = cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+}
+
+func TestRoleCache_FlushDuringLoadSkipsStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	cache := auth.NewRoleCache(repo, time.Hour, clock.NewFake(time.Now()))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo.EXPECT().ListUsersByRole(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+	gomock.InOrder(
+		repo.EXPECT().GetUserRoles(gomock.Any(), userID).
+			DoAndReturn(func(context.Context, uuid.UUID) ([]db.GetUserRolesRow, error) {
+				cache.InvalidateRole(ctx, uuid.New(), "sales")
+				return []db.GetUserRolesRow{{Code: "sales"}}, nil
+			}),
+		repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil),
+	)
+
+	_, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+
+	roles, err := cache.UserRoles(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+}
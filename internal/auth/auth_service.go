@@ -2,51 +2,100 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgconn"
 	"golang.org/x/crypto/bcrypt"
 
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/clock"
+	"go-mini-erp/internal/shared/database"
 	dbgen "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
 	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/validation"
+	"go-mini-erp/internal/shared/webhook"
 )
 
+// AuditRefreshReuse is the audit_logs.action for a reused refresh token
+const AuditRefreshReuse = "refresh_reuse"
+
 //go:generate mockgen -source=auth_service.go -destination=mocks/auth_service_mock.go -package=mocks
 type Service interface {
 	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
 	Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*UserProfile, error)
-	Logout(ctx context.Context, userID uuid.UUID) error
+	ProfileETag(ctx context.Context, userID uuid.UUID) (string, error)
+	Logout(ctx context.Context, userID, tokenID, sessionID uuid.UUID, expiresAt time.Time) error
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]RoleInfo, error)
-	AssignRoleToUser(ctx context.Context, userID, roleID, assignedBy uuid.UUID) (*RoleAssignmentResponse, error)
+	AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) (*RoleAssignmentResponse, error)
 	RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error
+	Introspect(ctx context.Context, token string) *IntrospectionResponse
+	ListRevokedTokens(ctx context.Context, since time.Time, params pagination.Params) (*pagination.Response[RevokedToken], error)
 }
 
 type service struct {
-	repo       Repository
-	queries    *dbgen.Queries
-	jwtManager JWTManager
+	repo        Repository
+	tx          database.Transactor
+	jwtManager  JWTManager
+	events      webhook.Publisher
+	defaultRole string
+	clock       clock.Clock
+	metrics     Metrics
+	activation  *AccountActivation
 }
 
+// NewService creates auth service; events nil = webhook tidak dikirim,
+// defaultRole kosong = user hasil Register tidak diberi role, clk nil =
+// wall clock, metrics nil = outcome login/refresh tidak dicatat, activation
+// nil = user hasil Register langsung aktif
 func NewService(
 	repo Repository,
-	queries *dbgen.Queries,
+	tx database.Transactor,
 	jwtManager JWTManager,
+	events webhook.Publisher,
+	defaultRole string,
+	clk clock.Clock,
+	metrics Metrics,
+	activation *AccountActivation,
 ) Service {
+	if events == nil {
+		events = webhook.Nop{}
+	}
+	if metrics == nil {
+		metrics = NopMetrics{}
+	}
+
 	return &service{
-		repo:       repo,
-		queries:    queries,
-		jwtManager: jwtManager,
+		repo:        repo,
+		tx:          tx,
+		jwtManager:  jwtManager,
+		events:      events,
+		defaultRole: defaultRole,
+		clock:       clock.OrReal(clk),
+		metrics:     metrics,
+		activation:  activation,
 	}
 }
 
 func (s *service) Login(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
-	user, err := s.repo.GetUserByEmail(ctx, req.Email)
+	res, err := s.login(ctx, req)
+	s.metrics.Login(outcomeOf(err))
+	return res, err
+}
+
+func (s *service) login(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
+	user, err := s.repo.GetUserByEmail(ctx, validation.NormalizeEmail(req.Email))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrInvalidCredentials
@@ -54,11 +103,8 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*LoginResponse,
 		return nil, err
 	}
 
-	// IsActive sekarang *bool, jadi aman
-	if user.IsActive == nil || !*user.IsActive {
-		return nil, ErrUserInactive
-	}
-
+	// password dicek dulu: status akun hanya untuk yang tahu password-nya,
+	// kalau tidak, email terdaftar bisa ditebak dari errornya
 	if err := bcrypt.CompareHashAndPassword(
 		[]byte(user.PasswordHash),
 		[]byte(req.Password),
@@ -66,25 +112,36 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*LoginResponse,
 		return nil, ErrInvalidCredentials
 	}
 
+	// IsActive sekarang *bool, jadi aman
+	if user.IsActive == nil || !*user.IsActive {
+		if s.activation != nil {
+			pending, err := s.activation.pending(ctx, user.ID)
+			if err != nil {
+				return nil, err
+			}
+			if pending {
+				return nil, ErrAccountNotActivated
+			}
+		}
+		return nil, ErrUserInactive
+	}
+
 	roles, err := s.repo.GetUserRoles(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	roleCodes := make([]string, 0, len(roles))
-	roleInfos := make([]RoleInfo, 0, len(roles))
-
 	for _, r := range roles {
 		roleCodes = append(roleCodes, r.Code)
-		roleInfos = append(roleInfos, RoleInfo{
-			ID:   r.ID,
-			Code: r.Code,
-			Name: r.Name,
-		})
 	}
 
+	// setiap login memulai refresh token family (sesi) baru
+	sessionID := uuid.New()
+
 	accessToken, err := s.jwtManager.GenerateAccessToken(
 		user.ID,
+		sessionID,
 		user.Username,
 		user.Email,
 		roleCodes,
@@ -93,37 +150,38 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*LoginResponse,
 		return nil, err
 	}
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	refreshToken, err := s.issueRefreshToken(ctx, user.ID, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
-	_ = s.repo.UpdateUserLastLogin(ctx, user.ID)
+	_ = s.repo.UpdateUserLastLogin(ctx, user.ID, s.clock.Now())
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		TokenType:    "Bearer",
-		ExpiresIn:    900,
+		ExpiresIn:    int(s.jwtManager.AccessTokenTTL().Seconds()),
 		User: UserInfo{
 			ID:       user.ID,
 			Username: user.Username,
 			Email:    user.Email,
 			FullName: user.FullName,
-			Roles:    roleInfos,
+			Roles:    mapRoles(roles),
 		},
+		PendingDeletionAt: dbutil.PgTimeToTimePtr(user.DeletionDueAt),
 	}, nil
 }
 
+// Register creates the user and its default role in one transaction.
+// The existence checks give friendly errors in the common case; the
+// UNIQUE constraints on users catch concurrent registrations.
 func (s *service) Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error) {
-	if exists, _ := s.repo.CheckUsernameExists(ctx, req.Username); exists {
-		return nil, ErrUsernameExists
-	}
-
-	if exists, _ := s.repo.CheckEmailExists(ctx, req.Email); exists {
-		return nil, ErrEmailExists
-	}
+	// email disimpan lowercase; username dibandingkan case-insensitive di query
+	req.Email = validation.NormalizeEmail(req.Email)
+	req.Username = strings.TrimSpace(req.Username)
 
+	// hash di luar transaksi supaya bcrypt tidak menahan koneksi
 	hashedPassword, err := bcrypt.GenerateFromPassword(
 		[]byte(req.Password),
 		bcrypt.DefaultCost,
@@ -132,26 +190,133 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*RegisterR
 		return nil, fmt.Errorf("hash password failed: %w", err)
 	}
 
-	active := true
+	// dengan aktivasi, user baru bisa login setelah membuka link di email
+	active := s.activation == nil
 
-	user, err := s.repo.CreateUser(ctx, dbgen.CreateUserParams{
-		Username:     req.Username,
-		Email:        req.Email,
-		PasswordHash: string(hashedPassword),
-		FullName:     req.FullName,
-		IsActive:     &active, // FIX: *bool
+	// user + default role (+ token aktivasi) dalam satu transaksi: tidak
+	// ada user tanpa role
+	var user dbgen.CreateUserRow
+	var activationToken string
+	err = s.tx.WithTx(ctx, func(q dbgen.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		exists, err := repo.CheckUsernameExists(ctx, req.Username)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrUsernameExists
+		}
+
+		exists, err = repo.CheckEmailExists(ctx, req.Email)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrEmailExists
+		}
+
+		user, err = repo.CreateUser(ctx, dbgen.CreateUserParams{
+			Username:     req.Username,
+			Email:        req.Email,
+			PasswordHash: string(hashedPassword),
+			FullName:     req.FullName,
+			IsActive:     &active,                 // FIX: *bool
+			CreatedBy:    dbutil.ActorPgUUID(ctx), // NULL untuk self-register
+		})
+		if err != nil {
+			// request lain lolos cek yang sama dan insert lebih dulu
+			if dup := mapUserUniqueViolation(err); dup != nil {
+				return dup
+			}
+			return fmt.Errorf("create user failed: %w", err)
+		}
+
+		if err := s.assignDefaultRole(ctx, repo, user.ID); err != nil {
+			return err
+		}
+
+		if s.activation != nil {
+			activationToken, err = s.activation.issue(ctx, repo, user.ID)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("create user failed: %w", err)
+		return nil, err
+	}
+
+	if s.activation != nil {
+		s.activation.send(ctx, user.Email, activationToken)
 	}
 
-	return &RegisterResponse{
+	res := &RegisterResponse{
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
 		FullName:  user.FullName,
 		CreatedAt: user.CreatedAt.Time,
-	}, nil
+	}
+
+	// async, tidak menambah latency register
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventUserCreated, res))
+
+	return res, nil
+}
+
+// mapUserUniqueViolation translates a UNIQUE violation on users into
+// ErrUsernameExists/ErrEmailExists; nil for any other error
+func mapUserUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return nil
+	}
+
+	switch pgErr.ConstraintName {
+	case "users_username_key", "users_username_lower_key":
+		return ErrUsernameExists
+	case "users_email_key", "users_email_lower_key":
+		return ErrEmailExists
+	}
+	return nil
+}
+
+// isRoleAssignmentViolation reports whether err is the UNIQUE(user_id,
+// role_id) violation on user_roles
+func isRoleAssignmentViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+		pgErr.ConstraintName == "user_roles_user_id_role_id_key"
+}
+
+// isRoleForeignKeyViolation reports whether err is the user_roles.role_id
+// foreign key violation
+func isRoleForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" &&
+		pgErr.ConstraintName == "user_roles_role_id_fkey"
+}
+
+// assignDefaultRole gives a new user the configured default role, if any
+func (s *service) assignDefaultRole(ctx context.Context, repo Repository, userID uuid.UUID) error {
+	if s.defaultRole == "" {
+		return nil
+	}
+
+	r, err := repo.GetRoleByCode(ctx, s.defaultRole)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("default role %q not found", s.defaultRole)
+		}
+		return err
+	}
+
+	_, err = repo.AssignRoleToUser(ctx, dbgen.AssignRoleToUserParams{
+		UserID:     userID,
+		RoleID:     r.ID,
+		AssignedBy: dbutil.ActorPgUUID(ctx),
+	})
+	return err
 }
 
 func (s *service) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]RoleInfo, error) {
@@ -160,33 +325,48 @@ func (s *service) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]RoleInf
 		return nil, err
 	}
 
-	roles := make([]RoleInfo, len(rolesRows))
-	for i, r := range rolesRows {
-		roles[i] = RoleInfo{
-			ID:   r.ID,
-			Code: r.Code,
-			Name: r.Name,
+	return mapRoles(rolesRows), nil
+}
+
+// AssignRoleToUser records the logged-in user (dari ctx) sebagai
+// assigned_by; tanpa actor (mis. dari CLI) tersimpan NULL
+func (s *service) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) (*RoleAssignmentResponse, error) {
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
 		}
+		return nil, err
 	}
-	return roles, nil
-}
 
-func (s *service) AssignRoleToUser(ctx context.Context, userID, roleID, assignedBy uuid.UUID) (*RoleAssignmentResponse, error) {
-	// cek apakah user exist
-	_, err := s.repo.GetUserByID(ctx, userID)
-	if err != nil {
-		return nil, ErrUserNotFound
+	if _, err := s.repo.GetRoleByID(ctx, roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
 	}
 
 	res, err := s.repo.AssignRoleToUser(ctx, dbgen.AssignRoleToUserParams{
 		UserID:     userID,
 		RoleID:     roleID,
-		AssignedBy: dbutil.UUIDPtrToPgUUID(&assignedBy),
+		AssignedBy: dbutil.ActorPgUUID(ctx),
 	})
 	if err != nil {
+		if isRoleAssignmentViolation(err) {
+			return nil, ErrRoleAlreadyAssigned
+		}
+		// role dihapus di antara GetRoleByID dan insert
+		if isRoleForeignKeyViolation(err) {
+			return nil, ErrRoleNotFound
+		}
 		return nil, err
 	}
 
+	// subscriber (mis. cache role user) membuang role lama user ini
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleAssigned, role.RoleAssignedEvent{
+		RoleID:  roleID,
+		UserIDs: []uuid.UUID{userID},
+	}))
+
 	return &RoleAssignmentResponse{
 		ID:         res.ID,
 		UserID:     res.UserID,
@@ -195,12 +375,50 @@ func (s *service) AssignRoleToUser(ctx context.Context, userID, roleID, assigned
 	}, nil
 }
 
+// RemoveRoleFromUser refuses to take the admin role away from the last
+// active admin, which would lock everyone out of admin endpoints. Cek dan
+// delete ada di satu transaksi dengan role admin terkunci, jadi dua admin
+// yang saling mencabut role bersamaan tidak sama-sama lolos.
 func (s *service) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
-	err := s.repo.RemoveRoleFromUser(ctx, userID, roleID)
-	return err
+	err := s.tx.WithTx(ctx, func(q dbgen.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		roles, err := repo.GetUserRoles(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range roles {
+			if r.ID != roleID || r.Code != seed.RoleAdmin {
+				continue
+			}
+			if err := guardLastAdmin(ctx, repo, userID); err != nil {
+				return err
+			}
+		}
+
+		return repo.RemoveRoleFromUser(ctx, userID, roleID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// publish setelah commit: admin yang dicabut tidak boleh tetap memakai
+	// role dari cache sampai ROLE_CACHE_TTL habis
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleRemoved, role.RoleAssignedEvent{
+		RoleID:  roleID,
+		UserIDs: []uuid.UUID{userID},
+	}))
+	return nil
 }
 
 func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+	res, err := s.refreshToken(ctx, refreshToken)
+	s.metrics.TokenRefresh(outcomeOf(err))
+	return res, err
+}
+
+func (s *service) refreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error) {
 	claims, err := s.jwtManager.ParseRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
@@ -211,6 +429,26 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 		return nil, ErrInvalidToken
 	}
 
+	// token tanpa jti (terbit sebelum rotasi disimpan) tidak bisa dilacak
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	stored, err := s.repo.GetRefreshToken(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if stored.UserID != userID {
+		return nil, ErrInvalidToken
+	}
+	if stored.RevokedAt.Valid {
+		return nil, s.revokeReusedFamily(ctx, stored)
+	}
+
 	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -220,10 +458,22 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 	}
 
 	if user.IsActive == nil || !*user.IsActive {
+		if s.activation != nil {
+			pending, err := s.activation.pending(ctx, user.ID)
+			if err != nil {
+				return nil, err
+			}
+			if pending {
+				return nil, ErrAccountNotActivated
+			}
+		}
 		return nil, ErrUserInactive
 	}
 
-	roles, _ := s.repo.GetUserRoles(ctx, userID)
+	roles, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 	roleCodes := make([]string, 0, len(roles))
 
 	for _, r := range roles {
@@ -232,6 +482,7 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 
 	accessToken, err := s.jwtManager.GenerateAccessToken(
 		user.ID,
+		stored.FamilyID,
 		user.Username,
 		user.Email,
 		roleCodes,
@@ -240,21 +491,110 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 		return nil, err
 	}
 
-	refresh, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	newID := uuid.New()
+	refresh, err := s.jwtManager.GenerateRefreshToken(user.ID, newID)
 	if err != nil {
 		return nil, err
 	}
 
+	// rotate dulu, insert token baru hanya kalau rotasi menang: request
+	// yang kalah race tidak meninggalkan token hidup di family
+	err = s.tx.WithTx(ctx, func(q dbgen.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		rotated, err := repo.RotateRefreshToken(ctx, dbgen.RotateRefreshTokenParams{
+			ID:         tokenID,
+			ReplacedBy: dbutil.UUIDPtrToPgUUID(&newID),
+		})
+		if err != nil {
+			return err
+		}
+		// request lain me-rotate token yang sama lebih dulu
+		if rotated == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		return s.storeRefreshToken(ctx, repo, newID, user.ID, stored.FamilyID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) {
+			return nil, s.revokeReusedFamily(ctx, stored)
+		}
+		return nil, err
+	}
+
 	return &TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refresh,
 		TokenType:    "Bearer",
-		ExpiresIn:    900,
+		ExpiresIn:    int(s.jwtManager.AccessTokenTTL().Seconds()),
 	}, nil
 }
 
+// issueRefreshToken stores a new refresh token row in familyID and signs
+// it with the row id as jti
+func (s *service) issueRefreshToken(ctx context.Context, userID, familyID uuid.UUID) (string, error) {
+	tokenID := uuid.New()
+
+	if err := s.storeRefreshToken(ctx, s.repo, tokenID, userID, familyID); err != nil {
+		return "", err
+	}
+
+	return s.jwtManager.GenerateRefreshToken(userID, tokenID)
+}
+
+// storeRefreshToken inserts the refresh token row tokenID into familyID
+func (s *service) storeRefreshToken(ctx context.Context, repo Repository, tokenID, userID, familyID uuid.UUID) error {
+	err := repo.CreateRefreshToken(ctx, dbgen.CreateRefreshTokenParams{
+		ID:        tokenID,
+		UserID:    userID,
+		FamilyID:  familyID,
+		ExpiresAt: dbutil.TimeToPgTime(s.clock.Now().Add(RefreshTokenTTL)),
+	})
+	if err != nil {
+		return fmt.Errorf("store refresh token failed: %w", err)
+	}
+	return nil
+}
+
+// revokeReusedFamily records the reuse and deletes the whole family of
+// token: pemegang token curian maupun pemilik asli harus login ulang.
+// Returns ErrRefreshTokenReused, or the delete error if the family
+// could not be revoked
+func (s *service) revokeReusedFamily(ctx context.Context, token dbgen.GetRefreshTokenRow) error {
+	s.recordRefreshReuse(ctx, token)
+
+	if err := s.repo.DeleteRefreshTokenFamily(ctx, token.FamilyID, token.UserID); err != nil {
+		return fmt.Errorf("delete refresh token family: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// recordRefreshReuse menulis audit log untuk alert tim security.
+// Gagal menulis audit tidak mengubah response ke client.
+func (s *service) recordRefreshReuse(ctx context.Context, token dbgen.GetRefreshTokenRow) {
+	log.Printf("auth: refresh token reuse detected user_id=%s token_id=%s", token.UserID, token.ID)
+
+	details, _ := json.Marshal(map[string]any{
+		"userId":     token.UserID,
+		"revokedAt":  dbutil.PgTimeToTimePtr(token.RevokedAt),
+		"replacedBy": dbutil.PgUUIDToUUIDPtr(token.ReplacedBy),
+	})
+
+	err := s.repo.CreateAuditLog(ctx, dbgen.CreateAuditLogParams{
+		UserID:    dbutil.UUIDPtrToPgUUID(&token.UserID),
+		TableName: "refresh_tokens",
+		RecordID:  token.ID,
+		Action:    AuditRefreshReuse,
+		NewValues: details,
+	})
+	if err != nil {
+		log.Printf("auth: write refresh reuse audit failed: %v", err)
+	}
+}
+
 func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*UserProfile, error) {
-	user, err := s.repo.GetUserByID(ctx, userID)
+	row, err := s.repo.GetUserProfile(ctx, userID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrUserNotFound
@@ -262,73 +602,99 @@ func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*UserProfil
 		return nil, err
 	}
 
-	roles, _ := s.repo.GetUserRoles(ctx, userID)
-	roleInfos := make([]RoleInfo, 0, len(roles))
-
-	for _, r := range roles {
-		roleInfos = append(roleInfos, RoleInfo{
-			ID:   r.ID,
-			Code: r.Code,
-			Name: r.Name,
-		})
-	}
+	return mapProfile(row)
+}
 
-	menus, err := s.repo.GetUserMenus(ctx, userID)
+// ProfileETag returns the ETag of GetProfile without building the
+// profile: updated_at user (juga berubah saat login) plus rbac_version,
+// yang dinaikkan trigger setiap role, menu atau assignment berubah
+func (s *service) ProfileETag(ctx context.Context, userID uuid.UUID) (string, error) {
+	row, err := s.repo.GetProfileVersion(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("get menus failed: %w", err)
-	}
-
-	menuInfos := make([]MenuInfo, 0, len(menus))
-	for _, m := range menus {
-		menuInfos = append(menuInfos, MenuInfo{
-			ID:        m.ID,
-			ParentID:  uuidFromPg(m.ParentID),
-			Code:      m.Code,
-			Name:      m.Name,
-			Path:      textToPtr(m.Path),
-			Icon:      textToPtr(m.Icon),
-			CanCreate: m.CanCreate,
-			CanRead:   m.CanRead,
-			CanUpdate: m.CanUpdate,
-			CanDelete: m.CanDelete,
-		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
 	}
 
-	return &UserProfile{
-		ID:          user.ID,
-		Username:    user.Username,
-		Email:       user.Email,
-		FullName:    user.FullName,
-		IsActive:    user.IsActive != nil && *user.IsActive,
-		LastLoginAt: timeFromPg(user.LastLoginAt),
-		CreatedAt:   user.CreatedAt.Time,
-		Roles:       roleInfos,
-		Menus:       menuInfos,
-	}, nil
+	return strconv.Quote(fmt.Sprintf("%d-%d", row.UpdatedAt.Time.UnixMicro(), row.RbacVersion)), nil
 }
 
-func (s *service) Logout(ctx context.Context, userID uuid.UUID) error {
-	// Token blacklist / revoke
+// Logout revokes the access token tokenID until it expires and deletes
+// the refresh token family sessionID, so the session cannot be refreshed
+// either. uuid.Nil (token lama tanpa jti/sid) dilewati.
+func (s *service) Logout(ctx context.Context, userID, tokenID, sessionID uuid.UUID, expiresAt time.Time) error {
+	if tokenID != uuid.Nil {
+		if err := s.repo.RevokeAccessToken(ctx, dbgen.RevokeAccessTokenParams{
+			Jti:       tokenID,
+			UserID:    userID,
+			ExpiresAt: dbutil.TimeToPgTime(expiresAt),
+		}); err != nil {
+			return fmt.Errorf("revoke access token: %w", err)
+		}
+	}
+
+	if sessionID != uuid.Nil {
+		if err := s.repo.DeleteRefreshTokenFamily(ctx, sessionID, userID); err != nil {
+			return fmt.Errorf("delete refresh token family: %w", err)
+		}
+	}
+
 	return nil
 }
 
-func textToPtr(t *string) *string {
-	if t == nil || *t == "" {
-		return nil
+// ListRevokedTokens pages through tokens revoked after since, oldest first
+func (s *service) ListRevokedTokens(
+	ctx context.Context,
+	since time.Time,
+	params pagination.Params,
+) (*pagination.Response[RevokedToken], error) {
+	sinceArg := dbutil.TimeToPgTime(since)
+
+	rows, err := s.repo.ListRevokedTokens(ctx, dbgen.ListRevokedTokensParams{
+		Since:       sinceArg,
+		LimitCount:  params.Limit(),
+		OffsetCount: params.Offset(),
+	})
+	if err != nil {
+		return nil, err
 	}
-	return t
-}
 
-func timeToPtr(t *time.Time) *time.Time {
-	if t == nil {
-		return nil
+	total, err := s.repo.CountRevokedTokens(ctx, sinceArg)
+	if err != nil {
+		return nil, err
 	}
-	return t
+
+	res := pagination.NewResponse(mapRevokedTokens(rows), total, params)
+	return &res, nil
 }
 
-func timeFromPg(t pgtype.Timestamptz) *time.Time {
-	if !t.Valid {
-		return nil
+// Introspect reports whether token is a currently valid access token.
+// Token expired, invalid atau refresh token = {active:false}, bukan error.
+func (s *service) Introspect(ctx context.Context, token string) *IntrospectionResponse {
+	claims, err := s.jwtManager.ParseAccessToken(token)
+	if err != nil {
+		return &IntrospectionResponse{Active: false}
 	}
-	return &t.Time
+
+	// sudah logout; gagal cek deny-list juga dianggap tidak aktif
+	if jti, err := uuid.Parse(claims.ID); err == nil {
+		revoked, err := s.repo.IsAccessTokenRevoked(ctx, jti)
+		if err != nil || revoked {
+			return &IntrospectionResponse{Active: false}
+		}
+	}
+
+	res := &IntrospectionResponse{
+		Active:   true,
+		Sub:      claims.UserID,
+		Username: claims.Username,
+		Roles:    claims.Roles,
+		Scope:    strings.Join(claims.Roles, " "),
+	}
+	if claims.ExpiresAt != nil {
+		res.Exp = claims.ExpiresAt.Unix()
+	}
+
+	return res
 }
@@ -3,13 +3,16 @@ package auth_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 
@@ -17,6 +20,7 @@ import (
 	"go-mini-erp/internal/auth/mocks"
 	db "go-mini-erp/internal/shared/database/sqlc"
 	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
 )
 
 /*
@@ -24,22 +28,36 @@ JWTManager stub:
 - Tidak pakai gomock (lebih simpel)
 - Fokus test business logic service
 */
-type jwtManagerStub struct{}
+type jwtManagerStub struct {
+	// claims dikembalikan ParseRefreshToken; nil = token invalid
+	claims *auth.Claims
+}
+
+func (j *jwtManagerStub) AccessTokenTTL() time.Duration {
+	return auth.DefaultAccessTokenTTL
+}
 
 func (j *jwtManagerStub) GenerateAccessToken(
-	userID uuid.UUID,
+	userID, sessionID uuid.UUID,
 	username, email string,
 	roles []string,
 ) (string, error) {
 	return "access-token", nil
 }
 
-func (j *jwtManagerStub) GenerateRefreshToken(userID uuid.UUID) (string, error) {
+func (j *jwtManagerStub) GenerateRefreshToken(userID, tokenID uuid.UUID) (string, error) {
 	return "refresh-token", nil
 }
 
+func (j *jwtManagerStub) ParseAccessToken(token string) (*auth.Claims, error) {
+	return nil, auth.ErrInvalidToken
+}
+
 func (j *jwtManagerStub) ParseRefreshToken(token string) (*auth.Claims, error) {
-	return nil, errors.New("not implemented")
+	if j.claims == nil {
+		return nil, auth.ErrInvalidToken
+	}
+	return j.claims, nil
 }
 
 // =======================
@@ -52,15 +70,15 @@ func TestLogin_Success(t *testing.T) {
 
 	jwtStub := &jwtManagerStub{}
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, jwtStub)
+	service := auth.NewService(repo, nil, jwtStub, nil, "", nil, nil, nil)
 
 	ctx := context.Background()
 	userID := uuid.New()
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 
 	repo.EXPECT().
-		GetUserByUsername(ctx, "testuser").
-		Return(db.GetUserByUsernameRow{
+		GetUserByEmail(ctx, "test@example.com").
+		Return(db.GetUserByEmailRow{
 			ID:           userID,
 			Username:     "testuser",
 			Email:        "test@example.com",
@@ -76,7 +94,11 @@ func TestLogin_Success(t *testing.T) {
 		}, nil)
 
 	repo.EXPECT().
-		UpdateUserLastLogin(ctx, userID).
+		CreateRefreshToken(ctx, gomock.Any()).
+		Return(nil)
+
+	repo.EXPECT().
+		UpdateUserLastLogin(ctx, userID, gomock.Any()).
 		Return(nil)
 
 	result, err := service.Login(ctx, auth.LoginRequest{
@@ -93,17 +115,91 @@ func TestLogin_Success(t *testing.T) {
 	assert.Equal(t, "admin", result.User.Roles[0].Code)
 }
 
+func TestLogin_ExpiresInFollowsAccessTTL(t *testing.T) {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	for _, ttl := range []time.Duration{5 * time.Minute, time.Hour} {
+		t.Run(ttl.String(), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockRepository(ctrl)
+			manager := auth.NewJWTManager("expires-in-test-secret-0123456789abcdef", ttl, nil)
+			service := auth.NewService(repo, nil, manager, nil, "", nil, nil, nil)
+
+			userID := uuid.New()
+			repo.EXPECT().
+				GetUserByEmail(gomock.Any(), "test@example.com").
+				Return(db.GetUserByEmailRow{
+					ID:           userID,
+					Email:        "test@example.com",
+					PasswordHash: string(hashed),
+					IsActive:     dbutil.BoolPtr(true),
+				}, nil)
+			repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil)
+			repo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().UpdateUserLastLogin(gomock.Any(), userID, gomock.Any()).Return(nil)
+
+			result, err := service.Login(context.Background(), auth.LoginRequest{
+				Email:    "test@example.com",
+				Password: "password123",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int(ttl.Seconds()), result.ExpiresIn)
+
+			// expiresIn harus sama dengan exp - iat token yang diterbitkan
+			claims, err := manager.ParseAccessToken(result.AccessToken)
+			require.NoError(t, err)
+			lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+			assert.Equal(t, result.ExpiresIn, int(lifetime.Seconds()))
+		})
+	}
+}
+
+func TestLogin_PendingDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+	dueAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	repo.EXPECT().
+		GetUserByEmail(gomock.Any(), "test@example.com").
+		Return(db.GetUserByEmailRow{
+			ID:            userID,
+			Email:         "test@example.com",
+			PasswordHash:  string(hashed),
+			IsActive:      dbutil.BoolPtr(true),
+			DeletionDueAt: dbutil.TimeToPgTime(dueAt),
+		}, nil)
+	repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil)
+	repo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().UpdateUserLastLogin(gomock.Any(), userID, gomock.Any()).Return(nil)
+
+	// login tetap berhasil supaya user bisa membatalkan penghapusan
+	result, err := service.Login(context.Background(), auth.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.PendingDeletionAt)
+	assert.Equal(t, dueAt, *result.PendingDeletionAt)
+}
+
 func TestLogin_InvalidUsername(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	jwtStub := &jwtManagerStub{}
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, jwtStub)
+	service := auth.NewService(repo, nil, jwtStub, nil, "", nil, nil, nil)
 
 	repo.EXPECT().
-		GetUserByUsername(gomock.Any(), "wronguser").
-		Return(db.GetUserByUsernameRow{}, pgx.ErrNoRows)
+		GetUserByEmail(gomock.Any(), "test@example.com").
+		Return(db.GetUserByEmailRow{}, pgx.ErrNoRows)
 
 	result, err := service.Login(context.Background(), auth.LoginRequest{
 		Email:    "test@example.com",
@@ -120,13 +216,13 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 	jwtStub := &jwtManagerStub{}
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, jwtStub)
+	service := auth.NewService(repo, nil, jwtStub, nil, "", nil, nil, nil)
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("correct"), bcrypt.DefaultCost)
 
 	repo.EXPECT().
-		GetUserByUsername(gomock.Any(), "testuser").
-		Return(db.GetUserByUsernameRow{
+		GetUserByEmail(gomock.Any(), "test@example.com").
+		Return(db.GetUserByEmailRow{
 			ID:           uuid.New(),
 			Username:     "testuser",
 			PasswordHash: string(hashed),
@@ -148,13 +244,13 @@ func TestLogin_UserInactive(t *testing.T) {
 
 	jwtStub := &jwtManagerStub{}
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, jwtStub)
+	service := auth.NewService(repo, nil, jwtStub, nil, "", nil, nil, nil)
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 
 	repo.EXPECT().
-		GetUserByUsername(gomock.Any(), "testuser").
-		Return(db.GetUserByUsernameRow{
+		GetUserByEmail(gomock.Any(), "test@example.com").
+		Return(db.GetUserByEmailRow{
 			ID:           uuid.New(),
 			Username:     "testuser",
 			PasswordHash: string(hashed),
@@ -170,16 +266,49 @@ func TestLogin_UserInactive(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestLogin_EmailCaseInsensitive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+
+	repo.EXPECT().
+		GetUserByEmail(gomock.Any(), "test@example.com").
+		Return(db.GetUserByEmailRow{
+			ID:           userID,
+			Username:     "testuser",
+			Email:        "test@example.com",
+			PasswordHash: string(hashed),
+			IsActive:     dbutil.BoolPtr(true),
+		}, nil)
+	repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, nil)
+	repo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().UpdateUserLastLogin(gomock.Any(), userID, gomock.Any()).Return(nil)
+
+	result, err := service.Login(context.Background(), auth.LoginRequest{
+		Email:    " Test@Example.COM",
+		Password: "password123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", result.User.Username)
+}
+
 func TestRegister_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	jwtStub := &jwtManagerStub{}
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, jwtStub)
+	service := auth.NewService(repo, &fakeTx{}, jwtStub, nil, "", nil, nil, nil)
 
 	userID := uuid.New()
 
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
 	repo.EXPECT().
 		CheckUsernameExists(gomock.Any(), "newuser").
 		Return(false, nil)
@@ -212,75 +341,397 @@ func TestRegister_Success(t *testing.T) {
 	assert.Equal(t, "new@example.com", result.Email)
 }
 
-func TestRegister_UsernameExists(t *testing.T) {
+func TestRegister_AssignsDefaultRole(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, &jwtManagerStub{})
+	service := auth.NewService(repo, &fakeTx{}, &jwtManagerStub{}, nil, "user", nil, nil, nil)
+
+	userID := uuid.New()
+	roleID := uuid.New()
 
+	repo.EXPECT().CheckUsernameExists(gomock.Any(), "newuser").Return(false, nil)
+	repo.EXPECT().CheckEmailExists(gomock.Any(), "new@example.com").Return(false, nil)
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(db.CreateUserRow{ID: userID, Username: "newuser"}, nil)
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "user").Return(db.Role{ID: roleID, Code: "user"}, nil)
 	repo.EXPECT().
-		CheckUsernameExists(gomock.Any(), "existing").
-		Return(true, nil)
+		AssignRoleToUser(gomock.Any(), db.AssignRoleToUserParams{UserID: userID, RoleID: roleID}).
+		Return(db.AssignRoleToUserRow{UserID: userID, RoleID: roleID}, nil)
 
 	result, err := service.Register(context.Background(), auth.RegisterRequest{
-		Username: "existing",
-		Email:    "x@y.com",
-		Password: "password",
+		Username: "newuser",
+		Email:    "new@example.com",
+		Password: "password123",
+		FullName: "New User",
 	})
 
-	assert.ErrorIs(t, err, auth.ErrUsernameExists)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, result.ID)
+}
+
+func TestRegister_DefaultRoleMissingRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	events := &publisherStub{}
+	service := auth.NewService(repo, tx, &jwtManagerStub{}, events, "user", nil, nil, nil)
+
+	repo.EXPECT().CheckUsernameExists(gomock.Any(), "newuser").Return(false, nil)
+	repo.EXPECT().CheckEmailExists(gomock.Any(), "new@example.com").Return(false, nil)
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(db.CreateUserRow{ID: uuid.New()}, nil)
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "user").Return(db.Role{}, pgx.ErrNoRows)
+
+	result, err := service.Register(context.Background(), auth.RegisterRequest{
+		Username: "newuser",
+		Email:    "new@example.com",
+		Password: "password123",
+	})
+
+	// user yang sudah di-insert ikut di-rollback
+	assert.ErrorContains(t, err, `default role "user" not found`)
 	assert.Nil(t, result)
+	assert.True(t, tx.rolledBack)
+	assert.Empty(t, events.events)
 }
 
-func TestGetProfile_Success(t *testing.T) {
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+// publisherStub mencatat event yang di-publish
+type publisherStub struct {
+	events []webhook.Event
+}
+
+func (p *publisherStub) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestRegister_PublishesUserCreated(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, &jwtManagerStub{})
+	events := &publisherStub{}
+	service := auth.NewService(repo, &fakeTx{}, &jwtManagerStub{}, events, "", nil, nil, nil)
 
 	userID := uuid.New()
 
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().CheckUsernameExists(gomock.Any(), "newuser").Return(false, nil)
+	repo.EXPECT().CheckEmailExists(gomock.Any(), "new@example.com").Return(false, nil)
 	repo.EXPECT().
-		GetUserByID(gomock.Any(), userID).
-		Return(db.GetUserByIDRow{
+		CreateUser(gomock.Any(), gomock.Any()).
+		Return(db.CreateUserRow{
 			ID:        userID,
-			Username:  "testuser",
-			Email:     "test@example.com",
-			FullName:  "Test User",
-			IsActive:  dbutil.BoolPtr(true),
+			Username:  "newuser",
+			Email:     "new@example.com",
+			FullName:  "New User",
 			CreatedAt: dbutil.TimeToPgTime(time.Now()),
 		}, nil)
 
+	_, err := service.Register(context.Background(), auth.RegisterRequest{
+		Username: "newuser",
+		Email:    "new@example.com",
+		Password: "password123",
+		FullName: "New User",
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, webhook.EventUserCreated, events.events[0].Type)
+		data := events.events[0].Data.(*auth.RegisterResponse)
+		assert.Equal(t, userID, data.ID)
+		assert.Equal(t, "newuser", data.Username)
+	}
+}
+
+func TestRegister_UsernameExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, &fakeTx{}, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
 	repo.EXPECT().
-		GetUserRoles(gomock.Any(), userID).
-		Return([]db.GetUserRolesRow{
-			{ID: uuid.New(), Code: "admin", Name: "Administrator"},
-		}, nil)
+		CheckUsernameExists(gomock.Any(), "existing").
+		Return(true, nil)
 
+	result, err := service.Register(context.Background(), auth.RegisterRequest{
+		Username: "existing",
+		Email:    "x@y.com",
+		Password: "password",
+	})
+
+	assert.ErrorIs(t, err, auth.ErrUsernameExists)
+	assert.Nil(t, result)
+}
+
+func TestRegister_NormalizesEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, &fakeTx{}, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().CheckUsernameExists(gomock.Any(), "newuser").Return(false, nil)
+	repo.EXPECT().CheckEmailExists(gomock.Any(), "new@example.com").Return(false, nil)
 	repo.EXPECT().
-		GetUserMenus(gomock.Any(), userID).
-		Return([]db.GetUserMenusRow{
+		CreateUser(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+			assert.Equal(t, "new@example.com", arg.Email)
+			return db.CreateUserRow{ID: uuid.New(), Username: arg.Username, Email: arg.Email}, nil
+		})
+
+	result, err := service.Register(context.Background(), auth.RegisterRequest{
+		Username: " newuser ",
+		Email:    "  New@Example.COM ",
+		Password: "password123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", result.Email)
+}
+
+// Duplikat beda huruf besar/kecil ditolak oleh unique index lower(...)
+func TestRegister_DifferingCaseDuplicate(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		want       error
+	}{
+		{"email", "users_email_lower_key", auth.ErrEmailExists},
+		{"username", "users_username_lower_key", auth.ErrUsernameExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := auth.NewService(repo, &fakeTx{}, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+			repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+			repo.EXPECT().CheckUsernameExists(gomock.Any(), "NewUser").Return(false, nil)
+			repo.EXPECT().CheckEmailExists(gomock.Any(), "new@example.com").Return(false, nil)
+			repo.EXPECT().
+				CreateUser(gomock.Any(), gomock.Any()).
+				Return(db.CreateUserRow{}, &pgconn.PgError{Code: "23505", ConstraintName: tt.constraint})
+
+			result, err := service.Register(context.Background(), auth.RegisterRequest{
+				Username: "NewUser",
+				Email:    "NEW@example.com",
+				Password: "password123",
+			})
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+// profileJSON meniru array jsonb dari GetUserProfile
+func profileJSON(t testing.TB, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func profileRow(t testing.TB, userID uuid.UUID) db.GetUserProfileRow {
+	return db.GetUserProfileRow{
+		ID:        userID,
+		Username:  "testuser",
+		Email:     "test@example.com",
+		FullName:  "Test User",
+		IsActive:  dbutil.BoolPtr(true),
+		CreatedAt: dbutil.TimeToPgTime(time.Now()),
+		Roles: profileJSON(t, []map[string]any{
+			{"id": uuid.New(), "code": "admin", "name": "Administrator", "description": nil, "assigned_at": "2026-01-01T08:00:00.123456+07:00"},
+		}),
+		Menus: profileJSON(t, []map[string]any{
 			{
-				ID:        uuid.New(),
-				Code:      "dashboard",
-				Name:      "Dashboard",
-				CanRead:   *dbutil.BoolPtr(true),
-				CanCreate: *dbutil.BoolPtr(true),
-				CanUpdate: *dbutil.BoolPtr(true),
-				CanDelete: *dbutil.BoolPtr(true),
+				"id": uuid.New(), "parent_id": nil, "code": "dashboard", "name": "Dashboard",
+				"path": "/dashboard", "icon": nil, "sort_order": 1,
+				"can_create": true, "can_read": true, "can_update": true, "can_delete": true,
 			},
-		}, nil)
+		}),
+	}
+}
+
+func TestGetProfile_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+
+	repo.EXPECT().
+		GetUserProfile(gomock.Any(), userID).
+		Return(profileRow(t, userID), nil)
 
 	result, err := service.GetProfile(context.Background(), userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "testuser", result.Username)
+	assert.True(t, result.IsActive)
 	assert.Len(t, result.Roles, 1)
+	assert.Equal(t, "admin", result.Roles[0].Code)
 	assert.Len(t, result.Menus, 1)
 	assert.True(t, result.Menus[0].CanRead)
+	assert.Equal(t, "/dashboard", *result.Menus[0].Path)
+	assert.Nil(t, result.Menus[0].ParentID)
+	assert.Nil(t, result.Menus[0].Icon)
+}
+
+func TestGetProfile_NoRolesOrMenus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+
+	repo.EXPECT().
+		GetUserProfile(gomock.Any(), userID).
+		Return(db.GetUserProfileRow{
+			ID:       userID,
+			Username: "newbie",
+			Roles:    []byte("[]"),
+			Menus:    []byte("[]"),
+		}, nil)
+
+	result, err := service.GetProfile(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "newbie", result.Username)
+	assert.NotNil(t, result.Roles)
+	assert.Empty(t, result.Roles)
+	assert.NotNil(t, result.Menus)
+	assert.Empty(t, result.Menus)
+}
+
+func TestGetProfile_MergesMenusAcrossRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+	productsID := uuid.New()
+	reportsID := uuid.New()
+
+	// role sales: products read+create, role warehouse: products read+update
+	repo.EXPECT().
+		GetUserProfile(gomock.Any(), userID).
+		Return(db.GetUserProfileRow{
+			ID:       userID,
+			Username: "testuser",
+			Roles: profileJSON(t, []map[string]any{
+				{"id": uuid.New(), "code": "sales", "name": "Sales"},
+				{"id": uuid.New(), "code": "warehouse", "name": "Warehouse"},
+			}),
+			Menus: profileJSON(t, []map[string]any{
+				{"id": productsID, "code": "products", "name": "Products", "can_read": true, "can_create": true},
+				{"id": reportsID, "code": "reports", "name": "Reports", "can_read": true},
+				{"id": productsID, "code": "products", "name": "Products", "can_read": true, "can_update": true},
+			}),
+		}, nil)
+
+	result, err := service.GetProfile(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Roles, 2)
+	assert.Len(t, result.Menus, 2)
+
+	products := result.Menus[0]
+	assert.Equal(t, productsID, products.ID)
+	assert.True(t, products.CanRead)
+	assert.True(t, products.CanCreate)
+	assert.True(t, products.CanUpdate)
+	assert.False(t, products.CanDelete)
+
+	assert.Equal(t, reportsID, result.Menus[1].ID)
+}
+
+func TestGetProfile_ReadOnlyMenus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+	row := profileRow(t, userID)
+	row.Menus = profileJSON(t, []map[string]any{
+		{"id": uuid.New(), "code": "products", "name": "Products", "can_read": true},
+		{"id": uuid.New(), "code": "reports", "name": "Reports", "can_read": true},
+	})
+	repo.EXPECT().GetUserProfile(gomock.Any(), userID).Return(row, nil)
+
+	result, err := service.GetProfile(context.Background(), userID)
+
+	require.NoError(t, err)
+	require.Len(t, result.Menus, 2)
+	for _, m := range result.Menus {
+		assert.True(t, m.CanRead, m.Code)
+		assert.False(t, m.CanCreate || m.CanUpdate || m.CanDelete, m.Code)
+	}
+}
+
+func TestGetProfile_HidesMenusWithoutPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+	masterID := uuid.New()
+	settingsID := uuid.New()
+	auditID := uuid.New()
+
+	// master: tanpa permission tapi punya child yang bisa dibaca;
+	// settings: tanpa permission, child-nya juga tanpa permission
+	row := profileRow(t, userID)
+	row.Menus = profileJSON(t, []map[string]any{
+		{"id": masterID, "code": "master", "name": "Master"},
+		{"id": settingsID, "code": "settings", "name": "Settings"},
+		{"id": uuid.New(), "code": "archive", "name": "Archive"},
+		{"id": uuid.New(), "parent_id": masterID, "code": "products", "name": "Products", "can_read": true},
+		{"id": auditID, "parent_id": settingsID, "code": "audit", "name": "Audit"},
+		{"id": uuid.New(), "parent_id": auditID, "code": "audit-export", "name": "Export"},
+	})
+	repo.EXPECT().GetUserProfile(gomock.Any(), userID).Return(row, nil)
+
+	result, err := service.GetProfile(context.Background(), userID)
+
+	require.NoError(t, err)
+	codes := make([]string, 0, len(result.Menus))
+	for _, m := range result.Menus {
+		codes = append(codes, m.Code)
+	}
+	assert.Equal(t, []string{"master", "products"}, codes)
 }
 
 func TestGetProfile_UserNotFound(t *testing.T) {
@@ -288,11 +739,11 @@ func TestGetProfile_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, &jwtManagerStub{})
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
 
 	repo.EXPECT().
-		GetUserByID(gomock.Any(), gomock.Any()).
-		Return(db.GetUserByIDRow{}, pgx.ErrNoRows)
+		GetUserProfile(gomock.Any(), gomock.Any()).
+		Return(db.GetUserProfileRow{}, pgx.ErrNoRows)
 
 	result, err := service.GetProfile(context.Background(), uuid.New())
 
@@ -300,17 +751,45 @@ func TestGetProfile_UserNotFound(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+// BenchmarkGetProfile: satu panggilan repo per profile, hasil gabungan
+// dicek di setiap iterasi
+func BenchmarkGetProfile(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, nil, &jwtManagerStub{}, nil, "", nil, nil, nil)
+
+	userID := uuid.New()
+	repo.EXPECT().
+		GetUserProfile(gomock.Any(), userID).
+		Return(profileRow(b, userID), nil).
+		Times(b.N)
+
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result, err := service.GetProfile(ctx, userID)
+		if err != nil || len(result.Roles) != 1 || len(result.Menus) != 1 {
+			b.Fatalf("unexpected profile %+v, err %v", result, err)
+		}
+	}
+}
+
 func TestAssignRoleToUser_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, nil)
+	service := auth.NewService(repo, nil, nil, nil, "", nil, nil, nil)
 
-	ctx := context.Background()
 	userID := uuid.New()
 	roleID := uuid.New()
-	assignedBy := uuid.New()
+	actor := uuid.New()
+	// assigned_by = user yang login (diisi AuthMiddleware)
+	ctx := dbutil.WithActorID(context.Background(), actor)
 
 	// mock get user by id
 	repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{
@@ -320,12 +799,13 @@ func TestAssignRoleToUser_Success(t *testing.T) {
 		FullName: "User One",
 		IsActive: dbutil.BoolPtr(true),
 	}, nil)
+	repo.EXPECT().GetRoleByID(ctx, roleID).Return(db.Role{ID: roleID, Code: "sales"}, nil)
 
 	// mock assign role
 	repo.EXPECT().AssignRoleToUser(ctx, db.AssignRoleToUserParams{
 		UserID:     userID,
 		RoleID:     roleID,
-		AssignedBy: dbutil.UUIDPtrToPgUUID(&assignedBy),
+		AssignedBy: dbutil.UUIDPtrToPgUUID(&actor),
 	}).Return(db.AssignRoleToUserRow{
 		ID:         uuid.New(),
 		UserID:     userID,
@@ -333,29 +813,304 @@ func TestAssignRoleToUser_Success(t *testing.T) {
 		AssignedAt: dbutil.TimeToPgTime(time.Now()),
 	}, nil)
 
-	resp, err := service.AssignRoleToUser(ctx, userID, roleID, assignedBy)
+	resp, err := service.AssignRoleToUser(ctx, userID, roleID)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, userID, resp.UserID)
 	assert.Equal(t, roleID, resp.RoleID)
 }
 
-func TestAssignRoleToUser_UserNotFound(t *testing.T) {
+func TestAssignRoleToUser_UserLookup(t *testing.T) {
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"not found", pgx.ErrNoRows, auth.ErrUserNotFound},
+		// error lain tidak disamarkan jadi 404
+		{"db error", dbErr, dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockRepository(ctrl)
+			service := auth.NewService(repo, nil, nil, nil, "", nil, nil, nil)
+
+			ctx := context.Background()
+			userID := uuid.New()
+
+			repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{}, tt.err)
+
+			resp, err := service.AssignRoleToUser(ctx, userID, uuid.New())
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAssignRoleToUser_RoleNotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(repo *mocks.MockRepository)
+	}{
+		{"unknown role", func(repo *mocks.MockRepository) {
+			repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any()).Return(db.Role{}, pgx.ErrNoRows)
+		}},
+		{"role deleted before insert", func(repo *mocks.MockRepository) {
+			repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any()).Return(db.Role{}, nil)
+			repo.EXPECT().AssignRoleToUser(gomock.Any(), gomock.Any()).Return(db.AssignRoleToUserRow{},
+				&pgconn.PgError{Code: "23503", ConstraintName: "user_roles_role_id_fkey"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockRepository(ctrl)
+			service := auth.NewService(repo, nil, nil, nil, "", nil, nil, nil)
+
+			userID := uuid.New()
+			repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(db.GetUserByIDRow{ID: userID}, nil)
+			tt.setup(repo)
+
+			resp, err := service.AssignRoleToUser(context.Background(), userID, uuid.New())
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, auth.ErrRoleNotFound)
+		})
+	}
+}
+
+func TestAssignRoleToUser_AlreadyAssigned(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := auth.NewService(repo, nil, nil)
+	service := auth.NewService(repo, nil, nil, nil, "", nil, nil, nil)
 
 	ctx := context.Background()
 	userID := uuid.New()
 	roleID := uuid.New()
-	assignedBy := uuid.New()
 
-	repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{}, auth.ErrUserNotFound)
+	repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{ID: userID}, nil)
+	repo.EXPECT().GetRoleByID(ctx, roleID).Return(db.Role{ID: roleID}, nil)
+	repo.EXPECT().AssignRoleToUser(ctx, gomock.Any()).Return(db.AssignRoleToUserRow{},
+		&pgconn.PgError{Code: "23505", ConstraintName: "user_roles_user_id_role_id_key"})
 
-	resp, err := service.AssignRoleToUser(ctx, userID, roleID, assignedBy)
-	assert.Error(t, err)
+	resp, err := service.AssignRoleToUser(ctx, userID, roleID)
 	assert.Nil(t, resp)
-	assert.ErrorIs(t, err, auth.ErrUserNotFound)
+	assert.ErrorIs(t, err, auth.ErrRoleAlreadyAssigned)
+}
+
+func TestRemoveRoleFromUser_LastAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := auth.NewService(repo, tx, nil, nil, "", nil, nil, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	adminRoleID := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetUserRoles(ctx, userID).Return([]db.GetUserRolesRow{
+		{ID: adminRoleID, Code: "admin"},
+		{ID: uuid.New(), Code: "user"},
+	}, nil)
+	// role admin dikunci sebelum dihitung, di dalam transaksi yang sama
+	gomock.InOrder(
+		repo.EXPECT().LockAdminRole(ctx).Return(nil),
+		repo.EXPECT().CountActiveAdmins(ctx, userID).Return(db.CountActiveAdminsRow{IsAdmin: true, Remaining: 0}, nil),
+	)
+	// RemoveRoleFromUser tidak boleh dipanggil
+
+	err := service.RemoveRoleFromUser(ctx, userID, adminRoleID)
+	assert.ErrorIs(t, err, auth.ErrLastAdmin)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestRemoveRoleFromUser_NonAdminRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, &fakeTx{}, nil, nil, "", nil, nil, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	userRoleID := uuid.New()
+
+	// role non-admin: tidak perlu kunci dan hitung admin
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetUserRoles(ctx, userID).Return([]db.GetUserRolesRow{
+		{ID: uuid.New(), Code: "admin"},
+		{ID: userRoleID, Code: "user"},
+	}, nil)
+	repo.EXPECT().RemoveRoleFromUser(ctx, userID, userRoleID).Return(nil)
+
+	assert.NoError(t, service.RemoveRoleFromUser(ctx, userID, userRoleID))
+}
+
+// =======================
+// REFRESH TOKEN
+// =======================
+
+func refreshClaims(userID, tokenID uuid.UUID) *auth.Claims {
+	claims := &auth.Claims{UserID: userID.String()}
+	claims.ID = tokenID.String()
+	return claims
+}
+
+func TestRefreshToken_Rotates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := auth.NewService(repo, tx, &jwtManagerStub{claims: refreshClaims(userID, tokenID)}, nil, "", nil, nil, nil)
+
+	ctx := context.Background()
+	var newID uuid.UUID
+
+	repo.EXPECT().GetRefreshToken(ctx, tokenID).Return(db.GetRefreshTokenRow{ID: tokenID, UserID: userID}, nil)
+	repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{ID: userID, IsActive: dbutil.BoolPtr(true)}, nil)
+	repo.EXPECT().GetUserRoles(ctx, userID).Return(nil, nil)
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	// rotate dulu, baru token baru disimpan
+	gomock.InOrder(
+		repo.EXPECT().RotateRefreshToken(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.RotateRefreshTokenParams) (int64, error) {
+				assert.Equal(t, tokenID, arg.ID)
+				newID = *dbutil.PgUUIDToUUIDPtr(arg.ReplacedBy)
+				return 1, nil
+			}),
+		repo.EXPECT().CreateRefreshToken(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.CreateRefreshTokenParams) error {
+				assert.Equal(t, newID, arg.ID)
+				assert.Equal(t, userID, arg.UserID)
+				return nil
+			}),
+	)
+
+	result, err := service.RefreshToken(ctx, "refresh-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh-token", result.RefreshToken)
+	assert.False(t, tx.rolledBack)
+}
+
+func TestRefreshToken_LostRotationRaceStoresNoToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := auth.NewService(repo, tx, &jwtManagerStub{claims: refreshClaims(userID, tokenID)}, nil, "", nil, nil, nil)
+
+	ctx := context.Background()
+
+	familyID := uuid.New()
+	repo.EXPECT().GetRefreshToken(ctx, tokenID).Return(db.GetRefreshTokenRow{ID: tokenID, UserID: userID, FamilyID: familyID}, nil)
+	repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{ID: userID, IsActive: dbutil.BoolPtr(true)}, nil)
+	repo.EXPECT().GetUserRoles(ctx, userID).Return(nil, nil)
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	// request lain sudah me-rotate token ini di antara GetRefreshToken dan update
+	repo.EXPECT().RotateRefreshToken(ctx, gomock.Any()).Return(int64(0), nil)
+	repo.EXPECT().CreateAuditLog(ctx, gomock.Any()).Return(nil)
+	repo.EXPECT().DeleteRefreshTokenFamily(ctx, familyID, userID).Return(nil)
+	// CreateRefreshToken tidak boleh dipanggil
+
+	result, err := service.RefreshToken(ctx, "refresh-token")
+
+	assert.ErrorIs(t, err, auth.ErrRefreshTokenReused)
+	assert.Nil(t, result)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestRefreshToken_RolesErrorIsReturned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	repo := mocks.NewMockRepository(ctrl)
+	service := auth.NewService(repo, &fakeTx{}, &jwtManagerStub{claims: refreshClaims(userID, tokenID)}, nil, "", nil, nil, nil)
+
+	ctx := context.Background()
+	dbErr := errors.New("connection reset")
+
+	repo.EXPECT().GetRefreshToken(ctx, tokenID).Return(db.GetRefreshTokenRow{ID: tokenID, UserID: userID}, nil)
+	repo.EXPECT().GetUserByID(ctx, userID).Return(db.GetUserByIDRow{ID: userID, IsActive: dbutil.BoolPtr(true)}, nil)
+	// tanpa role dari DB, token tanpa role tidak boleh diterbitkan
+	repo.EXPECT().GetUserRoles(ctx, userID).Return(nil, dbErr)
+
+	result, err := service.RefreshToken(ctx, "refresh-token")
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.Nil(t, result)
+}
+
+func TestRefreshToken_ReuseDetected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	familyID := uuid.New()
+	siblingID := uuid.New()
+	repo := mocks.NewMockRepository(ctrl)
+	jwt := &jwtManagerStub{claims: refreshClaims(userID, tokenID)}
+	service := auth.NewService(repo, nil, jwt, nil, "", nil, nil, nil)
+
+	ctx := context.Background()
+
+	// token sudah di-rotate sebelumnya, penggantinya (sibling) masih hidup
+	repo.EXPECT().GetRefreshToken(ctx, tokenID).Return(db.GetRefreshTokenRow{
+		ID:         tokenID,
+		UserID:     userID,
+		FamilyID:   familyID,
+		RevokedAt:  dbutil.TimeToPgTime(time.Now().Add(-time.Minute)),
+		ReplacedBy: dbutil.UUIDPtrToPgUUID(&siblingID),
+	}, nil)
+	familyDeleted := false
+	repo.EXPECT().DeleteRefreshTokenFamily(ctx, familyID, userID).
+		DoAndReturn(func(context.Context, uuid.UUID, uuid.UUID) error {
+			familyDeleted = true
+			return nil
+		})
+	repo.EXPECT().GetRefreshToken(ctx, siblingID).
+		DoAndReturn(func(context.Context, uuid.UUID) (db.GetRefreshTokenRow, error) {
+			if familyDeleted {
+				return db.GetRefreshTokenRow{}, pgx.ErrNoRows
+			}
+			return db.GetRefreshTokenRow{ID: siblingID, UserID: userID, FamilyID: familyID}, nil
+		})
+	repo.EXPECT().CreateAuditLog(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateAuditLogParams) error {
+			assert.Equal(t, userID, *dbutil.PgUUIDToUUIDPtr(arg.UserID))
+			assert.Equal(t, tokenID, arg.RecordID)
+			assert.Equal(t, auth.AuditRefreshReuse, arg.Action)
+			assert.Contains(t, string(arg.NewValues), userID.String())
+			return nil
+		})
+
+	result, err := service.RefreshToken(ctx, "rotated-out-token")
+
+	assert.ErrorIs(t, err, auth.ErrRefreshTokenReused)
+	assert.Nil(t, result)
+
+	// token terbaru di family ikut ditolak
+	jwt.claims = refreshClaims(userID, siblingID)
+	result, err = service.RefreshToken(ctx, "sibling-token")
+
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	assert.Nil(t, result)
 }
@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/ratelimit"
+)
+
+func newThrottledRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	throttle := auth.NewLoginThrottle(ratelimit.NewMemoryLimiter(), auth.LoginThrottleConfig{
+		MaxFailuresPerIdentifier: 3,
+		MaxFailuresPerIP:         100,
+		Window:                   15 * time.Minute,
+	})
+	handler := auth.NewHandler(mockService, throttle)
+
+	router := gin.New()
+	router.POST("/auth/login", handler.Login)
+	return router, mockService
+}
+
+func postLogin(router *gin.Engine, email, ip string) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(map[string]string{"email": email, "password": "guess"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = ip + ":40000"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLoginThrottle_IdentifierAcrossIPs(t *testing.T) {
+	router, mockService := newThrottledRouter(t)
+
+	// hanya 3 percobaan yang sampai ke service
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(nil, auth.ErrInvalidCredentials).
+		Times(3)
+
+	for i := 1; i <= 3; i++ {
+		w := postLogin(router, "victim@example.com", fmt.Sprintf("10.0.0.%d", i))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+
+	// IP baru, identifier sama (beda huruf besar/kecil) tetap diblokir
+	for i := 4; i <= 6; i++ {
+		w := postLogin(router, "Victim@Example.com", fmt.Sprintf("10.0.0.%d", i))
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "900", w.Header().Get("Retry-After"))
+
+		var body middleware.RateLimitedResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, 900, body.RetryAfterSeconds)
+		assert.Equal(t, 3, body.Limit)
+		assert.Equal(t, 900, body.WindowSeconds)
+	}
+}
+
+func TestLoginThrottle_OtherIdentifierUnaffected(t *testing.T) {
+	router, mockService := newThrottledRouter(t)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(nil, auth.ErrInvalidCredentials).
+		Times(4)
+
+	for i := 0; i < 3; i++ {
+		postLogin(router, "victim@example.com", "10.0.0.1")
+	}
+
+	w := postLogin(router, "someone@example.com", "10.0.0.1")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestLoginThrottle_SuccessResetsIdentifier(t *testing.T) {
+	router, mockService := newThrottledRouter(t)
+
+	gomock.InOrder(
+		mockService.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, auth.ErrInvalidCredentials).Times(2),
+		mockService.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&auth.LoginResponse{}, nil),
+		mockService.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, auth.ErrInvalidCredentials).Times(2),
+	)
+
+	postLogin(router, "user@example.com", "10.0.0.1")
+	postLogin(router, "user@example.com", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, postLogin(router, "user@example.com", "10.0.0.1").Code)
+
+	// counter mulai dari nol lagi
+	assert.Equal(t, http.StatusUnauthorized, postLogin(router, "user@example.com", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusUnauthorized, postLogin(router, "user@example.com", "10.0.0.1").Code)
+}
+
+func TestLoginThrottle_ParallelGuessesCannotExceedLimit(t *testing.T) {
+	router, mockService := newThrottledRouter(t)
+
+	// bcrypt lambat: semua goroutine sudah lewat Check sebelum login pertama selesai
+	var calls atomic.Int32
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, auth.LoginRequest) (*auth.LoginResponse, error) {
+			calls.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			return nil, auth.ErrInvalidCredentials
+		}).
+		AnyTimes()
+
+	var wg sync.WaitGroup
+	var limited atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if postLogin(router, "victim@example.com", fmt.Sprintf("10.0.1.%d", i)).Code == http.StatusTooManyRequests {
+				limited.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int32(17), limited.Load())
+}
+
+func TestLoginThrottle_OtherErrorsAreRefunded(t *testing.T) {
+	router, mockService := newThrottledRouter(t)
+
+	// error yang bukan kredensial salah tidak menghabiskan jatah identifier
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("db down")).
+		Times(5)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusInternalServerError, postLogin(router, "user@example.com", "10.0.0.1").Code)
+	}
+}
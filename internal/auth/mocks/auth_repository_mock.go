@@ -11,10 +11,13 @@ package mocks
 
 import (
 	context "context"
+	auth "go-mini-erp/internal/auth"
 	db "go-mini-erp/internal/shared/database/sqlc"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,6 +45,36 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ActivateUserByToken mocks base method.
+func (m *MockRepository) ActivateUserByToken(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUserByToken", ctx, tokenHash, now)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateUserByToken indicates an expected call of ActivateUserByToken.
+func (mr *MockRepositoryMockRecorder) ActivateUserByToken(ctx, tokenHash, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUserByToken", reflect.TypeOf((*MockRepository)(nil).ActivateUserByToken), ctx, tokenHash, now)
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockRepository) AnonymizeUser(ctx context.Context, id uuid.UUID, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, id, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockRepositoryMockRecorder) AnonymizeUser(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockRepository)(nil).AnonymizeUser), ctx, id, now)
+}
+
 // AssignRoleToUser mocks base method.
 func (m *MockRepository) AssignRoleToUser(ctx context.Context, arg db.AssignRoleToUserParams) (db.AssignRoleToUserRow, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +90,21 @@ func (mr *MockRepositoryMockRecorder) AssignRoleToUser(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleToUser", reflect.TypeOf((*MockRepository)(nil).AssignRoleToUser), ctx, arg)
 }
 
+// CancelUserDeletion mocks base method.
+func (m *MockRepository) CancelUserDeletion(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUserDeletion", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUserDeletion indicates an expected call of CancelUserDeletion.
+func (mr *MockRepositoryMockRecorder) CancelUserDeletion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserDeletion", reflect.TypeOf((*MockRepository)(nil).CancelUserDeletion), ctx, id)
+}
+
 // CheckEmailExists mocks base method.
 func (m *MockRepository) CheckEmailExists(ctx context.Context, email string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +135,64 @@ func (mr *MockRepositoryMockRecorder) CheckUsernameExists(ctx, username any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUsernameExists", reflect.TypeOf((*MockRepository)(nil).CheckUsernameExists), ctx, username)
 }
 
+// CountActiveAdmins mocks base method.
+func (m *MockRepository) CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveAdmins", ctx, userID)
+	ret0, _ := ret[0].(db.CountActiveAdminsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveAdmins indicates an expected call of CountActiveAdmins.
+func (mr *MockRepositoryMockRecorder) CountActiveAdmins(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveAdmins", reflect.TypeOf((*MockRepository)(nil).CountActiveAdmins), ctx, userID)
+}
+
+// CountRevokedTokens mocks base method.
+func (m *MockRepository) CountRevokedTokens(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRevokedTokens", ctx, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRevokedTokens indicates an expected call of CountRevokedTokens.
+func (mr *MockRepositoryMockRecorder) CountRevokedTokens(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRevokedTokens", reflect.TypeOf((*MockRepository)(nil).CountRevokedTokens), ctx, since)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockRepository) CreateAuditLog(ctx context.Context, arg db.CreateAuditLogParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockRepositoryMockRecorder) CreateAuditLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockRepository)(nil).CreateAuditLog), ctx, arg)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockRepository) CreateRefreshToken(ctx context.Context, arg db.CreateRefreshTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockRepositoryMockRecorder) CreateRefreshToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockRepository)(nil).CreateRefreshToken), ctx, arg)
+}
+
 // CreateUser mocks base method.
 func (m *MockRepository) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +208,122 @@ func (mr *MockRepositoryMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), ctx, arg)
 }
 
+// CreateUserActivation mocks base method.
+func (m *MockRepository) CreateUserActivation(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserActivation", ctx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserActivation indicates an expected call of CreateUserActivation.
+func (mr *MockRepositoryMockRecorder) CreateUserActivation(ctx, userID, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserActivation", reflect.TypeOf((*MockRepository)(nil).CreateUserActivation), ctx, userID, tokenHash, expiresAt)
+}
+
+// DeleteRefreshTokenFamily mocks base method.
+func (m *MockRepository) DeleteRefreshTokenFamily(ctx context.Context, familyID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRefreshTokenFamily", ctx, familyID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRefreshTokenFamily indicates an expected call of DeleteRefreshTokenFamily.
+func (mr *MockRepositoryMockRecorder) DeleteRefreshTokenFamily(ctx, familyID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRefreshTokenFamily", reflect.TypeOf((*MockRepository)(nil).DeleteRefreshTokenFamily), ctx, familyID, userID)
+}
+
+// DeleteUserRefreshTokens mocks base method.
+func (m *MockRepository) DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRefreshTokens indicates an expected call of DeleteUserRefreshTokens.
+func (mr *MockRepositoryMockRecorder) DeleteUserRefreshTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRefreshTokens", reflect.TypeOf((*MockRepository)(nil).DeleteUserRefreshTokens), ctx, userID)
+}
+
+// DeleteUserRoles mocks base method.
+func (m *MockRepository) DeleteUserRoles(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRoles", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRoles indicates an expected call of DeleteUserRoles.
+func (mr *MockRepositoryMockRecorder) DeleteUserRoles(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRoles", reflect.TypeOf((*MockRepository)(nil).DeleteUserRoles), ctx, userID)
+}
+
+// GetProfileVersion mocks base method.
+func (m *MockRepository) GetProfileVersion(ctx context.Context, userID uuid.UUID) (db.GetProfileVersionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileVersion", ctx, userID)
+	ret0, _ := ret[0].(db.GetProfileVersionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileVersion indicates an expected call of GetProfileVersion.
+func (mr *MockRepositoryMockRecorder) GetProfileVersion(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileVersion", reflect.TypeOf((*MockRepository)(nil).GetProfileVersion), ctx, userID)
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockRepository) GetRefreshToken(ctx context.Context, id uuid.UUID) (db.GetRefreshTokenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, id)
+	ret0, _ := ret[0].(db.GetRefreshTokenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockRepositoryMockRecorder) GetRefreshToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockRepository)(nil).GetRefreshToken), ctx, id)
+}
+
+// GetRoleByCode mocks base method.
+func (m *MockRepository) GetRoleByCode(ctx context.Context, code string) (db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByCode", ctx, code)
+	ret0, _ := ret[0].(db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByCode indicates an expected call of GetRoleByCode.
+func (mr *MockRepositoryMockRecorder) GetRoleByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByCode", reflect.TypeOf((*MockRepository)(nil).GetRoleByCode), ctx, code)
+}
+
+// GetRoleByID mocks base method.
+func (m *MockRepository) GetRoleByID(ctx context.Context, id uuid.UUID) (db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByID", ctx, id)
+	ret0, _ := ret[0].(db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByID indicates an expected call of GetRoleByID.
+func (mr *MockRepositoryMockRecorder) GetRoleByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockRepository)(nil).GetRoleByID), ctx, id)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (db.GetUserByEmailRow, error) {
 	m.ctrl.T.Helper()
@@ -147,19 +369,34 @@ func (mr *MockRepositoryMockRecorder) GetUserByUsername(ctx, username any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockRepository)(nil).GetUserByUsername), ctx, username)
 }
 
-// GetUserMenus mocks base method.
-func (m *MockRepository) GetUserMenus(ctx context.Context, userID uuid.UUID) ([]db.GetUserMenusRow, error) {
+// GetUserPasswordHash mocks base method.
+func (m *MockRepository) GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserMenus", ctx, userID)
-	ret0, _ := ret[0].([]db.GetUserMenusRow)
+	ret := m.ctrl.Call(m, "GetUserPasswordHash", ctx, id)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserMenus indicates an expected call of GetUserMenus.
-func (mr *MockRepositoryMockRecorder) GetUserMenus(ctx, userID any) *gomock.Call {
+// GetUserPasswordHash indicates an expected call of GetUserPasswordHash.
+func (mr *MockRepositoryMockRecorder) GetUserPasswordHash(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMenus", reflect.TypeOf((*MockRepository)(nil).GetUserMenus), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasswordHash", reflect.TypeOf((*MockRepository)(nil).GetUserPasswordHash), ctx, id)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockRepository) GetUserProfile(ctx context.Context, userID uuid.UUID) (db.GetUserProfileRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, userID)
+	ret0, _ := ret[0].(db.GetUserProfileRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockRepositoryMockRecorder) GetUserProfile(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockRepository)(nil).GetUserProfile), ctx, userID)
 }
 
 // GetUserRoles mocks base method.
@@ -177,6 +414,95 @@ func (mr *MockRepositoryMockRecorder) GetUserRoles(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockRepository)(nil).GetUserRoles), ctx, userID)
 }
 
+// HasPendingActivation mocks base method.
+func (m *MockRepository) HasPendingActivation(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingActivation", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPendingActivation indicates an expected call of HasPendingActivation.
+func (mr *MockRepositoryMockRecorder) HasPendingActivation(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingActivation", reflect.TypeOf((*MockRepository)(nil).HasPendingActivation), ctx, userID)
+}
+
+// IsAccessTokenRevoked mocks base method.
+func (m *MockRepository) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccessTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccessTokenRevoked indicates an expected call of IsAccessTokenRevoked.
+func (mr *MockRepositoryMockRecorder) IsAccessTokenRevoked(ctx, jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessTokenRevoked", reflect.TypeOf((*MockRepository)(nil).IsAccessTokenRevoked), ctx, jti)
+}
+
+// ListRevokedTokens mocks base method.
+func (m *MockRepository) ListRevokedTokens(ctx context.Context, arg db.ListRevokedTokensParams) ([]db.ListRevokedTokensRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevokedTokens", ctx, arg)
+	ret0, _ := ret[0].([]db.ListRevokedTokensRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevokedTokens indicates an expected call of ListRevokedTokens.
+func (mr *MockRepositoryMockRecorder) ListRevokedTokens(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevokedTokens", reflect.TypeOf((*MockRepository)(nil).ListRevokedTokens), ctx, arg)
+}
+
+// ListUsersByRole mocks base method.
+func (m *MockRepository) ListUsersByRole(ctx context.Context, arg db.ListUsersByRoleParams) ([]db.ListUsersByRoleRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByRole", ctx, arg)
+	ret0, _ := ret[0].([]db.ListUsersByRoleRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByRole indicates an expected call of ListUsersByRole.
+func (mr *MockRepositoryMockRecorder) ListUsersByRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByRole", reflect.TypeOf((*MockRepository)(nil).ListUsersByRole), ctx, arg)
+}
+
+// ListUsersDueForDeletion mocks base method.
+func (m *MockRepository) ListUsersDueForDeletion(ctx context.Context, now time.Time, limit int32) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersDueForDeletion", ctx, now, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersDueForDeletion indicates an expected call of ListUsersDueForDeletion.
+func (mr *MockRepositoryMockRecorder) ListUsersDueForDeletion(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersDueForDeletion", reflect.TypeOf((*MockRepository)(nil).ListUsersDueForDeletion), ctx, now, limit)
+}
+
+// LockAdminRole mocks base method.
+func (m *MockRepository) LockAdminRole(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAdminRole", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAdminRole indicates an expected call of LockAdminRole.
+func (mr *MockRepositoryMockRecorder) LockAdminRole(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAdminRole", reflect.TypeOf((*MockRepository)(nil).LockAdminRole), ctx)
+}
+
 // RemoveRoleFromUser mocks base method.
 func (m *MockRepository) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -191,16 +517,74 @@ func (mr *MockRepositoryMockRecorder) RemoveRoleFromUser(ctx, userID, roleID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoleFromUser", reflect.TypeOf((*MockRepository)(nil).RemoveRoleFromUser), ctx, userID, roleID)
 }
 
+// RevokeAccessToken mocks base method.
+func (m *MockRepository) RevokeAccessToken(ctx context.Context, arg db.RevokeAccessTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockRepositoryMockRecorder) RevokeAccessToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockRepository)(nil).RevokeAccessToken), ctx, arg)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockRepository) RotateRefreshToken(ctx context.Context, arg db.RotateRefreshTokenParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockRepositoryMockRecorder) RotateRefreshToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockRepository)(nil).RotateRefreshToken), ctx, arg)
+}
+
+// ScheduleUserDeletion mocks base method.
+func (m *MockRepository) ScheduleUserDeletion(ctx context.Context, id uuid.UUID, dueAt time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleUserDeletion", ctx, id, dueAt)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleUserDeletion indicates an expected call of ScheduleUserDeletion.
+func (mr *MockRepositoryMockRecorder) ScheduleUserDeletion(ctx, id, dueAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleUserDeletion", reflect.TypeOf((*MockRepository)(nil).ScheduleUserDeletion), ctx, id, dueAt)
+}
+
 // UpdateUserLastLogin mocks base method.
-func (m *MockRepository) UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error {
+func (m *MockRepository) UpdateUserLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserLastLogin", ctx, id)
+	ret := m.ctrl.Call(m, "UpdateUserLastLogin", ctx, id, at)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUserLastLogin indicates an expected call of UpdateUserLastLogin.
-func (mr *MockRepositoryMockRecorder) UpdateUserLastLogin(ctx, id any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateUserLastLogin(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserLastLogin", reflect.TypeOf((*MockRepository)(nil).UpdateUserLastLogin), ctx, id, at)
+}
+
+// WithQuerier mocks base method.
+func (m *MockRepository) WithQuerier(q db.Querier) auth.Repository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithQuerier", q)
+	ret0, _ := ret[0].(auth.Repository)
+	return ret0
+}
+
+// WithQuerier indicates an expected call of WithQuerier.
+func (mr *MockRepositoryMockRecorder) WithQuerier(q any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserLastLogin", reflect.TypeOf((*MockRepository)(nil).UpdateUserLastLogin), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithQuerier", reflect.TypeOf((*MockRepository)(nil).WithQuerier), q)
 }
@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	auth "go-mini-erp/internal/auth"
+	pagination "go-mini-erp/internal/shared/pagination"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -43,18 +45,18 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // AssignRoleToUser mocks base method.
-func (m *MockService) AssignRoleToUser(ctx context.Context, userID, roleID, assignedBy uuid.UUID) (*auth.RoleAssignmentResponse, error) {
+func (m *MockService) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) (*auth.RoleAssignmentResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignRoleToUser", ctx, userID, roleID, assignedBy)
+	ret := m.ctrl.Call(m, "AssignRoleToUser", ctx, userID, roleID)
 	ret0, _ := ret[0].(*auth.RoleAssignmentResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignRoleToUser indicates an expected call of AssignRoleToUser.
-func (mr *MockServiceMockRecorder) AssignRoleToUser(ctx, userID, roleID, assignedBy any) *gomock.Call {
+func (mr *MockServiceMockRecorder) AssignRoleToUser(ctx, userID, roleID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleToUser", reflect.TypeOf((*MockService)(nil).AssignRoleToUser), ctx, userID, roleID, assignedBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleToUser", reflect.TypeOf((*MockService)(nil).AssignRoleToUser), ctx, userID, roleID)
 }
 
 // GetProfile mocks base method.
@@ -87,6 +89,35 @@ func (mr *MockServiceMockRecorder) GetUserRoles(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockService)(nil).GetUserRoles), ctx, userID)
 }
 
+// Introspect mocks base method.
+func (m *MockService) Introspect(ctx context.Context, token string) *auth.IntrospectionResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Introspect", ctx, token)
+	ret0, _ := ret[0].(*auth.IntrospectionResponse)
+	return ret0
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockServiceMockRecorder) Introspect(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockService)(nil).Introspect), ctx, token)
+}
+
+// ListRevokedTokens mocks base method.
+func (m *MockService) ListRevokedTokens(ctx context.Context, since time.Time, params pagination.Params) (*pagination.Response[auth.RevokedToken], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevokedTokens", ctx, since, params)
+	ret0, _ := ret[0].(*pagination.Response[auth.RevokedToken])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevokedTokens indicates an expected call of ListRevokedTokens.
+func (mr *MockServiceMockRecorder) ListRevokedTokens(ctx, since, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevokedTokens", reflect.TypeOf((*MockService)(nil).ListRevokedTokens), ctx, since, params)
+}
+
 // Login mocks base method.
 func (m *MockService) Login(ctx context.Context, req auth.LoginRequest) (*auth.LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -103,17 +134,32 @@ func (mr *MockServiceMockRecorder) Login(ctx, req any) *gomock.Call {
 }
 
 // Logout mocks base method.
-func (m *MockService) Logout(ctx context.Context, userID uuid.UUID) error {
+func (m *MockService) Logout(ctx context.Context, userID, tokenID, sessionID uuid.UUID, expiresAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Logout", ctx, userID)
+	ret := m.ctrl.Call(m, "Logout", ctx, userID, tokenID, sessionID, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Logout indicates an expected call of Logout.
-func (mr *MockServiceMockRecorder) Logout(ctx, userID any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Logout(ctx, userID, tokenID, sessionID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockService)(nil).Logout), ctx, userID, tokenID, sessionID, expiresAt)
+}
+
+// ProfileETag mocks base method.
+func (m *MockService) ProfileETag(ctx context.Context, userID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfileETag", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileETag indicates an expected call of ProfileETag.
+func (mr *MockServiceMockRecorder) ProfileETag(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockService)(nil).Logout), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileETag", reflect.TypeOf((*MockService)(nil).ProfileETag), ctx, userID)
 }
 
 // RefreshToken mocks base method.
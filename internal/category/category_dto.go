@@ -0,0 +1,38 @@
+package category
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateCategoryRequest struct {
+	Code        string     `json:"code" binding:"required,max=50"`
+	Name        string     `json:"name" binding:"required,max=255"`
+	Description *string    `json:"description"`
+	ParentID    *uuid.UUID `json:"parentId"`
+	IsActive    *bool      `json:"isActive"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        string     `json:"name" binding:"required,max=255"`
+	Description *string    `json:"description"`
+	ParentID    *uuid.UUID `json:"parentId"`
+	IsActive    *bool      `json:"isActive" binding:"required"`
+}
+
+type CategoryResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	ParentID    *uuid.UUID `json:"parentId"`
+	IsActive    bool       `json:"isActive"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type CategoryTreeNode struct {
+	CategoryResponse
+	Children []CategoryTreeNode `json:"children"`
+}
@@ -0,0 +1,11 @@
+package category
+
+import "errors"
+
+var (
+	ErrCategoryNotFound   = errors.New("category not found")
+	ErrCategoryCodeExists = errors.New("category code already exists")
+	ErrParentNotFound     = errors.New("parent category not found")
+	ErrCategoryCycle      = errors.New("category cannot be moved under itself or its descendants")
+	ErrCategoryInUse      = errors.New("category still has subcategories or products")
+)
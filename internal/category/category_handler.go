@@ -0,0 +1,188 @@
+package category
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/httpcache"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateCategory godoc
+// @Summary Create category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateCategoryRequest true "Category"
+// @Success 201 {object} CategoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /categories [post]
+func (h *Handler) CreateCategory(c *gin.Context) {
+	var req CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.CreateCategory(c.Request.Context(), req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListCategories godoc
+// @Summary List categories
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param If-Modified-Since header string false "Last-Modified from a previous response; ignored when If-None-Match is sent"
+// @Success 200 {array} CategoryResponse
+// @Success 304 "Not modified"
+// @Router /categories [get]
+func (h *Handler) ListCategories(c *gin.Context) {
+	result, err := h.service.ListCategories(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	updatedAt := func(r CategoryResponse) time.Time { return r.UpdatedAt }
+	if httpcache.NotModified(c, httpcache.ListETag(result, updatedAt), httpcache.Latest(result, updatedAt)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCategoryTree godoc
+// @Summary Get category tree
+// @Description All categories nested under their parent
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} CategoryTreeNode
+// @Router /categories/tree [get]
+func (h *Handler) GetCategoryTree(c *gin.Context) {
+	result, err := h.service.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCategory godoc
+// @Summary Get category
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Success 200 {object} CategoryResponse
+// @Failure 404 {object} map[string]string
+// @Router /categories/{id} [get]
+func (h *Handler) GetCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	result, err := h.service.GetCategory(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateCategory godoc
+// @Summary Update category
+// @Description Moving a category under itself or one of its descendants is rejected
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param request body UpdateCategoryRequest true "Category"
+// @Success 200 {object} CategoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /categories/{id} [put]
+func (h *Handler) UpdateCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.UpdateCategory(c.Request.Context(), id, req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteCategory godoc
+// @Summary Delete category
+// @Tags categories
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /categories/{id} [delete]
+func (h *Handler) DeleteCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	if err := h.service.DeleteCategory(c.Request.Context(), id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrParentNotFound), errors.Is(err, ErrCategoryCycle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrCategoryCodeExists), errors.Is(err, ErrCategoryInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
@@ -0,0 +1,88 @@
+package category_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/category"
+	"go-mini-erp/internal/category/mocks"
+)
+
+func newCategoryRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := category.NewHandler(mockService)
+
+	router := gin.New()
+	router.GET("/categories", handler.ListCategories)
+	return router, mockService
+}
+
+func TestListCategoriesHandler_ConditionalGet(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	categories := []category.CategoryResponse{
+		{ID: uuid.New(), Code: "BEV", Name: "Beverages", UpdatedAt: updated},
+		{ID: uuid.New(), Code: "SNACK", Name: "Snacks", UpdatedAt: updated.Add(-time.Hour)},
+	}
+
+	list := func(t *testing.T, current []category.CategoryResponse, ifNoneMatch, ifModifiedSince string) *httptest.ResponseRecorder {
+		router, mockService := newCategoryRouter(t)
+		mockService.EXPECT().ListCategories(gomock.Any()).Return(current, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := list(t, categories, "", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	lastModified := first.Header().Get("Last-Modified")
+	require.Equal(t, "Sun, 01 Mar 2026 09:30:00 GMT", lastModified)
+
+	tests := []struct {
+		name            string
+		current         []category.CategoryResponse
+		ifNoneMatch     string
+		ifModifiedSince string
+		wantStatus      int
+	}{
+		{"etag unchanged", categories, etag, "", http.StatusNotModified},
+		{"category hard deleted", categories[:1], etag, "", http.StatusOK},
+		{"If-Modified-Since alone, unchanged", categories, "", lastModified, http.StatusNotModified},
+		{"If-Modified-Since alone, changed after", categories, "", "Sun, 01 Mar 2026 09:00:00 GMT", http.StatusOK},
+		{"If-None-Match wins over If-Modified-Since", categories[:1], etag, lastModified, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := list(t, tt.current, tt.ifNoneMatch, tt.ifModifiedSince)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, lastModified, w.Header().Get("Last-Modified"))
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"code":"BEV"`)
+			} else {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
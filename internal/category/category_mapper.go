@@ -0,0 +1,47 @@
+package category
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/tree"
+	"go-mini-erp/internal/shared/util/dbutil"
+
+	"github.com/google/uuid"
+)
+
+func mapCategory(c db.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:          c.ID,
+		Code:        c.Code,
+		Name:        c.Name,
+		Description: c.Description,
+		ParentID:    dbutil.PgUUIDToUUIDPtr(c.ParentID),
+		IsActive:    dbutil.BoolPtrValue(c.IsActive, false),
+		CreatedAt:   dbutil.PgTimeValue(c.CreatedAt),
+		UpdatedAt:   dbutil.PgTimeValue(c.UpdatedAt),
+	}
+}
+
+func mapCategories(rows []db.Category) []CategoryResponse {
+	categories := make([]CategoryResponse, 0, len(rows))
+
+	for _, c := range rows {
+		categories = append(categories, mapCategory(c))
+	}
+
+	return categories
+}
+
+/*
+buildCategoryTree menyusun list flat menjadi tree via shared/tree
+urutan sibling mengikuti urutan input (ORDER BY name)
+*/
+func buildCategoryTree(categories []CategoryResponse) []CategoryTreeNode {
+	roots := tree.Build(categories,
+		func(c CategoryResponse) uuid.UUID { return c.ID },
+		func(c CategoryResponse) *uuid.UUID { return c.ParentID },
+	)
+
+	return tree.Map(roots, func(c CategoryResponse, children []CategoryTreeNode) CategoryTreeNode {
+		return CategoryTreeNode{CategoryResponse: c, Children: children}
+	})
+}
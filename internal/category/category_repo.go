@@ -0,0 +1,72 @@
+package category
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=category_repo.go -destination=mocks/category_repository_mock.go -package=mocks
+
+// Repository defines category data access contract
+type Repository interface {
+	CreateCategory(ctx context.Context, arg db.CreateCategoryParams) (db.CreateCategoryRow, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (db.Category, error)
+	CheckCategoryCodeExists(ctx context.Context, code string) (bool, error)
+	ListCategories(ctx context.Context) ([]db.Category, error)
+	UpdateCategory(ctx context.Context, arg db.UpdateCategoryParams) (int64, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) (int64, error)
+	GetCategoryAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// LockCategoryTree serializes parent changes; hanya berarti di dalam transaksi
+	LockCategoryTree(ctx context.Context) error
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates category repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) CreateCategory(ctx context.Context, arg db.CreateCategoryParams) (db.CreateCategoryRow, error) {
+	return r.q.CreateCategory(ctx, arg)
+}
+
+func (r *repository) GetCategoryByID(ctx context.Context, id uuid.UUID) (db.Category, error) {
+	return r.q.GetCategoryByID(ctx, id)
+}
+
+func (r *repository) CheckCategoryCodeExists(ctx context.Context, code string) (bool, error) {
+	return r.q.CheckCategoryCodeExists(ctx, code)
+}
+
+func (r *repository) ListCategories(ctx context.Context) ([]db.Category, error) {
+	return r.q.ListCategories(ctx)
+}
+
+func (r *repository) UpdateCategory(ctx context.Context, arg db.UpdateCategoryParams) (int64, error) {
+	return r.q.UpdateCategory(ctx, arg)
+}
+
+func (r *repository) DeleteCategory(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.DeleteCategory(ctx, id)
+}
+
+func (r *repository) GetCategoryAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	return r.q.GetCategoryAncestorIDs(ctx, id)
+}
+
+func (r *repository) LockCategoryTree(ctx context.Context) error {
+	return r.q.LockCategoryTree(ctx)
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
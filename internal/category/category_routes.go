@@ -0,0 +1,19 @@
+package category
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/categories", middleware.AuthMiddleware(), middleware.RequireMenuForPath())
+	{
+		routes.POST("", h.CreateCategory)
+		routes.GET("", h.ListCategories)
+		routes.GET("/tree", h.GetCategoryTree)
+		routes.GET("/:id", h.GetCategory)
+		routes.PUT("/:id", h.UpdateCategory)
+		routes.DELETE("/:id", h.DeleteCategory)
+	}
+}
@@ -0,0 +1,201 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+//go:generate mockgen -source=category_service.go -destination=mocks/category_service_mock.go -package=mocks
+
+// Service defines category business logic
+type Service interface {
+	CreateCategory(ctx context.Context, req CreateCategoryRequest) (*CategoryResponse, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (*CategoryResponse, error)
+	ListCategories(ctx context.Context) ([]CategoryResponse, error)
+	GetCategoryTree(ctx context.Context) ([]CategoryTreeNode, error)
+	UpdateCategory(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (*CategoryResponse, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+}
+
+type service struct {
+	repo Repository
+	tx   database.Transactor
+}
+
+// NewService creates category service
+func NewService(repo Repository, tx database.Transactor) Service {
+	return &service{repo: repo, tx: tx}
+}
+
+func (s *service) CreateCategory(ctx context.Context, req CreateCategoryRequest) (*CategoryResponse, error) {
+	code := strings.TrimSpace(req.Code)
+
+	exists, err := s.repo.CheckCategoryCodeExists(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCategoryCodeExists
+	}
+
+	if req.ParentID != nil {
+		if err := s.ensureParentExists(ctx, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	active := dbutil.BoolPtrValue(req.IsActive, true)
+
+	created, err := s.repo.CreateCategory(ctx, db.CreateCategoryParams{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    dbutil.UUIDPtrToPgUUID(req.ParentID),
+		IsActive:    &active,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrCategoryCodeExists
+		}
+		return nil, fmt.Errorf("create category failed: %w", err)
+	}
+
+	return s.GetCategory(ctx, created.ID)
+}
+
+func (s *service) GetCategory(ctx context.Context, id uuid.UUID) (*CategoryResponse, error) {
+	c, err := s.repo.GetCategoryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	res := mapCategory(c)
+	return &res, nil
+}
+
+func (s *service) ListCategories(ctx context.Context) ([]CategoryResponse, error) {
+	rows, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapCategories(rows), nil
+}
+
+func (s *service) GetCategoryTree(ctx context.Context) ([]CategoryTreeNode, error) {
+	categories, err := s.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCategoryTree(categories), nil
+}
+
+// UpdateCategory checks for cycles and writes in one transaction; a
+// parent change takes the tree lock first so concurrent moves cannot
+// both pass the ancestor check.
+func (s *service) UpdateCategory(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (*CategoryResponse, error) {
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		if req.ParentID != nil {
+			if err := repo.LockCategoryTree(ctx); err != nil {
+				return err
+			}
+			if err := ensureNoCycle(ctx, repo, id, *req.ParentID); err != nil {
+				return err
+			}
+		}
+
+		affected, err := repo.UpdateCategory(ctx, db.UpdateCategoryParams{
+			ID:          id,
+			Name:        req.Name,
+			Description: req.Description,
+			ParentID:    dbutil.UUIDPtrToPgUUID(req.ParentID),
+			IsActive:    req.IsActive,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCategory(ctx, id)
+}
+
+func (s *service) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	affected, err := s.repo.DeleteCategory(ctx, id)
+	if err != nil {
+		// masih direferensikan sub-category atau product
+		if isForeignKeyViolation(err) {
+			return ErrCategoryInUse
+		}
+		return err
+	}
+	if affected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (s *service) ensureParentExists(ctx context.Context, parentID uuid.UUID) error {
+	if _, err := s.repo.GetCategoryByID(ctx, parentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ensureNoCycle rejects moving id under parentID when id is parentID
+// itself or one of parentID's ancestors (i.e. parentID is a descendant).
+func ensureNoCycle(ctx context.Context, repo Repository, id, parentID uuid.UUID) error {
+	if id == parentID {
+		return ErrCategoryCycle
+	}
+
+	ancestors, err := repo.GetCategoryAncestorIDs(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	// query mengembalikan parentID sendiri jika ada
+	if len(ancestors) == 0 {
+		return ErrParentNotFound
+	}
+	if slices.Contains(ancestors, id) {
+		return ErrCategoryCycle
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
@@ -0,0 +1,205 @@
+package category_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/category"
+	"go-mini-erp/internal/category/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	f.calls++
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+func TestGetCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := category.NewService(repo, &fakeTx{})
+
+	beverages := uuid.New()
+	coffee := uuid.New()
+	arabica := uuid.New()
+	snacks := uuid.New()
+
+	// urutan dari query: ORDER BY name
+	repo.EXPECT().ListCategories(gomock.Any()).Return([]db.Category{
+		{ID: arabica, Code: "ARABICA", Name: "Arabica", ParentID: dbutil.UUIDPtrToPgUUID(&coffee)},
+		{ID: beverages, Code: "BEV", Name: "Beverages"},
+		{ID: coffee, Code: "COFFEE", Name: "Coffee", ParentID: dbutil.UUIDPtrToPgUUID(&beverages)},
+		{ID: snacks, Code: "SNACK", Name: "Snacks"},
+	}, nil)
+
+	roots, err := service.GetCategoryTree(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, roots, 2)
+	assert.Equal(t, "Beverages", roots[0].Name)
+	assert.Equal(t, "Snacks", roots[1].Name)
+	assert.Empty(t, roots[1].Children)
+
+	require.Len(t, roots[0].Children, 1)
+	coffeeNode := roots[0].Children[0]
+	assert.Equal(t, coffee, coffeeNode.ID)
+	require.Len(t, coffeeNode.Children, 1)
+	assert.Equal(t, arabica, coffeeNode.Children[0].ID)
+	assert.Equal(t, coffee, *coffeeNode.Children[0].ParentID)
+}
+
+func TestUpdateCategory_RejectsCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := category.NewService(repo, &fakeTx{})
+
+	beverages := uuid.New()
+	coffee := uuid.New()
+	arabica := uuid.New()
+
+	// memindahkan Beverages ke bawah Arabica (cucunya sendiri)
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().LockCategoryTree(gomock.Any()).Return(nil)
+	repo.EXPECT().GetCategoryAncestorIDs(gomock.Any(), arabica).
+		Return([]uuid.UUID{arabica, coffee, beverages}, nil)
+
+	result, err := service.UpdateCategory(context.Background(), beverages, category.UpdateCategoryRequest{
+		Name:     "Beverages",
+		ParentID: &arabica,
+		IsActive: dbutil.BoolPtr(true),
+	})
+
+	assert.ErrorIs(t, err, category.ErrCategoryCycle)
+	assert.Nil(t, result)
+}
+
+func TestUpdateCategory_RejectsSelfParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := category.NewService(repo, &fakeTx{})
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().LockCategoryTree(gomock.Any()).Return(nil)
+	_, err := service.UpdateCategory(context.Background(), id, category.UpdateCategoryRequest{
+		Name:     "Loop",
+		ParentID: &id,
+		IsActive: dbutil.BoolPtr(true),
+	})
+
+	assert.ErrorIs(t, err, category.ErrCategoryCycle)
+}
+
+func TestUpdateCategory_MoveUnderSibling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := category.NewService(repo, tx)
+
+	beverages := uuid.New()
+	coffee := uuid.New()
+	tea := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	// lock diambil sebelum membaca ancestor supaya perpindahan paralel antre
+	gomock.InOrder(
+		repo.EXPECT().LockCategoryTree(gomock.Any()).Return(nil),
+		repo.EXPECT().GetCategoryAncestorIDs(gomock.Any(), coffee).Return([]uuid.UUID{coffee, beverages}, nil),
+	)
+	repo.EXPECT().UpdateCategory(gomock.Any(), db.UpdateCategoryParams{
+		ID:       tea,
+		Name:     "Tea",
+		ParentID: dbutil.UUIDPtrToPgUUID(&coffee),
+		IsActive: dbutil.BoolPtr(true),
+	}).Return(int64(1), nil)
+	repo.EXPECT().GetCategoryByID(gomock.Any(), tea).Return(db.Category{
+		ID:       tea,
+		Name:     "Tea",
+		ParentID: dbutil.UUIDPtrToPgUUID(&coffee),
+		IsActive: dbutil.BoolPtr(true),
+	}, nil)
+
+	result, err := service.UpdateCategory(context.Background(), tea, category.UpdateCategoryRequest{
+		Name:     "Tea",
+		ParentID: &coffee,
+		IsActive: dbutil.BoolPtr(true),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, coffee, *result.ParentID)
+	assert.Equal(t, 1, tx.calls)
+	assert.False(t, tx.rolledBack)
+}
+
+func TestUpdateCategory_CycleRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := category.NewService(repo, tx)
+
+	parent := uuid.New()
+	child := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().LockCategoryTree(gomock.Any()).Return(nil)
+	repo.EXPECT().GetCategoryAncestorIDs(gomock.Any(), child).Return([]uuid.UUID{child, parent}, nil)
+	// UpdateCategory tidak boleh dipanggil
+
+	_, err := service.UpdateCategory(context.Background(), parent, category.UpdateCategoryRequest{
+		Name:     "Parent",
+		ParentID: &child,
+		IsActive: dbutil.BoolPtr(true),
+	})
+
+	assert.ErrorIs(t, err, category.ErrCategoryCycle)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestUpdateCategory_ToRootSkipsLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := category.NewService(repo, &fakeTx{})
+
+	id := uuid.New()
+
+	// tanpa parent tidak mungkin membentuk cycle
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().UpdateCategory(gomock.Any(), db.UpdateCategoryParams{
+		ID:       id,
+		Name:     "Beverages",
+		IsActive: dbutil.BoolPtr(true),
+	}).Return(int64(0), nil)
+
+	_, err := service.UpdateCategory(context.Background(), id, category.UpdateCategoryRequest{
+		Name:     "Beverages",
+		IsActive: dbutil.BoolPtr(true),
+	})
+
+	assert.ErrorIs(t, err, category.ErrCategoryNotFound)
+}
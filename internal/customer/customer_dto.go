@@ -0,0 +1,67 @@
+package customer
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"go-mini-erp/internal/shared/pagination"
+)
+
+// BillingAddress disimpan di kolom customers.address
+
+type CreateCustomerRequest struct {
+	Code           string          `json:"code" binding:"required,max=50"`
+	Name           string          `json:"name" binding:"required,max=255"`
+	ContactPerson  *string         `json:"contactPerson" binding:"omitempty,max=255"`
+	Email          *string         `json:"email" binding:"omitempty,email,max=255"`
+	Phone          *string         `json:"phone" binding:"omitempty,max=50"`
+	BillingAddress *string         `json:"billingAddress"`
+	TaxID          *string         `json:"taxId" binding:"omitempty,max=100"`
+	CreditLimit    decimal.Decimal `json:"creditLimit" swaggertype:"string" example:"5000000.00"`
+	PaymentTerms   *int32          `json:"paymentTerms" binding:"omitempty,min=0"`
+	IsActive       *bool           `json:"isActive"`
+}
+
+type UpdateCustomerRequest struct {
+	Name           string          `json:"name" binding:"required,max=255"`
+	ContactPerson  *string         `json:"contactPerson" binding:"omitempty,max=255"`
+	Email          *string         `json:"email" binding:"omitempty,email,max=255"`
+	Phone          *string         `json:"phone" binding:"omitempty,max=50"`
+	BillingAddress *string         `json:"billingAddress"`
+	TaxID          *string         `json:"taxId" binding:"omitempty,max=100"`
+	CreditLimit    decimal.Decimal `json:"creditLimit" swaggertype:"string" example:"5000000.00"`
+	PaymentTerms   *int32          `json:"paymentTerms" binding:"omitempty,min=0"`
+	IsActive       *bool           `json:"isActive" binding:"required"`
+}
+
+type CustomerResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	Code           string          `json:"code"`
+	Name           string          `json:"name"`
+	ContactPerson  *string         `json:"contactPerson"`
+	Email          *string         `json:"email"`
+	Phone          *string         `json:"phone"`
+	BillingAddress *string         `json:"billingAddress"`
+	TaxID          *string         `json:"taxId"`
+	CreditLimit    decimal.Decimal `json:"creditLimit" swaggertype:"string" example:"5000000.00"`
+	PaymentTerms   int32           `json:"paymentTerms"`
+	IsActive       bool            `json:"isActive"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+	// DeletedAt hanya terisi di list dengan includeDeleted=true
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// ListCustomersResponse is pagination.Response[CustomerResponse] (untuk swagger)
+type ListCustomersResponse struct {
+	Data []CustomerResponse `json:"data"`
+	Meta struct {
+		Total      int64 `json:"total"`
+		Page       int   `json:"page"`
+		PageSize   int   `json:"pageSize"`
+		TotalPages int   `json:"totalPages"`
+	} `json:"meta"`
+	Links *pagination.Links `json:"links,omitempty"`
+}
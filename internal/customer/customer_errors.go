@@ -0,0 +1,12 @@
+package customer
+
+import "errors"
+
+var (
+	ErrCustomerNotFound   = errors.New("customer not found")
+	ErrCustomerCodeExists = errors.New("customer code already exists")
+
+	// ErrCustomerInUse: hard delete ditolak karena masih direferensikan
+	// sales order atau invoice
+	ErrCustomerInUse = errors.New("customer is still referenced and cannot be permanently deleted")
+)
@@ -0,0 +1,234 @@
+package customer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/softdelete"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateCustomer godoc
+// @Summary Create customer
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateCustomerRequest true "Customer"
+// @Success 201 {object} CustomerResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /customers [post]
+func (h *Handler) CreateCustomer(c *gin.Context) {
+	var req CreateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.CreditLimit.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creditLimit must not be negative"})
+		return
+	}
+
+	result, err := h.service.CreateCustomer(c.Request.Context(), req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListCustomers godoc
+// @Summary List customers
+// @Tags customers
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search by name, code or email"
+// @Param page query int false "Page (default 1)"
+// @Param pageSize query int false "Page size (default 10, max 100)"
+// @Param links query bool false "Include next/prev/first/last links"
+// @Param includeDeleted query bool false "Also return soft-deleted customers (with deletedAt)"
+// @Success 200 {object} ListCustomersResponse
+// @Failure 400 {object} map[string]string
+// @Router /customers [get]
+func (h *Handler) ListCustomers(c *gin.Context) {
+	params := pagination.ParsePagination(c)
+
+	opts, err := softdelete.ParseOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.ListCustomers(c.Request.Context(), c.Query("search"), opts, params)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	result.AddLinks(c)
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCustomer godoc
+// @Summary Get customer
+// @Tags customers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Success 200 {object} CustomerResponse
+// @Failure 404 {object} map[string]string
+// @Router /customers/{id} [get]
+func (h *Handler) GetCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	result, err := h.service.GetCustomer(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateCustomer godoc
+// @Summary Update customer
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Param request body UpdateCustomerRequest true "Customer"
+// @Success 200 {object} CustomerResponse
+// @Failure 404 {object} map[string]string
+// @Router /customers/{id} [put]
+func (h *Handler) UpdateCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	var req UpdateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.CreditLimit.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creditLimit must not be negative"})
+		return
+	}
+
+	result, err := h.service.UpdateCustomer(c.Request.Context(), id, req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteCustomer godoc
+// @Summary Delete customer (soft delete)
+// @Tags customers
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /customers/{id} [delete]
+func (h *Handler) DeleteCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	if err := h.service.DeleteCustomer(c.Request.Context(), id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreCustomer godoc
+// @Summary Restore a soft-deleted customer
+// @Tags customers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Success 200 {object} CustomerResponse
+// @Failure 404 {object} map[string]string
+// @Router /customers/{id}/restore [post]
+func (h *Handler) RestoreCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	result, err := h.service.RestoreCustomer(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// HardDeleteCustomer godoc
+// @Summary Permanently delete a soft-deleted customer
+// @Description Admin only. The customer must be soft-deleted first; one still referenced by sales orders or invoices returns 409.
+// @Tags customers
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /customers/{id}/permanent [delete]
+func (h *Handler) HardDeleteCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	if err := h.service.HardDeleteCustomer(c.Request.Context(), id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrCustomerNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrCustomerCodeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrCustomerInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
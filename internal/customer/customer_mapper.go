@@ -0,0 +1,50 @@
+package customer
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func mapCustomer(c db.GetCustomerByIDRow) *CustomerResponse {
+	return &CustomerResponse{
+		ID:             c.ID,
+		Code:           c.Code,
+		Name:           c.Name,
+		ContactPerson:  c.ContactPerson,
+		Email:          c.Email,
+		Phone:          c.Phone,
+		BillingAddress: c.Address,
+		TaxID:          c.TaxID,
+		CreditLimit:    dbutil.PgNumericToDecimal(c.CreditLimit),
+		PaymentTerms:   dbutil.Int32PtrValue(c.PaymentTerms),
+		IsActive:       dbutil.BoolPtrValue(c.IsActive, false),
+		CreatedAt:      dbutil.PgTimeValue(c.CreatedAt),
+		UpdatedAt:      dbutil.PgTimeValue(c.UpdatedAt),
+	}
+}
+
+func mapCustomers(rows []db.Customer) []CustomerResponse {
+	customers := make([]CustomerResponse, 0, len(rows))
+
+	for _, c := range rows {
+		res := mapCustomer(db.GetCustomerByIDRow{
+			ID:            c.ID,
+			Code:          c.Code,
+			Name:          c.Name,
+			ContactPerson: c.ContactPerson,
+			Email:         c.Email,
+			Phone:         c.Phone,
+			Address:       c.Address,
+			TaxID:         c.TaxID,
+			CreditLimit:   c.CreditLimit,
+			PaymentTerms:  c.PaymentTerms,
+			IsActive:      c.IsActive,
+			CreatedAt:     c.CreatedAt,
+			UpdatedAt:     c.UpdatedAt,
+		})
+		res.DeletedAt = dbutil.PgTimeToTimePtr(c.DeletedAt)
+		customers = append(customers, *res)
+	}
+
+	return customers
+}
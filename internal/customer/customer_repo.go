@@ -0,0 +1,69 @@
+package customer
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=customer_repo.go -destination=mocks/customer_repository_mock.go -package=mocks
+
+// Repository defines customer data access contract
+type Repository interface {
+	CreateCustomer(ctx context.Context, arg db.CreateCustomerParams) (db.CreateCustomerRow, error)
+	GetCustomerByID(ctx context.Context, id uuid.UUID) (db.GetCustomerByIDRow, error)
+	CheckCustomerCodeExists(ctx context.Context, code string) (bool, error)
+	ListCustomers(ctx context.Context, arg db.ListCustomersParams) ([]db.Customer, error)
+	CountCustomers(ctx context.Context, arg db.CountCustomersParams) (int64, error)
+	UpdateCustomer(ctx context.Context, arg db.UpdateCustomerParams) error
+	SoftDeleteCustomer(ctx context.Context, id uuid.UUID) (int64, error)
+	RestoreCustomer(ctx context.Context, id uuid.UUID) (int64, error)
+	HardDeleteCustomer(ctx context.Context, id uuid.UUID) (int64, error)
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates customer repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) CreateCustomer(ctx context.Context, arg db.CreateCustomerParams) (db.CreateCustomerRow, error) {
+	return r.q.CreateCustomer(ctx, arg)
+}
+
+func (r *repository) GetCustomerByID(ctx context.Context, id uuid.UUID) (db.GetCustomerByIDRow, error) {
+	return r.q.GetCustomerByID(ctx, id)
+}
+
+func (r *repository) CheckCustomerCodeExists(ctx context.Context, code string) (bool, error) {
+	return r.q.CheckCustomerCodeExists(ctx, code)
+}
+
+func (r *repository) ListCustomers(ctx context.Context, arg db.ListCustomersParams) ([]db.Customer, error) {
+	return r.q.ListCustomers(ctx, arg)
+}
+
+func (r *repository) CountCustomers(ctx context.Context, arg db.CountCustomersParams) (int64, error) {
+	return r.q.CountCustomers(ctx, arg)
+}
+
+func (r *repository) UpdateCustomer(ctx context.Context, arg db.UpdateCustomerParams) error {
+	return r.q.UpdateCustomer(ctx, arg)
+}
+
+func (r *repository) SoftDeleteCustomer(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.SoftDeleteCustomer(ctx, id)
+}
+
+func (r *repository) RestoreCustomer(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.RestoreCustomer(ctx, id)
+}
+
+func (r *repository) HardDeleteCustomer(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.HardDeleteCustomer(ctx, id)
+}
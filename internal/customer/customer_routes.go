@@ -0,0 +1,21 @@
+package customer
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/customers", middleware.AuthMiddleware(), middleware.RequireMenuForPath())
+	{
+		routes.POST("", h.CreateCustomer)
+		routes.GET("", h.ListCustomers)
+		routes.GET("/:id", h.GetCustomer)
+		routes.PUT("/:id", h.UpdateCustomer)
+		routes.DELETE("/:id", h.DeleteCustomer)
+		routes.POST("/:id/restore", h.RestoreCustomer)
+		routes.DELETE("/:id/permanent", middleware.RequireRole(seed.RoleAdmin), h.HardDeleteCustomer)
+	}
+}
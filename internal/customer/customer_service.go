@@ -0,0 +1,184 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/softdelete"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+//go:generate mockgen -source=customer_service.go -destination=mocks/customer_service_mock.go -package=mocks
+
+// Service defines customer business logic
+type Service interface {
+	CreateCustomer(ctx context.Context, req CreateCustomerRequest) (*CustomerResponse, error)
+	GetCustomer(ctx context.Context, id uuid.UUID) (*CustomerResponse, error)
+	ListCustomers(ctx context.Context, search string, opts softdelete.Options, params pagination.Params) (*pagination.Response[CustomerResponse], error)
+	UpdateCustomer(ctx context.Context, id uuid.UUID, req UpdateCustomerRequest) (*CustomerResponse, error)
+	DeleteCustomer(ctx context.Context, id uuid.UUID) error
+	RestoreCustomer(ctx context.Context, id uuid.UUID) (*CustomerResponse, error)
+	HardDeleteCustomer(ctx context.Context, id uuid.UUID) error
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates customer service
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) CreateCustomer(ctx context.Context, req CreateCustomerRequest) (*CustomerResponse, error) {
+	code := strings.TrimSpace(req.Code)
+
+	exists, err := s.repo.CheckCustomerCodeExists(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCustomerCodeExists
+	}
+
+	active := dbutil.BoolPtrValue(req.IsActive, true)
+
+	created, err := s.repo.CreateCustomer(ctx, db.CreateCustomerParams{
+		Code:          code,
+		Name:          req.Name,
+		ContactPerson: req.ContactPerson,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		Address:       req.BillingAddress,
+		TaxID:         req.TaxID,
+		CreditLimit:   dbutil.DecimalToPgNumeric(req.CreditLimit),
+		PaymentTerms:  req.PaymentTerms,
+		IsActive:      &active,
+	})
+	if err != nil {
+		// race dengan request lain yang membuat code sama
+		if isUniqueViolation(err) {
+			return nil, ErrCustomerCodeExists
+		}
+		return nil, fmt.Errorf("create customer failed: %w", err)
+	}
+
+	return s.GetCustomer(ctx, created.ID)
+}
+
+func (s *service) GetCustomer(ctx context.Context, id uuid.UUID) (*CustomerResponse, error) {
+	c, err := s.repo.GetCustomerByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, err
+	}
+
+	return mapCustomer(c), nil
+}
+
+func (s *service) ListCustomers(
+	ctx context.Context,
+	search string,
+	opts softdelete.Options,
+	params pagination.Params,
+) (*pagination.Response[CustomerResponse], error) {
+	var searchArg *string
+	if search = strings.TrimSpace(search); search != "" {
+		searchArg = &search
+	}
+
+	rows, err := s.repo.ListCustomers(ctx, db.ListCustomersParams{
+		IncludeDeleted: opts.IncludeDeleted,
+		Search:         searchArg,
+		LimitCount:     params.Limit(),
+		OffsetCount:    params.Offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountCustomers(ctx, db.CountCustomersParams{
+		IncludeDeleted: opts.IncludeDeleted,
+		Search:         searchArg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := pagination.NewResponse(mapCustomers(rows), total, params)
+	return &res, nil
+}
+
+func (s *service) UpdateCustomer(ctx context.Context, id uuid.UUID, req UpdateCustomerRequest) (*CustomerResponse, error) {
+	existing, err := s.repo.GetCustomerByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, err
+	}
+
+	paymentTerms := req.PaymentTerms
+	if paymentTerms == nil {
+		paymentTerms = existing.PaymentTerms
+	}
+
+	err = s.repo.UpdateCustomer(ctx, db.UpdateCustomerParams{
+		ID:            id,
+		Name:          req.Name,
+		ContactPerson: req.ContactPerson,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		Address:       req.BillingAddress,
+		TaxID:         req.TaxID,
+		CreditLimit:   dbutil.DecimalToPgNumeric(req.CreditLimit),
+		PaymentTerms:  paymentTerms,
+		IsActive:      req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCustomer(ctx, id)
+}
+
+func (s *service) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
+	affected, err := s.repo.SoftDeleteCustomer(ctx, id)
+	return softdelete.Affected(affected, err, ErrCustomerNotFound)
+}
+
+// RestoreCustomer undoes DeleteCustomer; customer yang tidak terhapus
+// juga ErrCustomerNotFound
+func (s *service) RestoreCustomer(ctx context.Context, id uuid.UUID) (*CustomerResponse, error) {
+	affected, err := s.repo.RestoreCustomer(ctx, id)
+	if err := softdelete.Affected(affected, err, ErrCustomerNotFound); err != nil {
+		return nil, err
+	}
+
+	return s.GetCustomer(ctx, id)
+}
+
+// HardDeleteCustomer removes a soft-deleted customer for good; customer
+// yang belum di-soft delete dianggap tidak ada
+func (s *service) HardDeleteCustomer(ctx context.Context, id uuid.UUID) error {
+	affected, err := s.repo.HardDeleteCustomer(ctx, id)
+	if softdelete.Referenced(err) {
+		return ErrCustomerInUse
+	}
+	return softdelete.Affected(affected, err, ErrCustomerNotFound)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
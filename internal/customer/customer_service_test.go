@@ -0,0 +1,249 @@
+package customer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/customer"
+	"go-mini-erp/internal/customer/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/softdelete"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func TestCreateCustomer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+	id := uuid.New()
+	creditLimit := decimal.RequireFromString("5000000.00")
+
+	repo.EXPECT().CheckCustomerCodeExists(ctx, "CUST-001").Return(false, nil)
+	repo.EXPECT().CreateCustomer(ctx, db.CreateCustomerParams{
+		Code:        "CUST-001",
+		Name:        "PT Maju Jaya",
+		Email:       dbutil.Ptr("billing@majujaya.co.id"),
+		Address:     dbutil.Ptr("Jl. Sudirman No. 1, Jakarta"),
+		CreditLimit: dbutil.DecimalToPgNumeric(creditLimit),
+		IsActive:    dbutil.BoolPtr(true),
+	}).Return(db.CreateCustomerRow{ID: id, Code: "CUST-001", Name: "PT Maju Jaya"}, nil)
+	repo.EXPECT().GetCustomerByID(ctx, id).Return(db.GetCustomerByIDRow{
+		ID:          id,
+		Code:        "CUST-001",
+		Name:        "PT Maju Jaya",
+		Email:       dbutil.Ptr("billing@majujaya.co.id"),
+		Address:     dbutil.Ptr("Jl. Sudirman No. 1, Jakarta"),
+		CreditLimit: dbutil.DecimalToPgNumeric(creditLimit),
+		IsActive:    dbutil.BoolPtr(true),
+	}, nil)
+
+	result, err := service.CreateCustomer(ctx, customer.CreateCustomerRequest{
+		Code:           " CUST-001 ",
+		Name:           "PT Maju Jaya",
+		Email:          dbutil.Ptr("billing@majujaya.co.id"),
+		BillingAddress: dbutil.Ptr("Jl. Sudirman No. 1, Jakarta"),
+		CreditLimit:    creditLimit,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.Equal(t, "CUST-001", result.Code)
+	assert.Equal(t, "Jl. Sudirman No. 1, Jakarta", *result.BillingAddress)
+	assert.True(t, creditLimit.Equal(result.CreditLimit))
+	assert.True(t, result.IsActive)
+}
+
+func TestCreateCustomer_DuplicateCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+
+	repo.EXPECT().CheckCustomerCodeExists(ctx, "CUST-001").Return(true, nil)
+
+	result, err := service.CreateCustomer(ctx, customer.CreateCustomerRequest{
+		Code: "CUST-001",
+		Name: "PT Maju Jaya",
+	})
+
+	assert.ErrorIs(t, err, customer.ErrCustomerCodeExists)
+	assert.Nil(t, result)
+}
+
+func TestCreateCustomer_DuplicateCodeRace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+
+	repo.EXPECT().CheckCustomerCodeExists(ctx, "CUST-001").Return(false, nil)
+	repo.EXPECT().CreateCustomer(ctx, gomock.Any()).
+		Return(db.CreateCustomerRow{}, &pgconn.PgError{Code: "23505"})
+
+	result, err := service.CreateCustomer(ctx, customer.CreateCustomerRequest{
+		Code: "CUST-001",
+		Name: "PT Maju Jaya",
+	})
+
+	assert.ErrorIs(t, err, customer.ErrCustomerCodeExists)
+	assert.Nil(t, result)
+}
+
+func TestListCustomers_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+	params := pagination.NewParams(2, 5)
+	search := "maju"
+
+	repo.EXPECT().ListCustomers(ctx, db.ListCustomersParams{
+		Search:      &search,
+		LimitCount:  5,
+		OffsetCount: 5,
+	}).Return([]db.Customer{
+		{ID: uuid.New(), Code: "CUST-006", Name: "CV Maju Bersama"},
+	}, nil)
+	repo.EXPECT().CountCustomers(ctx, db.CountCustomersParams{Search: &search}).Return(int64(6), nil)
+
+	result, err := service.ListCustomers(ctx, "  maju ", softdelete.Options{}, params)
+
+	require.NoError(t, err)
+	require.Len(t, result.Data, 1)
+	assert.Equal(t, "CV Maju Bersama", result.Data[0].Name)
+	assert.Equal(t, int64(6), result.Meta.Total)
+	assert.Equal(t, 2, result.Meta.TotalPages)
+}
+
+func TestListCustomers_EmptySearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+	params := pagination.NewParams(1, 10)
+
+	repo.EXPECT().ListCustomers(ctx, db.ListCustomersParams{
+		Search:      nil,
+		LimitCount:  10,
+		OffsetCount: 0,
+	}).Return(nil, nil)
+	repo.EXPECT().CountCustomers(ctx, db.CountCustomersParams{}).Return(int64(0), nil)
+
+	result, err := service.ListCustomers(ctx, "", softdelete.Options{}, params)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Data)
+	assert.Equal(t, int64(0), result.Meta.Total)
+}
+
+func TestListCustomers_IncludeDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+	deletedAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().ListCustomers(ctx, db.ListCustomersParams{
+		IncludeDeleted: true,
+		LimitCount:     10,
+	}).Return([]db.Customer{
+		{ID: uuid.New(), Code: "CUST-001", Name: "PT Aktif"},
+		{ID: uuid.New(), Code: "CUST-002", Name: "PT Lama", DeletedAt: dbutil.TimeToPgTime(deletedAt)},
+	}, nil)
+	repo.EXPECT().CountCustomers(ctx, db.CountCustomersParams{IncludeDeleted: true}).Return(int64(2), nil)
+
+	result, err := service.ListCustomers(ctx, "", softdelete.Options{IncludeDeleted: true}, pagination.NewParams(1, 10))
+
+	require.NoError(t, err)
+	require.Len(t, result.Data, 2)
+	assert.Nil(t, result.Data[0].DeletedAt)
+	require.NotNil(t, result.Data[1].DeletedAt)
+	assert.True(t, deletedAt.Equal(*result.Data[1].DeletedAt))
+}
+
+func TestRestoreCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+	id := uuid.New()
+
+	repo.EXPECT().RestoreCustomer(ctx, id).Return(int64(1), nil)
+	repo.EXPECT().GetCustomerByID(ctx, id).Return(db.GetCustomerByIDRow{ID: id, Code: "CUST-002"}, nil)
+
+	result, err := service.RestoreCustomer(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "CUST-002", result.Code)
+
+	// tidak ada customer terhapus dengan id ini
+	repo.EXPECT().RestoreCustomer(ctx, id).Return(int64(0), nil)
+
+	_, err = service.RestoreCustomer(ctx, id)
+	assert.ErrorIs(t, err, customer.ErrCustomerNotFound)
+}
+
+func TestHardDeleteCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := customer.NewService(repo)
+
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		rows    int64
+		repoErr error
+		want    error
+	}{
+		{"deleted", 1, nil, nil},
+		{"not soft-deleted first", 0, nil, customer.ErrCustomerNotFound},
+		{"still referenced", 0, &pgconn.PgError{Code: "23503"}, customer.ErrCustomerInUse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().HardDeleteCustomer(ctx, id).Return(tt.rows, tt.repoErr)
+
+			err := service.HardDeleteCustomer(ctx, id)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+
+	"go-mini-erp/internal/shared/validation"
+)
+
+type Handler struct {
+	schema graphql.Schema
+}
+
+func NewHandler(schema graphql.Schema) *Handler {
+	return &Handler{schema: schema}
+}
+
+// Request is a GraphQL-over-HTTP request body
+type Request struct {
+	Query         string         `json:"query" binding:"required"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+}
+
+// Query godoc
+// @Summary Run a read-only GraphQL query
+// @Description Queries users (with nested roles and menus), roles and menus. Mutations are not supported. Errors from resolvers are returned in the errors array with status 200. Admin only.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body Request true "GraphQL query"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} validation.ErrorResponse
+// @Failure 403 {object} map[string]string
+// @Router /graphql [post]
+func (h *Handler) Query(c *gin.Context) {
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        c.Request.Context(),
+	})
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,217 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/auth"
+	authmocks "go-mini-erp/internal/auth/mocks"
+	"go-mini-erp/internal/graph"
+	"go-mini-erp/internal/rbac"
+	rbacmocks "go-mini-erp/internal/rbac/mocks"
+	"go-mini-erp/internal/role"
+	rolemocks "go-mini-erp/internal/role/mocks"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/user"
+	usermocks "go-mini-erp/internal/user/mocks"
+)
+
+type services struct {
+	users    *usermocks.MockService
+	profiles *authmocks.MockService
+	roles    *rolemocks.MockService
+	rbac     *rbacmocks.MockService
+}
+
+func newGraphRouter(t *testing.T) (*gin.Engine, services) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	s := services{
+		users:    usermocks.NewMockService(ctrl),
+		profiles: authmocks.NewMockService(ctrl),
+		roles:    rolemocks.NewMockService(ctrl),
+		rbac:     rbacmocks.NewMockService(ctrl),
+	}
+
+	schema, err := graph.NewSchema(graph.Resolvers{
+		Users:    s.users,
+		Profiles: s.profiles,
+		Roles:    s.roles,
+		RBAC:     s.rbac,
+	})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/graphql", graph.NewHandler(schema).Query)
+	return router, s
+}
+
+func query(t *testing.T, router *gin.Engine, q string, variables map[string]any) map[string]any {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": q, "variables": variables})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return result
+}
+
+func TestGraphQL_UserWithNestedRoles(t *testing.T) {
+	router, s := newGraphRouter(t)
+
+	userID, adminID, salesID := uuid.New(), uuid.New(), uuid.New()
+	s.profiles.EXPECT().GetProfile(gomock.Any(), userID).Return(&auth.UserProfile{
+		ID:       userID,
+		Username: "jane",
+		Email:    "jane@example.com",
+		FullName: "Jane Doe",
+		IsActive: true,
+		Roles: []auth.RoleInfo{
+			{ID: adminID, Code: "admin", Name: "Administrator"},
+			{ID: salesID, Code: "sales", Name: "Sales"},
+		},
+		Menus: []auth.MenuInfo{
+			{ID: uuid.New(), Code: "dashboard", Name: "Dashboard", Path: dbutil.Ptr("/"), CanRead: true},
+		},
+	}, nil).Times(1)
+
+	result := query(t, router, `query ($id: ID!) {
+		user(id: $id) {
+			username
+			fullName
+			roles { id code name }
+			menus { code path canRead canDelete }
+		}
+	}`, map[string]any{"id": userID.String()})
+
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]any{
+		"user": map[string]any{
+			"username": "jane",
+			"fullName": "Jane Doe",
+			"roles": []any{
+				map[string]any{"id": adminID.String(), "code": "admin", "name": "Administrator"},
+				map[string]any{"id": salesID.String(), "code": "sales", "name": "Sales"},
+			},
+			"menus": []any{
+				map[string]any{"code": "dashboard", "path": "/", "canRead": true, "canDelete": false},
+			},
+		},
+	}, result["data"])
+}
+
+func TestGraphQL_UserNotFound(t *testing.T) {
+	router, s := newGraphRouter(t)
+
+	userID := uuid.New()
+	s.profiles.EXPECT().GetProfile(gomock.Any(), userID).Return(nil, auth.ErrUserNotFound)
+
+	result := query(t, router, `query ($id: ID!) { user(id: $id) { username } }`,
+		map[string]any{"id": userID.String()})
+
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]any{"user": nil}, result["data"])
+}
+
+// first membatasi hasil; tanpa field nested tidak ada GetProfile
+func TestGraphQL_UsersFirstWithoutNestedFields(t *testing.T) {
+	router, s := newGraphRouter(t)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	s.users.EXPECT().
+		ExportUsers(gomock.Any(), user.ListUsersFilter{IsActive: dbutil.BoolPtr(true)}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ user.ListUsersFilter, fn func([]user.UserSummary) error) error {
+			return fn([]user.UserSummary{
+				{ID: ids[0], Username: "ann"},
+				{ID: ids[1], Username: "bob"},
+				{ID: ids[2], Username: "cid"},
+			})
+		})
+
+	result := query(t, router, `{ users(isActive: true, first: 2) { username } }`, nil)
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]any{"users": []any{
+		map[string]any{"username": "ann"},
+		map[string]any{"username": "bob"},
+	}}, result["data"])
+}
+
+var rbacDocument = rbac.Document{
+	Menus: []rbac.MenuEntry{
+		{Code: "master", Name: "Master Data", IsActive: true},
+		{Code: "customers", ParentCode: dbutil.Ptr("master"), Name: "Customers", IsActive: true},
+	},
+}
+
+func TestGraphQL_RolesAndMenus(t *testing.T) {
+	router, s := newGraphRouter(t)
+
+	s.roles.EXPECT().
+		ListRoles(gomock.Any(), role.ListRolesFilter{Search: "sal"}).
+		Return([]role.RoleResponse{{ID: uuid.New(), Code: "sales", Name: "Sales", IsActive: true}}, nil)
+	s.rbac.EXPECT().Export(gomock.Any()).Return(&rbacDocument, nil)
+
+	result := query(t, router, `{
+		roles(search: "sal") { code isActive description }
+		menus { code parentCode }
+	}`, nil)
+
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]any{
+		"roles": []any{map[string]any{"code": "sales", "isActive": true, "description": nil}},
+		"menus": []any{
+			map[string]any{"code": "master", "parentCode": nil},
+			map[string]any{"code": "customers", "parentCode": "master"},
+		},
+	}, result["data"])
+}
+
+func TestGraphQL_InternalErrorHidden(t *testing.T) {
+	router, s := newGraphRouter(t)
+
+	s.roles.EXPECT().ListRoles(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New(`pq: relation "roles" does not exist`))
+
+	result := query(t, router, `{ roles { code } }`, nil)
+
+	errs, ok := result["errors"].([]any)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "internal error", errs[0].(map[string]any)["message"])
+}
+
+func TestGraphQL_MutationsNotSupported(t *testing.T) {
+	router, _ := newGraphRouter(t)
+
+	result := query(t, router, `mutation { deleteRole(id: "x") }`, nil)
+
+	assert.NotEmpty(t, result["errors"])
+	assert.Nil(t, result["data"])
+}
+
+func TestGraphQL_MissingQuery(t *testing.T) {
+	router, _ := newGraphRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,16 @@
+package graph
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+// RegisterRoutes mounts /graphql; data user lintas akun jadi admin only
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/graphql", middleware.AuthMiddleware(), middleware.RequireRole(seed.RoleAdmin))
+	{
+		routes.POST("", h.Query)
+	}
+}
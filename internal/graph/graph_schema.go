@@ -0,0 +1,306 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+
+	"go-mini-erp/internal/auth"
+	"go-mini-erp/internal/rbac"
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/user"
+)
+
+const (
+	defaultUsersFirst = 100
+	maxUsersFirst     = 500
+)
+
+// errStop menghentikan ExportUsers setelah cukup user terkumpul
+var errStop = errors.New("stop")
+
+// Resolvers backs the schema with the existing REST services
+type Resolvers struct {
+	Users    user.Service
+	Profiles auth.Service
+	Roles    role.Service
+	RBAC     rbac.Service
+}
+
+/*
+userNode is a User in the schema. roles dan menus dimuat lewat
+GetProfile hanya jika diminta, sekali per user; untuk query users itu
+berarti satu query profile per user yang field nested-nya diminta.
+*/
+type userNode struct {
+	ID          uuid.UUID  `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	FullName    string     `json:"fullName"`
+	IsActive    bool       `json:"isActive"`
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+
+	once    sync.Once
+	profile *auth.UserProfile
+	err     error
+}
+
+func (u *userNode) loadProfile(ctx context.Context, profiles auth.Service) (*auth.UserProfile, error) {
+	u.once.Do(func() {
+		if u.profile == nil {
+			u.profile, u.err = profiles.GetProfile(ctx, u.ID)
+		}
+	})
+	return u.profile, u.err
+}
+
+func newProfileNode(p *auth.UserProfile) *userNode {
+	return &userNode{
+		ID:          p.ID,
+		Username:    p.Username,
+		Email:       p.Email,
+		FullName:    p.FullName,
+		IsActive:    p.IsActive,
+		LastLoginAt: p.LastLoginAt,
+		profile:     p,
+	}
+}
+
+func newSummaryNode(s user.UserSummary) *userNode {
+	return &userNode{
+		ID:          s.ID,
+		Username:    s.Username,
+		Email:       s.Email,
+		FullName:    s.FullName,
+		IsActive:    s.IsActive,
+		LastLoginAt: s.LastLoginAt,
+	}
+}
+
+// NewSchema builds the read-only schema; tidak ada mutation
+func NewSchema(r Resolvers) (graphql.Schema, error) {
+	userRole := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "UserRole",
+		Description: "A role assigned to a user.",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"code": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	userMenu := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "UserMenu",
+		Description: "A menu the user can access, with permissions merged across roles.",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"parentId":  &graphql.Field{Type: graphql.ID},
+			"code":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"path":      &graphql.Field{Type: graphql.String},
+			"icon":      &graphql.Field{Type: graphql.String},
+			"canCreate": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"canRead":   &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"canUpdate": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"canDelete": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"username":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"fullName":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"isActive":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"lastLoginAt": &graphql.Field{Type: graphql.DateTime},
+			"roles": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userRole))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					profile, err := p.Source.(*userNode).loadProfile(p.Context, r.Profiles)
+					if err != nil {
+						return nil, internalError(p.Context, err)
+					}
+					return profile.Roles, nil
+				},
+			},
+			"menus": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userMenu))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					profile, err := p.Source.(*userNode).loadProfile(p.Context, r.Profiles)
+					if err != nil {
+						return nil, internalError(p.Context, err)
+					}
+					return profile.Menus, nil
+				},
+			},
+		},
+	})
+
+	roleType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Role",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"code":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.String},
+			"isActive":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	menuType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Menu",
+		Description: "A menu definition, related to its parent by code.",
+		Fields: graphql.Fields{
+			"code":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"parentCode": &graphql.Field{Type: graphql.String},
+			"name":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"path":       &graphql.Field{Type: graphql.String},
+			"icon":       &graphql.Field{Type: graphql.String},
+			"sortOrder":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"isActive":   &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Args: graphql.FieldConfigArgument{
+					"isActive": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"role":     &graphql.ArgumentConfig{Type: graphql.String, Description: "Role code"},
+					"first":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultUsersFirst},
+				},
+				Resolve: r.users,
+			},
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.user,
+			},
+			"roles": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(roleType))),
+				Args: graphql.FieldConfigArgument{
+					"search":   &graphql.ArgumentConfig{Type: graphql.String},
+					"isActive": &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+				Resolve: r.roles,
+			},
+			"role": &graphql.Field{
+				Type: roleType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.role,
+			},
+			"menus": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(menuType))),
+				Resolve: r.menus,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func (r Resolvers) users(p graphql.ResolveParams) (any, error) {
+	first, _ := p.Args["first"].(int)
+	if first <= 0 || first > maxUsersFirst {
+		return nil, errors.New("first must be between 1 and 500")
+	}
+
+	filter := user.ListUsersFilter{}
+	if v, ok := p.Args["isActive"].(bool); ok {
+		filter.IsActive = &v
+	}
+	filter.RoleCode, _ = p.Args["role"].(string)
+
+	nodes := make([]*userNode, 0)
+	err := r.Users.ExportUsers(p.Context, filter, func(batch []user.UserSummary) error {
+		for _, s := range batch {
+			nodes = append(nodes, newSummaryNode(s))
+			if len(nodes) == first {
+				return errStop
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, internalError(p.Context, err)
+	}
+
+	return nodes, nil
+}
+
+func (r Resolvers) user(p graphql.ResolveParams) (any, error) {
+	id, err := uuid.Parse(p.Args["id"].(string))
+	if err != nil {
+		return nil, errors.New("id must be a valid UUID")
+	}
+
+	profile, err := r.Profiles.GetProfile(p.Context, id)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError(p.Context, err)
+	}
+
+	return newProfileNode(profile), nil
+}
+
+func (r Resolvers) roles(p graphql.ResolveParams) (any, error) {
+	filter := role.ListRolesFilter{}
+	filter.Search, _ = p.Args["search"].(string)
+	if v, ok := p.Args["isActive"].(bool); ok {
+		filter.IsActive = &v
+	}
+
+	roles, err := r.Roles.ListRoles(p.Context, filter)
+	if err != nil {
+		return nil, internalError(p.Context, err)
+	}
+	return roles, nil
+}
+
+func (r Resolvers) role(p graphql.ResolveParams) (any, error) {
+	id, err := uuid.Parse(p.Args["id"].(string))
+	if err != nil {
+		return nil, errors.New("id must be a valid UUID")
+	}
+
+	found, err := r.Roles.GetRoleByID(p.Context, id)
+	if errors.Is(err, role.ErrRoleNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError(p.Context, err)
+	}
+	return found, nil
+}
+
+func (r Resolvers) menus(p graphql.ResolveParams) (any, error) {
+	doc, err := r.RBAC.Export(p.Context)
+	if err != nil {
+		return nil, internalError(p.Context, err)
+	}
+	return doc.Menus, nil
+}
+
+// internalError logs err and returns a generic error, supaya pesan
+// database tidak bocor ke field errors response
+func internalError(ctx context.Context, err error) error {
+	slog.ErrorContext(ctx, "graphql resolver failed", slog.String("error", err.Error()))
+	return errors.New("internal error")
+}
@@ -0,0 +1,179 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
+
+	"go-mini-erp/internal/shared/buildinfo"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusUp          = "up"
+	StatusDown        = "down"
+)
+
+// CheckFunc reports whether a dependency is reachable
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name    string
+	fn      CheckFunc
+	timeout time.Duration
+}
+
+// DependencyStatus is the per-dependency readiness result
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status  string                      `json:"status"`
+	Time    string                      `json:"time"`
+	Build   buildinfo.Info              `json:"build"`
+	Checks  map[string]DependencyStatus `json:"checks"`
+	Details map[string]any              `json:"details,omitempty"`
+}
+
+// DetailFunc returns informational data for /readyz; tidak mempengaruhi status
+type DetailFunc func() any
+
+type Handler struct {
+	checks  []check
+	details map[string]DetailFunc
+	timeout time.Duration
+}
+
+// NewHandler creates health handler; timeout is the default bound of each
+// dependency check
+func NewHandler(timeout time.Duration) *Handler {
+	return &Handler{timeout: timeout}
+}
+
+// AddCheck registers a dependency checked by /readyz
+func (h *Handler) AddCheck(name string, fn CheckFunc) {
+	h.AddCheckTimeout(name, h.timeout, fn)
+}
+
+// AddCheckTimeout is AddCheck with its own timeout, untuk dependency yang
+// wajar lebih lambat (atau harus lebih cepat) dari default
+func (h *Handler) AddCheckTimeout(name string, timeout time.Duration, fn CheckFunc) {
+	h.checks = append(h.checks, check{name: name, fn: fn, timeout: timeout})
+}
+
+// AddDetail adds a named summary (mis. statistik pool) to /readyz
+func (h *Handler) AddDetail(name string, fn DetailFunc) {
+	if h.details == nil {
+		h.details = map[string]DetailFunc{}
+	}
+	h.details[name] = fn
+}
+
+func (h *Handler) RegisterRoutes(r gin.IRoutes) {
+	r.GET("/livez", h.Livez)
+	r.GET("/readyz", h.Readyz)
+	r.GET("/health", h.Readyz)
+	r.GET("/version", h.Version)
+}
+
+// Livez reports the process is up; it never checks dependencies
+func (h *Handler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": StatusOK,
+		"time":   time.Now().Format(time.RFC3339),
+	})
+}
+
+// Readyz checks every registered dependency concurrently, 503 if any is
+// down. Probe selesai paling lama setelah timeout check terpanjang.
+func (h *Handler) Readyz(c *gin.Context) {
+	res := ReadinessResponse{
+		Status: StatusOK,
+		Time:   time.Now().Format(time.RFC3339),
+		Build:  buildinfo.Get(),
+		Checks: make(map[string]DependencyStatus, len(h.checks)),
+	}
+
+	for name, st := range h.runChecks(c.Request.Context()) {
+		if st.Status != StatusUp {
+			res.Status = StatusUnavailable
+		}
+		res.Checks[name] = st
+	}
+
+	if len(h.details) > 0 {
+		res.Details = make(map[string]any, len(h.details))
+		for name, fn := range h.details {
+			res.Details[name] = fn()
+		}
+	}
+
+	status := http.StatusOK
+	if res.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, res)
+}
+
+// runChecks runs all checks in parallel, each under its own timeout.
+// errgroup dipakai hanya untuk menunggu: check yang gagal tidak
+// membatalkan yang lain, jadi setiap goroutine mengembalikan nil.
+func (h *Handler) runChecks(ctx context.Context) map[string]DependencyStatus {
+	results := make([]DependencyStatus, len(h.checks))
+
+	var g errgroup.Group
+	for i, chk := range h.checks {
+		g.Go(func() error {
+			results[i] = runCheck(ctx, chk)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	statuses := make(map[string]DependencyStatus, len(h.checks))
+	for i, chk := range h.checks {
+		statuses[chk.name] = results[i]
+	}
+	return statuses
+}
+
+// runCheck stops waiting at the deadline even if fn ignores ctx, supaya
+// satu dependency yang hang tidak menahan seluruh probe
+func runCheck(ctx context.Context, chk check) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, chk.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- chk.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	st := DependencyStatus{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		st.Status = StatusDown
+		st.Error = err.Error()
+	}
+	return st
+}
+
+// Version returns the build metadata of the running binary
+func (h *Handler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
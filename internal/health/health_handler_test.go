@@ -0,0 +1,161 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/health"
+	"go-mini-erp/internal/shared/buildinfo"
+)
+
+func newRouter(h *health.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h.RegisterRoutes(router)
+	return router
+}
+
+func TestLivez_AlwaysOK(t *testing.T) {
+	h := health.NewHandler(time.Second)
+	h.AddCheck("database", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	w := httptest.NewRecorder()
+	newRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/livez", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadyz_AllUp(t *testing.T) {
+	h := health.NewHandler(time.Second)
+	h.AddCheck("database", func(ctx context.Context) error { return nil })
+
+	w := httptest.NewRecorder()
+	newRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res health.ReadinessResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, health.StatusOK, res.Status)
+	assert.Equal(t, health.StatusUp, res.Checks["database"].Status)
+}
+
+func TestReadyz_IncludesDetails(t *testing.T) {
+	h := health.NewHandler(time.Second)
+	h.AddCheck("database", func(ctx context.Context) error { return nil })
+	h.AddDetail("dbPool", func() any {
+		return map[string]int{"acquired": 2, "max": 10}
+	})
+
+	w := httptest.NewRecorder()
+	newRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"details":{"dbPool":{"acquired":2,"max":10}}`)
+}
+
+func TestReadyz_FailedPingReturns503(t *testing.T) {
+	h := health.NewHandler(time.Second)
+	h.AddCheck("database", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	for _, path := range []string{"/readyz", "/health"} {
+		w := httptest.NewRecorder()
+		newRouter(h).ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, path)
+
+		var res health.ReadinessResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, health.StatusUnavailable, res.Status)
+		assert.Equal(t, health.StatusDown, res.Checks["database"].Status)
+		assert.Equal(t, "connection refused", res.Checks["database"].Error)
+	}
+}
+
+func TestReadyz_CheckRespectsTimeout(t *testing.T) {
+	h := health.NewHandler(10 * time.Millisecond)
+	h.AddCheck("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	w := httptest.NewRecorder()
+	newRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestReadyz_ChecksRunConcurrently(t *testing.T) {
+	h := health.NewHandler(time.Second)
+	h.AddCheck("database", func(ctx context.Context) error { return nil })
+	// dependency yang hang dan tidak menghormati ctx
+	h.AddCheckTimeout("redis", 50*time.Millisecond, func(ctx context.Context) error {
+		time.Sleep(2 * time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	newRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+	elapsed := time.Since(start)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Less(t, elapsed, time.Second, "slow check must not hold the probe past its timeout")
+
+	var res health.ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, health.StatusUnavailable, res.Status)
+
+	assert.Equal(t, health.StatusUp, res.Checks["database"].Status)
+	assert.Less(t, res.Checks["database"].LatencyMs, float64(50))
+
+	redis := res.Checks["redis"]
+	assert.Equal(t, health.StatusDown, redis.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), redis.Error)
+	assert.GreaterOrEqual(t, redis.LatencyMs, float64(50))
+}
+
+func TestVersion_ReturnsBuildInfo(t *testing.T) {
+	prev := buildinfo.Get()
+	buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = "v1.2.3", "abc1234", "2026-01-02T03:04:05Z"
+	t.Cleanup(func() {
+		buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = prev.Version, prev.Commit, prev.BuildTime
+	})
+
+	router := newRouter(health.NewHandler(time.Second))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var info buildinfo.Info
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, buildinfo.Info{Version: "v1.2.3", Commit: "abc1234", BuildTime: "2026-01-02T03:04:05Z"}, info)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	var res health.ReadinessResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "v1.2.3", res.Build.Version)
+}
+
+func TestVersion_Defaults(t *testing.T) {
+	info := buildinfo.Get()
+	assert.Equal(t, "dev", info.Version)
+	assert.Equal(t, "unknown", info.Commit)
+	assert.Equal(t, "unknown", info.BuildTime)
+}
@@ -0,0 +1,90 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"go-mini-erp/internal/shared/pagination"
+)
+
+// AdjustStockRequest: delta positif menambah stok, negatif mengurangi
+type AdjustStockRequest struct {
+	ProductID     uuid.UUID       `json:"productId" binding:"required"`
+	LocationID    uuid.UUID       `json:"locationId" binding:"required"`
+	Delta         decimal.Decimal `json:"delta" swaggertype:"string" example:"-5"`
+	Reason        *string         `json:"reason" binding:"omitempty,max=255"`
+	Reference     *string         `json:"reference" binding:"omitempty,max=100"`
+	AllowNegative bool            `json:"allowNegative"`
+}
+
+// SetReorderPointRequest: reorderPoint null = berhenti memantau produk
+type SetReorderPointRequest struct {
+	ReorderPoint *decimal.Decimal `json:"reorderPoint" swaggertype:"string" example:"10"`
+}
+
+type StockMovementResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	MovementNumber string          `json:"movementNumber"`
+	LocationID     uuid.UUID       `json:"locationId"`
+	LocationName   string          `json:"locationName,omitempty"`
+	MovementType   string          `json:"movementType"`
+	Delta          decimal.Decimal `json:"delta" swaggertype:"string" example:"-5"`
+	Reason         *string         `json:"reason"`
+	Reference      *string         `json:"reference"`
+	CreatedBy      *uuid.UUID      `json:"createdBy"`
+	MovementDate   time.Time       `json:"movementDate"`
+}
+
+type AdjustStockResponse struct {
+	Movement StockMovementResponse `json:"movement"`
+	OnHand   decimal.Decimal       `json:"onHand" swaggertype:"string" example:"95"`
+}
+
+// StockAlertResponse is an open low-stock alert; OnHand is the current
+// total across locations
+type StockAlertResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	ProductID    uuid.UUID       `json:"productId"`
+	ProductCode  string          `json:"productCode"`
+	ProductName  string          `json:"productName"`
+	ReorderPoint decimal.Decimal `json:"reorderPoint" swaggertype:"string" example:"10"`
+	OnHand       decimal.Decimal `json:"onHand" swaggertype:"string" example:"4"`
+	AlertedAt    time.Time       `json:"alertedAt"`
+}
+
+// LowStockEvent is the payload of the inventory.low_stock event
+type LowStockEvent struct {
+	ProductID    uuid.UUID       `json:"productId"`
+	ProductCode  string          `json:"productCode"`
+	ProductName  string          `json:"productName"`
+	OnHand       decimal.Decimal `json:"onHand"`
+	ReorderPoint decimal.Decimal `json:"reorderPoint"`
+}
+
+type StockBalanceResponse struct {
+	LocationID   uuid.UUID       `json:"locationId"`
+	LocationCode string          `json:"locationCode"`
+	LocationName string          `json:"locationName"`
+	Quantity     decimal.Decimal `json:"quantity" swaggertype:"string" example:"100"`
+	ReservedQty  decimal.Decimal `json:"reservedQty" swaggertype:"string" example:"0"`
+	AvailableQty decimal.Decimal `json:"availableQty" swaggertype:"string" example:"100"`
+	LastUpdated  time.Time       `json:"lastUpdated"`
+}
+
+type StockResponse struct {
+	ProductID   uuid.UUID              `json:"productId"`
+	ProductCode string                 `json:"productCode"`
+	ProductName string                 `json:"productName"`
+	OnHand      decimal.Decimal        `json:"onHand" swaggertype:"string" example:"100"`
+	Balances    []StockBalanceResponse `json:"balances"`
+	Movements   MovementPage           `json:"movements"`
+}
+
+// MovementPage is pagination.Response[StockMovementResponse] (untuk swagger)
+type MovementPage struct {
+	Data  []StockMovementResponse `json:"data"`
+	Meta  pagination.Meta         `json:"meta"`
+	Links *pagination.Links       `json:"links,omitempty"`
+}
@@ -0,0 +1,11 @@
+package inventory
+
+import "errors"
+
+var (
+	ErrProductNotFound      = errors.New("product not found")
+	ErrLocationNotFound     = errors.New("stock location not found")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrZeroDelta            = errors.New("delta must not be zero")
+	ErrNegativeReorderPoint = errors.New("reorder point must not be negative")
+)
@@ -0,0 +1,149 @@
+package inventory
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// AdjustStock godoc
+// @Summary Adjust stock
+// @Description Records a stock movement and updates on-hand quantity. Rejects adjustments that would make stock negative unless allowNegative is set.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body AdjustStockRequest true "Adjustment"
+// @Success 201 {object} AdjustStockResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /inventory/adjust [post]
+func (h *Handler) AdjustStock(c *gin.Context) {
+	var req AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		userID = &id
+	}
+
+	result, err := h.service.AdjustStock(c.Request.Context(), req, userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetStock godoc
+// @Summary Get product stock
+// @Description Current on-hand quantity per location plus movement history (newest first)
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param productId path string true "Product ID"
+// @Param page query int false "Movement page (default 1)"
+// @Param pageSize query int false "Movement page size (default 10, max 100)"
+// @Param links query bool false "Include movement next/prev/first/last links"
+// @Success 200 {object} StockResponse
+// @Failure 404 {object} map[string]string
+// @Router /inventory/{productId} [get]
+func (h *Handler) GetStock(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	result, err := h.service.GetStock(c.Request.Context(), productID, pagination.ParsePagination(c))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	result.Movements.Links = pagination.LinksFor(c, result.Movements.Meta)
+	c.JSON(http.StatusOK, result)
+}
+
+// SetReorderPoint godoc
+// @Summary Set product reorder point
+// @Description Sets the on-hand level below which a low-stock alert is raised. Send null to stop monitoring the product.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param productId path string true "Product ID"
+// @Param request body SetReorderPointRequest true "Reorder point"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /inventory/{productId}/reorder-point [put]
+func (h *Handler) SetReorderPoint(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req SetReorderPointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.SetReorderPoint(c.Request.Context(), productID, req); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAlerts godoc
+// @Summary List low-stock alerts
+// @Description Products whose total on-hand is currently below their reorder point (oldest alert first)
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} StockAlertResponse
+// @Router /inventory/alerts [get]
+func (h *Handler) ListAlerts(c *gin.Context) {
+	alerts, err := h.service.ListAlerts(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrZeroDelta), errors.Is(err, ErrNegativeReorderPoint):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrProductNotFound), errors.Is(err, ErrLocationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInsufficientStock):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
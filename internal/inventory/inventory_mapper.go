@@ -0,0 +1,77 @@
+package inventory
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func mapMovement(m db.StockMovement) StockMovementResponse {
+	return StockMovementResponse{
+		ID:             m.ID,
+		MovementNumber: m.MovementNumber,
+		LocationID:     m.LocationID,
+		MovementType:   m.MovementType,
+		Delta:          dbutil.PgNumericToDecimal(m.Quantity),
+		Reason:         m.Reason,
+		Reference:      m.Reference,
+		CreatedBy:      dbutil.PgUUIDToUUIDPtr(m.CreatedBy),
+		MovementDate:   dbutil.PgTimeValue(m.MovementDate),
+	}
+}
+
+func mapMovements(rows []db.ListProductStockMovementsRow) []StockMovementResponse {
+	movements := make([]StockMovementResponse, 0, len(rows))
+
+	for _, m := range rows {
+		movements = append(movements, StockMovementResponse{
+			ID:             m.ID,
+			MovementNumber: m.MovementNumber,
+			LocationID:     m.LocationID,
+			LocationName:   m.LocationName,
+			MovementType:   m.MovementType,
+			Delta:          dbutil.PgNumericToDecimal(m.Quantity),
+			Reason:         m.Reason,
+			Reference:      m.Reference,
+			CreatedBy:      dbutil.PgUUIDToUUIDPtr(m.CreatedBy),
+			MovementDate:   dbutil.PgTimeValue(m.MovementDate),
+		})
+	}
+
+	return movements
+}
+
+func mapBalances(rows []db.ListProductStockBalancesRow) []StockBalanceResponse {
+	balances := make([]StockBalanceResponse, 0, len(rows))
+
+	for _, b := range rows {
+		balances = append(balances, StockBalanceResponse{
+			LocationID:   b.LocationID,
+			LocationCode: b.LocationCode,
+			LocationName: b.LocationName,
+			Quantity:     dbutil.PgNumericToDecimal(b.Quantity),
+			ReservedQty:  dbutil.PgNumericToDecimal(b.ReservedQty),
+			AvailableQty: dbutil.PgNumericToDecimal(b.AvailableQty),
+			LastUpdated:  dbutil.PgTimeValue(b.LastUpdated),
+		})
+	}
+
+	return balances
+}
+
+func mapAlerts(rows []db.ListOpenStockAlertsRow) []StockAlertResponse {
+	alerts := make([]StockAlertResponse, 0, len(rows))
+
+	for _, a := range rows {
+		alerts = append(alerts, StockAlertResponse{
+			ID:           a.ID,
+			ProductID:    a.ProductID,
+			ProductCode:  a.ProductCode,
+			ProductName:  a.ProductName,
+			ReorderPoint: dbutil.PgNumericToDecimal(a.ReorderPoint),
+			OnHand:       dbutil.PgNumericToDecimal(a.OnHand),
+			AlertedAt:    dbutil.PgTimeValue(a.CreatedAt),
+		})
+	}
+
+	return alerts
+}
@@ -0,0 +1,99 @@
+package inventory
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+//go:generate mockgen -source=inventory_repo.go -destination=mocks/inventory_repository_mock.go -package=mocks
+
+// Repository defines inventory data access contract
+type Repository interface {
+	GetProductByID(ctx context.Context, id uuid.UUID) (db.GetProductByIDRow, error)
+	LockStockBalance(ctx context.Context, arg db.LockStockBalanceParams) (pgtype.Numeric, error)
+	RecordStockMovement(ctx context.Context, arg db.RecordStockMovementParams) (db.StockMovement, error)
+	UpsertStockBalance(ctx context.Context, arg db.UpsertStockBalanceParams) (db.UpsertStockBalanceRow, error)
+	ListProductStockBalances(ctx context.Context, productID uuid.UUID) ([]db.ListProductStockBalancesRow, error)
+	ListProductStockMovements(ctx context.Context, arg db.ListProductStockMovementsParams) ([]db.ListProductStockMovementsRow, error)
+	CountProductStockMovements(ctx context.Context, productID uuid.UUID) (int64, error)
+
+	// Reorder point & low-stock alert
+	GetProductReorderPoint(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error)
+	SetProductReorderPoint(ctx context.Context, arg db.SetProductReorderPointParams) (int64, error)
+	SumProductOnHand(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error)
+	OpenStockAlert(ctx context.Context, arg db.OpenStockAlertParams) (int64, error)
+	ResolveStockAlert(ctx context.Context, productID uuid.UUID) (int64, error)
+	ListOpenStockAlerts(ctx context.Context) ([]db.ListOpenStockAlertsRow, error)
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates inventory repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) GetProductByID(ctx context.Context, id uuid.UUID) (db.GetProductByIDRow, error) {
+	return r.q.GetProductByID(ctx, id)
+}
+
+func (r *repository) LockStockBalance(ctx context.Context, arg db.LockStockBalanceParams) (pgtype.Numeric, error) {
+	return r.q.LockStockBalance(ctx, arg)
+}
+
+func (r *repository) RecordStockMovement(ctx context.Context, arg db.RecordStockMovementParams) (db.StockMovement, error) {
+	return r.q.RecordStockMovement(ctx, arg)
+}
+
+func (r *repository) UpsertStockBalance(ctx context.Context, arg db.UpsertStockBalanceParams) (db.UpsertStockBalanceRow, error) {
+	return r.q.UpsertStockBalance(ctx, arg)
+}
+
+func (r *repository) ListProductStockBalances(ctx context.Context, productID uuid.UUID) ([]db.ListProductStockBalancesRow, error) {
+	return r.q.ListProductStockBalances(ctx, productID)
+}
+
+func (r *repository) ListProductStockMovements(ctx context.Context, arg db.ListProductStockMovementsParams) ([]db.ListProductStockMovementsRow, error) {
+	return r.q.ListProductStockMovements(ctx, arg)
+}
+
+func (r *repository) CountProductStockMovements(ctx context.Context, productID uuid.UUID) (int64, error) {
+	return r.q.CountProductStockMovements(ctx, productID)
+}
+
+func (r *repository) GetProductReorderPoint(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error) {
+	return r.q.GetProductReorderPoint(ctx, productID)
+}
+
+func (r *repository) SetProductReorderPoint(ctx context.Context, arg db.SetProductReorderPointParams) (int64, error) {
+	return r.q.SetProductReorderPoint(ctx, arg)
+}
+
+func (r *repository) SumProductOnHand(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error) {
+	return r.q.SumProductOnHand(ctx, productID)
+}
+
+func (r *repository) OpenStockAlert(ctx context.Context, arg db.OpenStockAlertParams) (int64, error) {
+	return r.q.OpenStockAlert(ctx, arg)
+}
+
+func (r *repository) ResolveStockAlert(ctx context.Context, productID uuid.UUID) (int64, error) {
+	return r.q.ResolveStockAlert(ctx, productID)
+}
+
+func (r *repository) ListOpenStockAlerts(ctx context.Context) ([]db.ListOpenStockAlertsRow, error) {
+	return r.q.ListOpenStockAlerts(ctx)
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
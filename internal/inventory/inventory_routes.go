@@ -0,0 +1,17 @@
+package inventory
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/inventory", middleware.AuthMiddleware())
+	{
+		routes.POST("/adjust", middleware.RequireMenu("inventory", "update"), h.AdjustStock)
+		routes.GET("/alerts", middleware.RequireMenu("inventory", "read"), h.ListAlerts)
+		routes.GET("/:productId", middleware.RequireMenu("inventory", "read"), h.GetStock)
+		routes.PUT("/:productId/reorder-point", middleware.RequireMenu("inventory", "update"), h.SetReorderPoint)
+	}
+}
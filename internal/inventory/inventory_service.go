@@ -0,0 +1,298 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+//go:generate mockgen -source=inventory_service.go -destination=mocks/inventory_service_mock.go -package=mocks
+
+const movementTypeAdjustment = "adjustment"
+
+// Service defines inventory business logic
+type Service interface {
+	AdjustStock(ctx context.Context, req AdjustStockRequest, userID *uuid.UUID) (*AdjustStockResponse, error)
+	GetStock(ctx context.Context, productID uuid.UUID, params pagination.Params) (*StockResponse, error)
+	SetReorderPoint(ctx context.Context, productID uuid.UUID, req SetReorderPointRequest) error
+	ListAlerts(ctx context.Context) ([]StockAlertResponse, error)
+}
+
+type service struct {
+	repo   Repository
+	tx     database.Transactor
+	events webhook.Publisher
+}
+
+// NewService creates inventory service; events nil = event tidak dikirim
+func NewService(repo Repository, tx database.Transactor, events webhook.Publisher) Service {
+	if events == nil {
+		events = webhook.Nop{}
+	}
+	return &service{repo: repo, tx: tx, events: events}
+}
+
+// AdjustStock records a movement and applies it to the cached balance
+// in one transaction. The balance row is locked first so concurrent
+// adjustments on the same product/location are serialized.
+func (s *service) AdjustStock(ctx context.Context, req AdjustStockRequest, userID *uuid.UUID) (*AdjustStockResponse, error) {
+	if req.Delta.IsZero() {
+		return nil, ErrZeroDelta
+	}
+
+	var (
+		result   AdjustStockResponse
+		lowStock *LowStockEvent
+	)
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		product, err := repo.GetProductByID(ctx, req.ProductID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrProductNotFound
+			}
+			return err
+		}
+
+		// belum ada balance di lokasi ini = stok 0
+		current := decimal.Zero
+		qty, err := repo.LockStockBalance(ctx, db.LockStockBalanceParams{
+			ProductID:  req.ProductID,
+			LocationID: req.LocationID,
+		})
+		switch {
+		case err == nil:
+			current = dbutil.PgNumericToDecimal(qty)
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+
+		if current.Add(req.Delta).IsNegative() && !req.AllowNegative {
+			return ErrInsufficientStock
+		}
+
+		movement, err := repo.RecordStockMovement(ctx, db.RecordStockMovementParams{
+			MovementNumber: newMovementNumber(time.Now()),
+			ProductID:      req.ProductID,
+			LocationID:     req.LocationID,
+			MovementType:   movementTypeAdjustment,
+			Quantity:       dbutil.DecimalToPgNumeric(req.Delta),
+			Reason:         req.Reason,
+			Reference:      req.Reference,
+			CreatedBy:      dbutil.UUIDPtrToPgUUID(userID),
+		})
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return ErrLocationNotFound
+			}
+			return fmt.Errorf("record stock movement failed: %w", err)
+		}
+
+		balance, err := repo.UpsertStockBalance(ctx, db.UpsertStockBalanceParams{
+			ProductID:   req.ProductID,
+			LocationID:  req.LocationID,
+			Quantity:    dbutil.DecimalToPgNumeric(req.Delta),
+			ReservedQty: dbutil.DecimalToPgNumeric(decimal.Zero),
+		})
+		if err != nil {
+			return fmt.Errorf("update stock balance failed: %w", err)
+		}
+
+		result = AdjustStockResponse{
+			Movement: mapMovement(movement),
+			OnHand:   dbutil.PgNumericToDecimal(balance.Quantity),
+		}
+
+		lowStock, err = checkReorderPoint(ctx, repo, product)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// publish setelah commit supaya alert yang di-rollback tidak terkirim
+	if lowStock != nil {
+		s.events.Publish(ctx, webhook.NewEvent(webhook.EventInventoryLowStock, *lowStock))
+	}
+
+	return &result, nil
+}
+
+// checkReorderPoint compares the product's total on-hand with its
+// reorder point. Dropping below opens an alert (at most one open per
+// product) and returns the event to emit; being at or above it again,
+// or having no reorder point at all, resolves the open alert.
+func checkReorderPoint(ctx context.Context, repo Repository, product db.GetProductByIDRow) (*LowStockEvent, error) {
+	rp, err := repo.GetProductReorderPoint(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	// tidak dipantau lagi: alert lama jangan dibiarkan terbuka
+	if !rp.Valid {
+		if _, err := repo.ResolveStockAlert(ctx, product.ID); err != nil {
+			return nil, fmt.Errorf("resolve stock alert failed: %w", err)
+		}
+		return nil, nil
+	}
+	reorderPoint := dbutil.PgNumericToDecimal(rp)
+
+	total, err := repo.SumProductOnHand(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	onHand := dbutil.PgNumericToDecimal(total)
+
+	if !onHand.LessThan(reorderPoint) {
+		if _, err := repo.ResolveStockAlert(ctx, product.ID); err != nil {
+			return nil, fmt.Errorf("resolve stock alert failed: %w", err)
+		}
+		return nil, nil
+	}
+
+	opened, err := repo.OpenStockAlert(ctx, db.OpenStockAlertParams{
+		ProductID:    product.ID,
+		OnHand:       total,
+		ReorderPoint: rp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open stock alert failed: %w", err)
+	}
+	// alert sudah terbuka dari movement sebelumnya
+	if opened == 0 {
+		return nil, nil
+	}
+
+	return &LowStockEvent{
+		ProductID:    product.ID,
+		ProductCode:  product.Code,
+		ProductName:  product.Name,
+		OnHand:       onHand,
+		ReorderPoint: reorderPoint,
+	}, nil
+}
+
+// SetReorderPoint sets or clears (nil) the product's reorder point and
+// re-evaluates the alert against current stock in the same transaction.
+func (s *service) SetReorderPoint(ctx context.Context, productID uuid.UUID, req SetReorderPointRequest) error {
+	rp := pgtype.Numeric{}
+	if req.ReorderPoint != nil {
+		if req.ReorderPoint.IsNegative() {
+			return ErrNegativeReorderPoint
+		}
+		rp = dbutil.DecimalToPgNumeric(*req.ReorderPoint)
+	}
+
+	var lowStock *LowStockEvent
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		affected, err := repo.SetProductReorderPoint(ctx, db.SetProductReorderPointParams{
+			ID:           productID,
+			ReorderPoint: rp,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrProductNotFound
+		}
+
+		product, err := repo.GetProductByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		lowStock, err = checkReorderPoint(ctx, repo, product)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if lowStock != nil {
+		s.events.Publish(ctx, webhook.NewEvent(webhook.EventInventoryLowStock, *lowStock))
+	}
+	return nil
+}
+
+func (s *service) ListAlerts(ctx context.Context) ([]StockAlertResponse, error) {
+	rows, err := s.repo.ListOpenStockAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapAlerts(rows), nil
+}
+
+func (s *service) GetStock(ctx context.Context, productID uuid.UUID, params pagination.Params) (*StockResponse, error) {
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	balanceRows, err := s.repo.ListProductStockBalances(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	movementRows, err := s.repo.ListProductStockMovements(ctx, db.ListProductStockMovementsParams{
+		ProductID:   productID,
+		LimitCount:  params.Limit(),
+		OffsetCount: params.Offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountProductStockMovements(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewResponse(mapMovements(movementRows), total, params)
+
+	balances := mapBalances(balanceRows)
+	onHand := decimal.Zero
+	for _, b := range balances {
+		onHand = onHand.Add(b.Quantity)
+	}
+
+	return &StockResponse{
+		ProductID:   product.ID,
+		ProductCode: product.Code,
+		ProductName: product.Name,
+		OnHand:      onHand,
+		Balances:    balances,
+		Movements:   MovementPage{Data: page.Data, Meta: page.Meta},
+	}, nil
+}
+
+// ADJ-20260101-1A2B3C4D
+func newMovementNumber(now time.Time) string {
+	suffix := strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:8])
+	return fmt.Sprintf("ADJ-%s-%s", now.Format("20060102"), suffix)
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
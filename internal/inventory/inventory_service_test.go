@@ -0,0 +1,343 @@
+package inventory_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/inventory"
+	"go-mini-erp/internal/inventory/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	f.calls++
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+// publisherStub mencatat event yang di-publish
+type publisherStub struct {
+	events []webhook.Event
+}
+
+func (p *publisherStub) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func qty(n int64) pgtype.Numeric {
+	return dbutil.DecimalToPgNumeric(decimal.NewFromInt(n))
+}
+
+// expectMovement menyiapkan adjustment sukses untuk produk dengan reorder
+// point 10 dan total on-hand setelah movement = onHand
+func expectMovement(repo *mocks.MockRepository, product db.GetProductByIDRow, current, onHand int64) {
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetProductByID(gomock.Any(), product.ID).Return(product, nil)
+	repo.EXPECT().LockStockBalance(gomock.Any(), gomock.Any()).Return(qty(current), nil)
+	repo.EXPECT().RecordStockMovement(gomock.Any(), gomock.Any()).Return(db.StockMovement{ID: uuid.New()}, nil)
+	repo.EXPECT().UpsertStockBalance(gomock.Any(), gomock.Any()).Return(db.UpsertStockBalanceRow{Quantity: qty(onHand)}, nil)
+	repo.EXPECT().GetProductReorderPoint(gomock.Any(), product.ID).Return(qty(10), nil)
+	repo.EXPECT().SumProductOnHand(gomock.Any(), product.ID).Return(qty(onHand), nil)
+}
+
+func TestAdjustStock_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := inventory.NewService(repo, tx, nil)
+
+	ctx := context.Background()
+	productID := uuid.New()
+	locationID := uuid.New()
+	userID := uuid.New()
+	movementID := uuid.New()
+	delta := decimal.RequireFromString("-4")
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetProductByID(ctx, productID).Return(db.GetProductByIDRow{ID: productID}, nil)
+	repo.EXPECT().LockStockBalance(ctx, db.LockStockBalanceParams{
+		ProductID:  productID,
+		LocationID: locationID,
+	}).Return(dbutil.DecimalToPgNumeric(decimal.NewFromInt(10)), nil)
+	repo.EXPECT().RecordStockMovement(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.RecordStockMovementParams) (db.StockMovement, error) {
+			assert.True(t, strings.HasPrefix(arg.MovementNumber, "ADJ-"))
+			assert.Equal(t, "adjustment", arg.MovementType)
+			assert.True(t, delta.Equal(dbutil.PgNumericToDecimal(arg.Quantity)))
+			assert.Equal(t, "Damaged in transit", *arg.Reason)
+			assert.Equal(t, userID, *dbutil.PgUUIDToUUIDPtr(arg.CreatedBy))
+
+			return db.StockMovement{
+				ID:             movementID,
+				MovementNumber: arg.MovementNumber,
+				ProductID:      arg.ProductID,
+				LocationID:     arg.LocationID,
+				MovementType:   arg.MovementType,
+				Quantity:       arg.Quantity,
+				Reason:         arg.Reason,
+				CreatedBy:      arg.CreatedBy,
+			}, nil
+		})
+	repo.EXPECT().UpsertStockBalance(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.UpsertStockBalanceParams) (db.UpsertStockBalanceRow, error) {
+			assert.True(t, delta.Equal(dbutil.PgNumericToDecimal(arg.Quantity)))
+			return db.UpsertStockBalanceRow{
+				ProductID:  productID,
+				LocationID: locationID,
+				Quantity:   dbutil.DecimalToPgNumeric(decimal.NewFromInt(6)),
+			}, nil
+		})
+	// reorder point belum di-set: hanya alert lama yang ditutup
+	repo.EXPECT().GetProductReorderPoint(ctx, productID).Return(pgtype.Numeric{}, nil)
+	repo.EXPECT().ResolveStockAlert(ctx, productID).Return(int64(0), nil)
+
+	result, err := service.AdjustStock(ctx, inventory.AdjustStockRequest{
+		ProductID:  productID,
+		LocationID: locationID,
+		Delta:      delta,
+		Reason:     dbutil.Ptr("Damaged in transit"),
+	}, &userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, movementID, result.Movement.ID)
+	assert.True(t, delta.Equal(result.Movement.Delta))
+	assert.True(t, decimal.NewFromInt(6).Equal(result.OnHand))
+	assert.Equal(t, 1, tx.calls)
+	assert.False(t, tx.rolledBack)
+}
+
+func TestAdjustStock_OverdrawRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := inventory.NewService(repo, tx, nil)
+
+	productID := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetProductByID(gomock.Any(), productID).Return(db.GetProductByIDRow{ID: productID}, nil)
+	repo.EXPECT().LockStockBalance(gomock.Any(), gomock.Any()).
+		Return(dbutil.DecimalToPgNumeric(decimal.NewFromInt(3)), nil)
+
+	result, err := service.AdjustStock(context.Background(), inventory.AdjustStockRequest{
+		ProductID:  productID,
+		LocationID: uuid.New(),
+		Delta:      decimal.NewFromInt(-5),
+	}, nil)
+
+	assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+	assert.Nil(t, result)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestAdjustStock_AllowNegative(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := inventory.NewService(repo, &fakeTx{}, nil)
+
+	productID := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetProductByID(gomock.Any(), productID).Return(db.GetProductByIDRow{ID: productID}, nil)
+	// belum ada balance: dianggap 0
+	repo.EXPECT().LockStockBalance(gomock.Any(), gomock.Any()).Return(dbutil.DecimalToPgNumeric(decimal.Zero), pgx.ErrNoRows)
+	repo.EXPECT().RecordStockMovement(gomock.Any(), gomock.Any()).Return(db.StockMovement{ID: uuid.New()}, nil)
+	repo.EXPECT().UpsertStockBalance(gomock.Any(), gomock.Any()).Return(db.UpsertStockBalanceRow{
+		Quantity: dbutil.DecimalToPgNumeric(decimal.NewFromInt(-2)),
+	}, nil)
+	repo.EXPECT().GetProductReorderPoint(gomock.Any(), productID).Return(pgtype.Numeric{}, nil)
+	repo.EXPECT().ResolveStockAlert(gomock.Any(), productID).Return(int64(0), nil)
+
+	result, err := service.AdjustStock(context.Background(), inventory.AdjustStockRequest{
+		ProductID:     productID,
+		LocationID:    uuid.New(),
+		Delta:         decimal.NewFromInt(-2),
+		AllowNegative: true,
+	}, nil)
+
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(-2).Equal(result.OnHand))
+}
+
+func TestAdjustStock_ZeroDelta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tx := &fakeTx{}
+	service := inventory.NewService(mocks.NewMockRepository(ctrl), tx, nil)
+
+	_, err := service.AdjustStock(context.Background(), inventory.AdjustStockRequest{
+		ProductID:  uuid.New(),
+		LocationID: uuid.New(),
+	}, nil)
+
+	assert.ErrorIs(t, err, inventory.ErrZeroDelta)
+	assert.Equal(t, 0, tx.calls)
+}
+
+func TestAdjustStock_LowStockAlertOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := inventory.NewService(repo, &fakeTx{}, events)
+
+	product := db.GetProductByIDRow{ID: uuid.New(), Code: "SKU-001", Name: "Widget"}
+
+	// 12 -> 8: melewati reorder point, alert baru dibuka
+	expectMovement(repo, product, 12, 8)
+	repo.EXPECT().OpenStockAlert(gomock.Any(), db.OpenStockAlertParams{
+		ProductID:    product.ID,
+		OnHand:       qty(8),
+		ReorderPoint: qty(10),
+	}).Return(int64(1), nil)
+
+	// 8 -> 5: alert masih terbuka, tidak ada event kedua
+	expectMovement(repo, product, 8, 5)
+	repo.EXPECT().OpenStockAlert(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	for _, delta := range []int64{-4, -3} {
+		_, err := service.AdjustStock(context.Background(), inventory.AdjustStockRequest{
+			ProductID:  product.ID,
+			LocationID: uuid.New(),
+			Delta:      decimal.NewFromInt(delta),
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, events.events, 1)
+	assert.Equal(t, webhook.EventInventoryLowStock, events.events[0].Type)
+
+	payload := events.events[0].Data.(inventory.LowStockEvent)
+	assert.Equal(t, product.ID, payload.ProductID)
+	assert.Equal(t, "SKU-001", payload.ProductCode)
+	assert.True(t, decimal.NewFromInt(8).Equal(payload.OnHand))
+	assert.True(t, decimal.NewFromInt(10).Equal(payload.ReorderPoint))
+}
+
+func TestAdjustStock_RestockResolvesAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := inventory.NewService(repo, &fakeTx{}, events)
+
+	product := db.GetProductByIDRow{ID: uuid.New()}
+
+	// 5 -> 10: on-hand sama dengan reorder point sudah tidak low stock
+	expectMovement(repo, product, 5, 10)
+	repo.EXPECT().ResolveStockAlert(gomock.Any(), product.ID).Return(int64(1), nil)
+
+	_, err := service.AdjustStock(context.Background(), inventory.AdjustStockRequest{
+		ProductID:  product.ID,
+		LocationID: uuid.New(),
+		Delta:      decimal.NewFromInt(5),
+	}, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, events.events)
+}
+
+func TestSetReorderPoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := inventory.NewService(repo, &fakeTx{}, nil)
+
+	productID := uuid.New()
+	negative := decimal.NewFromInt(-1)
+	err := service.SetReorderPoint(context.Background(), productID, inventory.SetReorderPointRequest{ReorderPoint: &negative})
+	assert.ErrorIs(t, err, inventory.ErrNegativeReorderPoint)
+
+	// null = berhenti memantau
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().SetProductReorderPoint(gomock.Any(), db.SetProductReorderPointParams{ID: productID}).Return(int64(0), nil)
+	err = service.SetReorderPoint(context.Background(), productID, inventory.SetReorderPointRequest{})
+	assert.ErrorIs(t, err, inventory.ErrProductNotFound)
+}
+
+func TestSetReorderPoint_ClearResolvesAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	events := &publisherStub{}
+	service := inventory.NewService(repo, tx, events)
+
+	product := db.GetProductByIDRow{ID: uuid.New()}
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().SetProductReorderPoint(gomock.Any(), db.SetProductReorderPointParams{ID: product.ID}).Return(int64(1), nil)
+	repo.EXPECT().GetProductByID(gomock.Any(), product.ID).Return(product, nil)
+	repo.EXPECT().GetProductReorderPoint(gomock.Any(), product.ID).Return(pgtype.Numeric{}, nil)
+	// alert yang terbuka sebelum reorder point dihapus ikut ditutup
+	repo.EXPECT().ResolveStockAlert(gomock.Any(), product.ID).Return(int64(1), nil)
+
+	err := service.SetReorderPoint(context.Background(), product.ID, inventory.SetReorderPointRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, tx.calls)
+	assert.False(t, tx.rolledBack)
+	assert.Empty(t, events.events)
+}
+
+func TestSetReorderPoint_RaiseOpensAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := inventory.NewService(repo, &fakeTx{}, events)
+
+	product := db.GetProductByIDRow{ID: uuid.New(), Code: "SKU-001", Name: "Widget"}
+	reorderPoint := decimal.NewFromInt(10)
+
+	// stok 8 tidak berubah, tapi reorder point dinaikkan ke 10
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().SetProductReorderPoint(gomock.Any(), db.SetProductReorderPointParams{
+		ID:           product.ID,
+		ReorderPoint: qty(10),
+	}).Return(int64(1), nil)
+	repo.EXPECT().GetProductByID(gomock.Any(), product.ID).Return(product, nil)
+	repo.EXPECT().GetProductReorderPoint(gomock.Any(), product.ID).Return(qty(10), nil)
+	repo.EXPECT().SumProductOnHand(gomock.Any(), product.ID).Return(qty(8), nil)
+	repo.EXPECT().OpenStockAlert(gomock.Any(), db.OpenStockAlertParams{
+		ProductID:    product.ID,
+		OnHand:       qty(8),
+		ReorderPoint: qty(10),
+	}).Return(int64(1), nil)
+
+	err := service.SetReorderPoint(context.Background(), product.ID, inventory.SetReorderPointRequest{ReorderPoint: &reorderPoint})
+
+	require.NoError(t, err)
+	require.Len(t, events.events, 1)
+	assert.Equal(t, webhook.EventInventoryLowStock, events.events[0].Type)
+}
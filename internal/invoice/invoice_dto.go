@@ -0,0 +1,70 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Status invoice sesuai kolom customer_invoices.status
+const (
+	StatusUnpaid    = "unpaid"
+	StatusPartial   = "partial"
+	StatusPaid      = "paid"
+	StatusCancelled = "cancelled"
+)
+
+type RecordPaymentRequest struct {
+	Amount        decimal.Decimal `json:"amount" swaggertype:"string" example:"250000.00"`
+	PaymentMethod string          `json:"paymentMethod" binding:"required,oneof=cash bank_transfer check card"`
+	Reference     *string         `json:"reference" binding:"omitempty,max=255"`
+	Notes         *string         `json:"notes"`
+	// default hari ini
+	PaymentDate *string `json:"paymentDate" binding:"omitempty,datetime=2006-01-02" example:"2026-01-31"`
+}
+
+type PaymentResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	PaymentNumber string          `json:"paymentNumber"`
+	PaymentDate   time.Time       `json:"paymentDate"`
+	Amount        decimal.Decimal `json:"amount" swaggertype:"string" example:"250000.00"`
+	PaymentMethod *string         `json:"paymentMethod"`
+	Reference     *string         `json:"reference"`
+	Notes         *string         `json:"notes"`
+	CreatedBy     *uuid.UUID      `json:"createdBy"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+type RecordPaymentResponse struct {
+	Payment     PaymentResponse `json:"payment"`
+	TotalAmount decimal.Decimal `json:"totalAmount" swaggertype:"string" example:"1000000.00"`
+	PaidAmount  decimal.Decimal `json:"paidAmount" swaggertype:"string" example:"250000.00"`
+	Outstanding decimal.Decimal `json:"outstanding" swaggertype:"string" example:"750000.00"`
+	Status      string          `json:"status"`
+}
+
+// InvoicePaidEvent is the payload of the invoice.paid webhook
+type InvoicePaidEvent struct {
+	InvoiceID   uuid.UUID       `json:"invoiceId"`
+	PaymentID   uuid.UUID       `json:"paymentId"`
+	TotalAmount decimal.Decimal `json:"totalAmount"`
+	PaidAmount  decimal.Decimal `json:"paidAmount"`
+}
+
+type InvoiceResponse struct {
+	ID            uuid.UUID         `json:"id"`
+	InvoiceNumber string            `json:"invoiceNumber"`
+	CustomerID    uuid.UUID         `json:"customerId"`
+	CustomerName  string            `json:"customerName"`
+	SalesOrderID  *uuid.UUID        `json:"salesOrderId"`
+	InvoiceDate   time.Time         `json:"invoiceDate"`
+	DueDate       time.Time         `json:"dueDate"`
+	TotalAmount   decimal.Decimal   `json:"totalAmount" swaggertype:"string" example:"1000000.00"`
+	PaidAmount    decimal.Decimal   `json:"paidAmount" swaggertype:"string" example:"250000.00"`
+	Outstanding   decimal.Decimal   `json:"outstanding" swaggertype:"string" example:"750000.00"`
+	Status        string            `json:"status"`
+	Notes         *string           `json:"notes"`
+	Payments      []PaymentResponse `json:"payments"`
+	CreatedAt     time.Time         `json:"createdAt"`
+}
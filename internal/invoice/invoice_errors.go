@@ -0,0 +1,10 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrInvoiceNotFound  = errors.New("invoice not found")
+	ErrInvoiceCancelled = errors.New("invoice is cancelled")
+	ErrInvalidAmount    = errors.New("payment amount must be positive with at most 2 decimal places")
+	ErrOverpayment      = errors.New("payment exceeds outstanding balance")
+)
@@ -0,0 +1,99 @@
+package invoice
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetInvoice godoc
+// @Summary Get customer invoice
+// @Description Invoice detail including payments and outstanding balance
+// @Tags invoices
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} InvoiceResponse
+// @Failure 404 {object} map[string]string
+// @Router /invoices/{id} [get]
+func (h *Handler) GetInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invoice id"})
+		return
+	}
+
+	result, err := h.service.GetInvoice(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RecordPayment godoc
+// @Summary Record invoice payment
+// @Description Records a full or partial payment. Payments larger than the outstanding balance are rejected.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invoice ID"
+// @Param request body RecordPaymentRequest true "Payment"
+// @Success 201 {object} RecordPaymentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /invoices/{id}/payments [post]
+func (h *Handler) RecordPayment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invoice id"})
+		return
+	}
+
+	var req RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var userID *uuid.UUID
+	if uid, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		userID = &uid
+	}
+
+	result, err := h.service.RecordPayment(c.Request.Context(), id, req, userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvoiceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidAmount):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrOverpayment), errors.Is(err, ErrInvoiceCancelled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
@@ -0,0 +1,73 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func mapInvoice(inv db.GetCustomerInvoiceByIDRow, payments []db.ListInvoicePaymentsRow) *InvoiceResponse {
+	total := dbutil.PgNumericToDecimal(inv.TotalAmount)
+	paid := dbutil.PgNumericToDecimal(inv.PaidAmount)
+
+	return &InvoiceResponse{
+		ID:            inv.ID,
+		InvoiceNumber: inv.InvoiceNumber,
+		CustomerID:    inv.CustomerID,
+		CustomerName:  inv.CustomerName,
+		SalesOrderID:  dbutil.PgUUIDToUUIDPtr(inv.SoID),
+		InvoiceDate:   pgDateValue(inv.InvoiceDate),
+		DueDate:       pgDateValue(inv.DueDate),
+		TotalAmount:   total,
+		PaidAmount:    paid,
+		Outstanding:   total.Sub(paid),
+		Status:        dbutil.Deref(inv.Status, StatusUnpaid),
+		Notes:         inv.Notes,
+		Payments:      mapPayments(payments),
+		CreatedAt:     dbutil.PgTimeValue(inv.CreatedAt),
+	}
+}
+
+func mapPayments(rows []db.ListInvoicePaymentsRow) []PaymentResponse {
+	payments := make([]PaymentResponse, 0, len(rows))
+
+	for _, p := range rows {
+		payments = append(payments, mapPayment(db.Payment{
+			ID:            p.ID,
+			PaymentNumber: p.PaymentNumber,
+			PaymentDate:   p.PaymentDate,
+			Amount:        p.Amount,
+			PaymentMethod: p.PaymentMethod,
+			Reference:     p.Reference,
+			Notes:         p.Notes,
+			CreatedBy:     p.CreatedBy,
+			CreatedAt:     p.CreatedAt,
+		}))
+	}
+
+	return payments
+}
+
+func mapPayment(p db.Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:            p.ID,
+		PaymentNumber: p.PaymentNumber,
+		PaymentDate:   pgDateValue(p.PaymentDate),
+		Amount:        dbutil.PgNumericToDecimal(p.Amount),
+		PaymentMethod: p.PaymentMethod,
+		Reference:     p.Reference,
+		Notes:         p.Notes,
+		CreatedBy:     dbutil.PgUUIDToUUIDPtr(p.CreatedBy),
+		CreatedAt:     dbutil.PgTimeValue(p.CreatedAt),
+	}
+}
+
+func pgDateValue(d pgtype.Date) time.Time {
+	if !d.Valid {
+		return time.Time{}
+	}
+	return d.Time
+}
@@ -0,0 +1,57 @@
+package invoice
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+//go:generate mockgen -source=invoice_repo.go -destination=mocks/invoice_repository_mock.go -package=mocks
+
+// Repository defines invoice data access contract
+type Repository interface {
+	GetCustomerInvoiceByID(ctx context.Context, id uuid.UUID) (db.GetCustomerInvoiceByIDRow, error)
+	LockCustomerInvoice(ctx context.Context, id uuid.UUID) (db.LockCustomerInvoiceRow, error)
+	ApplyCustomerInvoicePayment(ctx context.Context, arg db.ApplyCustomerInvoicePaymentParams) (db.ApplyCustomerInvoicePaymentRow, error)
+	CreatePayment(ctx context.Context, arg db.CreatePaymentParams) (db.CreatePaymentRow, error)
+	ListInvoicePayments(ctx context.Context, invoiceID pgtype.UUID) ([]db.ListInvoicePaymentsRow, error)
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates invoice repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) GetCustomerInvoiceByID(ctx context.Context, id uuid.UUID) (db.GetCustomerInvoiceByIDRow, error) {
+	return r.q.GetCustomerInvoiceByID(ctx, id)
+}
+
+func (r *repository) LockCustomerInvoice(ctx context.Context, id uuid.UUID) (db.LockCustomerInvoiceRow, error) {
+	return r.q.LockCustomerInvoice(ctx, id)
+}
+
+func (r *repository) ApplyCustomerInvoicePayment(ctx context.Context, arg db.ApplyCustomerInvoicePaymentParams) (db.ApplyCustomerInvoicePaymentRow, error) {
+	return r.q.ApplyCustomerInvoicePayment(ctx, arg)
+}
+
+func (r *repository) CreatePayment(ctx context.Context, arg db.CreatePaymentParams) (db.CreatePaymentRow, error) {
+	return r.q.CreatePayment(ctx, arg)
+}
+
+func (r *repository) ListInvoicePayments(ctx context.Context, invoiceID pgtype.UUID) ([]db.ListInvoicePaymentsRow, error) {
+	return r.q.ListInvoicePayments(ctx, invoiceID)
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
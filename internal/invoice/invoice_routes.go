@@ -0,0 +1,15 @@
+package invoice
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/invoices", middleware.AuthMiddleware())
+	{
+		routes.GET("/:id", middleware.RequireMenu("finance", "read"), h.GetInvoice)
+		routes.POST("/:id/payments", middleware.RequireMenu("finance", "create"), h.RecordPayment)
+	}
+}
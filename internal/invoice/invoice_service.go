@@ -0,0 +1,178 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+//go:generate mockgen -source=invoice_service.go -destination=mocks/invoice_service_mock.go -package=mocks
+
+// kolom amount di invoice & payments adalah DECIMAL(15,2)
+const amountPlaces = 2
+
+// Service defines invoice business logic
+type Service interface {
+	GetInvoice(ctx context.Context, id uuid.UUID) (*InvoiceResponse, error)
+	RecordPayment(ctx context.Context, invoiceID uuid.UUID, req RecordPaymentRequest, userID *uuid.UUID) (*RecordPaymentResponse, error)
+}
+
+type service struct {
+	repo   Repository
+	tx     database.Transactor
+	events webhook.Publisher
+}
+
+// NewService creates invoice service; events nil = webhook tidak dikirim
+func NewService(repo Repository, tx database.Transactor, events webhook.Publisher) Service {
+	if events == nil {
+		events = webhook.Nop{}
+	}
+	return &service{repo: repo, tx: tx, events: events}
+}
+
+func (s *service) GetInvoice(ctx context.Context, id uuid.UUID) (*InvoiceResponse, error) {
+	inv, err := s.repo.GetCustomerInvoiceByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+
+	payments, err := s.repo.ListInvoicePayments(ctx, dbutil.UUIDPtrToPgUUID(&id))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapInvoice(inv, payments), nil
+}
+
+// RecordPayment stores a payment in the ledger and adds it to the
+// invoice's paid amount in one transaction. The invoice row is locked
+// so concurrent payments can't jointly overpay it.
+func (s *service) RecordPayment(
+	ctx context.Context,
+	invoiceID uuid.UUID,
+	req RecordPaymentRequest,
+	userID *uuid.UUID,
+) (*RecordPaymentResponse, error) {
+	if !req.Amount.IsPositive() || !req.Amount.Equal(req.Amount.Round(amountPlaces)) {
+		return nil, ErrInvalidAmount
+	}
+
+	paymentDate := time.Now()
+	if req.PaymentDate != nil {
+		d, err := time.Parse(time.DateOnly, *req.PaymentDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment date: %w", err)
+		}
+		paymentDate = d
+	}
+
+	var result RecordPaymentResponse
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		inv, err := repo.LockCustomerInvoice(ctx, invoiceID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvoiceNotFound
+			}
+			return err
+		}
+
+		if dbutil.Deref(inv.Status, StatusUnpaid) == StatusCancelled {
+			return ErrInvoiceCancelled
+		}
+
+		outstanding := dbutil.PgNumericToDecimal(inv.TotalAmount).
+			Sub(dbutil.PgNumericToDecimal(inv.PaidAmount))
+		if req.Amount.GreaterThan(outstanding) {
+			return ErrOverpayment
+		}
+
+		payment := db.CreatePaymentParams{
+			PaymentNumber: newPaymentNumber(time.Now()),
+			PaymentType:   "receivable",
+			PartnerType:   "customer",
+			PartnerID:     inv.CustomerID,
+			InvoiceID:     dbutil.UUIDPtrToPgUUID(&invoiceID),
+			PaymentDate:   pgtype.Date{Time: paymentDate, Valid: true},
+			Amount:        dbutil.DecimalToPgNumeric(req.Amount),
+			PaymentMethod: &req.PaymentMethod,
+			Reference:     req.Reference,
+			Notes:         req.Notes,
+			CreatedBy:     dbutil.UUIDPtrToPgUUID(userID),
+		}
+
+		created, err := repo.CreatePayment(ctx, payment)
+		if err != nil {
+			return fmt.Errorf("create payment failed: %w", err)
+		}
+
+		applied, err := repo.ApplyCustomerInvoicePayment(ctx, db.ApplyCustomerInvoicePaymentParams{
+			ID:     invoiceID,
+			Amount: payment.Amount,
+		})
+		if err != nil {
+			return fmt.Errorf("update invoice paid amount failed: %w", err)
+		}
+
+		total := dbutil.PgNumericToDecimal(applied.TotalAmount)
+		paid := dbutil.PgNumericToDecimal(applied.PaidAmount)
+
+		result = RecordPaymentResponse{
+			Payment: mapPayment(db.Payment{
+				ID:            created.ID,
+				PaymentNumber: created.PaymentNumber,
+				PaymentDate:   created.PaymentDate,
+				Amount:        created.Amount,
+				PaymentMethod: payment.PaymentMethod,
+				Reference:     payment.Reference,
+				Notes:         payment.Notes,
+				CreatedBy:     payment.CreatedBy,
+				CreatedAt:     created.CreatedAt,
+			}),
+			TotalAmount: total,
+			PaidAmount:  paid,
+			Outstanding: decimal.Max(total.Sub(paid), decimal.Zero),
+			Status:      dbutil.Deref(applied.Status, StatusUnpaid),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// hanya payment yang melunasi invoice yang memicu invoice.paid
+	if result.Status == StatusPaid {
+		s.events.Publish(ctx, webhook.NewEvent(webhook.EventInvoicePaid, InvoicePaidEvent{
+			InvoiceID:   invoiceID,
+			PaymentID:   result.Payment.ID,
+			TotalAmount: result.TotalAmount,
+			PaidAmount:  result.PaidAmount,
+		}))
+	}
+
+	return &result, nil
+}
+
+// PAY-20260101-1A2B3C4D
+func newPaymentNumber(now time.Time) string {
+	suffix := strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:8])
+	return fmt.Sprintf("PAY-%s-%s", now.Format("20060102"), suffix)
+}
@@ -0,0 +1,175 @@
+package invoice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/invoice"
+	"go-mini-erp/internal/invoice/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	f.calls++
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+// publisherStub mencatat event yang di-publish
+type publisherStub struct {
+	events []webhook.Event
+}
+
+func (p *publisherStub) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func money(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// expectPayment menyiapkan mock untuk invoice dengan total & paid tertentu,
+// lalu menerapkan amount ke paid_amount seperti query ApplyCustomerInvoicePayment
+func expectPayment(repo *mocks.MockRepository, invoiceID uuid.UUID, total, paid, amount decimal.Decimal, status string) {
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().LockCustomerInvoice(gomock.Any(), invoiceID).Return(db.LockCustomerInvoiceRow{
+		ID:          invoiceID,
+		CustomerID:  uuid.New(),
+		TotalAmount: dbutil.DecimalToPgNumeric(total),
+		PaidAmount:  dbutil.DecimalToPgNumeric(paid),
+		Status:      dbutil.Ptr("unpaid"),
+	}, nil)
+	repo.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreatePaymentParams) (db.CreatePaymentRow, error) {
+			return db.CreatePaymentRow{
+				ID:            uuid.New(),
+				PaymentNumber: arg.PaymentNumber,
+				PaymentType:   arg.PaymentType,
+				PartnerID:     arg.PartnerID,
+				PaymentDate:   arg.PaymentDate,
+				Amount:        arg.Amount,
+			}, nil
+		})
+	repo.EXPECT().ApplyCustomerInvoicePayment(gomock.Any(), db.ApplyCustomerInvoicePaymentParams{
+		ID:     invoiceID,
+		Amount: dbutil.DecimalToPgNumeric(amount),
+	}).Return(db.ApplyCustomerInvoicePaymentRow{
+		TotalAmount: dbutil.DecimalToPgNumeric(total),
+		PaidAmount:  dbutil.DecimalToPgNumeric(paid.Add(amount)),
+		Status:      dbutil.Ptr(status),
+	}, nil)
+}
+
+func TestRecordPayment_Partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	events := &publisherStub{}
+	service := invoice.NewService(repo, tx, events)
+
+	invoiceID := uuid.New()
+	expectPayment(repo, invoiceID, money("1000000.00"), money("0"), money("250000.50"), invoice.StatusPartial)
+
+	result, err := service.RecordPayment(context.Background(), invoiceID, invoice.RecordPaymentRequest{
+		Amount:        money("250000.50"),
+		PaymentMethod: "bank_transfer",
+		Reference:     dbutil.Ptr("TRF-001"),
+	}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, invoice.StatusPartial, result.Status)
+	assert.True(t, money("250000.50").Equal(result.PaidAmount))
+	assert.True(t, money("749999.50").Equal(result.Outstanding))
+	assert.Equal(t, "bank_transfer", *result.Payment.PaymentMethod)
+	assert.Equal(t, "TRF-001", *result.Payment.Reference)
+	assert.False(t, tx.rolledBack)
+	assert.Empty(t, events.events)
+}
+
+func TestRecordPayment_Full(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := invoice.NewService(repo, &fakeTx{}, events)
+
+	invoiceID := uuid.New()
+	// sisa tagihan dibayar lunas setelah pembayaran sebagian sebelumnya
+	expectPayment(repo, invoiceID, money("1000000.00"), money("250000.50"), money("749999.50"), invoice.StatusPaid)
+
+	result, err := service.RecordPayment(context.Background(), invoiceID, invoice.RecordPaymentRequest{
+		Amount:        money("749999.50"),
+		PaymentMethod: "cash",
+	}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, invoice.StatusPaid, result.Status)
+	assert.True(t, result.Outstanding.IsZero())
+
+	require.Len(t, events.events, 1)
+	assert.Equal(t, webhook.EventInvoicePaid, events.events[0].Type)
+	assert.Equal(t, invoiceID, events.events[0].Data.(invoice.InvoicePaidEvent).InvoiceID)
+}
+
+func TestRecordPayment_OverpaymentRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := invoice.NewService(repo, tx, nil)
+
+	invoiceID := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().LockCustomerInvoice(gomock.Any(), invoiceID).Return(db.LockCustomerInvoiceRow{
+		ID:          invoiceID,
+		TotalAmount: dbutil.DecimalToPgNumeric(money("1000000.00")),
+		PaidAmount:  dbutil.DecimalToPgNumeric(money("999999.99")),
+		Status:      dbutil.Ptr(invoice.StatusPartial),
+	}, nil)
+
+	result, err := service.RecordPayment(context.Background(), invoiceID, invoice.RecordPaymentRequest{
+		Amount:        money("0.02"),
+		PaymentMethod: "cash",
+	}, nil)
+
+	assert.ErrorIs(t, err, invoice.ErrOverpayment)
+	assert.Nil(t, result)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestRecordPayment_InvalidAmount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tx := &fakeTx{}
+	service := invoice.NewService(mocks.NewMockRepository(ctrl), tx, nil)
+
+	for _, amount := range []string{"0", "-10", "10.005"} {
+		_, err := service.RecordPayment(context.Background(), uuid.New(), invoice.RecordPaymentRequest{
+			Amount:        money(amount),
+			PaymentMethod: "cash",
+		}, nil)
+		assert.ErrorIs(t, err, invoice.ErrInvalidAmount, amount)
+	}
+	assert.Equal(t, 0, tx.calls)
+}
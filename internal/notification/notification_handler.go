@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+// DefaultHeartbeat menjaga koneksi tetap hidup di balik proxy yang
+// memutus koneksi idle
+const DefaultHeartbeat = 15 * time.Second
+
+type Handler struct {
+	hub       *Hub
+	heartbeat time.Duration
+}
+
+func NewHandler(hub *Hub, heartbeat time.Duration) *Handler {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+	return &Handler{hub: hub, heartbeat: heartbeat}
+}
+
+// Stream godoc
+// @Summary Stream notifications (SSE)
+// @Description Holds a Server-Sent Events connection and pushes events visible to the caller: admins get every event, other roles the events of menus they can read. A ": ping" comment is sent periodically as heartbeat; the stream closes when the access token expires or is revoked, and role changes apply from the next heartbeat.
+// @Tags events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {string} string "event stream"
+// @Router /events/stream [get]
+func (h *Handler) Stream(c *gin.Context) {
+	// stream berjalan lebih lama dari WriteTimeout server
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("notification: clear write deadline failed: %v", err)
+	}
+
+	sub, unsubscribe, err := h.hub.subscribe(c.Request.Context(), middleware.GetRoles(c))
+	if err != nil {
+		middleware.InternalError(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// comment awal supaya header langsung terkirim ke client
+	fmt.Fprint(c.Writer, ": connected\n\n")
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	// stream ditutup saat access token expired; client reconnect dengan
+	// token baru
+	claims := middleware.GetClaims(c)
+	var expired <-chan time.Time
+	if claims != nil && claims.ExpiresAt != nil {
+		timer := time.NewTimer(time.Until(claims.ExpiresAt.Time))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-expired:
+			return
+		case <-ticker.C:
+			// logout dan perubahan role berlaku paling lambat satu heartbeat
+			if claims != nil {
+				roles, err := middleware.CurrentRoles(ctx, claims)
+				if err != nil {
+					if !errors.Is(err, middleware.ErrTokenRevoked) {
+						log.Printf("notification: recheck stream access failed: %v", err)
+					}
+					return
+				}
+				if err := h.hub.setRoles(ctx, sub, roles); err != nil {
+					log.Printf("notification: recheck stream access failed: %v", err)
+					return
+				}
+			}
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-sub.ch:
+			// hub ditutup saat server shutdown
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("notification: marshal %s event %s failed: %v", event.Type, event.ID, err)
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
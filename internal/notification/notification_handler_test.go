@@ -0,0 +1,241 @@
+package notification_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/notification"
+	"go-mini-erp/internal/shared/ctxkeys"
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// newStreamServer memasang handler dengan roles yang sudah di-set,
+// menggantikan AuthMiddleware
+func newStreamServer(t *testing.T, hub *notification.Hub, heartbeat time.Duration, roles []string) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/events/stream", func(c *gin.Context) {
+		ctxkeys.SetRoles(c, roles)
+		c.Next()
+	}, notification.NewHandler(hub, heartbeat).Stream)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newClaimsStreamServer is newStreamServer for a request authenticated
+// with claims, the way AuthMiddleware leaves the context
+func newClaimsStreamServer(t *testing.T, hub *notification.Hub, heartbeat time.Duration, claims *middleware.Claims) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/events/stream", func(c *gin.Context) {
+		ctxkeys.SetRoles(c, claims.Roles)
+		ctxkeys.SetClaims(c, claims)
+		c.Next()
+	}, notification.NewHandler(hub, heartbeat).Stream)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newClaims(roles []string, ttl time.Duration) *middleware.Claims {
+	return &middleware.Claims{
+		UserID: uuid.NewString(),
+		Roles:  roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+	}
+}
+
+// streamEnds reports whether the server closed the stream within a second
+func streamEnds(t *testing.T, resp *http.Response) bool {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(io.Discard, resp.Body)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err == nil
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+type revocationsStub struct{ revoked atomic.Bool }
+
+func (s *revocationsStub) IsAccessTokenRevoked(context.Context, uuid.UUID, time.Time) (bool, error) {
+	return s.revoked.Load(), nil
+}
+
+type roleSourceStub struct{ roles atomic.Value }
+
+func (s *roleSourceStub) UserRoles(context.Context, uuid.UUID) ([]string, error) {
+	return s.roles.Load().([]string), nil
+}
+
+func openStream(t *testing.T, ctx context.Context, url string) *http.Response {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/events/stream", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// readUntil membaca baris sampai ada yang diawali prefix
+func readUntil(t *testing.T, r *bufio.Reader, prefix string) string {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+}
+
+func TestStream_EventReachesClient(t *testing.T) {
+	hub := notification.NewHub(nil)
+	srv := newStreamServer(t, hub, time.Minute, []string{"admin"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resp := openStream(t, ctx, srv.URL)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	require.Eventually(t, func() bool { return hub.Clients() == 1 }, time.Second, 5*time.Millisecond)
+
+	event := webhook.NewEvent(webhook.EventUserCreated, map[string]string{"username": "budi"})
+	hub.Publish(context.Background(), event)
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, event.ID.String(), readUntil(t, reader, "id: "))
+	assert.Equal(t, webhook.EventUserCreated, readUntil(t, reader, "event: "))
+
+	var got webhook.Event
+	require.NoError(t, json.Unmarshal([]byte(readUntil(t, reader, "data: ")), &got))
+	assert.Equal(t, event.ID, got.ID)
+}
+
+func TestStream_Heartbeat(t *testing.T) {
+	hub := notification.NewHub(nil)
+	srv := newStreamServer(t, hub, 10*time.Millisecond, []string{"user"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resp := openStream(t, ctx, srv.URL)
+	reader := bufio.NewReader(resp.Body)
+
+	assert.Equal(t, "", readUntil(t, reader, ": ping"))
+}
+
+func TestStream_DisconnectUnsubscribes(t *testing.T) {
+	hub := notification.NewHub(nil)
+	srv := newStreamServer(t, hub, time.Minute, []string{"admin"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	openStream(t, ctx, srv.URL)
+	require.Eventually(t, func() bool { return hub.Clients() == 1 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	assert.Eventually(t, func() bool { return hub.Clients() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestStream_ClosesWhenTokenExpires(t *testing.T) {
+	hub := notification.NewHub(nil)
+	srv := newClaimsStreamServer(t, hub, time.Minute, newClaims([]string{"admin"}, 50*time.Millisecond))
+
+	resp := openStream(t, context.Background(), srv.URL)
+
+	assert.True(t, streamEnds(t, resp))
+	assert.Eventually(t, func() bool { return hub.Clients() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestStream_ClosesWhenTokenRevoked(t *testing.T) {
+	revocations := &revocationsStub{}
+	middleware.SetTokenRevocations(revocations)
+	t.Cleanup(func() { middleware.SetTokenRevocations(nil) })
+
+	hub := notification.NewHub(nil)
+	srv := newClaimsStreamServer(t, hub, 10*time.Millisecond, newClaims([]string{"admin"}, time.Hour))
+
+	resp := openStream(t, context.Background(), srv.URL)
+	require.Eventually(t, func() bool { return hub.Clients() == 1 }, time.Second, 5*time.Millisecond)
+
+	revocations.revoked.Store(true)
+
+	assert.True(t, streamEnds(t, resp))
+}
+
+func TestStream_DemotedUserStopsReceiving(t *testing.T) {
+	roles := &roleSourceStub{}
+	roles.roles.Store([]string{"admin"})
+	middleware.SetRoleSource(roles)
+	t.Cleanup(func() { middleware.SetRoleSource(nil) })
+
+	hub := notification.NewHub(menuGrants{"stock_keeper": {"inventory"}})
+	srv := newClaimsStreamServer(t, hub, 10*time.Millisecond, newClaims([]string{"admin"}, time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resp := openStream(t, ctx, srv.URL)
+	reader := bufio.NewReader(resp.Body)
+	require.Eventually(t, func() bool { return hub.Clients() == 1 }, time.Second, 5*time.Millisecond)
+
+	// role admin dicabut; heartbeat berikutnya memuat role baru
+	roles.roles.Store([]string{"user"})
+	readUntil(t, reader, ": ping")
+	readUntil(t, reader, ": ping")
+
+	hub.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+	low := webhook.NewEvent(webhook.EventInventoryLowStock, nil)
+	roles.roles.Store([]string{"user", "stock_keeper"})
+	readUntil(t, reader, ": ping")
+	readUntil(t, reader, ": ping")
+	hub.Publish(context.Background(), low)
+
+	// event user.created tidak pernah sampai; yang pertama terbaca low_stock
+	assert.Equal(t, low.ID.String(), readUntil(t, reader, "id: "))
+}
+
+func TestStream_ClosesOnHubClose(t *testing.T) {
+	hub := notification.NewHub(nil)
+	srv := newStreamServer(t, hub, time.Minute, []string{"admin"})
+
+	resp := openStream(t, context.Background(), srv.URL)
+	require.Eventually(t, func() bool { return hub.Clients() == 1 }, time.Second, 5*time.Millisecond)
+
+	hub.Close()
+
+	assert.True(t, streamEnds(t, resp))
+}
@@ -0,0 +1,172 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// clientBuffer: event yang belum terkirim per client; kalau penuh,
+// event untuk client lambat itu di-drop supaya publisher tidak blok
+const clientBuffer = 16
+
+// audience maps an event type to the menu whose read grant lets a
+// non-admin see it. Admin selalu menerima semua event; event user/role
+// hanya untuk admin.
+var audience = map[string]string{
+	webhook.EventInventoryLowStock: "inventory",
+	webhook.EventInvoicePaid:       "finance",
+}
+
+// MenuChecker looks up role_menus grants; rbac.MenuAccess implements it
+type MenuChecker interface {
+	HasMenuAccess(ctx context.Context, roles []string, menuCode, permission string) (bool, error)
+}
+
+type client struct {
+	// visible = event type yang boleh diterima, dihitung dari grant menu
+	// saat subscribe/setRoles supaya Publish tidak query database
+	admin   bool
+	visible map[string]bool
+	ch      chan webhook.Event
+}
+
+// Hub fans published events out to connected stream clients.
+// It implements webhook.Publisher so it can sit next to the dispatcher.
+type Hub struct {
+	menus MenuChecker
+
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+	closed  bool
+}
+
+// NewHub: menus nil = event menu hanya untuk admin
+func NewHub(menus MenuChecker) *Hub {
+	return &Hub{menus: menus, clients: make(map[*client]struct{})}
+}
+
+// Subscribe registers a client with the given role codes. The returned
+// func must be called to unregister; it closes the channel. Setelah
+// Close, channel yang dikembalikan langsung tertutup.
+func (h *Hub) Subscribe(ctx context.Context, roles []string) (<-chan webhook.Event, func(), error) {
+	c, unsubscribe, err := h.subscribe(ctx, roles)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.ch, unsubscribe, nil
+}
+
+func (h *Hub) subscribe(ctx context.Context, roles []string) (*client, func(), error) {
+	admin, visible, err := h.audienceOf(ctx, roles)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &client{
+		admin:   admin,
+		visible: visible,
+		ch:      make(chan webhook.Event, clientBuffer),
+	}
+
+	h.mu.Lock()
+	if h.closed {
+		close(c.ch)
+	} else {
+		h.clients[c] = struct{}{}
+	}
+	h.mu.Unlock()
+
+	return c, func() { h.remove(c) }, nil
+}
+
+// remove unregisters c and closes its channel; aman dipanggil berulang
+// dan setelah Close
+func (h *Hub) remove(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		close(c.ch)
+	}
+}
+
+// setRoles replaces the roles c is filtered by, e.g. after the user was
+// demoted or a menu grant was revoked while the stream stays open
+func (h *Hub) setRoles(ctx context.Context, c *client, roles []string) error {
+	admin, visible, err := h.audienceOf(ctx, roles)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	c.admin, c.visible = admin, visible
+	h.mu.Unlock()
+	return nil
+}
+
+// audienceOf resolves which menu events roles may see from their read
+// grants
+func (h *Hub) audienceOf(ctx context.Context, roles []string) (bool, map[string]bool, error) {
+	admin := slices.Contains(roles, seed.RoleAdmin)
+	visible := make(map[string]bool, len(audience))
+	if admin || h.menus == nil || len(roles) == 0 {
+		return admin, visible, nil
+	}
+
+	for eventType, menu := range audience {
+		allowed, err := h.menus.HasMenuAccess(ctx, roles, menu, "read")
+		if err != nil {
+			return false, nil, fmt.Errorf("menu access %s: %w", menu, err)
+		}
+		visible[eventType] = allowed
+	}
+	return admin, visible, nil
+}
+
+// Close disconnects every client and refuses new ones, so stream handlers
+// return and http.Server.Shutdown can drain them
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for c := range h.clients {
+		delete(h.clients, c)
+		close(c.ch)
+	}
+}
+
+// Clients returns the number of connected clients
+func (h *Hub) Clients() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// Publish delivers event to every client allowed to see it, without blocking
+func (h *Hub) Publish(ctx context.Context, event webhook.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for c := range h.clients {
+		if !c.canSee(event.Type) {
+			continue
+		}
+
+		select {
+		case c.ch <- event:
+		default:
+			log.Printf("notification: client buffer full, dropping %s event %s", event.Type, event.ID)
+		}
+	}
+}
+
+func (c *client) canSee(eventType string) bool {
+	return c.admin || c.visible[eventType]
+}
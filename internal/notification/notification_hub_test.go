@@ -0,0 +1,116 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/notification"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// menuGrants: role code -> menu yang boleh di-read
+type menuGrants map[string][]string
+
+func (g menuGrants) HasMenuAccess(_ context.Context, roles []string, menuCode, permission string) (bool, error) {
+	for _, role := range roles {
+		if permission == "read" && slices.Contains(g[role], menuCode) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type failingMenus struct{}
+
+func (failingMenus) HasMenuAccess(context.Context, []string, string, string) (bool, error) {
+	return false, errors.New("db down")
+}
+
+func subscribe(t *testing.T, hub *notification.Hub, roles []string) (<-chan webhook.Event, func()) {
+	t.Helper()
+	ch, unsubscribe, err := hub.Subscribe(context.Background(), roles)
+	require.NoError(t, err)
+	return ch, unsubscribe
+}
+
+func TestHub_OnlyRelevantRolesReceive(t *testing.T) {
+	hub := notification.NewHub(nil)
+
+	admin, unsubAdmin := subscribe(t, hub, []string{"admin"})
+	defer unsubAdmin()
+	user, unsubUser := subscribe(t, hub, []string{"user"})
+	defer unsubUser()
+
+	hub.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+
+	assert.Len(t, admin, 1)
+	assert.Len(t, user, 0)
+}
+
+func TestHub_NonAdminReceivesEventsForTheirMenu(t *testing.T) {
+	// stock_keeper punya grant read menu inventory; role bernama
+	// "inventory" tanpa grant tidak ikut menerima
+	hub := notification.NewHub(menuGrants{"stock_keeper": {"inventory"}, "clerk": {"sales"}})
+
+	keeper, unsubKeeper := subscribe(t, hub, []string{"user", "stock_keeper"})
+	defer unsubKeeper()
+	clerk, unsubClerk := subscribe(t, hub, []string{"user", "clerk"})
+	defer unsubClerk()
+	lookalike, unsubLookalike := subscribe(t, hub, []string{"inventory"})
+	defer unsubLookalike()
+
+	hub.Publish(context.Background(), webhook.NewEvent(webhook.EventInventoryLowStock, nil))
+	hub.Publish(context.Background(), webhook.NewEvent(webhook.EventInvoicePaid, nil))
+
+	if assert.Len(t, keeper, 1) {
+		assert.Equal(t, webhook.EventInventoryLowStock, (<-keeper).Type)
+	}
+	assert.Len(t, clerk, 0)
+	assert.Len(t, lookalike, 0)
+}
+
+func TestHub_MenuLookupFailsRefusesSubscribe(t *testing.T) {
+	hub := notification.NewHub(failingMenus{})
+
+	_, _, err := hub.Subscribe(context.Background(), []string{"user"})
+	assert.Error(t, err)
+	assert.Equal(t, 0, hub.Clients())
+}
+
+func TestHub_CloseDisconnectsClients(t *testing.T) {
+	hub := notification.NewHub(nil)
+
+	ch, unsubscribe := subscribe(t, hub, []string{"admin"})
+	hub.Close()
+
+	_, open := <-ch
+	assert.False(t, open)
+	assert.Equal(t, 0, hub.Clients())
+	unsubscribe() // tidak panic walau channel sudah ditutup Close
+
+	// client baru setelah Close langsung diputus
+	late, _ := subscribe(t, hub, []string{"admin"})
+	_, open = <-late
+	assert.False(t, open)
+	assert.Equal(t, 0, hub.Clients())
+}
+
+func TestHub_UnsubscribeClosesChannel(t *testing.T) {
+	hub := notification.NewHub(nil)
+
+	ch, unsubscribe := subscribe(t, hub, []string{"admin"})
+	unsubscribe()
+	unsubscribe() // aman dipanggil dua kali
+
+	_, open := <-ch
+	assert.False(t, open)
+	assert.Equal(t, 0, hub.Clients())
+
+	// publish setelah unsubscribe tidak panic
+	hub.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+}
@@ -0,0 +1,14 @@
+package notification
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/events", middleware.AuthMiddleware())
+	{
+		routes.GET("/stream", h.Stream)
+	}
+}
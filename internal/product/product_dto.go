@@ -0,0 +1,60 @@
+package product
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"go-mini-erp/internal/shared/pagination"
+)
+
+// SKU disimpan di kolom products.code, UnitPrice di products.sale_price
+
+type CreateProductRequest struct {
+	SKU         string          `json:"sku" binding:"required,max=100"`
+	Name        string          `json:"name" binding:"required,max=255"`
+	Description *string         `json:"description"`
+	CategoryID  *uuid.UUID      `json:"categoryId"`
+	UomID       uuid.UUID       `json:"uomId" binding:"required"`
+	UnitPrice   decimal.Decimal `json:"unitPrice" swaggertype:"string" example:"15000.00"`
+	CostPrice   decimal.Decimal `json:"costPrice" swaggertype:"string" example:"12000.00"`
+	IsActive    *bool           `json:"isActive"`
+}
+
+type UpdateProductRequest struct {
+	Name        string          `json:"name" binding:"required,max=255"`
+	Description *string         `json:"description"`
+	CategoryID  *uuid.UUID      `json:"categoryId"`
+	UnitPrice   decimal.Decimal `json:"unitPrice" swaggertype:"string" example:"15000.00"`
+	CostPrice   decimal.Decimal `json:"costPrice" swaggertype:"string" example:"12000.00"`
+	IsActive    *bool           `json:"isActive" binding:"required"`
+}
+
+type ProductResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	SKU         string          `json:"sku"`
+	Name        string          `json:"name"`
+	Description *string         `json:"description"`
+	CategoryID  *uuid.UUID      `json:"categoryId"`
+	UomID       uuid.UUID       `json:"uomId"`
+	UnitPrice   decimal.Decimal `json:"unitPrice" swaggertype:"string" example:"15000.00"`
+	CostPrice   decimal.Decimal `json:"costPrice" swaggertype:"string" example:"12000.00"`
+	IsActive    bool            `json:"isActive"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	CreatedBy   *uuid.UUID      `json:"createdBy"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	UpdatedBy   *uuid.UUID      `json:"updatedBy"`
+}
+
+// ListProductsResponse is pagination.Response[ProductResponse] (untuk swagger)
+type ListProductsResponse struct {
+	Data []ProductResponse `json:"data"`
+	Meta struct {
+		Total      int64 `json:"total"`
+		Page       int   `json:"page"`
+		PageSize   int   `json:"pageSize"`
+		TotalPages int   `json:"totalPages"`
+	} `json:"meta"`
+	Links *pagination.Links `json:"links,omitempty"`
+}
@@ -0,0 +1,8 @@
+package product
+
+import "errors"
+
+var (
+	ErrProductNotFound = errors.New("product not found")
+	ErrSKUExists       = errors.New("sku already exists")
+)
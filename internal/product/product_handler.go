@@ -0,0 +1,173 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateProduct godoc
+// @Summary Create product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateProductRequest true "Product"
+// @Success 201 {object} ProductResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /products [post]
+func (h *Handler) CreateProduct(c *gin.Context) {
+	var req CreateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.UnitPrice.IsNegative() || req.CostPrice.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "prices must not be negative"})
+		return
+	}
+
+	result, err := h.service.CreateProduct(c.Request.Context(), req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListProducts godoc
+// @Summary List products
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search by name or SKU"
+// @Param page query int false "Page (default 1)"
+// @Param pageSize query int false "Page size (default 10, max 100)"
+// @Param links query bool false "Include next/prev/first/last links"
+// @Success 200 {object} ListProductsResponse
+// @Router /products [get]
+func (h *Handler) ListProducts(c *gin.Context) {
+	params := pagination.ParsePagination(c)
+
+	result, err := h.service.ListProducts(c.Request.Context(), c.Query("search"), params)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	result.AddLinks(c)
+	c.JSON(http.StatusOK, result)
+}
+
+// GetProduct godoc
+// @Summary Get product
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} ProductResponse
+// @Failure 404 {object} map[string]string
+// @Router /products/{id} [get]
+func (h *Handler) GetProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	result, err := h.service.GetProduct(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateProduct godoc
+// @Summary Update product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param request body UpdateProductRequest true "Product"
+// @Success 200 {object} ProductResponse
+// @Failure 404 {object} map[string]string
+// @Router /products/{id} [put]
+func (h *Handler) UpdateProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req UpdateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.UnitPrice.IsNegative() || req.CostPrice.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "prices must not be negative"})
+		return
+	}
+
+	result, err := h.service.UpdateProduct(c.Request.Context(), id, req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteProduct godoc
+// @Summary Delete product (soft delete)
+// @Tags products
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /products/{id} [delete]
+func (h *Handler) DeleteProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	if err := h.service.DeleteProduct(c.Request.Context(), id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrProductNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrSKUExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
@@ -0,0 +1,211 @@
+package product_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/product"
+	"go-mini-erp/internal/product/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+)
+
+func newProductRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := product.NewHandler(mockService)
+
+	router := gin.New()
+	router.POST("/products", handler.CreateProduct)
+	router.GET("/products", handler.ListProducts)
+	return router, mockService
+}
+
+// Test CreateProduct - Success
+func TestCreateProductHandler_Success(t *testing.T) {
+	router, mockService := newProductRouter(t)
+
+	uomID := uuid.New()
+	productID := uuid.New()
+
+	mockService.EXPECT().
+		CreateProduct(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, req product.CreateProductRequest) (*product.ProductResponse, error) {
+			assert.Equal(t, "SKU-001", req.SKU)
+			assert.True(t, decimal.RequireFromString("15000.50").Equal(req.UnitPrice))
+			return &product.ProductResponse{
+				ID:        productID,
+				SKU:       req.SKU,
+				Name:      req.Name,
+				UomID:     req.UomID,
+				UnitPrice: req.UnitPrice,
+				IsActive:  true,
+				CreatedAt: time.Now(),
+			}, nil
+		}).
+		Times(1)
+
+	body := map[string]any{
+		"sku":       "SKU-001",
+		"name":      "Kopi Arabika 250g",
+		"uomId":     uomID,
+		"unitPrice": "15000.50",
+	}
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response product.ProductResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, productID, response.ID)
+	assert.Equal(t, "SKU-001", response.SKU)
+	assert.Contains(t, w.Body.String(), `"unitPrice":"15000.5"`)
+}
+
+// Test CreateProduct - Duplicate SKU
+func TestCreateProductHandler_SKUExists(t *testing.T) {
+	router, mockService := newProductRouter(t)
+
+	mockService.EXPECT().
+		CreateProduct(gomock.Any(), gomock.Any()).
+		Return(nil, product.ErrSKUExists).
+		Times(1)
+
+	body := map[string]any{
+		"sku":       "SKU-001",
+		"name":      "Duplicate",
+		"uomId":     uuid.New(),
+		"unitPrice": 1000,
+	}
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+// Test CreateProduct - Validation
+func TestCreateProductHandler_BadRequest(t *testing.T) {
+	router, _ := newProductRouter(t)
+
+	cases := map[string]map[string]any{
+		"missing sku":    {"name": "No SKU", "uomId": uuid.New()},
+		"negative price": {"sku": "X", "name": "Neg", "uomId": uuid.New(), "unitPrice": "-1"},
+	}
+
+	for name, body := range cases {
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
+
+// Test ListProducts - search + pagination
+func TestListProductsHandler_Success(t *testing.T) {
+	router, mockService := newProductRouter(t)
+
+	params := pagination.NewParams(2, 5)
+	items := []product.ProductResponse{
+		{ID: uuid.New(), SKU: "KOPI-01", Name: "Kopi", UnitPrice: decimal.NewFromInt(15000)},
+	}
+	expected := pagination.NewResponse(items, 6, params)
+
+	mockService.EXPECT().
+		ListProducts(gomock.Any(), "kopi", params).
+		Return(&expected, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/products?search=kopi&page=2&pageSize=5", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response pagination.Response[product.ProductResponse]
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "KOPI-01", response.Data[0].SKU)
+	assert.Equal(t, int64(6), response.Meta.Total)
+	assert.Equal(t, 2, response.Meta.TotalPages)
+}
+
+// Error repo yang bukan sentinel: client dapat 500 generik, rantai error
+// lengkap masuk log beserta request id
+func TestCreateProductHandler_UnexpectedErrorLogged(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	var logs bytes.Buffer
+	middleware.SetErrorLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { middleware.SetErrorLogger(nil) })
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := product.NewHandler(product.NewService(repo))
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	router.POST("/products", handler.CreateProduct)
+
+	repo.EXPECT().GetProductByCode(gomock.Any(), "SKU-001").Return(db.GetProductByCodeRow{}, pgx.ErrNoRows)
+	repo.EXPECT().
+		CreateProduct(gomock.Any(), gomock.Any()).
+		Return(db.CreateProductRow{}, errors.New("connection reset by peer"))
+
+	jsonBody, _ := json.Marshal(map[string]any{
+		"sku":       "SKU-001",
+		"name":      "Kopi Arabika 250g",
+		"uomId":     uuid.New(),
+		"unitPrice": "15000",
+	})
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.RequestIDHeader, "req-500")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"Internal server error"}`, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "connection reset")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "req-500", entry["request_id"])
+	assert.Equal(t, "/products", entry["path"])
+	assert.Equal(t, "create product failed: connection reset by peer", entry["error"])
+	assert.Len(t, entry["chain"], 2)
+}
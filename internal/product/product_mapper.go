@@ -0,0 +1,48 @@
+package product
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func mapProduct(p db.GetProductByIDRow) *ProductResponse {
+	return &ProductResponse{
+		ID:          p.ID,
+		SKU:         p.Code,
+		Name:        p.Name,
+		Description: p.Description,
+		CategoryID:  dbutil.PgUUIDToUUIDPtr(p.CategoryID),
+		UomID:       p.UomID,
+		UnitPrice:   dbutil.PgNumericToDecimal(p.SalePrice),
+		CostPrice:   dbutil.PgNumericToDecimal(p.CostPrice),
+		IsActive:    dbutil.BoolPtrValue(p.IsActive, false),
+		CreatedAt:   dbutil.PgTimeValue(p.CreatedAt),
+		CreatedBy:   dbutil.PgUUIDToUUIDPtr(p.CreatedBy),
+		UpdatedAt:   dbutil.PgTimeValue(p.UpdatedAt),
+		UpdatedBy:   dbutil.PgUUIDToUUIDPtr(p.UpdatedBy),
+	}
+}
+
+func mapProducts(rows []db.ListProductsRow) []ProductResponse {
+	products := make([]ProductResponse, 0, len(rows))
+
+	for _, p := range rows {
+		products = append(products, ProductResponse{
+			ID:          p.ID,
+			SKU:         p.Code,
+			Name:        p.Name,
+			Description: p.Description,
+			CategoryID:  dbutil.PgUUIDToUUIDPtr(p.CategoryID),
+			UomID:       p.UomID,
+			UnitPrice:   dbutil.PgNumericToDecimal(p.SalePrice),
+			CostPrice:   dbutil.PgNumericToDecimal(p.CostPrice),
+			IsActive:    dbutil.BoolPtrValue(p.IsActive, false),
+			CreatedAt:   dbutil.PgTimeValue(p.CreatedAt),
+			CreatedBy:   dbutil.PgUUIDToUUIDPtr(p.CreatedBy),
+			UpdatedAt:   dbutil.PgTimeValue(p.UpdatedAt),
+			UpdatedBy:   dbutil.PgUUIDToUUIDPtr(p.UpdatedBy),
+		})
+	}
+
+	return products
+}
@@ -0,0 +1,59 @@
+package product
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=product_repo.go -destination=mocks/product_repository_mock.go -package=mocks
+
+// Repository defines product data access contract
+type Repository interface {
+	CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.CreateProductRow, error)
+	GetProductByID(ctx context.Context, id uuid.UUID) (db.GetProductByIDRow, error)
+	GetProductByCode(ctx context.Context, code string) (db.GetProductByCodeRow, error)
+	ListProducts(ctx context.Context, arg db.ListProductsParams) ([]db.ListProductsRow, error)
+	CountProducts(ctx context.Context, search *string) (int64, error)
+	UpdateProduct(ctx context.Context, arg db.UpdateProductParams) error
+	SoftDeleteProduct(ctx context.Context, id uuid.UUID) (int64, error)
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates product repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.CreateProductRow, error) {
+	return r.q.CreateProduct(ctx, arg)
+}
+
+func (r *repository) GetProductByID(ctx context.Context, id uuid.UUID) (db.GetProductByIDRow, error) {
+	return r.q.GetProductByID(ctx, id)
+}
+
+func (r *repository) GetProductByCode(ctx context.Context, code string) (db.GetProductByCodeRow, error) {
+	return r.q.GetProductByCode(ctx, code)
+}
+
+func (r *repository) ListProducts(ctx context.Context, arg db.ListProductsParams) ([]db.ListProductsRow, error) {
+	return r.q.ListProducts(ctx, arg)
+}
+
+func (r *repository) CountProducts(ctx context.Context, search *string) (int64, error) {
+	return r.q.CountProducts(ctx, search)
+}
+
+func (r *repository) UpdateProduct(ctx context.Context, arg db.UpdateProductParams) error {
+	return r.q.UpdateProduct(ctx, arg)
+}
+
+func (r *repository) SoftDeleteProduct(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.SoftDeleteProduct(ctx, id)
+}
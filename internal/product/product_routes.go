@@ -0,0 +1,18 @@
+package product
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/products", middleware.AuthMiddleware(), middleware.RequireMenuForPath())
+	{
+		routes.POST("", h.CreateProduct)
+		routes.GET("", h.ListProducts)
+		routes.GET("/:id", h.GetProduct)
+		routes.PUT("/:id", h.UpdateProduct)
+		routes.DELETE("/:id", h.DeleteProduct)
+	}
+}
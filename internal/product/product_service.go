@@ -0,0 +1,152 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+//go:generate mockgen -source=product_service.go -destination=mocks/product_service_mock.go -package=mocks
+
+// Service defines product business logic
+type Service interface {
+	CreateProduct(ctx context.Context, req CreateProductRequest) (*ProductResponse, error)
+	GetProduct(ctx context.Context, id uuid.UUID) (*ProductResponse, error)
+	ListProducts(ctx context.Context, search string, params pagination.Params) (*pagination.Response[ProductResponse], error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*ProductResponse, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates product service
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (*ProductResponse, error) {
+	if _, err := s.repo.GetProductByCode(ctx, req.SKU); err == nil {
+		return nil, ErrSKUExists
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	active := dbutil.BoolPtrValue(req.IsActive, true)
+
+	created, err := s.repo.CreateProduct(ctx, db.CreateProductParams{
+		Code:        req.SKU,
+		Name:        req.Name,
+		Description: req.Description,
+		CategoryID:  dbutil.UUIDPtrToPgUUID(req.CategoryID),
+		UomID:       req.UomID,
+		CostPrice:   dbutil.DecimalToPgNumeric(req.CostPrice),
+		SalePrice:   dbutil.DecimalToPgNumeric(req.UnitPrice),
+		IsActive:    &active,
+		CreatedBy:   dbutil.ActorPgUUID(ctx),
+	})
+	if err != nil {
+		// race dengan request lain yang membuat sku sama
+		if isUniqueViolation(err) {
+			return nil, ErrSKUExists
+		}
+		return nil, fmt.Errorf("create product failed: %w", err)
+	}
+
+	return s.GetProduct(ctx, created.ID)
+}
+
+func (s *service) GetProduct(ctx context.Context, id uuid.UUID) (*ProductResponse, error) {
+	p, err := s.repo.GetProductByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	return mapProduct(p), nil
+}
+
+func (s *service) ListProducts(
+	ctx context.Context,
+	search string,
+	params pagination.Params,
+) (*pagination.Response[ProductResponse], error) {
+	var searchArg *string
+	if search = strings.TrimSpace(search); search != "" {
+		searchArg = &search
+	}
+
+	rows, err := s.repo.ListProducts(ctx, db.ListProductsParams{
+		Search:      searchArg,
+		LimitCount:  params.Limit(),
+		OffsetCount: params.Offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountProducts(ctx, searchArg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := pagination.NewResponse(mapProducts(rows), total, params)
+	return &res, nil
+}
+
+func (s *service) UpdateProduct(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*ProductResponse, error) {
+	// ensure exists, sekaligus pertahankan min/max stock
+	existing, err := s.repo.GetProductByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	err = s.repo.UpdateProduct(ctx, db.UpdateProductParams{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		CategoryID:  dbutil.UUIDPtrToPgUUID(req.CategoryID),
+		CostPrice:   dbutil.DecimalToPgNumeric(req.CostPrice),
+		SalePrice:   dbutil.DecimalToPgNumeric(req.UnitPrice),
+		MinStock:    existing.MinStock,
+		MaxStock:    existing.MaxStock,
+		IsActive:    req.IsActive,
+		UpdatedBy:   dbutil.ActorPgUUID(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProduct(ctx, id)
+}
+
+func (s *service) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	affected, err := s.repo.SoftDeleteProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
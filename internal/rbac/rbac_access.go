@@ -0,0 +1,187 @@
+package rbac
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-mini-erp/internal/shared/clock"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+/*
+MenuAccess adalah pengecekan role_menus untuk middleware.RequireMenu
+dan middleware.RequireMenuForPath.
+
+Menu untuk sebuah route diambil dari pemetaan eksplisit route -> menu
+code, bukan ditebak dari kolom menus.path (path UI, mis.
+/master/products). Route dinormalisasi dulu: prefix API (/api/v1)
+dibuang, jadi /api/v1/products/:id menjadi /products/:id, lalu dicocokkan
+per segmen dari depan; route terpanjang yang cocok menang. Tidak ada
+yang cocok, dua route sama panjang menunjuk menu berbeda, atau menunya
+tidak ada/nonaktif = "" dan request ditolak; tidak pernah jatuh ke menu
+lain.
+
+Daftar menu aktif di-cache selama ttl; Import memanggil Invalidate
+setelah commit supaya perubahan menu dari instance ini langsung berlaku.
+*/
+type MenuAccess struct {
+	repo     Repository
+	prefixes []string
+	routes   []routeMenu
+	ttl      time.Duration
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	active    map[string]bool
+	expiresAt time.Time
+}
+
+// routeMenu is a route prefix split into segments and the menu guarding it
+type routeMenu struct {
+	segments []string
+	code     string
+}
+
+// NewMenuAccess: apiPrefixes = base path tiap versi API yang dibuang dari
+// route sebelum dicocokkan, routes = route resource (tanpa prefix API) ->
+// menu code, ttl = umur cache daftar menu, clk nil = wall clock
+func NewMenuAccess(repo Repository, apiPrefixes []string, routes map[string]string, ttl time.Duration, clk clock.Clock) *MenuAccess {
+	prefixes := make([]string, 0, len(apiPrefixes))
+	for _, p := range apiPrefixes {
+		if p = strings.TrimRight(p, "/"); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	// prefix terpanjang dicoba dulu: /erp/api/v1 sebelum /erp
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	mapped := make([]routeMenu, 0, len(routes))
+	for route, code := range routes {
+		if segments := splitPath(route); len(segments) > 0 {
+			mapped = append(mapped, routeMenu{segments: segments, code: code})
+		}
+	}
+
+	return &MenuAccess{
+		repo:     repo,
+		prefixes: prefixes,
+		routes:   mapped,
+		ttl:      ttl,
+		clock:    clock.OrReal(clk),
+	}
+}
+
+// MenuForPath returns the code of the active menu guarding routePath,
+// "" when no route maps to it, the mapping is ambiguous, or the menu is
+// missing or inactive
+func (a *MenuAccess) MenuForPath(ctx context.Context, routePath string) (string, error) {
+	route := splitPath(a.trimPrefix(routePath))
+
+	code, bestLen, ambiguous := "", 0, false
+	for _, r := range a.routes {
+		if !hasPrefix(route, r.segments) || len(r.segments) < bestLen {
+			continue
+		}
+		if len(r.segments) == bestLen {
+			ambiguous = ambiguous || r.code != code
+			continue
+		}
+		code, bestLen, ambiguous = r.code, len(r.segments), false
+	}
+	if code == "" || ambiguous {
+		return "", nil
+	}
+
+	active, err := a.activeMenus(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !active[code] {
+		return "", nil
+	}
+	return code, nil
+}
+
+// Invalidate drops the cached menu list; request berikutnya memuat ulang
+func (a *MenuAccess) Invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.active = nil
+	a.expiresAt = time.Time{}
+}
+
+// HasMenuAccess reports whether any of roles grants permission on menuCode
+func (a *MenuAccess) HasMenuAccess(ctx context.Context, roles []string, menuCode, permission string) (bool, error) {
+	return a.repo.HasPermission(ctx, db.HasMenuPermissionParams{
+		RoleCodes:  roles,
+		MenuCode:   menuCode,
+		Permission: permission,
+	})
+}
+
+func (a *MenuAccess) activeMenus(ctx context.Context) (map[string]bool, error) {
+	now := a.clock.Now()
+
+	a.mu.Lock()
+	active, fresh := a.active, now.Before(a.expiresAt)
+	a.mu.Unlock()
+	if fresh {
+		return active, nil
+	}
+
+	rows, err := a.repo.ListMenus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active = make(map[string]bool, len(rows))
+	for _, m := range rows {
+		if dbutil.Deref(m.IsActive, true) {
+			active[m.Code] = true
+		}
+	}
+
+	a.mu.Lock()
+	a.active, a.expiresAt = active, now.Add(a.ttl)
+	a.mu.Unlock()
+
+	return active, nil
+}
+
+func (a *MenuAccess) trimPrefix(routePath string) string {
+	for _, p := range a.prefixes {
+		if routePath == p || strings.HasPrefix(routePath, p+"/") {
+			return routePath[len(p):]
+		}
+	}
+	return routePath
+}
+
+// hasPrefix reports whether route starts with all of prefix's segments;
+// parameter route (:id, *path) tidak pernah cocok dengan segmen tetap
+func hasPrefix(route, prefix []string) bool {
+	if len(prefix) > len(route) {
+		return false
+	}
+	for i, segment := range prefix {
+		if route[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,73 @@
+package rbac
+
+// Document is the portable RBAC configuration. Relations are expressed
+// by code so it can be applied to any environment.
+type Document struct {
+	Roles  []RoleEntry  `json:"roles" binding:"dive"`
+	Menus  []MenuEntry  `json:"menus" binding:"dive"`
+	Grants []GrantEntry `json:"grants" binding:"dive"`
+}
+
+type RoleEntry struct {
+	Code        string  `json:"code" binding:"required,max=50"`
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description *string `json:"description"`
+	IsActive    bool    `json:"isActive"`
+}
+
+type MenuEntry struct {
+	Code       string  `json:"code" binding:"required,max=100"`
+	ParentCode *string `json:"parentCode"`
+	Name       string  `json:"name" binding:"required,max=255"`
+	Path       *string `json:"path"`
+	Icon       *string `json:"icon"`
+	SortOrder  int32   `json:"sortOrder"`
+	IsActive   bool    `json:"isActive"`
+}
+
+type GrantEntry struct {
+	RoleCode  string `json:"roleCode" binding:"required"`
+	MenuCode  string `json:"menuCode" binding:"required"`
+	CanCreate bool   `json:"canCreate"`
+	CanRead   bool   `json:"canRead"`
+	CanUpdate bool   `json:"canUpdate"`
+	CanDelete bool   `json:"canDelete"`
+}
+
+// ImportResult counts the entries written (or, on dry-run, that would
+// be written) and reports each entry in document order
+type ImportResult struct {
+	Roles   int          `json:"roles"`
+	Menus   int          `json:"menus"`
+	Grants  int          `json:"grants"`
+	Invalid int          `json:"invalid"`
+	DryRun  bool         `json:"dryRun"`
+	Items   []ImportItem `json:"items"`
+}
+
+// ImportItem is the outcome of one entry; Code grant berbentuk
+// "roleCode/menuCode"
+type ImportItem struct {
+	Kind    string `json:"kind" enums:"role,menu,grant"`
+	Code    string `json:"code"`
+	Outcome string `json:"outcome" enums:"create,update,skip,invalid"`
+	Error   string `json:"error,omitempty"`
+}
+
+// MenuCatalog is every menu plus the permission verbs a grant can give,
+// untuk UI edit role; tidak difilter grant milik pemanggil
+type MenuCatalog struct {
+	Permissions []string    `json:"permissions"`
+	Menus       []MenuEntry `json:"menus"`
+}
+
+// MenuTree is MenuCatalog with menus nested under their parent
+type MenuTree struct {
+	Permissions []string       `json:"permissions"`
+	Menus       []MenuTreeNode `json:"menus"`
+}
+
+type MenuTreeNode struct {
+	MenuEntry
+	Children []MenuTreeNode `json:"children"`
+}
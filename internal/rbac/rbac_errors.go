@@ -0,0 +1,9 @@
+package rbac
+
+import "errors"
+
+var (
+	// ErrInvalidDocument dibungkus oleh semua error validasi import,
+	// detailnya ada di pesan error
+	ErrInvalidDocument = errors.New("invalid rbac document")
+)
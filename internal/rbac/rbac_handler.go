@@ -0,0 +1,120 @@
+package rbac
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ExportRBAC godoc
+// @Summary Export RBAC configuration
+// @Description Returns all roles, menus and role-menu grants as a portable JSON document keyed by code. Admin only.
+// @Tags rbac
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} Document
+// @Failure 403 {object} map[string]string
+// @Router /rbac/export [get]
+func (h *Handler) ExportRBAC(c *gin.Context) {
+	doc, err := h.service.Export(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// ImportRBAC godoc
+// @Summary Import RBAC configuration
+// @Description Upserts roles, menus and grants by code in a single transaction and reports the outcome of every entry. References are validated before anything is written. With dryRun=true the import runs in a transaction that is always rolled back, and invalid entries are reported instead of failing the request. Admin only.
+// @Tags rbac
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body Document true "RBAC document"
+// @Param dryRun query bool false "Preview the outcome without writing anything"
+// @Success 200 {object} ImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /rbac/import [post]
+func (h *Handler) ImportRBAC(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dryRun must be true or false"})
+		return
+	}
+
+	var doc Document
+	if err := c.ShouldBindJSON(&doc); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Import(c.Request.Context(), doc, dryRun)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListMenus godoc
+// @Summary List all menus
+// @Description Every menu regardless of the caller's grants, plus the permission verbs a grant can give. Admin only.
+// @Tags rbac
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MenuCatalog
+// @Failure 403 {object} map[string]string
+// @Router /menus [get]
+func (h *Handler) ListMenus(c *gin.Context) {
+	catalog, err := h.service.ListMenus(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+// GetMenuTree godoc
+// @Summary Get menu tree
+// @Description Same catalog as GET /menus, nested by parent. Admin only.
+// @Tags rbac
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MenuTree
+// @Failure 403 {object} map[string]string
+// @Router /menus/tree [get]
+func (h *Handler) GetMenuTree(c *gin.Context) {
+	menuTree, err := h.service.MenuTree(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, menuTree)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidDocument):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
@@ -0,0 +1,124 @@
+package rbac_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/rbac"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// newMenusRouter mengisi repo dengan menu tanpa grant apa pun: katalog
+// tetap harus lengkap
+func newMenusRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := rbac.NewService(newMemoryRepo(), &fakeTx{}, nil)
+	_, err := svc.Import(context.Background(), rbac.Document{
+		Menus: []rbac.MenuEntry{
+			{Code: "master", Name: "Master Data", Icon: dbutil.Ptr("database"), SortOrder: 1, IsActive: true},
+			{Code: "products", ParentCode: dbutil.Ptr("master"), Name: "Products", Path: dbutil.Ptr("/products"), SortOrder: 2, IsActive: true},
+			{Code: "categories", ParentCode: dbutil.Ptr("master"), Name: "Categories", Path: dbutil.Ptr("/categories"), SortOrder: 1, IsActive: true},
+			{Code: "reports", Name: "Reports", Path: dbutil.Ptr("/reports"), SortOrder: 2, IsActive: false},
+		},
+	}, false)
+	require.NoError(t, err)
+
+	handler := rbac.NewHandler(svc)
+	router := gin.New()
+	router.GET("/menus", handler.ListMenus)
+	router.GET("/menus/tree", handler.GetMenuTree)
+	return router
+}
+
+func getJSON(t *testing.T, router *gin.Engine, path string, v any) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), v))
+}
+
+func TestListMenusHandler_FullCatalog(t *testing.T) {
+	router := newMenusRouter(t)
+
+	var catalog rbac.MenuCatalog
+	getJSON(t, router, "/menus", &catalog)
+
+	assert.Equal(t, []string{"create", "read", "update", "delete"}, catalog.Permissions)
+
+	codes := make([]string, 0, len(catalog.Menus))
+	for _, m := range catalog.Menus {
+		codes = append(codes, m.Code)
+	}
+	// urut sortOrder lalu code; menu nonaktif ikut
+	assert.Equal(t, []string{"categories", "master", "products", "reports"}, codes)
+
+	products := catalog.Menus[2]
+	assert.Equal(t, "Products", products.Name)
+	assert.Equal(t, "master", *products.ParentCode)
+	assert.Equal(t, "/products", *products.Path)
+	assert.Equal(t, "database", *catalog.Menus[1].Icon)
+}
+
+func TestGetMenuTreeHandler(t *testing.T) {
+	router := newMenusRouter(t)
+
+	var menuTree rbac.MenuTree
+	getJSON(t, router, "/menus/tree", &menuTree)
+
+	assert.Len(t, menuTree.Permissions, 4)
+	require.Len(t, menuTree.Menus, 2)
+
+	master := menuTree.Menus[0]
+	assert.Equal(t, "master", master.Code)
+	require.Len(t, master.Children, 2)
+	assert.Equal(t, "categories", master.Children[0].Code)
+	assert.Equal(t, "products", master.Children[1].Code)
+
+	assert.Equal(t, "reports", menuTree.Menus[1].Code)
+	assert.Empty(t, menuTree.Menus[1].Children)
+}
+
+func TestImportRBACHandler_DryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := newMemoryRepo()
+	handler := rbac.NewHandler(rbac.NewService(repo, &rollbackTx{repo: repo}, nil))
+	router := gin.New()
+	router.POST("/rbac/import", handler.ImportRBAC)
+
+	body, err := json.Marshal(sampleDocument())
+	require.NoError(t, err)
+
+	post := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/rbac/import?dryRun=maybe")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = post("/rbac/import?dryRun=true")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result rbac.ImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Roles)
+	assert.Len(t, result.Items, 8)
+	assert.Empty(t, repo.roles)
+}
@@ -0,0 +1,414 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// Kind of an ImportItem
+const (
+	ItemRole  = "role"
+	ItemMenu  = "menu"
+	ItemGrant = "grant"
+)
+
+// Outcome of an ImportItem. Dry-run melaporkan outcome yang sama dengan
+// import sungguhan; bedanya transaksi dry-run selalu di-rollback.
+const (
+	OutcomeCreate  = "create"
+	OutcomeUpdate  = "update"
+	OutcomeSkip    = "skip" // row di database sudah sama persis
+	OutcomeInvalid = "invalid"
+)
+
+// errDryRun membatalkan transaksi dry-run setelah semua upsert berjalan
+var errDryRun = errors.New("rbac: dry run rollback")
+
+/*
+Import upserts every role, menu and grant by code in one transaction
+and reports the outcome of each entry. Rows not in the document are
+kept. Grants may reference roles that already exist in the database
+without listing them under roles; those are resolved with a single
+lookup.
+
+The document is validated up front: a real import with any invalid
+entry writes nothing and fails with ErrInvalidDocument. With dryRun the
+valid entries are still upserted, so database errors surface too, but
+the transaction is always rolled back and invalid entries are reported
+instead of failing the request.
+*/
+func (s *service) Import(ctx context.Context, doc Document, dryRun bool) (*ImportResult, error) {
+	plan := validateDocument(doc)
+	if len(plan.problems) > 0 && !dryRun {
+		return nil, plan.err()
+	}
+
+	existing, err := s.resolveRoles(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.problems) > 0 && !dryRun {
+		return nil, plan.err()
+	}
+
+	err = s.tx.WithTx(ctx, func(q db.Querier) error {
+		if err := plan.apply(ctx, s.repo.WithQuerier(q), existing); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	if !dryRun && s.menus != nil {
+		s.menus.Invalidate()
+	}
+
+	return plan.result(dryRun), nil
+}
+
+// importPlan holds the report slot of every document entry, in the same
+// order as doc, plus what validation found
+type importPlan struct {
+	doc    Document
+	roles  []ImportItem
+	menus  []ImportItem
+	grants []ImportItem
+
+	// menuOrder: index menu valid, parent sebelum child
+	menuOrder []int
+	// external: role code di grants yang tidak didefinisikan di dokumen
+	external []string
+	// problems dirangkum di pesan ErrInvalidDocument
+	problems []string
+}
+
+func newItems(kind string, codes []string) []ImportItem {
+	items := make([]ImportItem, len(codes))
+	for i, code := range codes {
+		items[i] = ImportItem{Kind: kind, Code: code}
+	}
+	return items
+}
+
+func grantCode(g GrantEntry) string {
+	return g.RoleCode + "/" + g.MenuCode
+}
+
+// reject marks items[i] invalid because of its own content
+func (p *importPlan) reject(items []ImportItem, i int, msg string) {
+	p.problems = append(p.problems, msg)
+	markInvalid(items, i, msg)
+}
+
+// markInvalid tidak menambah problems; dipakai untuk entry yang ikut
+// invalid karena entry lain (parent, role yang hilang)
+func markInvalid(items []ImportItem, i int, msg string) {
+	if items[i].Outcome == OutcomeInvalid {
+		return
+	}
+	items[i].Outcome = OutcomeInvalid
+	items[i].Error = msg
+}
+
+func (p *importPlan) err() error {
+	return fmt.Errorf("%w: %s", ErrInvalidDocument, strings.Join(p.problems, "; "))
+}
+
+// validateDocument checks that codes are unique and every parent and
+// grant menu reference points at a valid entry in the same document.
+// Semua masalah dikumpulkan, bukan hanya yang pertama.
+func validateDocument(doc Document) *importPlan {
+	p := &importPlan{doc: doc}
+
+	roleCodes := make([]string, len(doc.Roles))
+	roles := make(map[string]bool, len(doc.Roles))
+	for i, r := range doc.Roles {
+		roleCodes[i] = r.Code
+	}
+	p.roles = newItems(ItemRole, roleCodes)
+	for i, r := range doc.Roles {
+		if roles[r.Code] {
+			p.reject(p.roles, i, fmt.Sprintf("duplicate role code %q", r.Code))
+			continue
+		}
+		roles[r.Code] = true
+	}
+
+	menuCodes := make([]string, len(doc.Menus))
+	byCode := make(map[string]int, len(doc.Menus))
+	for i, m := range doc.Menus {
+		menuCodes[i] = m.Code
+	}
+	p.menus = newItems(ItemMenu, menuCodes)
+	for i, m := range doc.Menus {
+		if _, ok := byCode[m.Code]; ok {
+			p.reject(p.menus, i, fmt.Sprintf("duplicate menu code %q", m.Code))
+			continue
+		}
+		byCode[m.Code] = i
+	}
+
+	p.orderMenus(byCode)
+
+	grantCodes := make([]string, len(doc.Grants))
+	for i, g := range doc.Grants {
+		grantCodes[i] = grantCode(g)
+	}
+	p.grants = newItems(ItemGrant, grantCodes)
+	grants := make(map[[2]string]bool, len(doc.Grants))
+	for i, g := range doc.Grants {
+		key := [2]string{g.RoleCode, g.MenuCode}
+		if grants[key] {
+			p.reject(p.grants, i, fmt.Sprintf("duplicate grant %s/%s", g.RoleCode, g.MenuCode))
+			continue
+		}
+		grants[key] = true
+
+		menu, ok := byCode[g.MenuCode]
+		if !ok {
+			p.reject(p.grants, i, fmt.Sprintf("grant references unknown menu %q", g.MenuCode))
+			continue
+		}
+		if p.menus[menu].Outcome == OutcomeInvalid {
+			markInvalid(p.grants, i, fmt.Sprintf("menu %q is invalid", g.MenuCode))
+			continue
+		}
+
+		if !roles[g.RoleCode] {
+			roles[g.RoleCode] = true
+			p.external = append(p.external, g.RoleCode)
+		}
+	}
+
+	return p
+}
+
+// orderMenus sorts the valid menus parent-first (depth-first, keeping
+// input order). Unknown parents and parent cycles are rejected, children
+// of a rejected menu are invalid too.
+func (p *importPlan) orderMenus(byCode map[string]int) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	menus := p.doc.Menus
+	state := make([]int, len(menus))
+
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case done:
+			return p.menus[i].Outcome != OutcomeInvalid
+		case visiting:
+			p.reject(p.menus, i, fmt.Sprintf("menu %q is its own ancestor", menus[i].Code))
+			return false
+		}
+		state[i] = visiting
+		defer func() { state[i] = done }()
+
+		m := menus[i]
+		if m.ParentCode != nil {
+			parent, ok := byCode[*m.ParentCode]
+			if !ok {
+				p.reject(p.menus, i, fmt.Sprintf("menu %q references unknown parent %q", m.Code, *m.ParentCode))
+				return false
+			}
+			if !visit(parent) {
+				markInvalid(p.menus, i, fmt.Sprintf("parent %q is invalid", *m.ParentCode))
+				return false
+			}
+		}
+
+		// entry yang ditolak di tengah rekursi (cycle) tidak ikut diurutkan
+		if p.menus[i].Outcome == OutcomeInvalid {
+			return false
+		}
+		p.menuOrder = append(p.menuOrder, i)
+		return true
+	}
+
+	for i := range menus {
+		if p.menus[i].Outcome == OutcomeInvalid {
+			continue
+		}
+		visit(i)
+	}
+}
+
+// resolveRoles maps the external grant roles to the ids of existing
+// roles. Grant ke role yang tidak ada ditandai invalid; semua code yang
+// hilang dirangkum dalam satu problem.
+func (s *service) resolveRoles(ctx context.Context, p *importPlan) (map[string]uuid.UUID, error) {
+	ids := make(map[string]uuid.UUID, len(p.external))
+	if len(p.external) == 0 {
+		return ids, nil
+	}
+
+	roles, err := s.repo.GetRolesByCodes(ctx, p.external)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		ids[r.Code] = r.ID
+	}
+
+	missing := make(map[string]bool)
+	var quoted []string
+	for _, code := range p.external {
+		if _, ok := ids[code]; !ok {
+			missing[code] = true
+			quoted = append(quoted, strconv.Quote(code))
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	p.problems = append(p.problems, "grant references unknown role "+strings.Join(quoted, ", "))
+	for i, g := range p.doc.Grants {
+		if missing[g.RoleCode] {
+			markInvalid(p.grants, i, fmt.Sprintf("grant references unknown role %q", g.RoleCode))
+		}
+	}
+
+	return ids, nil
+}
+
+// apply upserts every entry not marked invalid and fills in its outcome
+// by comparing with the rows read at the start of the transaction
+func (p *importPlan) apply(ctx context.Context, repo Repository, existing map[string]uuid.UUID) error {
+	before, err := loadDocument(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	roleIDs := make(map[string]uuid.UUID, len(p.doc.Roles)+len(existing))
+	for code, id := range existing {
+		roleIDs[code] = id
+	}
+	prevRoles := indexBy(before.Roles, func(r RoleEntry) string { return r.Code })
+	for i, r := range p.doc.Roles {
+		if p.roles[i].Outcome == OutcomeInvalid {
+			continue
+		}
+		id, err := repo.UpsertRole(ctx, db.ImportRoleParams{
+			Code:        r.Code,
+			Name:        r.Name,
+			Description: r.Description,
+			IsActive:    dbutil.BoolPtr(r.IsActive),
+		})
+		if err != nil {
+			return err
+		}
+		roleIDs[r.Code] = id
+		p.roles[i].Outcome = outcomeOf(prevRoles, r.Code, r)
+	}
+
+	// menuOrder sudah parent dulu, jadi parent id selalu tersedia
+	menuIDs := make(map[string]uuid.UUID, len(p.menuOrder))
+	prevMenus := indexBy(before.Menus, func(m MenuEntry) string { return m.Code })
+	for _, i := range p.menuOrder {
+		m := p.doc.Menus[i]
+
+		var parentID *uuid.UUID
+		if m.ParentCode != nil {
+			id := menuIDs[*m.ParentCode]
+			parentID = &id
+		}
+
+		id, err := repo.UpsertMenu(ctx, db.ImportMenuParams{
+			ParentID:  dbutil.UUIDPtrToPgUUID(parentID),
+			Code:      m.Code,
+			Name:      m.Name,
+			Path:      m.Path,
+			Icon:      m.Icon,
+			SortOrder: dbutil.Ptr(m.SortOrder),
+			IsActive:  dbutil.BoolPtr(m.IsActive),
+		})
+		if err != nil {
+			return err
+		}
+		menuIDs[m.Code] = id
+		p.menus[i].Outcome = outcomeOf(prevMenus, m.Code, m)
+	}
+
+	prevGrants := indexBy(before.Grants, grantCode)
+	for i, g := range p.doc.Grants {
+		if p.grants[i].Outcome == OutcomeInvalid {
+			continue
+		}
+		if err := repo.UpsertGrant(ctx, db.ImportRoleMenuParams{
+			RoleID:    roleIDs[g.RoleCode],
+			MenuID:    menuIDs[g.MenuCode],
+			CanCreate: dbutil.BoolPtr(g.CanCreate),
+			CanRead:   dbutil.BoolPtr(g.CanRead),
+			CanUpdate: dbutil.BoolPtr(g.CanUpdate),
+			CanDelete: dbutil.BoolPtr(g.CanDelete),
+		}); err != nil {
+			return err
+		}
+		p.grants[i].Outcome = outcomeOf(prevGrants, grantCode(g), g)
+	}
+
+	return nil
+}
+
+func indexBy[T any](entries []T, key func(T) string) map[string]T {
+	m := make(map[string]T, len(entries))
+	for _, e := range entries {
+		m[key(e)] = e
+	}
+	return m
+}
+
+// outcomeOf: entry dibandingkan dengan bentuk export-nya, jadi pointer
+// ikut dibandingkan isinya
+func outcomeOf[T any](prev map[string]T, key string, entry T) string {
+	old, ok := prev[key]
+	switch {
+	case !ok:
+		return OutcomeCreate
+	case reflect.DeepEqual(old, entry):
+		return OutcomeSkip
+	default:
+		return OutcomeUpdate
+	}
+}
+
+func (p *importPlan) result(dryRun bool) *ImportResult {
+	items := make([]ImportItem, 0, len(p.roles)+len(p.menus)+len(p.grants))
+	items = append(items, p.roles...)
+	items = append(items, p.menus...)
+	items = append(items, p.grants...)
+
+	res := &ImportResult{DryRun: dryRun, Items: items}
+	for _, it := range items {
+		if it.Outcome == OutcomeInvalid {
+			res.Invalid++
+			continue
+		}
+		switch it.Kind {
+		case ItemRole:
+			res.Roles++
+		case ItemMenu:
+			res.Menus++
+		case ItemGrant:
+			res.Grants++
+		}
+	}
+	return res
+}
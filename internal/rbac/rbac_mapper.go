@@ -0,0 +1,64 @@
+package rbac
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/tree"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// Default di bawah mengikuti DEFAULT kolom di migration
+
+func mapRoleRows(rows []db.ListRBACRolesRow) []RoleEntry {
+	roles := make([]RoleEntry, 0, len(rows))
+	for _, r := range rows {
+		roles = append(roles, RoleEntry{
+			Code:        r.Code,
+			Name:        r.Name,
+			Description: r.Description,
+			IsActive:    dbutil.BoolPtrValue(r.IsActive, true),
+		})
+	}
+	return roles
+}
+
+func mapMenuRows(rows []db.ListRBACMenusRow) []MenuEntry {
+	menus := make([]MenuEntry, 0, len(rows))
+	for _, m := range rows {
+		menus = append(menus, MenuEntry{
+			Code:       m.Code,
+			ParentCode: m.ParentCode,
+			Name:       m.Name,
+			Path:       m.Path,
+			Icon:       m.Icon,
+			SortOrder:  dbutil.Int32PtrValue(m.SortOrder),
+			IsActive:   dbutil.BoolPtrValue(m.IsActive, true),
+		})
+	}
+	return menus
+}
+
+func mapGrantRows(rows []db.ListRBACGrantsRow) []GrantEntry {
+	grants := make([]GrantEntry, 0, len(rows))
+	for _, g := range rows {
+		grants = append(grants, GrantEntry{
+			RoleCode:  g.RoleCode,
+			MenuCode:  g.MenuCode,
+			CanCreate: dbutil.BoolPtrValue(g.CanCreate, false),
+			CanRead:   dbutil.BoolPtrValue(g.CanRead, true),
+			CanUpdate: dbutil.BoolPtrValue(g.CanUpdate, false),
+			CanDelete: dbutil.BoolPtrValue(g.CanDelete, false),
+		})
+	}
+	return grants
+}
+
+func buildMenuTree(menus []MenuEntry) []MenuTreeNode {
+	roots := tree.Build(menus,
+		func(m MenuEntry) string { return m.Code },
+		func(m MenuEntry) *string { return m.ParentCode },
+	)
+
+	return tree.Map(roots, func(m MenuEntry, children []MenuTreeNode) MenuTreeNode {
+		return MenuTreeNode{MenuEntry: m, Children: children}
+	})
+}
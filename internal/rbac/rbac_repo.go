@@ -0,0 +1,75 @@
+package rbac
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=rbac_repo.go -destination=mocks/rbac_repository_mock.go -package=mocks
+
+type Repository interface {
+	ListRoles(ctx context.Context) ([]db.ListRBACRolesRow, error)
+	ListMenus(ctx context.Context) ([]db.ListRBACMenusRow, error)
+	ListGrants(ctx context.Context) ([]db.ListRBACGrantsRow, error)
+
+	// GetRolesByCodes returns the existing roles among codes
+	GetRolesByCodes(ctx context.Context, codes []string) ([]db.Role, error)
+
+	// Upsert by code, mengembalikan id row
+	UpsertRole(ctx context.Context, arg db.ImportRoleParams) (uuid.UUID, error)
+	UpsertMenu(ctx context.Context, arg db.ImportMenuParams) (uuid.UUID, error)
+	UpsertGrant(ctx context.Context, arg db.ImportRoleMenuParams) error
+
+	// HasPermission: apakah salah satu role punya permission di menu
+	HasPermission(ctx context.Context, arg db.HasMenuPermissionParams) (bool, error)
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
+}
+
+type repository struct {
+	q db.Querier
+}
+
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) ListRoles(ctx context.Context) ([]db.ListRBACRolesRow, error) {
+	return r.q.ListRBACRoles(ctx)
+}
+
+func (r *repository) ListMenus(ctx context.Context) ([]db.ListRBACMenusRow, error) {
+	return r.q.ListRBACMenus(ctx)
+}
+
+func (r *repository) ListGrants(ctx context.Context) ([]db.ListRBACGrantsRow, error) {
+	return r.q.ListRBACGrants(ctx)
+}
+
+func (r *repository) GetRolesByCodes(ctx context.Context, codes []string) ([]db.Role, error) {
+	return r.q.GetRolesByCodes(ctx, codes)
+}
+
+func (r *repository) UpsertRole(ctx context.Context, arg db.ImportRoleParams) (uuid.UUID, error) {
+	return r.q.ImportRole(ctx, arg)
+}
+
+func (r *repository) UpsertMenu(ctx context.Context, arg db.ImportMenuParams) (uuid.UUID, error) {
+	return r.q.ImportMenu(ctx, arg)
+}
+
+func (r *repository) UpsertGrant(ctx context.Context, arg db.ImportRoleMenuParams) error {
+	return r.q.ImportRoleMenu(ctx, arg)
+}
+
+func (r *repository) HasPermission(ctx context.Context, arg db.HasMenuPermissionParams) (bool, error) {
+	return r.q.HasMenuPermission(ctx, arg)
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
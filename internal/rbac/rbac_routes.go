@@ -0,0 +1,23 @@
+package rbac
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/rbac", middleware.AuthMiddleware(), middleware.RequireRole(seed.RoleAdmin))
+	{
+		routes.GET("/export", h.ExportRBAC)
+		routes.POST("/import", h.ImportRBAC)
+	}
+
+	// katalog lengkap untuk UI edit role, bukan menu milik pemanggil
+	menus := r.Group("/menus", middleware.AuthMiddleware(), middleware.RequireRole(seed.RoleAdmin))
+	{
+		menus.GET("", h.ListMenus)
+		menus.GET("/tree", h.GetMenuTree)
+	}
+}
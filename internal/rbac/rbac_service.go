@@ -0,0 +1,107 @@
+package rbac
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+//go:generate mockgen -source=rbac_service.go -destination=mocks/rbac_service_mock.go -package=mocks
+
+type Service interface {
+	Export(ctx context.Context) (*Document, error)
+	Import(ctx context.Context, doc Document, dryRun bool) (*ImportResult, error)
+	ListMenus(ctx context.Context) (*MenuCatalog, error)
+	MenuTree(ctx context.Context) (*MenuTree, error)
+}
+
+// Permissions are the verbs a role_menus grant can give, in the order
+// UI biasanya menampilkannya
+var Permissions = []string{"create", "read", "update", "delete"}
+
+type service struct {
+	repo  Repository
+	tx    database.Transactor
+	menus *MenuAccess
+}
+
+// NewService: menus = cache yang dikosongkan setelah Import, nil = tidak ada
+func NewService(repo Repository, tx database.Transactor, menus *MenuAccess) Service {
+	return &service{repo: repo, tx: tx, menus: menus}
+}
+
+// Export dibaca dalam satu transaksi supaya roles, menus dan grants
+// berasal dari snapshot yang sama
+func (s *service) Export(ctx context.Context) (*Document, error) {
+	var doc *Document
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		doc, err = loadDocument(ctx, s.repo.WithQuerier(q))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func loadDocument(ctx context.Context, repo Repository) (*Document, error) {
+	roles, err := repo.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	menus, err := repo.ListMenus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := repo.ListGrants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		Roles:  mapRoleRows(roles),
+		Menus:  mapMenuRows(menus),
+		Grants: mapGrantRows(grants),
+	}, nil
+}
+
+// ListMenus returns every menu, active or not, ordered by sortOrder then
+// code like the navigation
+func (s *service) ListMenus(ctx context.Context) (*MenuCatalog, error) {
+	menus, err := s.sortedMenus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MenuCatalog{Permissions: Permissions, Menus: menus}, nil
+}
+
+// MenuTree is ListMenus nested by parentCode
+func (s *service) MenuTree(ctx context.Context) (*MenuTree, error) {
+	menus, err := s.sortedMenus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MenuTree{Permissions: Permissions, Menus: buildMenuTree(menus)}, nil
+}
+
+func (s *service) sortedMenus(ctx context.Context) ([]MenuEntry, error) {
+	rows, err := s.repo.ListMenus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// rows sudah urut code; stable sort menjaga code sebagai tie-breaker
+	menus := mapMenuRows(rows)
+	slices.SortStableFunc(menus, func(a, b MenuEntry) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+	return menus, nil
+}
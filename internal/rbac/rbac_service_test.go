@@ -0,0 +1,582 @@
+package rbac_test
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/rbac"
+	"go-mini-erp/internal/shared/clock"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	f.calls++
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+// memoryRepo menyimpan RBAC di map, cukup untuk round-trip export/import
+type memoryRepo struct {
+	roles   map[string]db.ImportRoleParams
+	menus   map[string]db.ImportMenuParams
+	grants  map[[2]uuid.UUID]db.ImportRoleMenuParams
+	roleIDs map[string]uuid.UUID
+	menuIDs map[string]uuid.UUID
+	codes   map[uuid.UUID]string // id role/menu -> code
+	lookups int                  // jumlah panggilan GetRolesByCodes
+	listed  int                  // jumlah panggilan ListMenus
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		roles:   map[string]db.ImportRoleParams{},
+		menus:   map[string]db.ImportMenuParams{},
+		grants:  map[[2]uuid.UUID]db.ImportRoleMenuParams{},
+		roleIDs: map[string]uuid.UUID{},
+		menuIDs: map[string]uuid.UUID{},
+		codes:   map[uuid.UUID]string{},
+	}
+}
+
+// idFor mengembalikan id yang stabil per code, seperti ON CONFLICT (code)
+func (m *memoryRepo) idFor(ids map[string]uuid.UUID, code string) uuid.UUID {
+	id, ok := ids[code]
+	if !ok {
+		id = uuid.New()
+		ids[code] = id
+		m.codes[id] = code
+	}
+	return id
+}
+
+func (m *memoryRepo) ListRoles(ctx context.Context) ([]db.ListRBACRolesRow, error) {
+	rows := []db.ListRBACRolesRow{}
+	for _, r := range m.roles {
+		rows = append(rows, db.ListRBACRolesRow{Code: r.Code, Name: r.Name, Description: r.Description, IsActive: r.IsActive})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Code < rows[j].Code })
+	return rows, nil
+}
+
+func (m *memoryRepo) ListMenus(ctx context.Context) ([]db.ListRBACMenusRow, error) {
+	m.listed++
+	rows := []db.ListRBACMenusRow{}
+	for _, mn := range m.menus {
+		var parent *string
+		if mn.ParentID.Valid {
+			parent = dbutil.Ptr(m.codes[uuid.UUID(mn.ParentID.Bytes)])
+		}
+		rows = append(rows, db.ListRBACMenusRow{
+			Code: mn.Code, ParentCode: parent, Name: mn.Name, Path: mn.Path,
+			Icon: mn.Icon, SortOrder: mn.SortOrder, IsActive: mn.IsActive,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Code < rows[j].Code })
+	return rows, nil
+}
+
+func (m *memoryRepo) ListGrants(ctx context.Context) ([]db.ListRBACGrantsRow, error) {
+	rows := []db.ListRBACGrantsRow{}
+	for _, g := range m.grants {
+		rows = append(rows, db.ListRBACGrantsRow{
+			RoleCode: m.codes[g.RoleID], MenuCode: m.codes[g.MenuID],
+			CanCreate: g.CanCreate, CanRead: g.CanRead, CanUpdate: g.CanUpdate, CanDelete: g.CanDelete,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].RoleCode != rows[j].RoleCode {
+			return rows[i].RoleCode < rows[j].RoleCode
+		}
+		return rows[i].MenuCode < rows[j].MenuCode
+	})
+	return rows, nil
+}
+
+func (m *memoryRepo) GetRolesByCodes(ctx context.Context, codes []string) ([]db.Role, error) {
+	m.lookups++
+	rows := []db.Role{}
+	for _, code := range codes {
+		if r, ok := m.roles[code]; ok {
+			rows = append(rows, db.Role{ID: m.roleIDs[code], Code: r.Code, Name: r.Name})
+		}
+	}
+	return rows, nil
+}
+
+func (m *memoryRepo) UpsertRole(ctx context.Context, arg db.ImportRoleParams) (uuid.UUID, error) {
+	m.roles[arg.Code] = arg
+	return m.idFor(m.roleIDs, arg.Code), nil
+}
+
+func (m *memoryRepo) UpsertMenu(ctx context.Context, arg db.ImportMenuParams) (uuid.UUID, error) {
+	if arg.ParentID.Valid {
+		if _, ok := m.codes[uuid.UUID(arg.ParentID.Bytes)]; !ok {
+			panic("parent menu upserted after child")
+		}
+	}
+	m.menus[arg.Code] = arg
+	return m.idFor(m.menuIDs, arg.Code), nil
+}
+
+func (m *memoryRepo) UpsertGrant(ctx context.Context, arg db.ImportRoleMenuParams) error {
+	m.grants[[2]uuid.UUID{arg.RoleID, arg.MenuID}] = arg
+	return nil
+}
+
+func (m *memoryRepo) HasPermission(ctx context.Context, arg db.HasMenuPermissionParams) (bool, error) {
+	for _, g := range m.grants {
+		if m.codes[g.MenuID] != arg.MenuCode {
+			continue
+		}
+		for _, role := range arg.RoleCodes {
+			if m.codes[g.RoleID] != role {
+				continue
+			}
+			granted := map[string]*bool{
+				"create": g.CanCreate, "read": g.CanRead, "update": g.CanUpdate, "delete": g.CanDelete,
+			}[arg.Permission]
+			if dbutil.Deref(granted, false) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryRepo) WithQuerier(q db.Querier) rbac.Repository {
+	return m
+}
+
+func sampleDocument() rbac.Document {
+	return rbac.Document{
+		Roles: []rbac.RoleEntry{
+			{Code: "admin", Name: "Administrator", IsActive: true},
+			{Code: "sales", Name: "Sales", Description: dbutil.Ptr("Sales team"), IsActive: false},
+		},
+		// child ditulis sebelum parent untuk menguji urutan import
+		Menus: []rbac.MenuEntry{
+			{Code: "customers", ParentCode: dbutil.Ptr("master"), Name: "Customers", Path: dbutil.Ptr("/customers"), SortOrder: 2, IsActive: true},
+			{Code: "dashboard", Name: "Dashboard", Path: dbutil.Ptr("/"), Icon: dbutil.Ptr("home"), SortOrder: 0, IsActive: true},
+			{Code: "master", Name: "Master Data", Icon: dbutil.Ptr("database"), SortOrder: 1, IsActive: true},
+		},
+		Grants: []rbac.GrantEntry{
+			{RoleCode: "admin", MenuCode: "customers", CanCreate: true, CanRead: true, CanUpdate: true, CanDelete: true},
+			{RoleCode: "admin", MenuCode: "dashboard", CanRead: true},
+			{RoleCode: "sales", MenuCode: "customers", CanRead: true, CanUpdate: true},
+		},
+	}
+}
+
+func importInto(t *testing.T, repo *memoryRepo, doc rbac.Document) {
+	t.Helper()
+	svc := rbac.NewService(repo, &fakeTx{}, nil)
+	_, err := svc.Import(context.Background(), doc, false)
+	require.NoError(t, err)
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	source := newMemoryRepo()
+	importInto(t, source, sampleDocument())
+
+	exported, err := rbac.NewService(source, &fakeTx{}, nil).Export(ctx)
+	require.NoError(t, err)
+
+	// lewat JSON seperti di HTTP
+	raw, err := json.Marshal(exported)
+	require.NoError(t, err)
+	var doc rbac.Document
+	require.NoError(t, json.Unmarshal(raw, &doc))
+
+	target := newMemoryRepo()
+	importInto(t, target, doc)
+
+	reExported, err := rbac.NewService(target, &fakeTx{}, nil).Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, exported, reExported)
+
+	// import ulang ke environment yang sama tidak mengubah apa pun
+	importInto(t, target, doc)
+	again, err := rbac.NewService(target, &fakeTx{}, nil).Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, exported, again)
+	assert.Len(t, again.Grants, 3)
+}
+
+func TestImport_ValidationFailsBeforeWriting(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(doc *rbac.Document)
+	}{
+		{"duplicate role", func(doc *rbac.Document) {
+			doc.Roles = append(doc.Roles, rbac.RoleEntry{Code: "admin", Name: "Again"})
+		}},
+		{"duplicate menu", func(doc *rbac.Document) {
+			doc.Menus = append(doc.Menus, rbac.MenuEntry{Code: "master", Name: "Again"})
+		}},
+		{"unknown parent", func(doc *rbac.Document) {
+			doc.Menus[0].ParentCode = dbutil.Ptr("missing")
+		}},
+		{"parent cycle", func(doc *rbac.Document) {
+			doc.Menus[2].ParentCode = dbutil.Ptr("customers")
+		}},
+		{"grant unknown role", func(doc *rbac.Document) {
+			doc.Grants[0].RoleCode = "ghost"
+		}},
+		{"grant unknown menu", func(doc *rbac.Document) {
+			doc.Grants[0].MenuCode = "ghost"
+		}},
+		{"duplicate grant", func(doc *rbac.Document) {
+			doc.Grants = append(doc.Grants, doc.Grants[0])
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := sampleDocument()
+			tt.mutate(&doc)
+
+			repo := newMemoryRepo()
+			tx := &fakeTx{}
+			_, err := rbac.NewService(repo, tx, nil).Import(context.Background(), doc, false)
+
+			assert.ErrorIs(t, err, rbac.ErrInvalidDocument)
+			assert.Equal(t, 0, tx.calls)
+			assert.Empty(t, repo.roles)
+		})
+	}
+}
+
+// grant boleh menunjuk role yang sudah ada di database tanpa menulisnya
+// ulang di roles
+func TestImport_GrantsExistingRole(t *testing.T) {
+	repo := newMemoryRepo()
+	importInto(t, repo, sampleDocument())
+	repo.lookups = 0
+
+	doc := rbac.Document{
+		Menus: []rbac.MenuEntry{{Code: "reports", Name: "Reports", IsActive: true}},
+		Grants: []rbac.GrantEntry{
+			{RoleCode: "admin", MenuCode: "reports", CanRead: true},
+			{RoleCode: "sales", MenuCode: "reports", CanRead: true},
+		},
+	}
+	importInto(t, repo, doc)
+
+	assert.Equal(t, 1, repo.lookups, "roles resolved in one batch")
+	granted := map[string]bool{}
+	for _, g := range repo.grants {
+		if repo.codes[g.MenuID] == "reports" {
+			granted[repo.codes[g.RoleID]] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{"admin": true, "sales": true}, granted)
+}
+
+func TestImport_MissingRolesListed(t *testing.T) {
+	repo := newMemoryRepo()
+	importInto(t, repo, sampleDocument())
+
+	doc := rbac.Document{
+		Menus: []rbac.MenuEntry{{Code: "reports", Name: "Reports", IsActive: true}},
+		Grants: []rbac.GrantEntry{
+			{RoleCode: "sales", MenuCode: "reports", CanRead: true},
+			{RoleCode: "ghost", MenuCode: "reports", CanRead: true},
+			{RoleCode: "phantom", MenuCode: "reports", CanRead: true},
+		},
+	}
+	tx := &fakeTx{}
+	_, err := rbac.NewService(repo, tx, nil).Import(context.Background(), doc, false)
+
+	require.ErrorIs(t, err, rbac.ErrInvalidDocument)
+	assert.Contains(t, err.Error(), `"ghost", "phantom"`)
+	assert.NotContains(t, err.Error(), `"sales"`)
+	assert.Equal(t, 0, tx.calls)
+	assert.NotContains(t, repo.menus, "reports")
+}
+
+// rollbackTx memulihkan isi memoryRepo kalau fn mengembalikan error,
+// seperti ROLLBACK di Postgres
+type rollbackTx struct {
+	repo       *memoryRepo
+	rolledBack bool
+}
+
+func (f *rollbackTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	saved := *f.repo
+	saved.roles = maps.Clone(f.repo.roles)
+	saved.menus = maps.Clone(f.repo.menus)
+	saved.grants = maps.Clone(f.repo.grants)
+	saved.roleIDs = maps.Clone(f.repo.roleIDs)
+	saved.menuIDs = maps.Clone(f.repo.menuIDs)
+	saved.codes = maps.Clone(f.repo.codes)
+
+	err := fn(nil)
+	if err != nil {
+		*f.repo = saved
+		f.rolledBack = true
+	}
+	return err
+}
+
+// changedDocument mengubah satu role, menambah satu menu dan grant, dan
+// membiarkan sisanya sama dengan sampleDocument
+func changedDocument() rbac.Document {
+	doc := sampleDocument()
+	doc.Roles[1].Name = "Sales & Marketing"
+	doc.Menus = append(doc.Menus, rbac.MenuEntry{Code: "reports", Name: "Reports", SortOrder: 3, IsActive: true})
+	doc.Grants = append(doc.Grants, rbac.GrantEntry{RoleCode: "sales", MenuCode: "reports", CanRead: true})
+	return doc
+}
+
+func outcomes(items []rbac.ImportItem) map[string]string {
+	out := map[string]string{}
+	for _, it := range items {
+		out[it.Kind+":"+it.Code] = it.Outcome
+	}
+	return out
+}
+
+func TestImport_DryRunWritesNothing(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo()
+	importInto(t, repo, sampleDocument())
+
+	before, err := rbac.NewService(repo, &fakeTx{}, nil).Export(ctx)
+	require.NoError(t, err)
+
+	tx := &rollbackTx{repo: repo}
+	preview, err := rbac.NewService(repo, tx, nil).Import(ctx, changedDocument(), true)
+	require.NoError(t, err)
+
+	assert.True(t, tx.rolledBack)
+	assert.True(t, preview.DryRun)
+	after, err := rbac.NewService(repo, &fakeTx{}, nil).Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	assert.Equal(t, map[string]string{
+		"role:admin":            rbac.OutcomeSkip,
+		"role:sales":            rbac.OutcomeUpdate,
+		"menu:customers":        rbac.OutcomeSkip,
+		"menu:dashboard":        rbac.OutcomeSkip,
+		"menu:master":           rbac.OutcomeSkip,
+		"menu:reports":          rbac.OutcomeCreate,
+		"grant:admin/customers": rbac.OutcomeSkip,
+		"grant:admin/dashboard": rbac.OutcomeSkip,
+		"grant:sales/customers": rbac.OutcomeSkip,
+		"grant:sales/reports":   rbac.OutcomeCreate,
+	}, outcomes(preview.Items))
+
+	// import sungguhan melaporkan hal yang sama persis
+	applied, err := rbac.NewService(repo, &rollbackTx{repo: repo}, nil).Import(ctx, changedDocument(), false)
+	require.NoError(t, err)
+
+	assert.False(t, applied.DryRun)
+	preview.DryRun = false
+	assert.Equal(t, preview, applied)
+	assert.Contains(t, repo.menus, "reports")
+}
+
+func TestImport_DryRunReportsInvalidEntries(t *testing.T) {
+	ctx := context.Background()
+
+	doc := sampleDocument()
+	doc.Menus[0].ParentCode = dbutil.Ptr("missing") // customers
+	doc.Grants = append(doc.Grants, rbac.GrantEntry{RoleCode: "ghost", MenuCode: "dashboard", CanRead: true})
+
+	repo := newMemoryRepo()
+	preview, err := rbac.NewService(repo, &rollbackTx{repo: repo}, nil).Import(ctx, doc, true)
+	require.NoError(t, err)
+
+	assert.Empty(t, repo.roles)
+	assert.Equal(t, 4, preview.Invalid)
+	assert.Equal(t, map[string]string{
+		"role:admin":            rbac.OutcomeCreate,
+		"role:sales":            rbac.OutcomeCreate,
+		"menu:customers":        rbac.OutcomeInvalid,
+		"menu:dashboard":        rbac.OutcomeCreate,
+		"menu:master":           rbac.OutcomeCreate,
+		"grant:admin/customers": rbac.OutcomeInvalid,
+		"grant:admin/dashboard": rbac.OutcomeCreate,
+		"grant:sales/customers": rbac.OutcomeInvalid,
+		"grant:ghost/dashboard": rbac.OutcomeInvalid,
+	}, outcomes(preview.Items))
+	assert.Equal(t, `menu "customers" references unknown parent "missing"`, preview.Items[2].Error)
+
+	// tanpa dryRun dokumen yang sama ditolak seluruhnya
+	tx := &fakeTx{}
+	_, err = rbac.NewService(repo, tx, nil).Import(ctx, doc, false)
+	assert.ErrorIs(t, err, rbac.ErrInvalidDocument)
+	assert.Equal(t, 0, tx.calls)
+}
+
+// testRouteMenus memetakan route seperti cmd/api, ditambah sub-resource
+// dengan menu sendiri
+var testRouteMenus = map[string]string{
+	"/customers":             "customers",
+	"/settings/users":        "users",
+	"/settings/users/roles":  "user-roles",
+	"/reports/users":         "report-users",
+	"/products":              "products",
+	"/settings/roles":        "roles",
+	"/settings/roles/export": "roles",
+}
+
+func newMenuAccess(repo *memoryRepo) (*rbac.MenuAccess, *clock.FakeClock) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	return rbac.NewMenuAccess(repo, []string{"/api/v1", "/api/v2"}, testRouteMenus, time.Minute, clk), clk
+}
+
+func TestMenuAccess_MenuForPath(t *testing.T) {
+	repo := newMemoryRepo()
+	doc := sampleDocument()
+	doc.Menus = append(doc.Menus,
+		rbac.MenuEntry{Code: "users", Name: "Users", Path: dbutil.Ptr("/settings/users"), IsActive: true},
+		rbac.MenuEntry{Code: "report-users", Name: "User report", Path: dbutil.Ptr("/reports/users"), IsActive: true},
+		rbac.MenuEntry{Code: "user-roles", Name: "User roles", Path: dbutil.Ptr("/settings/users/roles"), IsActive: true},
+		rbac.MenuEntry{Code: "roles", Name: "Roles", Path: dbutil.Ptr("/settings/roles"), IsActive: true},
+	)
+	importInto(t, repo, doc)
+	access, _ := newMenuAccess(repo)
+
+	tests := map[string]string{
+		"/api/v1/customers":           "customers",
+		"/api/v2/customers/:id":       "customers",
+		"/api/v1/customers/:id/notes": "customers",
+		"/api/v1/invoices/:id":        "",
+		// hanya dicocokkan dari depan, bukan segmen mana pun
+		"/api/v1/customers/:id/roles": "customers",
+		"/api/v1/roles/:id":           "",
+		"/api/v1/users":               "",
+		// route terpanjang yang cocok menang
+		"/api/v1/settings/users/:id":       "users",
+		"/api/v1/reports/users":            "report-users",
+		"/api/v1/settings/users/roles/:id": "user-roles",
+		// dua route sama panjang ke menu yang sama tidak ambigu
+		"/api/v1/settings/roles/export": "roles",
+		// menu products tidak ada: ditolak
+		"/api/v1/products": "",
+	}
+
+	for route, want := range tests {
+		got, err := access.MenuForPath(context.Background(), route)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, route)
+	}
+}
+
+func TestMenuAccess_InactiveMenuDeniesWithoutFallback(t *testing.T) {
+	repo := newMemoryRepo()
+	doc := sampleDocument()
+	doc.Menus = append(doc.Menus,
+		rbac.MenuEntry{Code: "products", Name: "Products", Path: dbutil.Ptr("/products"), IsActive: false},
+		rbac.MenuEntry{Code: "report-products", Name: "Product report", Path: dbutil.Ptr("/reports/products"), IsActive: true},
+		rbac.MenuEntry{Code: "inventory-products", Name: "Stock", Path: dbutil.Ptr("/inventory/products"), IsActive: true},
+	)
+	importInto(t, repo, doc)
+
+	routes := map[string]string{
+		"/products":           "products",
+		"/reports/products":   "report-products",
+		"/inventory/products": "inventory-products",
+	}
+	access := rbac.NewMenuAccess(repo, []string{"/api/v1"}, routes, time.Minute, nil)
+
+	// menu products nonaktif: tidak jatuh ke menu lain yang berakhiran products
+	code, err := access.MenuForPath(context.Background(), "/api/v1/products/:id")
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	code, err = access.MenuForPath(context.Background(), "/api/v1/reports/products")
+	require.NoError(t, err)
+	assert.Equal(t, "report-products", code)
+}
+
+func TestMenuAccess_AmbiguousRouteDenied(t *testing.T) {
+	repo := newMemoryRepo()
+	importInto(t, repo, sampleDocument())
+
+	// "/customers" dan "customers/" sama setelah dinormalisasi
+	routes := map[string]string{"/customers": "customers", "customers/": "dashboard"}
+	access := rbac.NewMenuAccess(repo, []string{"/api/v1"}, routes, time.Minute, nil)
+
+	code, err := access.MenuForPath(context.Background(), "/api/v1/customers")
+	require.NoError(t, err)
+	assert.Empty(t, code)
+}
+
+func TestMenuAccess_CachesMenus(t *testing.T) {
+	repo := newMemoryRepo()
+	importInto(t, repo, sampleDocument())
+	access, clk := newMenuAccess(repo)
+	ctx := context.Background()
+
+	// menuFor returns the menu of route and whether ListMenus was queried
+	menuFor := func(route string) (string, bool) {
+		before := repo.listed
+		code, err := access.MenuForPath(ctx, route)
+		require.NoError(t, err)
+		return code, repo.listed > before
+	}
+
+	code, loaded := menuFor("/api/v1/customers")
+	assert.Equal(t, "customers", code)
+	assert.True(t, loaded)
+	_, loaded = menuFor("/api/v1/customers/:id")
+	assert.False(t, loaded)
+
+	// import lewat service yang memegang cache membuangnya saat itu juga
+	svc := rbac.NewService(repo, &fakeTx{}, access)
+	doc := sampleDocument()
+	doc.Menus[0].IsActive = false
+	_, err := svc.Import(ctx, doc, false)
+	require.NoError(t, err)
+
+	code, loaded = menuFor("/api/v1/customers/:id")
+	assert.Empty(t, code)
+	assert.True(t, loaded)
+
+	// dry-run tidak mengubah apa pun, cache tetap dipakai
+	_, err = svc.Import(ctx, sampleDocument(), true)
+	require.NoError(t, err)
+	_, loaded = menuFor("/api/v1/customers")
+	assert.False(t, loaded)
+
+	// perubahan dari instance lain terlihat setelah TTL
+	clk.Advance(time.Minute)
+	_, loaded = menuFor("/api/v1/customers")
+	assert.True(t, loaded)
+}
+
+func TestMenuAccess_HasMenuAccess(t *testing.T) {
+	repo := newMemoryRepo()
+	importInto(t, repo, sampleDocument())
+	access, _ := newMenuAccess(repo)
+	ctx := context.Background()
+
+	allowed, err := access.HasMenuAccess(ctx, []string{"sales"}, "customers", "update")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = access.HasMenuAccess(ctx, []string{"sales"}, "customers", "delete")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
@@ -9,23 +9,80 @@ import (
 type CreateRoleRequest struct {
 	Code        string  `json:"code" binding:"required,min=3,max=50"`
 	Name        string  `json:"name" binding:"required,min=3,max=100"`
-	Description *string `json:"description"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
 }
 
+// UpdateRoleRequest: field yang tidak dikirim (null) tidak diubah
 type UpdateRoleRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=3,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	IsActive    *bool   `json:"isActive"`
+}
+
+type CloneRoleRequest struct {
+	Code        string  `json:"code" binding:"required,min=3,max=50"`
 	Name        string  `json:"name" binding:"required,min=3,max=100"`
-	Description *string `json:"description"`
-	IsActive    *bool   `json:"isActive" binding:"required"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+}
+
+// BulkAssignRequest: uuid tidak valid ditolak saat binding (400)
+type BulkAssignRequest struct {
+	UserIDs []uuid.UUID `json:"userIds" binding:"required,min=1,max=500"`
+}
+
+// Status per user pada hasil bulk assign
+const (
+	AssignStatusAssigned        = "assigned"
+	AssignStatusAlreadyAssigned = "already_assigned"
+	AssignStatusUserNotFound    = "user_not_found"
+)
+
+type BulkAssignItem struct {
+	UserID uuid.UUID `json:"userId"`
+	Status string    `json:"status" example:"assigned"`
+}
+
+type BulkAssignResponse struct {
+	RoleID   uuid.UUID        `json:"roleId"`
+	Assigned int              `json:"assigned"`
+	Skipped  int              `json:"skipped"`
+	Results  []BulkAssignItem `json:"results"`
+}
+
+// RoleAssignedEvent is the payload of webhook.EventRoleAssigned and
+// webhook.EventRoleRemoved
+type RoleAssignedEvent struct {
+	RoleID  uuid.UUID   `json:"roleId"`
+	UserIDs []uuid.UUID `json:"userIds"`
+}
+
+// ListRolesFilter dipakai bersama oleh GET /roles dan GET /roles/export
+type ListRolesFilter struct {
+	Search   string
+	IsActive *bool
 }
 
 type RoleResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Description *string   `json:"description"`
-	IsActive    bool      `json:"isActive"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          uuid.UUID  `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	IsActive    bool       `json:"isActive"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CreatedBy   *uuid.UUID `json:"createdBy"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	UpdatedBy   *uuid.UUID `json:"updatedBy"`
+	Version     int32      `json:"version"`
+}
+
+// RoleUser is one user holding a role, for GET /roles/:id/users
+type RoleUser struct {
+	UserID     uuid.UUID `json:"userId"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	FullName   string    `json:"fullName"`
+	IsActive   bool      `json:"isActive"`
+	AssignedAt time.Time `json:"assignedAt"`
 }
 
 type RoleProfile struct {
@@ -0,0 +1,16 @@
+package role
+
+import "errors"
+
+var (
+	ErrRoleCodeExists = errors.New("role code already exists")
+	ErrRoleNotFound   = errors.New("role not found")
+	ErrRoleInUse      = errors.New("role is still assigned to users")
+	ErrConflict       = errors.New("role was modified by another request")
+	ErrLastAdmin      = errors.New("cannot delete or deactivate the admin role while it grants the only admin access")
+
+	ErrVersionRequired = errors.New("If-Match header with the role ETag is required")
+	ErrInvalidIfMatch  = errors.New("If-Match must be a role ETag")
+
+	ErrInvalidIsActive = errors.New("isActive must be true or false")
+)
@@ -1,10 +1,22 @@
 package role
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/httpcache"
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/util/csvutil"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/validation"
 )
 
 type Handler struct {
@@ -17,14 +29,14 @@ func NewHandler(service Service) *Handler {
 
 func (h *Handler) CreateRole(c *gin.Context) {
 	var req CreateRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := validation.ShouldBindStrictJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
 		return
 	}
 
 	role, err := h.service.CreateRole(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleRoleError(c, err)
 		return
 	}
 
@@ -32,64 +44,255 @@ func (h *Handler) CreateRole(c *gin.Context) {
 }
 
 func (h *Handler) GetRoleByID(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role id"})
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
 		return
 	}
 
 	role, err := h.service.GetRoleByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "role not found"})
+		handleRoleError(c, err)
 		return
 	}
 
+	c.Header("ETag", roleETag(role.Version))
 	c.JSON(http.StatusOK, role)
 }
 
+// ListRoles answers 304 when the filtered list still matches the
+// If-None-Match ETag, or If-Modified-Since when no ETag is sent
 func (h *Handler) ListRoles(c *gin.Context) {
-	roles, err := h.service.ListRoles(c.Request.Context())
+	filter, err := parseListRolesFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleRoleError(c, err)
+		return
+	}
+
+	roles, err := h.service.ListRoles(c.Request.Context(), filter)
+	if err != nil {
+		handleRoleError(c, err)
+		return
+	}
+
+	updatedAt := func(r RoleResponse) time.Time { return r.UpdatedAt }
+	if httpcache.NotModified(c, httpcache.ListETag(roles, updatedAt), httpcache.Latest(roles, updatedAt)) {
 		return
 	}
 	c.JSON(http.StatusOK, roles)
 }
 
+// UpdateRole requires If-Match with the ETag from GetRoleByID so two
+// admins editing the same role cannot overwrite each other.
 func (h *Handler) UpdateRole(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	version, err := parseIfMatch(c.GetHeader("If-Match"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role id"})
+		handleRoleError(c, err)
 		return
 	}
 
 	var req UpdateRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, validation.Response(err))
 		return
 	}
 
-	err = h.service.UpdateRole(c.Request.Context(), id, req)
+	role, err := h.service.UpdateRole(c.Request.Context(), id, version, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleRoleError(c, err)
 		return
 	}
 
+	c.Header("ETag", roleETag(role.Version))
 	c.Status(http.StatusNoContent)
 }
 
 func (h *Handler) DeleteRole(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role id"})
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
 		return
 	}
 
-	err = h.service.DeleteRole(c.Request.Context(), id)
+	err := h.service.DeleteRole(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleRoleError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+func (h *Handler) CloneRole(c *gin.Context) {
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req CloneRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	role, err := h.service.CloneRole(c.Request.Context(), id, req)
+	if err != nil {
+		handleRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// AssignBulk grants the role to many users at once; the 200 body lists
+// the outcome for each user.
+func (h *Handler) AssignBulk(c *gin.Context) {
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req BulkAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	res, err := h.service.AssignBulk(c.Request.Context(), id, req.UserIDs)
+	if err != nil {
+		handleRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+var exportHeader = []string{"code", "name", "description", "is_active", "created_at"}
+
+// ExportRoles streams the filtered role list as CSV. It accepts the
+// same search/isActive query params as ListRoles.
+func (h *Handler) ExportRoles(c *gin.Context) {
+	filter, err := parseListRolesFilter(c)
+	if err != nil {
+		handleRoleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("roles-%s.csv", time.Now().UTC().Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write(exportHeader); err != nil {
+		return
+	}
+
+	err = h.service.ExportRoles(c.Request.Context(), filter, func(batch []RoleResponse) error {
+		for _, r := range batch {
+			if err := csvutil.WriteRecord(w, []string{
+				r.Code,
+				r.Name,
+				dbutil.StringPtrValue(r.Description),
+				strconv.FormatBool(r.IsActive),
+				r.CreatedAt.UTC().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+		}
+
+		w.Flush()
+		c.Writer.Flush()
+		return w.Error()
+	})
+	if err != nil {
+		// header sudah terkirim, tidak bisa ganti status; potong response
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	w.Flush()
+}
+
+// parseListRolesFilter reads ?search= and ?isActive= (true/false)
+func parseListRolesFilter(c *gin.Context) (ListRolesFilter, error) {
+	filter := ListRolesFilter{Search: c.Query("search")}
+
+	if raw := c.Query("isActive"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, ErrInvalidIsActive
+		}
+		filter.IsActive = &active
+	}
+
+	return filter, nil
+}
+
+func roleETag(version int32) string {
+	return strconv.Quote(strconv.FormatInt(int64(version), 10))
+}
+
+// parseIfMatch reads a role ETag ("3" or W/"3"); "*" returns nil = tanpa cek version
+func parseIfMatch(header string) (*int32, error) {
+	header = strings.TrimSpace(header)
+	switch header {
+	case "":
+		return nil, ErrVersionRequired
+	case "*":
+		return nil, nil
+	}
+
+	raw, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return nil, ErrInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidIfMatch
+	}
+
+	v := int32(version)
+	return &v, nil
+}
+
+// ListRoleUsers returns the users holding the role, oldest assignment
+// first, paginated with ?page & ?pageSize
+func (h *Handler) ListRoleUsers(c *gin.Context) {
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListRoleUsers(c.Request.Context(), id, pagination.ParsePagination(c))
+	if err != nil {
+		handleRoleError(c, err)
+		return
+	}
+
+	result.AddLinks(c)
+	c.JSON(http.StatusOK, result)
+}
+
+// handleRoleError maps role errors to HTTP status codes
+func handleRoleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrRoleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrRoleCodeExists), errors.Is(err, ErrRoleInUse), errors.Is(err, ErrConflict),
+		errors.Is(err, ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrVersionRequired):
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidIfMatch), errors.Is(err, ErrInvalidIsActive):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case validation.FieldErrors(err) != nil:
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+	default:
+		middleware.InternalError(c, err)
+	}
+}
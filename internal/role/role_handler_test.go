@@ -0,0 +1,596 @@
+package role_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/role/mocks"
+	"go-mini-erp/internal/seed"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func newRoleRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := role.NewHandler(mockService)
+
+	router := gin.New()
+	router.POST("/roles", handler.CreateRole)
+	router.GET("/roles", handler.ListRoles)
+	router.GET("/roles/export", handler.ExportRoles)
+	router.GET("/roles/:id", handler.GetRoleByID)
+	router.PATCH("/roles/:id", handler.UpdateRole)
+	router.DELETE("/roles/:id", handler.DeleteRole)
+	router.GET("/roles/:id/users", handler.ListRoleUsers)
+	router.POST("/roles/:id/assign-bulk", handler.AssignBulk)
+	return router, mockService
+}
+
+func TestExportRolesHandler_CSV(t *testing.T) {
+	router, mockService := newRoleRouter(t)
+
+	createdAt := time.Date(2026, 1, 15, 8, 30, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		ExportRoles(gomock.Any(), role.ListRolesFilter{Search: "sales", IsActive: dbutil.BoolPtr(true)}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ role.ListRolesFilter, fn func([]role.RoleResponse) error) error {
+			return fn([]role.RoleResponse{{
+				ID:          uuid.New(),
+				Code:        "sales",
+				Name:        "Sales, Team \"A\"",
+				Description: dbutil.Ptr("Handles quotations\nand orders"),
+				IsActive:    true,
+				CreatedAt:   createdAt,
+			}, {
+				ID:          uuid.New(),
+				Code:        "ops",
+				Name:        "=1+1",
+				Description: dbutil.Ptr("@SUM(A1:A9)"),
+				IsActive:    true,
+				CreatedAt:   createdAt,
+			}})
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/roles/export?search=sales&isActive=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=\"roles-")
+
+	lines := strings.SplitN(w.Body.String(), "\n", 2)
+	assert.Equal(t, "code,name,description,is_active,created_at", lines[0])
+	assert.Equal(t,
+		"sales,\"Sales, Team \"\"A\"\"\",\"Handles quotations\nand orders\",true,2026-01-15T08:30:00Z\n"+
+			"ops,'=1+1,'@SUM(A1:A9),true,2026-01-15T08:30:00Z\n",
+		lines[1],
+	)
+}
+
+func TestExportRolesHandler_InvalidFilter(t *testing.T) {
+	router, _ := newRoleRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/roles/export?isActive=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRoleHandlers_MalformedID(t *testing.T) {
+	// mock tanpa EXPECT: service tidak boleh dipanggil
+	router, _ := newRoleRouter(t)
+
+	for _, method := range []string{http.MethodGet, http.MethodPatch, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/roles/not-a-uuid", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, method)
+		assert.JSONEq(t, `{
+			"error": "invalid path parameter",
+			"fields": [{"field": "id", "rule": "uuid", "message": "id must be a valid UUID"}]
+		}`, w.Body.String(), method)
+	}
+}
+
+func TestGetRoleHandler_NotFound(t *testing.T) {
+	router, mockService := newRoleRouter(t)
+
+	id := uuid.New()
+	mockService.EXPECT().GetRoleByID(gomock.Any(), id).Return(nil, role.ErrRoleNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/roles/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": "role not found"}`, w.Body.String())
+}
+
+func TestUpdateRoleHandler_NotFound(t *testing.T) {
+	router, mockService := newRoleRouter(t)
+
+	id := uuid.New()
+	mockService.EXPECT().UpdateRole(gomock.Any(), id, gomock.Any(), gomock.Any()).Return(nil, role.ErrRoleNotFound)
+
+	req := httptest.NewRequest(http.MethodPatch, "/roles/"+id.String(), strings.NewReader(`{"name": "Sales"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRoleHandlers_ErrorStatus(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		expect func(m *mocks.MockService)
+		status int
+	}{
+		{
+			name:   "create code exists",
+			method: http.MethodPost,
+			path:   "/roles",
+			body:   `{"code": "sales", "name": "Sales"}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(nil, role.ErrRoleCodeExists)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "create validation",
+			method: http.MethodPost,
+			path:   "/roles",
+			body:   `{"code": "sales"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "get not found",
+			method: http.MethodGet,
+			path:   "/roles/" + id.String(),
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetRoleByID(gomock.Any(), id).Return(nil, role.ErrRoleNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "list invalid filter",
+			method: http.MethodGet,
+			path:   "/roles?isActive=maybe",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "list db error",
+			method: http.MethodGet,
+			path:   "/roles",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().ListRoles(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "delete in use",
+			method: http.MethodDelete,
+			path:   "/roles/" + id.String(),
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().DeleteRole(gomock.Any(), id).Return(role.ErrRoleInUse)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "delete not found",
+			method: http.MethodDelete,
+			path:   "/roles/" + id.String(),
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().DeleteRole(gomock.Any(), id).Return(role.ErrRoleNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := newRoleRouter(t)
+			if tt.expect != nil {
+				tt.expect(mockService)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusInternalServerError {
+				// detail error db tidak boleh bocor ke client
+				assert.JSONEq(t, `{"error": "Internal server error"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetRoleHandler_ETag(t *testing.T) {
+	router, mockService := newRoleRouter(t)
+
+	id := uuid.New()
+	mockService.EXPECT().GetRoleByID(gomock.Any(), id).Return(&role.RoleResponse{ID: id, Version: 5}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/roles/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+}
+
+func TestUpdateRoleHandler_IfMatch(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		expect  func(m *mocks.MockService)
+		status  int
+		etag    string
+	}{
+		{
+			name:    "current version",
+			ifMatch: `"5"`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().UpdateRole(gomock.Any(), id, dbutil.Ptr(int32(5)), gomock.Any()).
+					Return(&role.RoleResponse{ID: id, Version: 6}, nil)
+			},
+			status: http.StatusNoContent,
+			etag:   `"6"`,
+		},
+		{
+			name:    "stale version",
+			ifMatch: `W/"4"`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().UpdateRole(gomock.Any(), id, dbutil.Ptr(int32(4)), gomock.Any()).
+					Return(nil, role.ErrConflict)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:    "wildcard skips check",
+			ifMatch: "*",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().UpdateRole(gomock.Any(), id, (*int32)(nil), gomock.Any()).
+					Return(&role.RoleResponse{ID: id, Version: 2}, nil)
+			},
+			status: http.StatusNoContent,
+			etag:   `"2"`,
+		},
+		{
+			name:   "missing",
+			status: http.StatusPreconditionRequired,
+		},
+		{
+			name:    "malformed",
+			ifMatch: "abc",
+			status:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := newRoleRouter(t)
+			if tt.expect != nil {
+				tt.expect(mockService)
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/roles/"+id.String(), strings.NewReader(`{"name": "Sales"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.etag, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestAssignBulkHandler_InvalidUserID(t *testing.T) {
+	// mock tanpa EXPECT: satu id rusak menolak seluruh request
+	router, _ := newRoleRouter(t)
+
+	body := `{"userIds": ["` + uuid.NewString() + `", "not-a-uuid"]}`
+	req := httptest.NewRequest(http.MethodPost, "/roles/"+uuid.NewString()+"/assign-bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAssignBulkRoute_AdminOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const secret = "test-secret-for-role-routes"
+	middleware.SetJWTSecret(secret)
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	router := gin.New()
+	role.NewHandler(mockService).RegisterRoutes(router.Group("/api/v1"))
+
+	bearer := func(roles ...string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, middleware.Claims{
+			UserID: uuid.NewString(),
+			Roles:  roles,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	roleID, userID := uuid.New(), uuid.New()
+	path := "/api/v1/roles/" + roleID.String() + "/assign-bulk"
+	body := `{"userIds": ["` + userID.String() + `"]}`
+
+	// pemegang menu roles tanpa role admin tidak boleh menjadikan siapa pun admin
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearer("staff"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	mockService.EXPECT().
+		AssignBulk(gomock.Any(), roleID, []uuid.UUID{userID}).
+		Return(&role.BulkAssignResponse{RoleID: roleID}, nil)
+
+	req = httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearer(seed.RoleAdmin))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListRolesHandler_ConditionalGet(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	roles := []role.RoleResponse{
+		{ID: uuid.New(), Code: "sales", UpdatedAt: updated.Add(-time.Hour)},
+		{ID: uuid.New(), Code: "admin", UpdatedAt: updated},
+	}
+
+	list := func(t *testing.T, current []role.RoleResponse, ifNoneMatch, ifModifiedSince string) *httptest.ResponseRecorder {
+		router, mockService := newRoleRouter(t)
+		mockService.EXPECT().ListRoles(gomock.Any(), gomock.Any()).Return(current, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/roles", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := list(t, roles, "", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	lastModified := first.Header().Get("Last-Modified")
+	require.Equal(t, "Sun, 01 Mar 2026 09:30:00 GMT", lastModified)
+
+	edited := []role.RoleResponse{roles[0], roles[1]}
+	edited[1].UpdatedAt = updated.Add(300 * time.Millisecond)
+
+	tests := []struct {
+		name            string
+		current         []role.RoleResponse
+		ifNoneMatch     string
+		ifModifiedSince string
+		wantStatus      int
+	}{
+		{"unchanged since client copy", roles, etag, "", http.StatusNotModified},
+		{"role hard deleted", roles[:1], etag, "", http.StatusOK},
+		{"second edit within the same second", edited, etag, "", http.StatusOK},
+		{"If-Modified-Since alone, unchanged", roles, "", lastModified, http.StatusNotModified},
+		{"If-Modified-Since alone, role changed after", roles, "", "Sun, 01 Mar 2026 09:00:00 GMT", http.StatusOK},
+		// If-None-Match menang: tanggal yang masih cocok tidak menutupi hard delete
+		{"If-None-Match wins over If-Modified-Since", roles[:1], etag, lastModified, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := list(t, tt.current, tt.ifNoneMatch, tt.ifModifiedSince)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.NotEmpty(t, w.Header().Get("ETag"))
+			assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"code":"sales"`)
+			} else {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetRoleByIDHandler_ContextDone(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        func() (context.Context, context.CancelFunc)
+		wantStatus int
+		wantLevel  string
+	}{
+		{
+			name:       "client disconnected",
+			ctx:        func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			wantStatus: middleware.StatusClientClosedRequest,
+		},
+		{
+			name: "deadline exceeded",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			},
+			wantStatus: http.StatusGatewayTimeout,
+			wantLevel:  "WARN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+
+			var logs bytes.Buffer
+			middleware.SetErrorLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+			t.Cleanup(func() { middleware.SetErrorLogger(nil) })
+
+			// repo menunggu context selesai lalu mengembalikan error
+			// seperti driver pgx
+			repo := mocks.NewMockRepository(ctrl)
+			repo.EXPECT().
+				GetRoleByID(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ uuid.UUID) (db.Role, error) {
+					<-ctx.Done()
+					return db.Role{}, fmt.Errorf("query roles: %w", ctx.Err())
+				})
+
+			router := gin.New()
+			router.GET("/roles/:id", role.NewHandler(role.NewService(repo, &fakeTx{}, nil)).GetRoleByID)
+
+			ctx, cancel := tt.ctx()
+			cancel()
+
+			req := httptest.NewRequest(http.MethodGet, "/roles/"+uuid.NewString(), nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.NotContains(t, logs.String(), `"level":"ERROR"`)
+			if tt.wantLevel != "" {
+				assert.Contains(t, logs.String(), `"level":"`+tt.wantLevel+`"`)
+			}
+		})
+	}
+}
+
+func TestRoleHandlers_DescriptionTooLong(t *testing.T) {
+	id := uuid.New()
+	body := `{"code": "sales", "name": "Sales", "description": "` + strings.Repeat("a", 501) + `"}`
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		ifMatch string
+	}{
+		{"create", http.MethodPost, "/roles", ""},
+		{"update", http.MethodPatch, "/roles/" + id.String(), `"1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newRoleRouter(t)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{
+				"error": "validation failed",
+				"fields": [{"field": "description", "rule": "max", "message": "description must be at most 500 characters"}]
+			}`, w.Body.String())
+		})
+	}
+}
+
+func TestCreateRoleHandler_UnknownField(t *testing.T) {
+	router, _ := newRoleRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/roles",
+		strings.NewReader(`{"code":"sales","name":"Sales","isActve":true}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"error": "unknown field \"isActve\"",
+		"fields": [{"field":"isActve","rule":"unknown","message":"isActve is not a recognized field"}]
+	}`, w.Body.String())
+}
+
+func TestListRoleUsersHandler(t *testing.T) {
+	router, mockService := newRoleRouter(t)
+
+	roleID := uuid.New()
+	userID := uuid.New()
+	assignedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		ListRoleUsers(gomock.Any(), roleID, pagination.Params{Page: 2, PageSize: 5}).
+		Return(&pagination.Response[role.RoleUser]{
+			Data: []role.RoleUser{{
+				UserID: userID, Username: "jane", Email: "jane@example.com",
+				FullName: "Jane", IsActive: true, AssignedAt: assignedAt,
+			}},
+			Meta: pagination.Meta{Total: 6, Page: 2, PageSize: 5, TotalPages: 2},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/roles/"+roleID.String()+"/users?page=2&pageSize=5", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"data": [{
+			"userId": %q, "username": "jane", "email": "jane@example.com",
+			"fullName": "Jane", "isActive": true, "assignedAt": "2026-03-01T09:00:00Z"
+		}],
+		"meta": {"total": 6, "page": 2, "pageSize": 5, "totalPages": 2}
+	}`, userID), w.Body.String())
+}
+
+func TestListRoleUsersHandler_RoleNotFound(t *testing.T) {
+	router, mockService := newRoleRouter(t)
+
+	mockService.EXPECT().ListRoleUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, role.ErrRoleNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/roles/"+uuid.NewString()+"/users", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
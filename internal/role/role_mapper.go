@@ -0,0 +1,76 @@
+package role
+
+import (
+	"strings"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/validation"
+)
+
+func mapRole(r db.Role) *RoleResponse {
+	return &RoleResponse{
+		ID:          r.ID,
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		IsActive:    dbutil.BoolPtrValue(r.IsActive, false),
+		CreatedAt:   dbutil.PgTimeValue(r.CreatedAt),
+		CreatedBy:   dbutil.PgUUIDToUUIDPtr(r.CreatedBy),
+		UpdatedAt:   dbutil.PgTimeValue(r.UpdatedAt),
+		UpdatedBy:   dbutil.PgUUIDToUUIDPtr(r.UpdatedBy),
+		Version:     r.Version,
+	}
+}
+
+func mapRoles(rows []db.Role) []RoleResponse {
+	roles := make([]RoleResponse, 0, len(rows))
+
+	for _, r := range rows {
+		roles = append(roles, *mapRole(r))
+	}
+
+	return roles
+}
+
+// params builds ListRoles query args; limit nil = tanpa batas
+func mapRoleUsers(rows []db.ListUsersByRoleRow) []RoleUser {
+	users := make([]RoleUser, 0, len(rows))
+
+	for _, r := range rows {
+		users = append(users, RoleUser{
+			UserID:     r.ID,
+			Username:   r.Username,
+			Email:      r.Email,
+			FullName:   r.FullName,
+			IsActive:   dbutil.BoolPtrValue(r.IsActive, false),
+			AssignedAt: dbutil.PgTimeValue(r.AssignedAt),
+		})
+	}
+
+	return users
+}
+
+func (f ListRolesFilter) params(limit *int32, offset int32) db.ListRolesParams {
+	var search *string
+	if s := strings.TrimSpace(f.Search); s != "" {
+		search = &s
+	}
+
+	return db.ListRolesParams{
+		Search:      search,
+		IsActive:    f.IsActive,
+		LimitCount:  limit,
+		OffsetCount: offset,
+	}
+}
+
+// sanitizeDescription strips control characters; deskripsi dirender apa
+// adanya di admin UI. nil tetap nil (patch: field tidak diubah).
+func sanitizeDescription(d *string) *string {
+	if d == nil {
+		return nil
+	}
+	clean := validation.StripControl(*d)
+	return &clean
+}
@@ -8,16 +8,37 @@ import (
 	"github.com/google/uuid"
 )
 
-//go:generate mockgen -source=repository.go -destination=mocks/role_repository_mock.go -package=mocks
+//go:generate mockgen -source=role_repo.go -destination=mocks/role_repository_mock.go -package=mocks
 
 type Repository interface {
 	// Basic CRUD
 	CreateRole(ctx context.Context, arg db.CreateRoleParams) (db.Role, error)
 	GetRoleByID(ctx context.Context, id uuid.UUID) (db.Role, error)
 	GetRoleByCode(ctx context.Context, code string) (db.Role, error)
-	ListRoles(ctx context.Context) ([]db.Role, error)
+	// GetRolesByCodes returns the roles found, ordered by code; missing
+	// codes are simply absent
+	GetRolesByCodes(ctx context.Context, codes []string) ([]db.Role, error)
+	ListRoles(ctx context.Context, arg db.ListRolesParams) ([]db.Role, error)
 	UpdateRole(ctx context.Context, arg db.UpdateRoleParams) (db.Role, error)
 	DeleteRole(ctx context.Context, id uuid.UUID) error
+	CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error)
+
+	// Users holding a role, tanpa user yang di-soft delete
+	ListUsersByRole(ctx context.Context, arg db.ListUsersByRoleParams) ([]db.ListUsersByRoleRow, error)
+	CountUsersByRole(ctx context.Context, roleID uuid.UUID) (int64, error)
+
+	// Menu grants
+	CopyRoleMenus(ctx context.Context, sourceRoleID, targetRoleID uuid.UUID) error
+
+	// User assignment
+	ListExistingUserIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	AssignRoleIfMissing(ctx context.Context, arg db.AssignRoleIfMissingParams) (int64, error)
+	CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error)
+	// LockAdminRole serializes last-admin checks; hanya berarti di dalam transaksi
+	LockAdminRole(ctx context.Context) error
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
 }
 
 type repository struct {
@@ -40,8 +61,12 @@ func (r *repository) GetRoleByCode(ctx context.Context, code string) (db.Role, e
 	return r.q.GetRoleByCode(ctx, code)
 }
 
-func (r *repository) ListRoles(ctx context.Context) ([]db.Role, error) {
-	return r.q.ListRoles(ctx)
+func (r *repository) GetRolesByCodes(ctx context.Context, codes []string) ([]db.Role, error) {
+	return r.q.GetRolesByCodes(ctx, codes)
+}
+
+func (r *repository) ListRoles(ctx context.Context, arg db.ListRolesParams) ([]db.Role, error) {
+	return r.q.ListRoles(ctx, arg)
 }
 
 func (r *repository) UpdateRole(ctx context.Context, arg db.UpdateRoleParams) (db.Role, error) {
@@ -51,3 +76,42 @@ func (r *repository) UpdateRole(ctx context.Context, arg db.UpdateRoleParams) (d
 func (r *repository) DeleteRole(ctx context.Context, id uuid.UUID) error {
 	return r.q.DeleteRole(ctx, id)
 }
+
+func (r *repository) CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	return r.q.CountRoleUsers(ctx, roleID)
+}
+
+func (r *repository) ListUsersByRole(ctx context.Context, arg db.ListUsersByRoleParams) ([]db.ListUsersByRoleRow, error) {
+	return r.q.ListUsersByRole(ctx, arg)
+}
+
+func (r *repository) CountUsersByRole(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	return r.q.CountUsersByRole(ctx, roleID)
+}
+
+func (r *repository) CopyRoleMenus(ctx context.Context, sourceRoleID, targetRoleID uuid.UUID) error {
+	return r.q.CopyRoleMenus(ctx, db.CopyRoleMenusParams{
+		SourceRoleID: sourceRoleID,
+		TargetRoleID: targetRoleID,
+	})
+}
+
+func (r *repository) ListExistingUserIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	return r.q.ListExistingUserIDs(ctx, ids)
+}
+
+func (r *repository) AssignRoleIfMissing(ctx context.Context, arg db.AssignRoleIfMissingParams) (int64, error) {
+	return r.q.AssignRoleIfMissing(ctx, arg)
+}
+
+func (r *repository) CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error) {
+	return r.q.CountActiveAdmins(ctx, userID)
+}
+
+func (r *repository) LockAdminRole(ctx context.Context) error {
+	return r.q.LockAdminRole(ctx)
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
@@ -0,0 +1,171 @@
+//go:build integration
+
+package role_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/shared/database/dbtest"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func TestMain(m *testing.M) { dbtest.Main(m) }
+
+func TestRepositoryIntegration_RoleCRUD(t *testing.T) {
+	repo := role.NewRepository(db.New(dbtest.New(t)))
+	ctx := context.Background()
+
+	created, err := repo.CreateRole(ctx, db.CreateRoleParams{
+		Code:        "sales",
+		Name:        "Sales",
+		Description: dbutil.Ptr("Handles quotations"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), created.Version)
+	assert.True(t, dbutil.BoolPtrValue(created.IsActive, false))
+
+	byID, err := repo.GetRoleByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "sales", byID.Code)
+
+	byCode, err := repo.GetRoleByCode(ctx, "sales")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, byCode.ID)
+
+	updated, err := repo.UpdateRole(ctx, db.UpdateRoleParams{
+		ID:          created.ID,
+		Name:        "Sales Team",
+		Description: created.Description,
+		IsActive:    dbutil.BoolPtr(false),
+		Version:     created.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Sales Team", updated.Name)
+	assert.Equal(t, created.Version+1, updated.Version)
+
+	// version lama: tidak ada row yang ter-update
+	_, err = repo.UpdateRole(ctx, db.UpdateRoleParams{
+		ID:       created.ID,
+		Name:     "Stale",
+		IsActive: dbutil.BoolPtr(true),
+		Version:  created.Version,
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	users, err := repo.CountRoleUsers(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Zero(t, users)
+
+	require.NoError(t, repo.DeleteRole(ctx, created.ID))
+
+	_, err = repo.GetRoleByID(ctx, created.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestRepositoryIntegration_ListRoles(t *testing.T) {
+	repo := role.NewRepository(db.New(dbtest.New(t)))
+	ctx := context.Background()
+
+	for _, r := range []db.CreateRoleParams{
+		{Code: "sales", Name: "Sales"},
+		{Code: "sales-lead", Name: "Sales Lead"},
+		{Code: "warehouse", Name: "Warehouse"},
+	} {
+		_, err := repo.CreateRole(ctx, r)
+		require.NoError(t, err)
+	}
+
+	all, err := repo.ListRoles(ctx, db.ListRolesParams{})
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	search := "SALES"
+	matched, err := repo.ListRoles(ctx, db.ListRolesParams{Search: &search})
+	require.NoError(t, err)
+	assert.Len(t, matched, 2)
+
+	limit := int32(1)
+	page, err := repo.ListRoles(ctx, db.ListRolesParams{LimitCount: &limit, OffsetCount: 1})
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+}
+
+func TestRepositoryIntegration_GetRolesByCodes(t *testing.T) {
+	repo := role.NewRepository(db.New(dbtest.New(t)))
+	ctx := context.Background()
+
+	for _, r := range []db.CreateRoleParams{
+		{Code: "warehouse", Name: "Warehouse"},
+		{Code: "sales", Name: "Sales"},
+	} {
+		_, err := repo.CreateRole(ctx, r)
+		require.NoError(t, err)
+	}
+
+	found, err := repo.GetRolesByCodes(ctx, []string{"warehouse", "ghost", "sales"})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "sales", found[0].Code)
+	assert.Equal(t, "warehouse", found[1].Code)
+
+	none, err := repo.GetRolesByCodes(ctx, []string{"ghost"})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestRepositoryIntegration_ListUsersByRole(t *testing.T) {
+	pool := dbtest.New(t)
+	q := db.New(pool)
+	repo := role.NewRepository(q)
+	ctx := context.Background()
+
+	sales, err := repo.CreateRole(ctx, db.CreateRoleParams{Code: "sales", Name: "Sales"})
+	require.NoError(t, err)
+	empty, err := repo.CreateRole(ctx, db.CreateRoleParams{Code: "auditor", Name: "Auditor"})
+	require.NoError(t, err)
+
+	var deletedID uuid.UUID
+	for _, name := range []string{"ann", "bob", "cid"} {
+		u, err := q.CreateUser(ctx, db.CreateUserParams{
+			Username:     name,
+			Email:        name + "@example.com",
+			PasswordHash: "x",
+			FullName:     name,
+			IsActive:     dbutil.BoolPtr(true),
+		})
+		require.NoError(t, err)
+
+		_, err = repo.AssignRoleIfMissing(ctx, db.AssignRoleIfMissingParams{UserID: u.ID, RoleID: sales.ID})
+		require.NoError(t, err)
+		if name == "bob" {
+			deletedID = u.ID
+		}
+	}
+
+	// user yang di-soft delete tidak ikut
+	_, err = pool.Exec(ctx, "UPDATE users SET deleted_at = NOW() WHERE id = $1", deletedID)
+	require.NoError(t, err)
+
+	rows, err := repo.ListUsersByRole(ctx, db.ListUsersByRoleParams{RoleID: sales.ID, LimitCount: 10})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "ann", rows[0].Username)
+	assert.Equal(t, "cid", rows[1].Username)
+	assert.True(t, rows[0].AssignedAt.Valid)
+
+	total, err := repo.CountUsersByRole(ctx, sales.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	none, err := repo.ListUsersByRole(ctx, db.ListUsersByRoleParams{RoleID: empty.ID, LimitCount: 10})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
@@ -1,14 +1,27 @@
 package role
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/middleware"
+)
 
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
-	routes := r.Group("/roles")
+	routes := r.Group("/roles", middleware.AuthMiddleware())
 	{
-		routes.POST("", h.CreateRole)
-		routes.GET("", h.ListRoles)
-		routes.GET("/:id", h.GetRoleByID)
-		routes.PUT("/:id", h.UpdateRole)
-		routes.DELETE("/:id", h.DeleteRole)
+		routes.POST("", middleware.RequireMenu("roles", "create"), h.CreateRole)
+		routes.GET("", middleware.RequireMenu("roles", "read"), h.ListRoles)
+		routes.GET("/export", middleware.RequireMenu("roles", "read"), h.ExportRoles)
+		routes.GET("/:id", middleware.RequireMenu("roles", "read"), h.GetRoleByID)
+		routes.GET("/:id/users", middleware.RequireMenu("roles", "read"), h.ListRoleUsers)
+		routes.PATCH("/:id", middleware.RequireMenu("roles", "update"), h.UpdateRole)
+		// PUT tetap diterima untuk client lama; semantik sama dengan PATCH
+		routes.PUT("/:id", middleware.RequireMenu("roles", "update"), h.UpdateRole)
+		routes.DELETE("/:id", middleware.RequireMenu("roles", "delete"), h.DeleteRole)
+		routes.POST("/:id/clone", middleware.RequireMenu("roles", "create"), h.CloneRole)
+		// role apa pun bisa di-assign, termasuk admin; sama dengan
+		// /users/:id/roles, hanya admin yang boleh
+		routes.POST("/:id/assign-bulk", middleware.RequireMenu("roles", "update"), middleware.RequireRole(seed.RoleAdmin), h.AssignBulk)
 	}
 }
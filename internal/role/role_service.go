@@ -4,118 +4,429 @@ import (
 	"context"
 	"errors"
 
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/database"
 	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/patch"
 	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+//go:generate mockgen -source=role_service.go -destination=mocks/role_service_mock.go -package=mocks
+
 type Service interface {
 	CreateRole(ctx context.Context, req CreateRoleRequest) (*RoleResponse, error)
 	GetRoleByID(ctx context.Context, id uuid.UUID) (*RoleResponse, error)
-	ListRoles(ctx context.Context) ([]RoleResponse, error)
-	UpdateRole(ctx context.Context, id uuid.UUID, req UpdateRoleRequest) error
+	GetRoleByCode(ctx context.Context, code string) (*RoleResponse, error)
+	GetRolesByCodes(ctx context.Context, codes []string) ([]RoleResponse, error)
+	ListRoles(ctx context.Context, filter ListRolesFilter) ([]RoleResponse, error)
+	ExportRoles(ctx context.Context, filter ListRolesFilter, fn func(batch []RoleResponse) error) error
+	UpdateRole(ctx context.Context, id uuid.UUID, version *int32, req UpdateRoleRequest) (*RoleResponse, error)
 	DeleteRole(ctx context.Context, id uuid.UUID) error
+	CloneRole(ctx context.Context, id uuid.UUID, req CloneRoleRequest) (*RoleResponse, error)
+	AssignBulk(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) (*BulkAssignResponse, error)
+	ListRoleUsers(ctx context.Context, id uuid.UUID, params pagination.Params) (*pagination.Response[RoleUser], error)
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	tx     database.Transactor
+	events webhook.Publisher
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+// NewService creates role service; events nil = webhook tidak dikirim
+func NewService(repo Repository, tx database.Transactor, events webhook.Publisher) Service {
+	if events == nil {
+		events = webhook.Nop{}
+	}
+	return &service{repo: repo, tx: tx, events: events}
 }
 
 func (s *service) CreateRole(ctx context.Context, req CreateRoleRequest) (*RoleResponse, error) {
 	// cek apakah code sudah ada
-	_, err := s.repo.GetRoleByCode(ctx, req.Code)
-	if err == nil {
-		return nil, errors.New("role code already exists")
+	if err := checkCodeAvailable(ctx, s.repo, req.Code); err != nil {
+		return nil, err
 	}
-	// err != nil bisa error db lain atau not found
-	// jika error bukan not found return error
-	// Asumsi GetRoleByCode pakai pgx.ErrNoRows pada not found
 
 	// Buat role baru
 	roleRow, err := s.repo.CreateRole(ctx, db.CreateRoleParams{
 		Code:        req.Code,
 		Name:        req.Name,
-		Description: req.Description,
+		Description: sanitizeDescription(req.Description),
+		CreatedBy:   dbutil.ActorPgUUID(ctx),
 	})
 	if err != nil {
-		return nil, err
+		return nil, mapCodeViolation(err)
 	}
 
-	return &RoleResponse{
-		ID:          roleRow.ID,
-		Code:        roleRow.Code,
-		Name:        roleRow.Name,
-		Description: roleRow.Description,
-		IsActive:    dbutil.BoolPtrValue(roleRow.IsActive, false),
-		CreatedAt:   dbutil.PgTimeValue(roleRow.CreatedAt),
-		UpdatedAt:   dbutil.PgTimeValue(roleRow.UpdatedAt),
-	}, nil
+	res := mapRole(roleRow)
+
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleCreated, res))
+
+	return res, nil
 }
 
 func (s *service) GetRoleByID(ctx context.Context, id uuid.UUID) (*RoleResponse, error) {
 	role, err := s.repo.GetRoleByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, mapNotFound(err)
 	}
 
-	return &RoleResponse{
-		ID:          role.ID,
-		Code:        role.Code,
-		Name:        role.Name,
-		Description: role.Description,
-		IsActive:    dbutil.BoolPtrValue(role.IsActive, false),
-		CreatedAt:   dbutil.PgTimeValue(role.CreatedAt),
-		UpdatedAt:   dbutil.PgTimeValue(role.UpdatedAt),
-	}, nil
+	return mapRole(role), nil
 }
 
-func (s *service) ListRoles(ctx context.Context) ([]RoleResponse, error) {
-	roles, err := s.repo.ListRoles(ctx)
+func (s *service) GetRoleByCode(ctx context.Context, code string) (*RoleResponse, error) {
+	role, err := s.repo.GetRoleByCode(ctx, code)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return mapRole(role), nil
+}
+
+// GetRolesByCodes resolves many codes in one query; code yang tidak ada
+// tidak ikut di hasil, bandingkan dengan input untuk mendeteksinya
+func (s *service) GetRolesByCodes(ctx context.Context, codes []string) ([]RoleResponse, error) {
+	if len(codes) == 0 {
+		return []RoleResponse{}, nil
+	}
+
+	roles, err := s.repo.GetRolesByCodes(ctx, codes)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]RoleResponse, 0, len(roles))
-	for _, r := range roles {
-		result = append(result, RoleResponse{
-			ID:          r.ID,
-			Code:        r.Code,
-			Name:        r.Name,
-			Description: r.Description,
-			IsActive:    dbutil.BoolPtrValue(r.IsActive, false),
-			CreatedAt:   dbutil.PgTimeValue(r.CreatedAt),
-			UpdatedAt:   dbutil.PgTimeValue(r.UpdatedAt),
-		})
+	return mapRoles(roles), nil
+}
+
+func (s *service) ListRoles(ctx context.Context, filter ListRolesFilter) ([]RoleResponse, error) {
+	roles, err := s.repo.ListRoles(ctx, filter.params(nil, 0))
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return mapRoles(roles), nil
+}
+
+// exportBatchSize adalah jumlah row per query saat export
+const exportBatchSize int32 = 500
+
+// ExportRoles pages through the filtered role list and hands each
+// batch to fn, so callers can stream output without loading every row.
+func (s *service) ExportRoles(ctx context.Context, filter ListRolesFilter, fn func(batch []RoleResponse) error) error {
+	limit := exportBatchSize
+
+	for offset := int32(0); ; offset += limit {
+		roles, err := s.repo.ListRoles(ctx, filter.params(&limit, offset))
+		if err != nil {
+			return err
+		}
+		if len(roles) == 0 {
+			return nil
+		}
+
+		if err := fn(mapRoles(roles)); err != nil {
+			return err
+		}
+
+		if int32(len(roles)) < limit {
+			return nil
+		}
+	}
 }
 
+// UpdateRole applies req only if the role is still at version; nil
+// version (If-Match: *) skips the check. A stale version is ErrConflict.
 func (s *service) UpdateRole(
 	ctx context.Context,
 	id uuid.UUID,
+	version *int32,
 	req UpdateRoleRequest,
-) error {
+) (*RoleResponse, error) {
+	var updated db.Role
 
-	// ensure exists
-	if _, err := s.repo.GetRoleByID(ctx, id); err != nil {
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		existing, err := repo.GetRoleByID(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		if version != nil && *version != existing.Version {
+			return ErrConflict
+		}
+
+		params := db.UpdateRoleParams{
+			ID:          id,
+			Name:        existing.Name,
+			Description: existing.Description,
+			IsActive:    existing.IsActive,
+			UpdatedBy:   dbutil.ActorPgUUID(ctx),
+			Version:     existing.Version,
+		}
+		req.Description = sanitizeDescription(req.Description)
+		if err := patch.ApplyUpdate(&params, req); err != nil {
+			return err
+		}
+
+		// menonaktifkan role admin mencabut akses semua admin sekaligus,
+		// sama seperti DeleteRole
+		deactivating := dbutil.BoolPtrValue(existing.IsActive, false) && !dbutil.BoolPtrValue(params.IsActive, false)
+		if existing.Code == seed.RoleAdmin && deactivating {
+			if err := repo.LockAdminRole(ctx); err != nil {
+				return err
+			}
+			admins, err := repo.CountActiveAdmins(ctx, uuid.Nil)
+			if err != nil {
+				return err
+			}
+			if admins.Remaining > 0 {
+				return ErrLastAdmin
+			}
+		}
+
+		updated, err = repo.UpdateRole(ctx, params)
+		if err != nil {
+			// role sudah ada di atas; no rows berarti version berubah di antaranya
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrConflict
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapRole(updated)
+	// subscriber (mis. cache role user) membuang data role ini
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleUpdated, res))
+
+	return res, nil
+}
+
+// DeleteRole refuses to delete a role that is still assigned to users;
+// user_roles cascades on delete, so the assignments would silently vanish.
+func (s *service) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	var deleted db.Role
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		existing, err := repo.GetRoleByID(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		deleted = existing
+
+		// menghapus role admin mencabut akses semua admin sekaligus
+		if existing.Code == seed.RoleAdmin {
+			if err := repo.LockAdminRole(ctx); err != nil {
+				return err
+			}
+			admins, err := repo.CountActiveAdmins(ctx, uuid.Nil)
+			if err != nil {
+				return err
+			}
+			if admins.Remaining > 0 {
+				return ErrLastAdmin
+			}
+		}
+
+		users, err := repo.CountRoleUsers(ctx, id)
+		if err != nil {
+			return err
+		}
+		if users > 0 {
+			return ErrRoleInUse
+		}
+
+		return repo.DeleteRole(ctx, id)
+	})
+	if err != nil {
 		return err
 	}
 
-	_, err := s.repo.UpdateRole(ctx, db.UpdateRoleParams{
-		ID:          id,
-		Name:        req.Name,
-		Description: req.Description,
-		IsActive:    req.IsActive,
+	// data = role terakhir sebelum dihapus, supaya subscriber tahu code-nya
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleDeleted, mapRole(deleted)))
+	return nil
+}
+
+// CloneRole copies a role and all of its menu grants under a new code.
+// Role + grants are written in one transaction.
+func (s *service) CloneRole(ctx context.Context, id uuid.UUID, req CloneRoleRequest) (*RoleResponse, error) {
+	var cloned db.Role
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		source, err := repo.GetRoleByID(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+
+		if err := checkCodeAvailable(ctx, repo, req.Code); err != nil {
+			return err
+		}
+
+		description := sanitizeDescription(req.Description)
+		if description == nil {
+			description = source.Description
+		}
+
+		cloned, err = repo.CreateRole(ctx, db.CreateRoleParams{
+			Code:        req.Code,
+			Name:        req.Name,
+			Description: description,
+			CreatedBy:   dbutil.ActorPgUUID(ctx),
+		})
+		if err != nil {
+			return mapCodeViolation(err)
+		}
+
+		return repo.CopyRoleMenus(ctx, source.ID, cloned.ID)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapRole(cloned)
+
+	// publish setelah commit supaya subscriber tidak melihat role yang di-rollback
+	s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleCreated, res))
+
+	return res, nil
+}
+
+// AssignBulk grants the role to every listed user in one transaction.
+// Unknown users and users that already hold the role are reported per
+// user instead of failing the batch.
+func (s *service) AssignBulk(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) (*BulkAssignResponse, error) {
+	res := &BulkAssignResponse{RoleID: id, Results: make([]BulkAssignItem, 0, len(userIDs))}
+	var assigned []uuid.UUID
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		if _, err := repo.GetRoleByID(ctx, id); err != nil {
+			return mapNotFound(err)
+		}
+
+		existing, err := repo.ListExistingUserIDs(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		known := make(map[uuid.UUID]bool, len(existing))
+		for _, uid := range existing {
+			known[uid] = true
+		}
+
+		seen := make(map[uuid.UUID]bool, len(userIDs))
+		for _, uid := range userIDs {
+			// id yang sama dua kali di request cukup diproses sekali
+			if seen[uid] {
+				continue
+			}
+			seen[uid] = true
+
+			status := AssignStatusUserNotFound
+			if known[uid] {
+				rows, err := repo.AssignRoleIfMissing(ctx, db.AssignRoleIfMissingParams{
+					UserID:     uid,
+					RoleID:     id,
+					AssignedBy: dbutil.ActorPgUUID(ctx),
+				})
+				if err != nil {
+					return err
+				}
+
+				status = AssignStatusAlreadyAssigned
+				if rows > 0 {
+					status = AssignStatusAssigned
+					assigned = append(assigned, uid)
+				}
+			}
+
+			res.Results = append(res.Results, BulkAssignItem{UserID: uid, Status: status})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Assigned = len(assigned)
+	res.Skipped = len(res.Results) - res.Assigned
+
+	// subscriber (mis. cache permission) membuang data user yang berubah
+	if len(assigned) > 0 {
+		s.events.Publish(ctx, webhook.NewEvent(webhook.EventRoleAssigned, RoleAssignedEvent{
+			RoleID:  id,
+			UserIDs: assigned,
+		}))
+	}
+
+	return res, nil
+}
+
+// ListRoleUsers pages through the users holding role id. Role yang tidak
+// ada = ErrRoleNotFound, role tanpa user = halaman kosong.
+func (s *service) ListRoleUsers(ctx context.Context, id uuid.UUID, params pagination.Params) (*pagination.Response[RoleUser], error) {
+	if _, err := s.repo.GetRoleByID(ctx, id); err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	rows, err := s.repo.ListUsersByRole(ctx, db.ListUsersByRoleParams{
+		RoleID:      id,
+		LimitCount:  params.Limit(),
+		OffsetCount: params.Offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountUsersByRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := pagination.NewResponse(mapRoleUsers(rows), total, params)
+	return &res, nil
+}
+
+// checkCodeAvailable returns ErrRoleCodeExists when code is taken; error
+// lookup selain not found dikembalikan apa adanya
+func checkCodeAvailable(ctx context.Context, repo Repository, code string) error {
+	_, err := repo.GetRoleByCode(ctx, code)
+	if err == nil {
+		return ErrRoleCodeExists
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
 	return err
 }
 
-func (s *service) DeleteRole(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteRole(ctx, id)
+// mapCodeViolation translates the UNIQUE(code) violation on roles, dari
+// create lain yang lolos checkCodeAvailable bersamaan, into ErrRoleCodeExists
+func mapCodeViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "roles_code_key" {
+		return ErrRoleCodeExists
+	}
+	return err
+}
+
+// mapNotFound translates pgx.ErrNoRows from a role lookup into ErrRoleNotFound
+func mapNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrRoleNotFound
+	}
+	return err
 }
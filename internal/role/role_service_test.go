@@ -0,0 +1,678 @@
+package role_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/role"
+	"go-mini-erp/internal/role/mocks"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	f.calls++
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+// publisherStub mencatat event yang di-publish
+type publisherStub struct {
+	events []webhook.Event
+}
+
+func (p *publisherStub) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestCloneRole_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := role.NewService(repo, tx, nil)
+
+	ctx := context.Background()
+	sourceID := uuid.New()
+	clonedID := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(ctx, sourceID).Return(db.Role{
+		ID:          sourceID,
+		Code:        "sales",
+		Name:        "Sales",
+		Description: dbutil.Ptr("Sales team"),
+	}, nil)
+	repo.EXPECT().GetRoleByCode(ctx, "sales_lead").Return(db.Role{}, pgx.ErrNoRows)
+	repo.EXPECT().CreateRole(ctx, db.CreateRoleParams{
+		Code:        "sales_lead",
+		Name:        "Sales Lead",
+		Description: dbutil.Ptr("Sales team"),
+	}).Return(db.Role{ID: clonedID, Code: "sales_lead", Name: "Sales Lead", IsActive: dbutil.BoolPtr(true)}, nil)
+	repo.EXPECT().CopyRoleMenus(ctx, sourceID, clonedID).Return(nil)
+
+	result, err := service.CloneRole(ctx, sourceID, role.CloneRoleRequest{
+		Code: "sales_lead",
+		Name: "Sales Lead",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, clonedID, result.ID)
+	assert.True(t, result.IsActive)
+	assert.Equal(t, 1, tx.calls)
+	assert.False(t, tx.rolledBack)
+}
+
+func TestCloneRole_CopyFailsRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := role.NewService(repo, tx, nil)
+
+	sourceID := uuid.New()
+	errCopy := errors.New("copy failed")
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), sourceID).Return(db.Role{ID: sourceID}, nil)
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "copy").Return(db.Role{}, pgx.ErrNoRows)
+	repo.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(db.Role{ID: uuid.New()}, nil)
+	repo.EXPECT().CopyRoleMenus(gomock.Any(), sourceID, gomock.Any()).Return(errCopy)
+
+	result, err := service.CloneRole(context.Background(), sourceID, role.CloneRoleRequest{
+		Code: "copy",
+		Name: "Copy",
+	})
+
+	assert.ErrorIs(t, err, errCopy)
+	assert.Nil(t, result)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestCloneRole_CodeExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	sourceID := uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), sourceID).Return(db.Role{ID: sourceID}, nil)
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "admin").Return(db.Role{ID: uuid.New()}, nil)
+
+	result, err := service.CloneRole(context.Background(), sourceID, role.CloneRoleRequest{
+		Code: "admin",
+		Name: "Admin",
+	})
+
+	assert.ErrorIs(t, err, role.ErrRoleCodeExists)
+	assert.Nil(t, result)
+}
+
+func TestCreateRole_CodeErrors(t *testing.T) {
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		setup   func(repo *mocks.MockRepository)
+		wantErr error
+	}{
+		{"lookup fails", func(repo *mocks.MockRepository) {
+			repo.EXPECT().GetRoleByCode(gomock.Any(), "sales").Return(db.Role{}, dbErr)
+		}, dbErr},
+		// create lain dengan code yang sama menang di antara cek dan insert
+		{"concurrent create", func(repo *mocks.MockRepository) {
+			repo.EXPECT().GetRoleByCode(gomock.Any(), "sales").Return(db.Role{}, pgx.ErrNoRows)
+			repo.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(db.Role{},
+				&pgconn.PgError{Code: "23505", ConstraintName: "roles_code_key"})
+		}, role.ErrRoleCodeExists},
+	}
+
+	for _, tt := range tests {
+		t.Run("create "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockRepository(ctrl)
+			service := role.NewService(repo, &fakeTx{}, nil)
+			tt.setup(repo)
+
+			res, err := service.CreateRole(context.Background(), role.CreateRoleRequest{Code: "sales", Name: "Sales"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, res)
+		})
+
+		t.Run("clone "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockRepository(ctrl)
+			tx := &fakeTx{}
+			service := role.NewService(repo, tx, nil)
+
+			sourceID := uuid.New()
+			repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+			repo.EXPECT().GetRoleByID(gomock.Any(), sourceID).Return(db.Role{ID: sourceID}, nil)
+			tt.setup(repo)
+
+			res, err := service.CloneRole(context.Background(), sourceID, role.CloneRoleRequest{Code: "sales", Name: "Sales"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, res)
+			assert.True(t, tx.rolledBack)
+		})
+	}
+}
+
+func TestExportRoles_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	full := make([]db.Role, 500)
+	for i := range full {
+		full[i] = db.Role{ID: uuid.New(), Code: "r"}
+	}
+
+	limit := int32(500)
+	gomock.InOrder(
+		repo.EXPECT().ListRoles(gomock.Any(), db.ListRolesParams{LimitCount: &limit, OffsetCount: 0}).Return(full, nil),
+		repo.EXPECT().ListRoles(gomock.Any(), db.ListRolesParams{LimitCount: &limit, OffsetCount: 500}).Return(full[:1], nil),
+	)
+
+	var batches []int
+	err := service.ExportRoles(context.Background(), role.ListRolesFilter{Search: "  "}, func(batch []role.RoleResponse) error {
+		batches = append(batches, len(batch))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{500, 1}, batches)
+}
+
+func TestUpdateRole_PartialKeepsOtherFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{
+		ID:          id,
+		Code:        "sales",
+		Name:        "Sales",
+		Description: dbutil.Ptr("Handles quotations"),
+		IsActive:    dbutil.BoolPtr(true),
+		Version:     2,
+	}, nil)
+	repo.EXPECT().UpdateRole(gomock.Any(), db.UpdateRoleParams{
+		ID:          id,
+		Name:        "Sales",
+		Description: dbutil.Ptr("Handles quotations"),
+		IsActive:    dbutil.BoolPtr(false),
+		Version:     2,
+	}).Return(db.Role{ID: id, Version: 3}, nil)
+
+	res, err := service.UpdateRole(context.Background(), id, dbutil.Ptr(int32(2)), role.UpdateRoleRequest{IsActive: dbutil.BoolPtr(false)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), res.Version)
+}
+
+func TestUpdateRole_StaleVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Name: "Sales", Version: 4}, nil)
+	// UpdateRole tidak boleh dipanggil
+
+	_, err := service.UpdateRole(context.Background(), id, dbutil.Ptr(int32(3)), role.UpdateRoleRequest{Name: dbutil.Ptr("Sales Team")})
+
+	assert.ErrorIs(t, err, role.ErrConflict)
+}
+
+func TestUpdateRole_ConcurrentUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Name: "Sales", Version: 4}, nil)
+	// request lain menaikkan version di antara GetRoleByID dan UpdateRole
+	repo.EXPECT().UpdateRole(gomock.Any(), gomock.Any()).Return(db.Role{}, pgx.ErrNoRows)
+
+	_, err := service.UpdateRole(context.Background(), id, dbutil.Ptr(int32(4)), role.UpdateRoleRequest{Name: dbutil.Ptr("Sales Team")})
+
+	assert.ErrorIs(t, err, role.ErrConflict)
+}
+
+func TestGetRoleByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any()).Return(db.Role{}, pgx.ErrNoRows)
+
+	result, err := service.GetRoleByID(context.Background(), uuid.New())
+
+	assert.ErrorIs(t, err, role.ErrRoleNotFound)
+	assert.Nil(t, result)
+}
+
+func TestUpdateRole_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any()).Return(db.Role{}, pgx.ErrNoRows)
+
+	_, err := service.UpdateRole(context.Background(), uuid.New(), nil, role.UpdateRoleRequest{Name: dbutil.Ptr("Sales")})
+
+	assert.ErrorIs(t, err, role.ErrRoleNotFound)
+}
+
+func TestDeleteRole_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := role.NewService(repo, tx, nil)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id}, nil)
+	repo.EXPECT().CountRoleUsers(gomock.Any(), id).Return(int64(3), nil)
+	// DeleteRole tidak boleh dipanggil
+
+	err := service.DeleteRole(context.Background(), id)
+
+	assert.ErrorIs(t, err, role.ErrRoleInUse)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestDeleteRole_Unassigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id}, nil)
+	repo.EXPECT().CountRoleUsers(gomock.Any(), id).Return(int64(0), nil)
+	repo.EXPECT().DeleteRole(gomock.Any(), id).Return(nil)
+
+	assert.NoError(t, service.DeleteRole(context.Background(), id))
+}
+
+func TestRoleLifecycle_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := role.NewService(repo, &fakeTx{}, events)
+	ctx := context.Background()
+	id := uuid.New()
+
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "sales").Return(db.Role{}, pgx.ErrNoRows)
+	repo.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(db.Role{ID: id, Code: "sales", Name: "Sales", Version: 1}, nil)
+	_, err := service.CreateRole(ctx, role.CreateRoleRequest{Code: "sales", Name: "Sales"})
+	assert.NoError(t, err)
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Code: "sales", Name: "Sales", Version: 1}, nil)
+	repo.EXPECT().UpdateRole(gomock.Any(), gomock.Any()).Return(db.Role{ID: id, Code: "sales", Name: "Sales Team", Version: 2}, nil)
+	_, err = service.UpdateRole(ctx, id, nil, role.UpdateRoleRequest{Name: dbutil.Ptr("Sales Team")})
+	assert.NoError(t, err)
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Code: "sales", Name: "Sales Team", Version: 2}, nil)
+	repo.EXPECT().CountRoleUsers(gomock.Any(), id).Return(int64(0), nil)
+	repo.EXPECT().DeleteRole(gomock.Any(), id).Return(nil)
+	assert.NoError(t, service.DeleteRole(ctx, id))
+
+	want := []string{webhook.EventRoleCreated, webhook.EventRoleUpdated, webhook.EventRoleDeleted}
+	if assert.Len(t, events.events, len(want)) {
+		for i, e := range events.events {
+			assert.Equal(t, want[i], e.Type)
+			if data, ok := e.Data.(*role.RoleResponse); assert.True(t, ok, e.Type) {
+				assert.Equal(t, id, data.ID, e.Type)
+			}
+		}
+	}
+}
+
+func TestDeleteRole_FailureDoesNotPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := role.NewService(repo, &fakeTx{}, events)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Code: "sales"}, nil)
+	repo.EXPECT().CountRoleUsers(gomock.Any(), id).Return(int64(2), nil)
+
+	assert.ErrorIs(t, service.DeleteRole(context.Background(), id), role.ErrRoleInUse)
+	assert.Empty(t, events.events)
+}
+
+func TestCreateRole_RecordsActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	actor := uuid.New()
+	ctx := dbutil.WithActorID(context.Background(), actor)
+
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "sales").Return(db.Role{}, pgx.ErrNoRows)
+	repo.EXPECT().
+		CreateRole(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateRoleParams) (db.Role, error) {
+			assert.Equal(t, dbutil.UUIDPtrToPgUUID(&actor), arg.CreatedBy)
+			return db.Role{ID: uuid.New(), Code: arg.Code, Name: arg.Name, CreatedBy: arg.CreatedBy, UpdatedBy: arg.CreatedBy}, nil
+		})
+
+	res, err := service.CreateRole(ctx, role.CreateRoleRequest{Code: "sales", Name: "Sales"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &actor, res.CreatedBy)
+	assert.Equal(t, &actor, res.UpdatedBy)
+}
+
+func TestAssignBulk_MixedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	service := role.NewService(repo, &fakeTx{}, events)
+
+	roleID := uuid.New()
+	newUser, assignedUser, ghost := uuid.New(), uuid.New(), uuid.New()
+	userIDs := []uuid.UUID{newUser, assignedUser, ghost, newUser}
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), roleID).Return(db.Role{ID: roleID}, nil)
+	repo.EXPECT().ListExistingUserIDs(gomock.Any(), userIDs).Return([]uuid.UUID{newUser, assignedUser}, nil)
+	repo.EXPECT().
+		AssignRoleIfMissing(gomock.Any(), db.AssignRoleIfMissingParams{UserID: newUser, RoleID: roleID}).
+		Return(int64(1), nil)
+	repo.EXPECT().
+		AssignRoleIfMissing(gomock.Any(), db.AssignRoleIfMissingParams{UserID: assignedUser, RoleID: roleID}).
+		Return(int64(0), nil)
+
+	res, err := service.AssignBulk(context.Background(), roleID, userIDs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Assigned)
+	assert.Equal(t, 2, res.Skipped)
+	assert.Equal(t, []role.BulkAssignItem{
+		{UserID: newUser, Status: role.AssignStatusAssigned},
+		{UserID: assignedUser, Status: role.AssignStatusAlreadyAssigned},
+		{UserID: ghost, Status: role.AssignStatusUserNotFound},
+	}, res.Results)
+
+	// hanya user yang benar-benar berubah yang dikirim ke subscriber
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, webhook.EventRoleAssigned, events.events[0].Type)
+		assert.Equal(t, role.RoleAssignedEvent{RoleID: roleID, UserIDs: []uuid.UUID{newUser}}, events.events[0].Data)
+	}
+}
+
+func TestAssignBulk_InsertFailsRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	events := &publisherStub{}
+	service := role.NewService(repo, tx, events)
+
+	roleID, userID := uuid.New(), uuid.New()
+
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), roleID).Return(db.Role{ID: roleID}, nil)
+	repo.EXPECT().ListExistingUserIDs(gomock.Any(), gomock.Any()).Return([]uuid.UUID{userID}, nil)
+	repo.EXPECT().AssignRoleIfMissing(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("connection reset"))
+
+	res, err := service.AssignBulk(context.Background(), roleID, []uuid.UUID{userID})
+
+	assert.Error(t, err)
+	assert.Nil(t, res)
+	assert.True(t, tx.rolledBack)
+	assert.Empty(t, events.events)
+}
+
+func TestDeleteRole_AdminRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	service := role.NewService(repo, tx, nil)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Code: "admin"}, nil)
+	gomock.InOrder(
+		repo.EXPECT().LockAdminRole(gomock.Any()).Return(nil),
+		repo.EXPECT().CountActiveAdmins(gomock.Any(), uuid.Nil).Return(db.CountActiveAdminsRow{Remaining: 1}, nil),
+	)
+	// DeleteRole tidak boleh dipanggil
+
+	err := service.DeleteRole(context.Background(), id)
+
+	assert.ErrorIs(t, err, role.ErrLastAdmin)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestUpdateRole_DeactivateAdminRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	events := &publisherStub{}
+	service := role.NewService(repo, tx, events)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{
+		ID:       id,
+		Code:     "admin",
+		Name:     "Administrator",
+		IsActive: dbutil.BoolPtr(true),
+		Version:  1,
+	}, nil)
+	// lock diambil dulu supaya dua request paralel tidak sama-sama lolos hitungan
+	gomock.InOrder(
+		repo.EXPECT().LockAdminRole(gomock.Any()).Return(nil),
+		repo.EXPECT().CountActiveAdmins(gomock.Any(), uuid.Nil).Return(db.CountActiveAdminsRow{Remaining: 1}, nil),
+	)
+	// UpdateRole tidak boleh dipanggil
+
+	_, err := service.UpdateRole(context.Background(), id, nil, role.UpdateRoleRequest{IsActive: dbutil.BoolPtr(false)})
+
+	assert.ErrorIs(t, err, role.ErrLastAdmin)
+	assert.Equal(t, 1, tx.calls)
+	assert.True(t, tx.rolledBack)
+	assert.Empty(t, events.events)
+}
+
+func TestRoleDescription_ControlCharactersStripped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	raw := "Handles\x00 quotations\x1b[31m\nand orders"
+	want := dbutil.Ptr("Handles quotations[31m\nand orders")
+
+	repo.EXPECT().GetRoleByCode(gomock.Any(), "sales").Return(db.Role{}, pgx.ErrNoRows)
+	repo.EXPECT().
+		CreateRole(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateRoleParams) (db.Role, error) {
+			assert.Equal(t, want, arg.Description)
+			return db.Role{ID: uuid.New(), Code: arg.Code, Description: arg.Description}, nil
+		})
+
+	_, err := service.CreateRole(context.Background(), role.CreateRoleRequest{Code: "sales", Name: "Sales", Description: &raw})
+	assert.NoError(t, err)
+
+	id := uuid.New()
+	repo.EXPECT().WithQuerier(gomock.Any()).Return(repo)
+	repo.EXPECT().GetRoleByID(gomock.Any(), id).Return(db.Role{ID: id, Name: "Sales", Version: 1}, nil)
+	repo.EXPECT().
+		UpdateRole(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.UpdateRoleParams) (db.Role, error) {
+			assert.Equal(t, want, arg.Description)
+			return db.Role{ID: id, Version: 2}, nil
+		})
+
+	_, err = service.UpdateRole(context.Background(), id, nil, role.UpdateRoleRequest{Description: &raw})
+	assert.NoError(t, err)
+}
+
+func TestGetRolesByCodes_MixedCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	ctx := context.Background()
+	salesID, adminID := uuid.New(), uuid.New()
+	codes := []string{"sales", "ghost", "admin"}
+
+	repo.EXPECT().GetRolesByCodes(ctx, codes).Return([]db.Role{
+		{ID: adminID, Code: "admin", Name: "Administrator"},
+		{ID: salesID, Code: "sales", Name: "Sales"},
+	}, nil)
+
+	roles, err := service.GetRolesByCodes(ctx, codes)
+
+	assert.NoError(t, err)
+	found := map[string]uuid.UUID{}
+	for _, r := range roles {
+		found[r.Code] = r.ID
+	}
+	assert.Equal(t, map[string]uuid.UUID{"admin": adminID, "sales": salesID}, found)
+	assert.NotContains(t, found, "ghost")
+}
+
+func TestGetRolesByCodes_EmptySkipsQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	roles, err := service.GetRolesByCodes(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+}
+
+func TestListRoleUsers_SeveralUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	ctx := context.Background()
+	roleID := uuid.New()
+	assignedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	params := pagination.NewParams(2, 2)
+
+	repo.EXPECT().GetRoleByID(ctx, roleID).Return(db.Role{ID: roleID, Code: "sales"}, nil)
+	repo.EXPECT().ListUsersByRole(ctx, db.ListUsersByRoleParams{
+		RoleID:      roleID,
+		LimitCount:  2,
+		OffsetCount: 2,
+	}).Return([]db.ListUsersByRoleRow{
+		{ID: uuid.New(), Username: "cid", FullName: "Cid", IsActive: dbutil.BoolPtr(true), AssignedAt: dbutil.TimeToPgTime(assignedAt)},
+		{ID: uuid.New(), Username: "dee", FullName: "Dee", IsActive: dbutil.BoolPtr(false), AssignedAt: dbutil.TimeToPgTime(assignedAt.Add(time.Hour))},
+	}, nil)
+	repo.EXPECT().CountUsersByRole(ctx, roleID).Return(int64(4), nil)
+
+	res, err := service.ListRoleUsers(ctx, roleID, params)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Data, 2)
+	assert.Equal(t, "cid", res.Data[0].Username)
+	assert.True(t, res.Data[0].IsActive)
+	assert.Equal(t, assignedAt, res.Data[0].AssignedAt)
+	assert.False(t, res.Data[1].IsActive)
+	assert.Equal(t, pagination.Meta{Total: 4, Page: 2, PageSize: 2, TotalPages: 2}, res.Meta)
+}
+
+func TestListRoleUsers_NoUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	ctx := context.Background()
+	roleID := uuid.New()
+
+	repo.EXPECT().GetRoleByID(ctx, roleID).Return(db.Role{ID: roleID}, nil)
+	repo.EXPECT().ListUsersByRole(ctx, gomock.Any()).Return(nil, nil)
+	repo.EXPECT().CountUsersByRole(ctx, roleID).Return(int64(0), nil)
+
+	res, err := service.ListRoleUsers(ctx, roleID, pagination.NewParams(1, 10))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res.Data)
+	assert.Empty(t, res.Data)
+	assert.Equal(t, int64(0), res.Meta.Total)
+}
+
+func TestListRoleUsers_RoleNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := role.NewService(repo, &fakeTx{}, nil)
+
+	repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any()).Return(db.Role{}, pgx.ErrNoRows)
+
+	_, err := service.ListRoleUsers(context.Background(), uuid.New(), pagination.NewParams(1, 10))
+
+	assert.ErrorIs(t, err, role.ErrRoleNotFound)
+}
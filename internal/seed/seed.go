@@ -0,0 +1,218 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+var ErrAdminCredentialsRequired = errors.New("admin username, email and password are required")
+
+// Store adalah subset db.Querier yang dipakai seeder
+type Store interface {
+	UpsertRole(ctx context.Context, arg db.UpsertRoleParams) (db.Role, error)
+	UpsertMenu(ctx context.Context, arg db.UpsertMenuParams) (db.Menu, error)
+	GrantRoleMenu(ctx context.Context, arg db.GrantRoleMenuParams) error
+	GetUserByUsername(ctx context.Context, username string) (db.GetUserByUsernameRow, error)
+	CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error)
+	AssignRoleToUserIfMissing(ctx context.Context, arg db.AssignRoleToUserIfMissingParams) error
+}
+
+// AdminConfig berisi kredensial admin awal (dari env)
+type AdminConfig struct {
+	Username string
+	Email    string
+	Password string
+	FullName string
+}
+
+// Result merangkum apa yang dilakukan oleh Run
+type Result struct {
+	Roles        int
+	Menus        int
+	AdminCreated bool
+}
+
+type roleSeed struct {
+	Code        string
+	Name        string
+	Description string
+}
+
+type menuSeed struct {
+	Code      string
+	Parent    string
+	Name      string
+	Path      string
+	Icon      string
+	SortOrder int32
+}
+
+type permission struct {
+	Create, Read, Update, Delete bool
+}
+
+var defaultRoles = []roleSeed{
+	{Code: RoleAdmin, Name: "Administrator", Description: "Full access to all menus"},
+	{Code: RoleUser, Name: "User", Description: "Default role for registered users"},
+}
+
+// parent harus muncul sebelum child
+var defaultMenus = []menuSeed{
+	{Code: "dashboard", Name: "Dashboard", Path: "/dashboard", Icon: "home", SortOrder: 1},
+	{Code: "master", Name: "Master Data", Icon: "database", SortOrder: 2},
+	{Code: "products", Parent: "master", Name: "Products", Path: "/master/products", SortOrder: 1},
+	{Code: "customers", Parent: "master", Name: "Customers", Path: "/master/customers", SortOrder: 2},
+	{Code: "suppliers", Parent: "master", Name: "Suppliers", Path: "/master/suppliers", SortOrder: 3},
+	{Code: "categories", Parent: "master", Name: "Categories", Path: "/master/categories", SortOrder: 4},
+	{Code: "sales", Name: "Sales", Path: "/sales", Icon: "shopping-cart", SortOrder: 3},
+	{Code: "procurement", Name: "Procurement", Path: "/procurement", Icon: "truck", SortOrder: 4},
+	{Code: "inventory", Name: "Inventory", Path: "/inventory", Icon: "box", SortOrder: 5},
+	{Code: "finance", Name: "Finance", Path: "/finance", Icon: "dollar-sign", SortOrder: 6},
+	{Code: "settings", Name: "Settings", Icon: "settings", SortOrder: 7},
+	{Code: "users", Parent: "settings", Name: "Users", Path: "/settings/users", SortOrder: 1},
+	{Code: "roles", Parent: "settings", Name: "Roles", Path: "/settings/roles", SortOrder: 2},
+}
+
+var (
+	fullAccess = permission{Create: true, Read: true, Update: true, Delete: true}
+	readOnly   = permission{Read: true}
+)
+
+// grants per role; admin mendapat akses penuh ke semua menu
+var defaultGrants = map[string]map[string]permission{
+	RoleUser: {
+		"dashboard": readOnly,
+	},
+}
+
+// Run membuat role, menu, grant dan admin awal. Aman dijalankan berulang:
+// data yang sudah ada tidak diduplikasi.
+func Run(ctx context.Context, store Store, admin AdminConfig) (*Result, error) {
+	if admin.Username == "" || admin.Email == "" || admin.Password == "" {
+		return nil, ErrAdminCredentialsRequired
+	}
+
+	roleIDs := make(map[string]db.Role, len(defaultRoles))
+	for _, r := range defaultRoles {
+		role, err := store.UpsertRole(ctx, db.UpsertRoleParams{
+			Code:        r.Code,
+			Name:        r.Name,
+			Description: dbutil.Ptr(r.Description),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("seed role %s: %w", r.Code, err)
+		}
+		roleIDs[r.Code] = role
+	}
+
+	menus := make(map[string]db.Menu, len(defaultMenus))
+	for _, m := range defaultMenus {
+		params := db.UpsertMenuParams{
+			Code:      m.Code,
+			Name:      m.Name,
+			Path:      dbutil.Coalesce(&m.Path),
+			Icon:      dbutil.Coalesce(&m.Icon),
+			SortOrder: dbutil.Ptr(m.SortOrder),
+		}
+		if m.Parent != "" {
+			parent := menus[m.Parent].ID
+			params.ParentID = dbutil.UUIDPtrToPgUUID(&parent)
+		}
+
+		menu, err := store.UpsertMenu(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("seed menu %s: %w", m.Code, err)
+		}
+		menus[m.Code] = menu
+	}
+
+	for _, r := range defaultRoles {
+		for _, m := range defaultMenus {
+			perm, ok := defaultGrants[r.Code][m.Code]
+			if r.Code == RoleAdmin {
+				perm, ok = fullAccess, true
+			}
+			if !ok {
+				continue
+			}
+
+			if err := store.GrantRoleMenu(ctx, db.GrantRoleMenuParams{
+				RoleID:    roleIDs[r.Code].ID,
+				MenuID:    menus[m.Code].ID,
+				CanCreate: dbutil.Ptr(perm.Create),
+				CanRead:   dbutil.Ptr(perm.Read),
+				CanUpdate: dbutil.Ptr(perm.Update),
+				CanDelete: dbutil.Ptr(perm.Delete),
+			}); err != nil {
+				return nil, fmt.Errorf("grant %s -> %s: %w", r.Code, m.Code, err)
+			}
+		}
+	}
+
+	created, err := seedAdmin(ctx, store, admin, roleIDs[RoleAdmin].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Roles:        len(roleIDs),
+		Menus:        len(menus),
+		AdminCreated: created,
+	}, nil
+}
+
+func seedAdmin(ctx context.Context, store Store, admin AdminConfig, roleID uuid.UUID) (bool, error) {
+	user, err := store.GetUserByUsername(ctx, admin.Username)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("lookup admin user: %w", err)
+	}
+
+	created := false
+	userID := user.ID
+	if errors.Is(err, pgx.ErrNoRows) {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(admin.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return false, fmt.Errorf("hash admin password: %w", err)
+		}
+
+		fullName := admin.FullName
+		if fullName == "" {
+			fullName = "Administrator"
+		}
+
+		newUser, err := store.CreateUser(ctx, db.CreateUserParams{
+			Username:     admin.Username,
+			Email:        admin.Email,
+			PasswordHash: string(hashed),
+			FullName:     fullName,
+			IsActive:     dbutil.BoolPtr(true),
+		})
+		if err != nil {
+			return false, fmt.Errorf("create admin user: %w", err)
+		}
+		userID = newUser.ID
+		created = true
+	}
+
+	if err := store.AssignRoleToUserIfMissing(ctx, db.AssignRoleToUserIfMissingParams{
+		UserID: userID,
+		RoleID: roleID,
+	}); err != nil {
+		return false, fmt.Errorf("assign admin role: %w", err)
+	}
+
+	return created, nil
+}
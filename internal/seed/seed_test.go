@@ -0,0 +1,142 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/seed"
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// fakeStore meniru constraint UNIQUE / ON CONFLICT di database
+type fakeStore struct {
+	roles     map[string]db.Role
+	menus     map[string]db.Menu
+	grants    map[[2]uuid.UUID]db.GrantRoleMenuParams
+	users     map[string]db.GetUserByUsernameRow
+	userRoles map[[2]uuid.UUID]bool
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		roles:     map[string]db.Role{},
+		menus:     map[string]db.Menu{},
+		grants:    map[[2]uuid.UUID]db.GrantRoleMenuParams{},
+		users:     map[string]db.GetUserByUsernameRow{},
+		userRoles: map[[2]uuid.UUID]bool{},
+	}
+}
+
+func (f *fakeStore) UpsertRole(_ context.Context, arg db.UpsertRoleParams) (db.Role, error) {
+	if r, ok := f.roles[arg.Code]; ok {
+		return r, nil
+	}
+	r := db.Role{ID: uuid.New(), Code: arg.Code, Name: arg.Name, Description: arg.Description}
+	f.roles[arg.Code] = r
+	return r, nil
+}
+
+func (f *fakeStore) UpsertMenu(_ context.Context, arg db.UpsertMenuParams) (db.Menu, error) {
+	if m, ok := f.menus[arg.Code]; ok {
+		return m, nil
+	}
+	m := db.Menu{ID: uuid.New(), ParentID: arg.ParentID, Code: arg.Code, Name: arg.Name}
+	f.menus[arg.Code] = m
+	return m, nil
+}
+
+func (f *fakeStore) GrantRoleMenu(_ context.Context, arg db.GrantRoleMenuParams) error {
+	key := [2]uuid.UUID{arg.RoleID, arg.MenuID}
+	if _, ok := f.grants[key]; !ok {
+		f.grants[key] = arg
+	}
+	return nil
+}
+
+func (f *fakeStore) GetUserByUsername(_ context.Context, username string) (db.GetUserByUsernameRow, error) {
+	u, ok := f.users[username]
+	if !ok {
+		return db.GetUserByUsernameRow{}, pgx.ErrNoRows
+	}
+	return u, nil
+}
+
+func (f *fakeStore) CreateUser(_ context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+	id := uuid.New()
+	f.users[arg.Username] = db.GetUserByUsernameRow{
+		ID:           id,
+		Username:     arg.Username,
+		Email:        arg.Email,
+		PasswordHash: arg.PasswordHash,
+		FullName:     arg.FullName,
+	}
+	return db.CreateUserRow{ID: id, Username: arg.Username, Email: arg.Email}, nil
+}
+
+func (f *fakeStore) AssignRoleToUserIfMissing(_ context.Context, arg db.AssignRoleToUserIfMissingParams) error {
+	f.userRoles[[2]uuid.UUID{arg.UserID, arg.RoleID}] = true
+	return nil
+}
+
+var adminCfg = seed.AdminConfig{
+	Username: "admin",
+	Email:    "admin@example.com",
+	Password: "secret123",
+}
+
+func TestRun_CreatesDefaults(t *testing.T) {
+	store := newFakeStore()
+
+	result, err := seed.Run(context.Background(), store, adminCfg)
+	require.NoError(t, err)
+
+	assert.True(t, result.AdminCreated)
+	assert.Contains(t, store.roles, seed.RoleAdmin)
+	assert.Contains(t, store.roles, seed.RoleUser)
+	assert.Len(t, store.users, 1)
+	assert.NotEqual(t, adminCfg.Password, store.users["admin"].PasswordHash)
+
+	adminID := store.users["admin"].ID
+	assert.True(t, store.userRoles[[2]uuid.UUID{adminID, store.roles[seed.RoleAdmin].ID}])
+
+	// admin mendapat akses penuh ke setiap menu
+	for _, m := range store.menus {
+		g, ok := store.grants[[2]uuid.UUID{store.roles[seed.RoleAdmin].ID, m.ID}]
+		require.True(t, ok, "admin missing grant for %s", m.Code)
+		assert.True(t, *g.CanDelete)
+	}
+
+	// child menu menunjuk ke parent
+	assert.Equal(t, [16]byte(store.menus["master"].ID), store.menus["products"].ParentID.Bytes)
+}
+
+func TestRun_Idempotent(t *testing.T) {
+	store := newFakeStore()
+	ctx := context.Background()
+
+	_, err := seed.Run(ctx, store, adminCfg)
+	require.NoError(t, err)
+
+	roles, menus, grants, users, userRoles :=
+		len(store.roles), len(store.menus), len(store.grants), len(store.users), len(store.userRoles)
+
+	result, err := seed.Run(ctx, store, adminCfg)
+	require.NoError(t, err)
+
+	assert.False(t, result.AdminCreated)
+	assert.Len(t, store.roles, roles)
+	assert.Len(t, store.menus, menus)
+	assert.Len(t, store.grants, grants)
+	assert.Len(t, store.users, users)
+	assert.Len(t, store.userRoles, userRoles)
+}
+
+func TestRun_MissingAdminCredentials(t *testing.T) {
+	_, err := seed.Run(context.Background(), newFakeStore(), seed.AdminConfig{Username: "admin"})
+	assert.ErrorIs(t, err, seed.ErrAdminCredentialsRequired)
+}
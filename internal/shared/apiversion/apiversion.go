@@ -0,0 +1,104 @@
+/*
+Package apiversion mounts module routes under several API versions side by
+side (/api/v1, /api/v2, ...). Module biasa cukup punya RegisterRoutes dan
+didaftarkan sama persis di semua versi; module yang DTO-nya berbeda per
+versi mengimplementasikan VersionedModule.
+*/
+package apiversion
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Version string
+
+const (
+	V1 Version = "v1"
+	V2 Version = "v2"
+)
+
+// Supported lists the versions main mounts, oldest first
+var Supported = []Version{V1, V2}
+
+// contextKey: tipe unexported, tidak bentrok dengan key string di gin.Context
+type contextKey struct{}
+
+// Module registers the same routes on every version
+type Module interface {
+	RegisterRoutes(r *gin.RouterGroup)
+}
+
+// VersionedModule registers routes that differ per version
+type VersionedModule interface {
+	RegisterVersionRoutes(v Version, r *gin.RouterGroup)
+}
+
+// Groups holds one router group per version
+type Groups struct {
+	groups   map[Version]*gin.RouterGroup
+	versions []Version
+}
+
+/*
+NewGroups creates a group per version. basePath adalah path versi
+pertama (API_BASE_PATH, mis. /erp/api/v1); versi lain menggantikan
+segmen versi terakhirnya, lihat Path.
+*/
+func NewGroups(router gin.IRouter, basePath string, versions ...Version) Groups {
+	g := Groups{groups: make(map[Version]*gin.RouterGroup, len(versions)), versions: versions}
+
+	for _, v := range versions {
+		g.groups[v] = router.Group(Path(basePath, v), setVersion(v))
+	}
+
+	return g
+}
+
+// Group returns the router group of v, nil if v is not mounted
+func (g Groups) Group(v Version) *gin.RouterGroup {
+	return g.groups[v]
+}
+
+// Register mounts the same routes of m on every version
+func (g Groups) Register(m Module) {
+	for _, v := range g.versions {
+		m.RegisterRoutes(g.groups[v])
+	}
+}
+
+// RegisterVersioned lets m register its own routes for each version
+func (g Groups) RegisterVersioned(m VersionedModule) {
+	for _, v := range g.versions {
+		m.RegisterVersionRoutes(v, g.groups[v])
+	}
+}
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// Path returns the group path of v: the trailing version segment of
+// basePath is replaced, or v is appended when basePath has none
+func Path(basePath string, v Version) string {
+	if versionSegment.MatchString(path.Base(basePath)) {
+		return path.Join(path.Dir(basePath), string(v))
+	}
+	return path.Join(basePath, string(v))
+}
+
+// FromContext returns the version of the group that matched the request,
+// V1 for routes outside any version group
+func FromContext(c *gin.Context) Version {
+	if v, ok := c.Get(contextKey{}); ok {
+		return v.(Version)
+	}
+	return V1
+}
+
+func setVersion(v Version) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(contextKey{}, v)
+		c.Next()
+	}
+}
@@ -0,0 +1,100 @@
+package apiversion_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/apiversion"
+)
+
+// userModule merender field yang sama dengan casing berbeda per versi
+type userModule struct{}
+
+func (userModule) RegisterVersionRoutes(v apiversion.Version, r *gin.RouterGroup) {
+	r.GET("/users/me", func(c *gin.Context) {
+		if v == apiversion.V1 {
+			c.JSON(http.StatusOK, gin.H{"full_name": "Jane"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"fullName": "Jane"})
+	})
+}
+
+// pingModule sama persis di semua versi
+type pingModule struct{}
+
+func (pingModule) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, string(apiversion.FromContext(c)))
+	})
+}
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	groups := apiversion.NewGroups(router, "/api/v1", apiversion.Supported...)
+	groups.Register(pingModule{})
+	groups.RegisterVersioned(userModule{})
+
+	return router
+}
+
+func get(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		basePath string
+		version  apiversion.Version
+		want     string
+	}{
+		{"/api/v1", apiversion.V1, "/api/v1"},
+		{"/api/v1", apiversion.V2, "/api/v2"},
+		{"/erp/api/v1", apiversion.V2, "/erp/api/v2"},
+		{"/api", apiversion.V2, "/api/v2"},
+		{"/", apiversion.V1, "/v1"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, apiversion.Path(tt.basePath, tt.version), tt.basePath)
+	}
+}
+
+func TestRegisterVersioned_FieldCasingPerVersion(t *testing.T) {
+	router := newRouter()
+
+	w := get(router, "/api/v1/users/me")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"full_name":"Jane"}`, w.Body.String())
+
+	w = get(router, "/api/v2/users/me")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"fullName":"Jane"}`, w.Body.String())
+}
+
+func TestRegister_SameRoutesOnEveryVersion(t *testing.T) {
+	router := newRouter()
+
+	for _, v := range apiversion.Supported {
+		w := get(router, apiversion.Path("/api/v1", v)+"/ping")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(v), w.Body.String())
+	}
+
+	assert.Equal(t, http.StatusNotFound, get(router, "/api/v3/ping").Code)
+}
+
+func TestFromContext_DefaultsToV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	assert.Equal(t, apiversion.V1, apiversion.FromContext(c))
+}
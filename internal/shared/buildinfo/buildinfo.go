@@ -0,0 +1,34 @@
+// Package buildinfo holds version metadata injected at build time:
+//
+//	go build -ldflags "-X go-mini-erp/internal/shared/buildinfo.Version=v1.2.3 \
+//	  -X go-mini-erp/internal/shared/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X go-mini-erp/internal/shared/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import "fmt"
+
+// di-set via -ldflags -X; default untuk build lokal
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+}
+
+// Get returns the build metadata of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	}
+}
+
+func (i Info) String() string {
+	return fmt.Sprintf("version=%s commit=%s built=%s", i.Version, i.Commit, i.BuildTime)
+}
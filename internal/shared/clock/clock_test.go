@@ -0,0 +1,30 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/clock"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFake(start)
+
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), c.Now())
+
+	c.Set(start)
+	assert.Equal(t, start, c.Now())
+}
+
+func TestOrReal(t *testing.T) {
+	assert.Equal(t, clock.Real{}, clock.OrReal(nil))
+
+	fake := clock.NewFake(time.Time{})
+	assert.Same(t, fake, clock.OrReal(fake))
+}
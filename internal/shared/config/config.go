@@ -0,0 +1,368 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is application configuration loaded from environment
+type Config struct {
+	AppEnv      string
+	HTTP        HTTPConfig
+	Database    DatabaseConfig
+	CORS        CORSConfig
+	Tracing     TracingConfig
+	Redis       RedisConfig
+	RateLimit   RateLimitConfig
+	Maintenance MaintenanceConfig
+	Webhook     WebhookConfig
+	Auth        AuthConfig
+}
+
+// AuthConfig: DefaultRoleCode kosong = user baru tidak diberi role.
+//
+// TrustRolesFromToken = true memakai claim roles di access token tanpa
+// query database, tapi role yang dicabut admin tetap berlaku sampai token
+// expired. false memuat role dari database per request, di-cache selama
+// RoleCacheTTL, jadi perubahan role berlaku paling lambat setelah TTL
+// dengan biaya satu query per user per TTL.
+type AuthConfig struct {
+	DefaultRoleCode     string
+	TrustRolesFromToken bool
+	RoleCacheTTL        time.Duration
+	// MenuCacheTTL: umur cache daftar menu RequireMenuForPath; import RBAC
+	// di instance yang sama langsung membuangnya
+	MenuCacheTTL time.Duration
+	// RevocationCacheTTL: berapa lama token yang belum logout dianggap
+	// tetap aktif tanpa cek deny-list; 0 = cek setiap request
+	RevocationCacheTTL time.Duration
+	// umur access token, juga dilaporkan sebagai expiresIn
+	AccessTokenTTL time.Duration
+	// cookie tempat browser app menyimpan access token, dipakai kalau
+	// header Authorization tidak ada; kosong = hanya header
+	AccessTokenCookie string
+	// key client gateway; kosong = /auth/introspect dan /auth/revoked
+	// tidak tersedia
+	GatewayAPIKeys []string
+	// masa tenggang DELETE /auth/account sebelum user dianonimkan;
+	// 0 = user tidak bisa menghapus akunnya sendiri
+	AccountDeletionGrace time.Duration
+	// seberapa sering worker mencari akun yang masa tenggangnya habis
+	AccountDeletionInterval time.Duration
+	// ActivationRequired: user hasil register harus membuka link aktivasi
+	// sebelum bisa login
+	ActivationRequired bool
+	ActivationTokenTTL time.Duration
+	// ActivationURL is the link target in the activation email; kosong =
+	// GET /auth/activate di base path API
+	ActivationURL string
+}
+
+// WebhookConfig: URLs kosong = webhook tidak dikirim
+type WebhookConfig struct {
+	URLs        []string
+	Secret      string
+	MaxAttempts int
+	BaseDelay   time.Duration
+	Timeout     time.Duration
+	QueueSize   int
+}
+
+// RedisConfig: URL kosong = Redis tidak dipakai (fallback in-memory)
+type RedisConfig struct {
+	URL string
+}
+
+// MaintenanceConfig: Enabled memaksa maintenance sejak startup; selain
+// itu maintenance aktif selama RedisKey ada di Redis (jika REDIS_URL di-set)
+type MaintenanceConfig struct {
+	Enabled    bool
+	RedisKey   string
+	RetryAfter time.Duration
+}
+
+type RateLimitConfig struct {
+	Enabled  bool
+	Requests int
+	Window   time.Duration
+	// RouteCosts: berapa dari Requests yang dipakai satu request ke route
+	// itu (path relatif terhadap base path tiap versi, mis. "/auth/login");
+	// menimpa default di cmd/api, 0 = tidak dihitung
+	RouteCosts map[string]int
+
+	// login gagal per identifier (email) dan per IP dalam LoginWindow
+	LoginMaxFailures   int
+	LoginIPMaxFailures int
+	LoginWindow        time.Duration
+
+	// POST /auth/resend-verification per IP (429) dan per email (diam-diam
+	// tidak dikirim) dalam ResendWindow; berlaku walau Enabled = false
+	ResendMaxPerIP    int
+	ResendMaxPerEmail int
+	ResendWindow      time.Duration
+}
+
+type HTTPConfig struct {
+	MaxBodyBytes int64
+	// ImportMaxBodyBytes menggantikan MaxBodyBytes untuk route upload
+	// (import RBAC)
+	ImportMaxBodyBytes int64
+	// APIBasePath adalah prefix semua route API, mis. "/erp/api/v1" di
+	// belakang proxy yang menambah prefix
+	APIBasePath string
+
+	// timeout http.Server; default production lebih ketat dari dev
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// StreamWriteTimeout menggantikan WriteTimeout untuk route streaming
+	// (export CSV); 0 = tanpa batas
+	StreamWriteTimeout time.Duration
+
+	// TrustedProxies: IP/CIDR reverse proxy yang X-Forwarded-For-nya
+	// dipercaya untuk ClientIP; kosong = tidak ada, IP client diambil dari
+	// koneksi sehingga header tidak bisa dipalsukan untuk lolos rate limit
+	TrustedProxies []string
+}
+
+// DefaultAPIBasePath is used when API_BASE_PATH is unset
+const DefaultAPIBasePath = "/api/v1"
+
+// NormalizeBasePath returns p with exactly one leading and no trailing
+// slash; kosong atau "/" menjadi DefaultAPIBasePath
+func NormalizeBasePath(p string) string {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return DefaultAPIBasePath
+	}
+	return "/" + p
+}
+
+type DatabaseConfig struct {
+	URL             string
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+	// QueryTimeout membatasi tiap statement, terpisah dari timeout
+	// request; 0 = tanpa batas
+	QueryTimeout time.Duration
+
+	// retry saat startup (DB belum siap di container)
+	ConnectAttempts  int
+	ConnectBaseDelay time.Duration
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string // response header yang boleh dibaca JS (mis. ETag)
+	AllowCredentials bool
+}
+
+// TracingConfig mengatur OpenTelemetry; endpoint exporter dibaca
+// langsung dari OTEL_EXPORTER_OTLP_* oleh SDK
+type TracingConfig struct {
+	Enabled     bool
+	ServiceName string
+	SampleRatio float64
+}
+
+var defaultDevOrigins = []string{
+	"http://localhost:3000",
+	"http://localhost:5173",
+	"http://127.0.0.1:3000",
+	"http://127.0.0.1:5173",
+}
+
+// Load reads configuration from environment variables
+func Load() Config {
+	appEnv := GetString("APP_ENV", "development")
+
+	// di luar production, default izinkan origin localhost
+	var defaultOrigins []string
+	if appEnv != "production" {
+		defaultOrigins = defaultDevOrigins
+	}
+
+	// dev: timeout longgar supaya breakpoint/debugger tidak memutus request
+	readTimeout, writeTimeout := 15*time.Second, 15*time.Second
+	if appEnv != "production" {
+		readTimeout, writeTimeout = time.Minute, time.Minute
+	}
+
+	return Config{
+		AppEnv: appEnv,
+		HTTP: HTTPConfig{
+			MaxBodyBytes:       int64(GetInt("HTTP_MAX_BODY_BYTES", 1<<20)),         // 1 MiB
+			ImportMaxBodyBytes: int64(GetInt("HTTP_IMPORT_MAX_BODY_BYTES", 10<<20)), // 10 MiB
+			APIBasePath:        NormalizeBasePath(GetString("API_BASE_PATH", DefaultAPIBasePath)),
+
+			ReadTimeout:        GetDuration("HTTP_READ_TIMEOUT", readTimeout),
+			WriteTimeout:       GetDuration("HTTP_WRITE_TIMEOUT", writeTimeout),
+			IdleTimeout:        GetDuration("HTTP_IDLE_TIMEOUT", time.Minute),
+			StreamWriteTimeout: GetDuration("HTTP_STREAM_WRITE_TIMEOUT", 10*time.Minute),
+
+			TrustedProxies: GetList("TRUSTED_PROXIES", nil),
+		},
+		Database: DatabaseConfig{
+			URL:             os.Getenv("DB_URL"),
+			MaxConns:        int32(GetInt("DB_MAX_CONNS", 10)),
+			MinConns:        int32(GetInt("DB_MIN_CONNS", 2)),
+			MaxConnLifetime: GetDuration("DB_MAX_CONN_LIFETIME", time.Hour),
+			MaxConnIdleTime: GetDuration("DB_MAX_CONN_IDLE_TIME", 30*time.Minute),
+			QueryTimeout:    GetDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+
+			ConnectAttempts:  GetInt("DB_CONNECT_ATTEMPTS", 5),
+			ConnectBaseDelay: GetDuration("DB_CONNECT_BASE_DELAY", time.Second),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: GetList("CORS_ALLOWED_ORIGINS", defaultOrigins),
+			AllowedMethods: GetList("CORS_ALLOWED_METHODS", []string{
+				"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS",
+			}),
+			AllowedHeaders: GetList("CORS_ALLOWED_HEADERS", []string{
+				"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token",
+				"Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With",
+				"If-Match", "If-None-Match",
+			}),
+			ExposedHeaders:   GetList("CORS_EXPOSED_HEADERS", []string{"ETag"}),
+			AllowCredentials: GetBool("CORS_ALLOW_CREDENTIALS", true),
+		},
+		Tracing: TracingConfig{
+			Enabled:     GetBool("TRACING_ENABLED", false),
+			ServiceName: GetString("OTEL_SERVICE_NAME", "go-mini-erp"),
+			SampleRatio: GetFloat("TRACING_SAMPLE_RATIO", 1.0),
+		},
+		Redis: RedisConfig{
+			URL: os.Getenv("REDIS_URL"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:  GetBool("RATE_LIMIT_ENABLED", true),
+			Requests: GetInt("RATE_LIMIT_REQUESTS", 100),
+			Window:   GetDuration("RATE_LIMIT_WINDOW", time.Minute),
+
+			RouteCosts: GetIntMap("RATE_LIMIT_ROUTE_COSTS"),
+
+			LoginMaxFailures:   GetInt("LOGIN_MAX_FAILURES", 5),
+			LoginIPMaxFailures: GetInt("LOGIN_IP_MAX_FAILURES", 50),
+			LoginWindow:        GetDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+
+			ResendMaxPerIP:    GetInt("RESEND_VERIFICATION_MAX_PER_IP", 10),
+			ResendMaxPerEmail: GetInt("RESEND_VERIFICATION_MAX_PER_EMAIL", 3),
+			ResendWindow:      GetDuration("RESEND_VERIFICATION_WINDOW", time.Hour),
+		},
+		Maintenance: MaintenanceConfig{
+			Enabled:    GetBool("MAINTENANCE_MODE", false),
+			RedisKey:   GetString("MAINTENANCE_REDIS_KEY", "maintenance"),
+			RetryAfter: GetDuration("MAINTENANCE_RETRY_AFTER", 5*time.Minute),
+		},
+		Webhook: WebhookConfig{
+			URLs:        GetList("WEBHOOK_URLS", nil),
+			Secret:      os.Getenv("WEBHOOK_SECRET"),
+			MaxAttempts: GetInt("WEBHOOK_MAX_ATTEMPTS", 5),
+			BaseDelay:   GetDuration("WEBHOOK_BASE_DELAY", time.Second),
+			Timeout:     GetDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			QueueSize:   GetInt("WEBHOOK_QUEUE_SIZE", 100),
+		},
+		Auth: AuthConfig{
+			DefaultRoleCode:         GetString("DEFAULT_ROLE_CODE", "user"),
+			TrustRolesFromToken:     GetBool("TRUST_ROLES_FROM_TOKEN", false),
+			RoleCacheTTL:            GetDuration("ROLE_CACHE_TTL", 30*time.Second),
+			MenuCacheTTL:            GetDuration("MENU_CACHE_TTL", 30*time.Second),
+			RevocationCacheTTL:      GetDuration("TOKEN_REVOCATION_CACHE_TTL", 5*time.Second),
+			AccessTokenTTL:          GetDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			AccessTokenCookie:       os.Getenv("ACCESS_TOKEN_COOKIE"),
+			GatewayAPIKeys:          GetList("GATEWAY_API_KEYS", nil),
+			AccountDeletionGrace:    GetDuration("ACCOUNT_DELETION_GRACE", 0),
+			AccountDeletionInterval: GetDuration("ACCOUNT_DELETION_INTERVAL", time.Hour),
+			ActivationRequired:      GetBool("ACCOUNT_ACTIVATION_REQUIRED", false),
+			ActivationTokenTTL:      GetDuration("ACCOUNT_ACTIVATION_TOKEN_TTL", 48*time.Hour),
+			ActivationURL:           os.Getenv("ACCOUNT_ACTIVATION_URL"),
+		},
+	}
+}
+
+// IsProduction reports whether APP_ENV is production
+func (c Config) IsProduction() bool {
+	return c.AppEnv == "production"
+}
+
+// ==========================
+// Env helpers
+// ==========================
+
+func GetString(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+func GetBool(key string, def bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func GetInt(key string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func GetFloat(key string, def float64) float64 {
+	v, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func GetDuration(key string, def time.Duration) time.Duration {
+	v, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// GetList splits a comma-separated env value, trimming blanks
+func GetList(key string, def []string) []string {
+	raw := os.Getenv(key)
+	if strings.TrimSpace(raw) == "" {
+		return def
+	}
+
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// GetIntMap parses "key=n,key=n"; entri yang n-nya bukan angka dilewati
+func GetIntMap(key string) map[string]int {
+	out := make(map[string]int)
+	for _, part := range GetList(key, nil) {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		out[strings.TrimSpace(k)] = n
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+/*
+Package ctxkeys owns the keys of values middleware stores on a gin.Context.
+Key-nya bertipe unexported, jadi tidak bisa ditimpa atau dibaca lewat
+c.Set/c.Get dengan string biasa (mis. c.Set("user_id", ...)) dan salah
+ketik nama key menjadi compile error, bukan nilai kosong diam-diam.
+
+Setter dipanggil middleware (AuthMiddleware, RequestIDMiddleware); handler
+membaca lewat getter di sini atau middleware.GetUserID dkk.
+*/
+package ctxkeys
+
+import "github.com/gin-gonic/gin"
+
+type key int
+
+const (
+	userIDKey key = iota
+	usernameKey
+	rolesKey
+	claimsKey
+	requestIDKey
+)
+
+func SetUserID(c *gin.Context, id string) { c.Set(userIDKey, id) }
+
+// UserID returns the authenticated user id, "" outside AuthMiddleware
+func UserID(c *gin.Context) string { return c.GetString(userIDKey) }
+
+func SetUsername(c *gin.Context, username string) { c.Set(usernameKey, username) }
+
+func Username(c *gin.Context) string { return c.GetString(usernameKey) }
+
+func SetRoles(c *gin.Context, roles []string) { c.Set(rolesKey, roles) }
+
+// Roles returns the role codes of the user; kosong (bukan nil) kalau belum
+// di-set
+func Roles(c *gin.Context) []string {
+	if roles := c.GetStringSlice(rolesKey); roles != nil {
+		return roles
+	}
+	return []string{}
+}
+
+// SetClaims stores the verified token claims. Generic karena tipe Claims
+// ada di paket middleware, yang mengimpor paket ini.
+func SetClaims[T any](c *gin.Context, claims *T) { c.Set(claimsKey, claims) }
+
+// Claims returns the claims stored by SetClaims with the same T, nil when
+// absent
+func Claims[T any](c *gin.Context) *T {
+	v, _ := c.Get(claimsKey)
+	claims, _ := v.(*T)
+	return claims
+}
+
+func SetRequestID(c *gin.Context, id string) { c.Set(requestIDKey, id) }
+
+// RequestID returns the id set by RequestIDMiddleware, "" without it
+func RequestID(c *gin.Context) string { return c.GetString(requestIDKey) }
@@ -0,0 +1,74 @@
+package ctxkeys_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+)
+
+type claims struct {
+	Subject string
+}
+
+func newContext() *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	return c
+}
+
+func TestAccessors_RoundTrip(t *testing.T) {
+	c := newContext()
+
+	ctxkeys.SetUserID(c, "user-1")
+	ctxkeys.SetUsername(c, "alice")
+	ctxkeys.SetRoles(c, []string{"admin", "sales"})
+	ctxkeys.SetClaims(c, &claims{Subject: "user-1"})
+	ctxkeys.SetRequestID(c, "req-1")
+
+	assert.Equal(t, "user-1", ctxkeys.UserID(c))
+	assert.Equal(t, "alice", ctxkeys.Username(c))
+	assert.Equal(t, []string{"admin", "sales"}, ctxkeys.Roles(c))
+	assert.Equal(t, &claims{Subject: "user-1"}, ctxkeys.Claims[claims](c))
+	assert.Equal(t, "req-1", ctxkeys.RequestID(c))
+}
+
+func TestAccessors_ZeroValues(t *testing.T) {
+	c := newContext()
+
+	assert.Empty(t, ctxkeys.UserID(c))
+	assert.Empty(t, ctxkeys.Username(c))
+	assert.Equal(t, []string{}, ctxkeys.Roles(c))
+	assert.Nil(t, ctxkeys.Claims[claims](c))
+	assert.Empty(t, ctxkeys.RequestID(c))
+}
+
+func TestAccessors_DoNotCollideWithStringKeys(t *testing.T) {
+	c := newContext()
+
+	// nilai dengan nama key lama tidak terbaca lewat accessor...
+	c.Set("user_id", "spoofed")
+	c.Set("roles", []string{"admin"})
+	c.Set("claims", &claims{Subject: "spoofed"})
+	c.Set("request_id", "spoofed")
+
+	assert.Empty(t, ctxkeys.UserID(c))
+	assert.Equal(t, []string{}, ctxkeys.Roles(c))
+	assert.Nil(t, ctxkeys.Claims[claims](c))
+	assert.Empty(t, ctxkeys.RequestID(c))
+
+	// ...dan accessor tidak menimpanya
+	ctxkeys.SetUserID(c, "user-1")
+	assert.Equal(t, "user-1", ctxkeys.UserID(c))
+	assert.Equal(t, "spoofed", c.GetString("user_id"))
+}
+
+func TestClaims_OtherTypeIsNil(t *testing.T) {
+	c := newContext()
+	ctxkeys.SetClaims(c, &claims{Subject: "user-1"})
+
+	type otherClaims struct{ Subject string }
+	assert.Nil(t, ctxkeys.Claims[otherClaims](c))
+}
@@ -0,0 +1,166 @@
+//go:build integration
+
+/*
+Package dbtest menjalankan Postgres di container (testcontainers) untuk
+integration test repository. Migration dijalankan sekali ke database
+template; setiap test mendapat database baru hasil copy template, jadi
+test tidak saling melihat data.
+
+Pemakaian di package test:
+
+	func TestMain(m *testing.M) { dbtest.Main(m) }
+
+	func TestSomething(t *testing.T) {
+		pool := dbtest.New(t)
+		...
+	}
+
+Jalankan dengan: go test -tags=integration ./...
+*/
+package dbtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"go-mini-erp/internal/shared/database"
+)
+
+const (
+	image      = "postgres:16-alpine"
+	templateDB = "erp_template"
+)
+
+// adminURL points at the container's "postgres" database, dipakai untuk
+// CREATE/DROP DATABASE per test
+var adminURL string
+
+// Main starts the container, migrates the template database, runs the
+// package's tests and removes the container
+func Main(m *testing.M) {
+	ctx := context.Background()
+
+	ctr, err := postgres.Run(ctx, image,
+		postgres.WithDatabase(templateDB),
+		postgres.WithUsername("erp"),
+		postgres.WithPassword("erp"),
+		postgres.BasicWaitStrategies(),
+	)
+	if err != nil {
+		log.Fatalf("dbtest: start postgres: %v", err)
+	}
+
+	code := run(ctx, ctr, m)
+
+	if err := testcontainers.TerminateContainer(ctr); err != nil {
+		log.Printf("dbtest: terminate postgres: %v", err)
+	}
+	os.Exit(code)
+}
+
+func run(ctx context.Context, ctr *postgres.PostgresContainer, m *testing.M) int {
+	dsn, err := ctr.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		log.Printf("dbtest: connection string: %v", err)
+		return 1
+	}
+
+	if err := database.MigrateUp(dsn); err != nil {
+		log.Printf("dbtest: migrate template: %v", err)
+		return 1
+	}
+
+	adminURL, err = withDatabase(dsn, "postgres")
+	if err != nil {
+		log.Printf("dbtest: %v", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// New creates an empty, migrated database for t and returns a pool to
+// it. Database dan pool dibersihkan otomatis di akhir test.
+func New(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	if adminURL == "" {
+		t.Fatal("dbtest: call dbtest.Main from TestMain")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	name := "test_" + randomSuffix(t)
+
+	admin, err := pgx.Connect(ctx, adminURL)
+	if err != nil {
+		t.Fatalf("dbtest: connect admin: %v", err)
+	}
+	defer admin.Close(ctx)
+
+	ident := pgx.Identifier{name}.Sanitize()
+	if _, err := admin.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", ident, pgx.Identifier{templateDB}.Sanitize())); err != nil {
+		t.Fatalf("dbtest: create database: %v", err)
+	}
+
+	dbURL, err := withDatabase(adminURL, name)
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		t.Fatalf("dbtest: connect %s: %v", name, err)
+	}
+
+	t.Cleanup(func() {
+		pool.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		admin, err := pgx.Connect(ctx, adminURL)
+		if err != nil {
+			t.Logf("dbtest: drop %s: %v", name, err)
+			return
+		}
+		defer admin.Close(ctx)
+
+		if _, err := admin.Exec(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", ident)); err != nil {
+			t.Logf("dbtest: drop %s: %v", name, err)
+		}
+	})
+
+	return pool
+}
+
+// withDatabase returns dsn with its database name replaced
+func withDatabase(dsn, name string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("parse dsn: %w", err)
+	}
+	u.Path = "/" + name
+	return u.String(), nil
+}
+
+func randomSuffix(t *testing.T) string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("dbtest: random name: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"go-mini-erp/db/migrations"
+)
+
+// NewMigrator creates a golang-migrate instance backed by the
+// embedded migration files. Caller must Close it.
+func NewMigrator(dbURL string) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("open embedded migrations failed: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, migrateURL(dbURL))
+	if err != nil {
+		return nil, fmt.Errorf("create migrator failed: %w", err)
+	}
+
+	return m, nil
+}
+
+// MigrateUp applies all pending up migrations and logs the
+// version before and after
+func MigrateUp(dbURL string) error {
+	m, err := NewMigrator(dbURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	from := currentVersion(m)
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate up failed: %w", err)
+	}
+
+	to := currentVersion(m)
+	if from == to {
+		log.Printf("Migrations up to date (version %s)", to)
+	} else {
+		log.Printf("Migrations applied: %s -> %s", from, to)
+	}
+
+	return nil
+}
+
+func currentVersion(m *migrate.Migrate) string {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return "none"
+	}
+	if err != nil {
+		return "unknown"
+	}
+	if dirty {
+		return fmt.Sprintf("%d (dirty)", version)
+	}
+	return fmt.Sprintf("%d", version)
+}
+
+// migrateURL switches postgres:// DSNs to the pgx5:// scheme
+// expected by the golang-migrate pgx/v5 driver
+func migrateURL(dbURL string) string {
+	for _, prefix := range []string{"postgresql://", "postgres://"} {
+		if strings.HasPrefix(dbURL, prefix) {
+			return "pgx5://" + strings.TrimPrefix(dbURL, prefix)
+		}
+	}
+	return dbURL
+}
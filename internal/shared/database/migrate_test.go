@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/database"
+)
+
+// TEST_DB_URL must point to a throwaway database; the test
+// drops every table in it.
+func testDBURL(t *testing.T) string {
+	url := os.Getenv("TEST_DB_URL")
+	if url == "" {
+		t.Skip("TEST_DB_URL not set, skipping migration test")
+	}
+	return url
+}
+
+func countUserTables(t *testing.T, url string) int {
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	var n int
+	err = conn.QueryRow(ctx, `
+		SELECT COUNT(*) FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+	`).Scan(&n)
+	require.NoError(t, err)
+	return n
+}
+
+func TestMigrateUpThenDown_LeavesCleanSchema(t *testing.T) {
+	url := testDBURL(t)
+
+	require.NoError(t, database.MigrateUp(url))
+	assert.Greater(t, countUserTables(t, url), 0)
+
+	m, err := database.NewMigrator(url)
+	require.NoError(t, err)
+	defer m.Close()
+
+	err = m.Down()
+	if err != nil {
+		assert.ErrorIs(t, err, migrate.ErrNoChange)
+	}
+
+	assert.Equal(t, 0, countUserTables(t, url))
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStats is the part of pgxpool.Stat exported as metrics
+type PoolStats struct {
+	Acquired int32 `json:"acquired"`
+	Idle     int32 `json:"idle"`
+	Total    int32 `json:"total"`
+	Max      int32 `json:"max"`
+}
+
+// PoolStatSource reads the current pool stats; stub di test
+type PoolStatSource func() PoolStats
+
+// PgxPoolStats adapts pool.Stat to a PoolStatSource
+func PgxPoolStats(pool *pgxpool.Pool) PoolStatSource {
+	return func() PoolStats {
+		s := pool.Stat()
+		return PoolStats{
+			Acquired: s.AcquiredConns(),
+			Idle:     s.IdleConns(),
+			Total:    s.TotalConns(),
+			Max:      s.MaxConns(),
+		}
+	}
+}
+
+// PoolMetrics copies pool stats into Prometheus gauges on every Update
+// and keeps the last snapshot for /readyz
+type PoolMetrics struct {
+	source PoolStatSource
+
+	acquired prometheus.Gauge
+	idle     prometheus.Gauge
+	total    prometheus.Gauge
+	max      prometheus.Gauge
+
+	mu   sync.RWMutex
+	last PoolStats
+}
+
+// NewPoolMetrics registers the db_pool_* gauges with reg
+func NewPoolMetrics(reg prometheus.Registerer, source PoolStatSource) *PoolMetrics {
+	gauge := func(name, help string) prometheus.Gauge {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: help})
+		reg.MustRegister(g)
+		return g
+	}
+
+	return &PoolMetrics{
+		source:   source,
+		acquired: gauge("db_pool_acquired_conns", "Connections currently checked out of the pool."),
+		idle:     gauge("db_pool_idle_conns", "Idle connections in the pool."),
+		total:    gauge("db_pool_total_conns", "Open connections, acquired plus idle plus constructing."),
+		max:      gauge("db_pool_max_conns", "Configured maximum pool size."),
+	}
+}
+
+// Update reads the source once and sets every gauge
+func (m *PoolMetrics) Update() {
+	s := m.source()
+
+	m.acquired.Set(float64(s.Acquired))
+	m.idle.Set(float64(s.Idle))
+	m.total.Set(float64(s.Total))
+	m.max.Set(float64(s.Max))
+
+	m.mu.Lock()
+	m.last = s
+	m.mu.Unlock()
+}
+
+// Snapshot returns the stats of the last Update
+func (m *PoolMetrics) Snapshot() PoolStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+// Run updates the gauges every interval until ctx is cancelled
+func (m *PoolMetrics) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.Update()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Update()
+		}
+	}
+}
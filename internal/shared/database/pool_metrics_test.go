@@ -0,0 +1,51 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/database"
+)
+
+// gaugeValues mengumpulkan nilai semua gauge di reg per nama metric
+func gaugeValues(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	values := map[string]float64{}
+	for _, f := range families {
+		values[f.GetName()] = f.GetMetric()[0].GetGauge().GetValue()
+	}
+	return values
+}
+
+func TestPoolMetrics_Update(t *testing.T) {
+	stats := database.PoolStats{Acquired: 3, Idle: 5, Total: 8, Max: 10}
+
+	reg := prometheus.NewRegistry()
+	metrics := database.NewPoolMetrics(reg, func() database.PoolStats { return stats })
+
+	metrics.Update()
+
+	assert.Equal(t, map[string]float64{
+		"db_pool_acquired_conns": 3,
+		"db_pool_idle_conns":     5,
+		"db_pool_total_conns":    8,
+		"db_pool_max_conns":      10,
+	}, gaugeValues(t, reg))
+	assert.Equal(t, stats, metrics.Snapshot())
+
+	// pool makin penuh: update berikutnya menimpa nilai lama
+	stats = database.PoolStats{Acquired: 10, Idle: 0, Total: 10, Max: 10}
+	metrics.Update()
+
+	values := gaugeValues(t, reg)
+	assert.Equal(t, float64(10), values["db_pool_acquired_conns"])
+	assert.Equal(t, float64(0), values["db_pool_idle_conns"])
+	assert.Equal(t, stats, metrics.Snapshot())
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"go-mini-erp/internal/shared/config"
+)
+
+// NewPoolConfig parses the DSN and applies pool sizing from config.
+// Zero values keep pgxpool's defaults.
+func NewPoolConfig(cfg config.DatabaseConfig) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse database url failed: %w", err)
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if poolCfg.MinConns > poolCfg.MaxConns {
+		poolCfg.MinConns = poolCfg.MaxConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	return poolCfg, nil
+}
+
+// PoolSummary formats the effective pool settings for startup logs
+func PoolSummary(c *pgxpool.Config) string {
+	return fmt.Sprintf(
+		"max_conns=%d min_conns=%d max_conn_lifetime=%s max_conn_idle_time=%s",
+		c.MaxConns, c.MinConns, c.MaxConnLifetime, c.MaxConnIdleTime,
+	)
+}
+
+// Connect creates the pool and pings it, retrying with backoff
+// until the database is reachable
+func Connect(ctx context.Context, poolCfg *pgxpool.Config, retry RetryConfig) (*pgxpool.Pool, error) {
+	return Retry(ctx, retry, func(ctx context.Context) (*pgxpool.Pool, error) {
+		pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, err
+		}
+
+		return pool, nil
+	})
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	dbgen "go-mini-erp/internal/shared/database/sqlc"
+)
+
+/*
+WithQueryTimeout wraps conn so every statement runs under its own
+context.WithTimeout, jadi satu query lambat tidak menghabiskan seluruh
+budget request. Deadline request yang lebih pendek tetap menang, dan
+pgx membatalkan query di server saat context habis. timeout <= 0 =
+conn dikembalikan apa adanya.
+
+Context Query dilepas saat rows di-Close, QueryRow saat Scan; query
+sqlc selalu melakukan keduanya sebelum return.
+*/
+func WithQueryTimeout(conn dbgen.DBTX, timeout time.Duration) dbgen.DBTX {
+	if timeout <= 0 {
+		return conn
+	}
+	return &timeoutConn{conn: conn, timeout: timeout}
+}
+
+type timeoutConn struct {
+	conn    dbgen.DBTX
+	timeout time.Duration
+}
+
+func (t *timeoutConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.conn.Exec(ctx, sql, args...)
+}
+
+func (t *timeoutConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	rows, err := t.conn.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+func (t *timeoutConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	return &timeoutRow{row: t.conn.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+type timeoutRows struct {
+	pgx.Rows
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+type timeoutRow struct {
+	row    pgx.Row
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.row.Scan(dest...)
+}
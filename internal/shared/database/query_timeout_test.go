@@ -0,0 +1,98 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/database"
+)
+
+// slowConn menahan setiap statement sampai context-nya selesai, seperti
+// query yang tidak pernah kembali
+type slowConn struct{}
+
+func (slowConn) Exec(ctx context.Context, _ string, _ ...interface{}) (pgconn.CommandTag, error) {
+	<-ctx.Done()
+	return pgconn.CommandTag{}, ctx.Err()
+}
+
+func (slowConn) Query(ctx context.Context, _ string, _ ...interface{}) (pgx.Rows, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (slowConn) QueryRow(ctx context.Context, _ string, _ ...interface{}) pgx.Row {
+	return slowRow{ctx: ctx}
+}
+
+type slowRow struct{ ctx context.Context }
+
+func (r slowRow) Scan(...any) error {
+	<-r.ctx.Done()
+	return r.ctx.Err()
+}
+
+func TestWithQueryTimeout_SlowQueryReturnsDeadlineError(t *testing.T) {
+	conn := database.WithQueryTimeout(slowConn{}, 20*time.Millisecond)
+	ctx := context.Background()
+
+	tests := map[string]func() error{
+		"Exec": func() error {
+			_, err := conn.Exec(ctx, "UPDATE roles SET name = name")
+			return err
+		},
+		"Query": func() error {
+			_, err := conn.Query(ctx, "SELECT id FROM roles")
+			return err
+		},
+		"QueryRow": func() error {
+			var id int
+			return conn.QueryRow(ctx, "SELECT 1").Scan(&id)
+		},
+	}
+
+	for name, run := range tests {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			err := run()
+
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
+
+func TestWithQueryTimeout_ShorterRequestDeadlineWins(t *testing.T) {
+	conn := database.WithQueryTimeout(slowConn{}, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := conn.Exec(ctx, "SELECT 1")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithQueryTimeout_ZeroDisables(t *testing.T) {
+	conn := slowConn{}
+
+	assert.Equal(t, conn, database.WithQueryTimeout(conn, 0))
+}
+
+// pg_sleep melewati timeout: pgx harus membatalkan query dan kembali cepat
+func TestWithQueryTimeout_Postgres(t *testing.T) {
+	_, pool := newTestDB(t)
+	conn := database.WithQueryTimeout(pool, 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := conn.Exec(context.Background(), "SELECT pg_sleep(5)")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
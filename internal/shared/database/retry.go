@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const maxRetryDelay = 30 * time.Second
+
+// RetryConfig controls bounded retry with exponential backoff
+type RetryConfig struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// OnRetry dipanggil sebelum menunggu delay (untuk logging)
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// BackoffDelay returns base * 2^(attempt-1), capped at max
+func BackoffDelay(base, max time.Duration, attempt int) time.Duration {
+	if max <= 0 {
+		max = maxRetryDelay
+	}
+
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// Retry runs fn until it succeeds, attempts are exhausted, or ctx
+// is cancelled (e.g. SIGINT during startup)
+func Retry[T any](ctx context.Context, cfg RetryConfig, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+
+	attempts := cfg.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if attempt == attempts {
+			break
+		}
+
+		delay := BackoffDelay(cfg.BaseDelay, cfg.MaxDelay, attempt)
+		if cfg.OnRetry != nil {
+			cfg.OnRetry(attempt, delay, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, fmt.Errorf("retry aborted: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	return zero, fmt.Errorf("giving up after %d attempts: %w", attempts, lastErr)
+}
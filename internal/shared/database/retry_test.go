@@ -0,0 +1,87 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/database"
+)
+
+// stubConnector fails the first `failures` calls
+type stubConnector struct {
+	failures int
+	calls    int
+}
+
+func (s *stubConnector) connect(ctx context.Context) (string, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return "", errors.New("connection refused")
+	}
+	return "pool", nil
+}
+
+func TestBackoffDelay_Schedule(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := time.Second
+
+	assert.Equal(t, 100*time.Millisecond, database.BackoffDelay(base, max, 1))
+	assert.Equal(t, 200*time.Millisecond, database.BackoffDelay(base, max, 2))
+	assert.Equal(t, 400*time.Millisecond, database.BackoffDelay(base, max, 3))
+	assert.Equal(t, 800*time.Millisecond, database.BackoffDelay(base, max, 4))
+	assert.Equal(t, time.Second, database.BackoffDelay(base, max, 5))
+	assert.Equal(t, time.Second, database.BackoffDelay(base, max, 10))
+}
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	stub := &stubConnector{failures: 3}
+	var delays []time.Duration
+
+	result, err := database.Retry(context.Background(), database.RetryConfig{
+		Attempts:  5,
+		BaseDelay: time.Millisecond,
+		OnRetry: func(attempt int, delay time.Duration, err error) {
+			delays = append(delays, delay)
+		},
+	}, stub.connect)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pool", result)
+	assert.Equal(t, 4, stub.calls)
+	assert.Equal(t, []time.Duration{
+		time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond,
+	}, delays)
+}
+
+func TestRetry_GivesUpAfterAttempts(t *testing.T) {
+	stub := &stubConnector{failures: 10}
+
+	_, err := database.Retry(context.Background(), database.RetryConfig{
+		Attempts:  3,
+		BaseDelay: time.Millisecond,
+	}, stub.connect)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Equal(t, 3, stub.calls)
+}
+
+func TestRetry_AbortsOnContextCancel(t *testing.T) {
+	stub := &stubConnector{failures: 10}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := database.Retry(ctx, database.RetryConfig{
+		Attempts:  5,
+		BaseDelay: time.Hour,
+		OnRetry: func(attempt int, delay time.Duration, err error) {
+			cancel()
+		},
+	}, stub.connect)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, stub.calls)
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go-mini-erp/db/migrations"
+	dbgen "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// ErrSchemaBehind means the database is missing migrations embedded in
+// this binary, or the last migration failed halfway (dirty)
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// SchemaVersion is the applied migration version read from schema_migrations
+type SchemaVersion struct {
+	Version uint
+	Dirty   bool
+}
+
+func (v SchemaVersion) String() string {
+	if v.Dirty {
+		return fmt.Sprintf("%d (dirty)", v.Version)
+	}
+	return fmt.Sprintf("%d", v.Version)
+}
+
+// SchemaVersionSource reads the applied version; stub di test
+type SchemaVersionSource func(ctx context.Context) (SchemaVersion, error)
+
+// ExpectedSchemaVersion returns the highest migration version embedded
+// in the binary
+func ExpectedSchemaVersion() (uint, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("open embedded migrations failed: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("read embedded migrations failed: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read embedded migrations failed: %w", err)
+		}
+		version = next
+	}
+}
+
+// PgxSchemaVersion reads schema_migrations (tabel milik golang-migrate).
+// Database yang belum pernah di-migrate dilaporkan sebagai versi 0.
+func PgxSchemaVersion(conn dbgen.DBTX) SchemaVersionSource {
+	return func(ctx context.Context) (SchemaVersion, error) {
+		var v SchemaVersion
+		var version int64
+		err := conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &v.Dirty)
+		if errors.Is(err, pgx.ErrNoRows) || isUndefinedTable(err) {
+			return SchemaVersion{}, nil
+		}
+		if err != nil {
+			return SchemaVersion{}, fmt.Errorf("read schema version failed: %w", err)
+		}
+		v.Version = uint(version)
+		return v, nil
+	}
+}
+
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+}
+
+/*
+CheckSchemaVersion membandingkan versi database dengan expected.
+Database di belakang (atau dirty) menghasilkan ErrSchemaBehind karena
+query sqlc akan gagal dengan error yang membingungkan. Database yang
+lebih baru diterima: saat rolling deploy instance lama tetap berjalan
+setelah instance baru menjalankan migration.
+*/
+func CheckSchemaVersion(ctx context.Context, source SchemaVersionSource, expected uint) (SchemaVersion, error) {
+	actual, err := source(ctx)
+	if err != nil {
+		return actual, err
+	}
+	if actual.Dirty || actual.Version < expected {
+		return actual, fmt.Errorf("%w: expected version %d, got %s", ErrSchemaBehind, expected, actual)
+	}
+	return actual, nil
+}
+
+// SchemaCheck adapts CheckSchemaVersion to a /readyz check
+func SchemaCheck(source SchemaVersionSource, expected uint) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := CheckSchemaVersion(ctx, source, expected)
+		return err
+	}
+}
@@ -0,0 +1,92 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/db/migrations"
+	"go-mini-erp/internal/health"
+	"go-mini-erp/internal/shared/database"
+)
+
+func stubSchema(v database.SchemaVersion, err error) database.SchemaVersionSource {
+	return func(ctx context.Context) (database.SchemaVersion, error) { return v, err }
+}
+
+func TestExpectedSchemaVersion(t *testing.T) {
+	// migration bernomor urut, jadi versi tertinggi = jumlah file up
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+
+	version, err := database.ExpectedSchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint(len(ups)), version)
+}
+
+func TestCheckSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		actual  database.SchemaVersion
+		wantErr bool
+	}{
+		{"up to date", database.SchemaVersion{Version: 10}, false},
+		{"newer than binary", database.SchemaVersion{Version: 11}, false},
+		{"behind", database.SchemaVersion{Version: 9}, true},
+		{"never migrated", database.SchemaVersion{}, true},
+		{"dirty", database.SchemaVersion{Version: 10, Dirty: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := database.CheckSchemaVersion(context.Background(), stubSchema(tt.actual, nil), 10)
+
+			assert.Equal(t, tt.actual, actual)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, database.ErrSchemaBehind)
+				assert.Contains(t, err.Error(), "expected version 10, got "+tt.actual.String())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckSchemaVersion_SourceError(t *testing.T) {
+	readErr := errors.New("connection refused")
+
+	_, err := database.CheckSchemaVersion(context.Background(), stubSchema(database.SchemaVersion{}, readErr), 10)
+
+	assert.ErrorIs(t, err, readErr)
+	assert.NotErrorIs(t, err, database.ErrSchemaBehind)
+}
+
+func TestSchemaCheck_ReadyzUnavailableWhenBehind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := health.NewHandler(time.Second)
+	h.AddCheck("schema", database.SchemaCheck(stubSchema(database.SchemaVersion{Version: 9}, nil), 10))
+
+	router := gin.New()
+	h.RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var res health.ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, health.StatusUnavailable, res.Status)
+	assert.Equal(t, health.StatusDown, res.Checks["schema"].Status)
+	assert.Contains(t, res.Checks["schema"].Error, "expected version 10, got 9")
+}
@@ -12,6 +12,68 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const activateUserByToken = `-- name: ActivateUserByToken :one
+WITH consumed AS (
+    DELETE FROM user_activations
+    WHERE token_hash = $1
+        AND expires_at > $2
+    RETURNING user_id
+)
+UPDATE users
+SET is_active = true,
+    updated_at = NOW()
+FROM consumed
+WHERE users.id = consumed.user_id
+    AND users.deleted_at IS NULL
+RETURNING users.id
+`
+
+type ActivateUserByTokenParams struct {
+	TokenHash string             `json:"token_hash"`
+	Now       pgtype.Timestamptz `json:"now"`
+}
+
+// token dipakai sekali: row aktivasi dihapus dan user diaktifkan dalam satu
+// statement; no rows = token tidak dikenal, sudah dipakai, atau expired
+func (q *Queries) ActivateUserByToken(ctx context.Context, arg ActivateUserByTokenParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, activateUserByToken, arg.TokenHash, arg.Now)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const anonymizeUser = `-- name: AnonymizeUser :execrows
+UPDATE users
+SET username = 'deleted-' || replace(id::text, '-', ''),
+    email = 'deleted-' || id::text || '@invalid',
+    password_hash = '',
+    full_name = 'Deleted user',
+    is_active = false,
+    last_login_at = NULL,
+    deletion_due_at = NULL,
+    deleted_at = $1,
+    updated_at = NOW()
+WHERE id = $2
+    AND deleted_at IS NULL
+    AND deletion_due_at <= $1
+`
+
+type AnonymizeUserParams struct {
+	Now pgtype.Timestamptz `json:"now"`
+	ID  uuid.UUID          `json:"id"`
+}
+
+// Baris user tetap ada karena direferensikan created_by/updated_by di
+// tabel lain; data pribadinya diganti. Cek deletion_due_at diulang di
+// sini supaya cancel yang datang bersamaan dengan worker tetap menang.
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeUser, arg.Now, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const assignRoleToUser = `-- name: AssignRoleToUser :one
 INSERT INTO user_roles (
     user_id,
@@ -48,10 +110,28 @@ func (q *Queries) AssignRoleToUser(ctx context.Context, arg AssignRoleToUserPara
 	return i, err
 }
 
+const cancelUserDeletion = `-- name: CancelUserDeletion :execrows
+UPDATE users
+SET deletion_due_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NULL
+    AND deletion_due_at IS NOT NULL
+`
+
+// 0 row = user tidak sedang dijadwalkan untuk dihapus
+func (q *Queries) CancelUserDeletion(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelUserDeletion, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const checkEmailExists = `-- name: CheckEmailExists :one
 SELECT EXISTS(
     SELECT 1 FROM users 
-    WHERE email = $1 
+    WHERE lower(email) = lower($1) 
     AND deleted_at IS NULL
 ) as exists
 `
@@ -66,7 +146,7 @@ func (q *Queries) CheckEmailExists(ctx context.Context, email string) (bool, err
 const checkUsernameExists = `-- name: CheckUsernameExists :one
 SELECT EXISTS(
     SELECT 1 FROM users 
-    WHERE username = $1 
+    WHERE lower(username) = lower($1) 
     AND deleted_at IS NULL
 ) as exists
 `
@@ -78,25 +158,100 @@ func (q *Queries) CheckUsernameExists(ctx context.Context, username string) (boo
 	return exists, err
 }
 
+const countRevokedTokens = `-- name: CountRevokedTokens :one
+SELECT COUNT(*)
+FROM revoked_tokens
+WHERE revoked_at > $1
+`
+
+func (q *Queries) CountRevokedTokens(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countRevokedTokens, since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuditLog = `-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (
+    user_id,
+    table_name,
+    record_id,
+    action,
+    new_values
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+`
+
+type CreateAuditLogParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	TableName string      `json:"table_name"`
+	RecordID  uuid.UUID   `json:"record_id"`
+	Action    string      `json:"action"`
+	NewValues []byte      `json:"new_values"`
+}
+
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error {
+	_, err := q.db.Exec(ctx, createAuditLog,
+		arg.UserID,
+		arg.TableName,
+		arg.RecordID,
+		arg.Action,
+		arg.NewValues,
+	)
+	return err
+}
+
+const createRefreshToken = `-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (
+    id,
+    user_id,
+    family_id,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type CreateRefreshTokenParams struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	FamilyID  uuid.UUID          `json:"family_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
+	_, err := q.db.Exec(ctx, createRefreshToken,
+		arg.ID,
+		arg.UserID,
+		arg.FamilyID,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     username,
     email,
     password_hash,
     full_name,
-    is_active
+    is_active,
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $6
 )
 RETURNING id, username, email, full_name, is_active, created_at
 `
 
 type CreateUserParams struct {
-	Username     string `json:"username"`
-	Email        string `json:"email"`
-	PasswordHash string `json:"password_hash"`
-	FullName     string `json:"full_name"`
-	IsActive     *bool  `json:"is_active"`
+	Username     string      `json:"username"`
+	Email        string      `json:"email"`
+	PasswordHash string      `json:"password_hash"`
+	FullName     string      `json:"full_name"`
+	IsActive     *bool       `json:"is_active"`
+	CreatedBy    pgtype.UUID `json:"created_by"`
 }
 
 type CreateUserRow struct {
@@ -115,6 +270,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		arg.PasswordHash,
 		arg.FullName,
 		arg.IsActive,
+		arg.CreatedBy,
 	)
 	var i CreateUserRow
 	err := row.Scan(
@@ -128,6 +284,125 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 	return i, err
 }
 
+const createUserActivation = `-- name: CreateUserActivation :exec
+INSERT INTO user_activations (
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW()
+`
+
+type CreateUserActivationParams struct {
+	UserID    uuid.UUID          `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+// register ulang tidak mungkin (email unik), tapi token baru menggantikan
+// yang lama kalau suatu saat dikirim ulang
+func (q *Queries) CreateUserActivation(ctx context.Context, arg CreateUserActivationParams) error {
+	_, err := q.db.Exec(ctx, createUserActivation, arg.UserID, arg.TokenHash, arg.ExpiresAt)
+	return err
+}
+
+const deleteRefreshTokenFamily = `-- name: DeleteRefreshTokenFamily :exec
+DELETE FROM refresh_tokens
+WHERE family_id = $1
+    AND user_id = $2
+`
+
+type DeleteRefreshTokenFamilyParams struct {
+	FamilyID uuid.UUID `json:"family_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+// logout: semua refresh token sesi ini (termasuk yang sudah di-rotate)
+// dihapus, jadi dipakai lagi = invalid token, bukan reuse
+func (q *Queries) DeleteRefreshTokenFamily(ctx context.Context, arg DeleteRefreshTokenFamilyParams) error {
+	_, err := q.db.Exec(ctx, deleteRefreshTokenFamily, arg.FamilyID, arg.UserID)
+	return err
+}
+
+const deleteUserRefreshTokens = `-- name: DeleteUserRefreshTokens :exec
+DELETE FROM refresh_tokens
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserRefreshTokens, userID)
+	return err
+}
+
+const deleteUserRoles = `-- name: DeleteUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserRoles(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserRoles, userID)
+	return err
+}
+
+const getProfileVersion = `-- name: GetProfileVersion :one
+SELECT
+    u.updated_at,
+    v.version AS rbac_version
+FROM users u
+CROSS JOIN rbac_version v
+WHERE u.id = $1
+    AND u.deleted_at IS NULL
+`
+
+type GetProfileVersionRow struct {
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	RbacVersion int64              `json:"rbac_version"`
+}
+
+// Bahan ETag GetProfile: updated_at user ditambah counter rbac_version
+// yang naik setiap role/menu/assignment berubah.
+func (q *Queries) GetProfileVersion(ctx context.Context, id uuid.UUID) (GetProfileVersionRow, error) {
+	row := q.db.QueryRow(ctx, getProfileVersion, id)
+	var i GetProfileVersionRow
+	err := row.Scan(&i.UpdatedAt, &i.RbacVersion)
+	return i, err
+}
+
+const getRefreshToken = `-- name: GetRefreshToken :one
+SELECT id, user_id, family_id, expires_at, revoked_at, replaced_by
+FROM refresh_tokens
+WHERE id = $1
+LIMIT 1
+`
+
+type GetRefreshTokenRow struct {
+	ID         uuid.UUID          `json:"id"`
+	UserID     uuid.UUID          `json:"user_id"`
+	FamilyID   uuid.UUID          `json:"family_id"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	ReplacedBy pgtype.UUID        `json:"replaced_by"`
+}
+
+func (q *Queries) GetRefreshToken(ctx context.Context, id uuid.UUID) (GetRefreshTokenRow, error) {
+	row := q.db.QueryRow(ctx, getRefreshToken, id)
+	var i GetRefreshTokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FamilyID,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ReplacedBy,
+	)
+	return i, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT 
     id,
@@ -137,24 +412,26 @@ SELECT
     full_name,
     is_active,
     last_login_at,
+    deletion_due_at,
     created_at,
     updated_at
 FROM users
-WHERE email = $1 
+WHERE lower(email) = lower($1) 
     AND deleted_at IS NULL
 LIMIT 1
 `
 
 type GetUserByEmailRow struct {
-	ID           uuid.UUID          `json:"id"`
-	Username     string             `json:"username"`
-	Email        string             `json:"email"`
-	PasswordHash string             `json:"password_hash"`
-	FullName     string             `json:"full_name"`
-	IsActive     *bool              `json:"is_active"`
-	LastLoginAt  pgtype.Timestamptz `json:"last_login_at"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	ID            uuid.UUID          `json:"id"`
+	Username      string             `json:"username"`
+	Email         string             `json:"email"`
+	PasswordHash  string             `json:"password_hash"`
+	FullName      string             `json:"full_name"`
+	IsActive      *bool              `json:"is_active"`
+	LastLoginAt   pgtype.Timestamptz `json:"last_login_at"`
+	DeletionDueAt pgtype.Timestamptz `json:"deletion_due_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
@@ -168,6 +445,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.FullName,
 		&i.IsActive,
 		&i.LastLoginAt,
+		&i.DeletionDueAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -229,7 +507,7 @@ SELECT
     created_at,
     updated_at
 FROM users
-WHERE username = $1 
+WHERE lower(username) = lower($1) 
     AND deleted_at IS NULL
 LIMIT 1
 `
@@ -263,73 +541,104 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUs
 	return i, err
 }
 
-const getUserMenus = `-- name: GetUserMenus :many
-SELECT 
-    m.id,
-    m.parent_id,
-    m.code,
-    m.name,
-    m.path,
-    m.icon,
-    m.sort_order,
-    -- Cast hasil MAX ke boolean agar SQLC men-generate tipe bool
-    (MAX(rm.can_create::int) > 0)::BOOLEAN as can_create,
-    (MAX(rm.can_read::int) > 0)::BOOLEAN as can_read,
-    (MAX(rm.can_update::int) > 0)::BOOLEAN as can_update,
-    (MAX(rm.can_delete::int) > 0)::BOOLEAN as can_delete
-FROM menus m
-INNER JOIN role_menus rm ON m.id = rm.menu_id
-INNER JOIN user_roles ur ON rm.role_id = ur.role_id
-WHERE ur.user_id = $1 
-    AND m.is_active = true
-GROUP BY m.id, m.parent_id, m.code, m.name, m.path, m.icon, m.sort_order
-ORDER BY m.sort_order, m.name
-`
-
-type GetUserMenusRow struct {
-	ID        uuid.UUID   `json:"id"`
-	ParentID  pgtype.UUID `json:"parent_id"`
-	Code      string      `json:"code"`
-	Name      string      `json:"name"`
-	Path      *string     `json:"path"`
-	Icon      *string     `json:"icon"`
-	SortOrder *int32      `json:"sort_order"`
-	CanCreate bool        `json:"can_create"`
-	CanRead   bool        `json:"can_read"`
-	CanUpdate bool        `json:"can_update"`
-	CanDelete bool        `json:"can_delete"`
-}
-
-func (q *Queries) GetUserMenus(ctx context.Context, userID uuid.UUID) ([]GetUserMenusRow, error) {
-	rows, err := q.db.Query(ctx, getUserMenus, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetUserMenusRow
-	for rows.Next() {
-		var i GetUserMenusRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.ParentID,
-			&i.Code,
-			&i.Name,
-			&i.Path,
-			&i.Icon,
-			&i.SortOrder,
-			&i.CanCreate,
-			&i.CanRead,
-			&i.CanUpdate,
-			&i.CanDelete,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+const getUserPasswordHash = `-- name: GetUserPasswordHash :one
+SELECT password_hash
+FROM users
+WHERE id = $1
+    AND deleted_at IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getUserPasswordHash, id)
+	var password_hash string
+	err := row.Scan(&password_hash)
+	return password_hash, err
+}
+
+const getUserProfile = `-- name: GetUserProfile :one
+SELECT
+    u.id,
+    u.username,
+    u.email,
+    u.full_name,
+    u.is_active,
+    u.last_login_at,
+    u.created_at,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object(
+            'id', r.id,
+            'code', r.code,
+            'name', r.name,
+            'description', r.description,
+            'assigned_at', ur.assigned_at
+        ) ORDER BY r.name)
+        FROM roles r
+        INNER JOIN user_roles ur ON r.id = ur.role_id
+        WHERE ur.user_id = u.id
+            AND r.is_active = true
+    ), '[]'::jsonb)::jsonb AS roles,
+    COALESCE((
+        SELECT jsonb_agg(to_jsonb(um) ORDER BY um.parent_id NULLS FIRST, um.sort_order, um.name, um.code)
+        FROM (
+            SELECT
+                m.id,
+                m.parent_id,
+                m.code,
+                m.name,
+                m.path,
+                m.icon,
+                m.sort_order,
+                (MAX(rm.can_create::int) > 0) AS can_create,
+                (MAX(rm.can_read::int) > 0) AS can_read,
+                (MAX(rm.can_update::int) > 0) AS can_update,
+                (MAX(rm.can_delete::int) > 0) AS can_delete
+            FROM menus m
+            INNER JOIN role_menus rm ON m.id = rm.menu_id
+            INNER JOIN user_roles ur ON rm.role_id = ur.role_id
+            WHERE ur.user_id = u.id
+                AND m.is_active = true
+            GROUP BY m.id, m.parent_id, m.code, m.name, m.path, m.icon, m.sort_order
+        ) um
+    ), '[]'::jsonb)::jsonb AS menus
+FROM users u
+WHERE u.id = $1
+    AND u.deleted_at IS NULL
+LIMIT 1
+`
+
+type GetUserProfileRow struct {
+	ID          uuid.UUID          `json:"id"`
+	Username    string             `json:"username"`
+	Email       string             `json:"email"`
+	FullName    string             `json:"full_name"`
+	IsActive    *bool              `json:"is_active"`
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	Roles       []byte             `json:"roles"`
+	Menus       []byte             `json:"menus"`
+}
+
+// User, role dan menu dalam satu round-trip untuk GetProfile. Role dan
+// menu dikirim sebagai array JSON (di-decode di auth_mapper.go);
+// user tanpa role/menu mendapat '[]'. Urutan menu dibuat total (root
+// dulu, lalu sort_order, name, code) supaya sidebar tidak berubah-ubah
+// antar request.
+func (q *Queries) GetUserProfile(ctx context.Context, id uuid.UUID) (GetUserProfileRow, error) {
+	row := q.db.QueryRow(ctx, getUserProfile, id)
+	var i GetUserProfileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.IsActive,
+		&i.LastLoginAt,
+		&i.CreatedAt,
+		&i.Roles,
+		&i.Menus,
+	)
+	return i, err
 }
 
 const getUserRoles = `-- name: GetUserRoles :many
@@ -380,6 +689,120 @@ func (q *Queries) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]GetUser
 	return items, nil
 }
 
+const hasPendingActivation = `-- name: HasPendingActivation :one
+SELECT EXISTS (
+    SELECT 1 FROM user_activations WHERE user_id = $1
+)
+`
+
+func (q *Queries) HasPendingActivation(ctx context.Context, userID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, hasPendingActivation, userID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const isAccessTokenRevoked = `-- name: IsAccessTokenRevoked :one
+SELECT EXISTS (
+    SELECT 1 FROM revoked_tokens WHERE jti = $1
+)
+`
+
+func (q *Queries) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isAccessTokenRevoked, jti)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listRevokedTokens = `-- name: ListRevokedTokens :many
+SELECT jti, expires_at, revoked_at
+FROM revoked_tokens
+WHERE revoked_at > $1
+ORDER BY revoked_at, jti
+LIMIT $3
+OFFSET $2
+`
+
+type ListRevokedTokensParams struct {
+	Since       pgtype.Timestamptz `json:"since"`
+	OffsetCount int32              `json:"offset_count"`
+	LimitCount  int32              `json:"limit_count"`
+}
+
+type ListRevokedTokensRow struct {
+	Jti       uuid.UUID          `json:"jti"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+func (q *Queries) ListRevokedTokens(ctx context.Context, arg ListRevokedTokensParams) ([]ListRevokedTokensRow, error) {
+	rows, err := q.db.Query(ctx, listRevokedTokens, arg.Since, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRevokedTokensRow
+	for rows.Next() {
+		var i ListRevokedTokensRow
+		if err := rows.Scan(&i.Jti, &i.ExpiresAt, &i.RevokedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersDueForDeletion = `-- name: ListUsersDueForDeletion :many
+SELECT id
+FROM users
+WHERE deletion_due_at <= $1
+    AND deleted_at IS NULL
+ORDER BY deletion_due_at, id
+LIMIT $2
+`
+
+type ListUsersDueForDeletionParams struct {
+	Now        pgtype.Timestamptz `json:"now"`
+	LimitCount int32              `json:"limit_count"`
+}
+
+func (q *Queries) ListUsersDueForDeletion(ctx context.Context, arg ListUsersDueForDeletionParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listUsersDueForDeletion, arg.Now, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAdminRole = `-- name: LockAdminRole :exec
+SELECT id FROM roles
+WHERE code = 'admin'
+FOR UPDATE
+`
+
+// Dipanggil di awal transaksi yang bisa menghabiskan admin aktif (hapus
+// akun) supaya dua transaksi tidak sama-sama melihat admin lain tersisa.
+func (q *Queries) LockAdminRole(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockAdminRole)
+	return err
+}
+
 const removeRoleFromUser = `-- name: RemoveRoleFromUser :exec
 DELETE FROM user_roles
 WHERE user_id = $1 AND role_id = $2
@@ -395,14 +818,86 @@ func (q *Queries) RemoveRoleFromUser(ctx context.Context, arg RemoveRoleFromUser
 	return err
 }
 
+const revokeAccessToken = `-- name: RevokeAccessToken :exec
+INSERT INTO revoked_tokens (
+    jti,
+    user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (jti) DO NOTHING
+`
+
+type RevokeAccessTokenParams struct {
+	Jti       uuid.UUID          `json:"jti"`
+	UserID    uuid.UUID          `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) RevokeAccessToken(ctx context.Context, arg RevokeAccessTokenParams) error {
+	_, err := q.db.Exec(ctx, revokeAccessToken, arg.Jti, arg.UserID, arg.ExpiresAt)
+	return err
+}
+
+const rotateRefreshToken = `-- name: RotateRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = NOW(),
+    replaced_by = $1
+WHERE id = $2
+    AND revoked_at IS NULL
+`
+
+type RotateRefreshTokenParams struct {
+	ReplacedBy pgtype.UUID `json:"replaced_by"`
+	ID         uuid.UUID   `json:"id"`
+}
+
+// hanya token yang belum di-revoke; 0 row = sudah dipakai (reuse/race)
+func (q *Queries) RotateRefreshToken(ctx context.Context, arg RotateRefreshTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rotateRefreshToken, arg.ReplacedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const scheduleUserDeletion = `-- name: ScheduleUserDeletion :one
+UPDATE users
+SET deletion_due_at = COALESCE(deletion_due_at, $1),
+    updated_at = NOW()
+WHERE id = $2
+    AND deleted_at IS NULL
+RETURNING deletion_due_at
+`
+
+type ScheduleUserDeletionParams struct {
+	DueAt pgtype.Timestamptz `json:"due_at"`
+	ID    uuid.UUID          `json:"id"`
+}
+
+// Jadwal yang sudah ada tidak digeser: mengulang DELETE /auth/account
+// mengembalikan tanggal yang sama.
+func (q *Queries) ScheduleUserDeletion(ctx context.Context, arg ScheduleUserDeletionParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, scheduleUserDeletion, arg.DueAt, arg.ID)
+	var deletion_due_at pgtype.Timestamptz
+	err := row.Scan(&deletion_due_at)
+	return deletion_due_at, err
+}
+
 const updateUserLastLogin = `-- name: UpdateUserLastLogin :exec
 UPDATE users
-SET last_login_at = NOW(),
+SET last_login_at = $1,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = $2
 `
 
-func (q *Queries) UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, updateUserLastLogin, id)
+type UpdateUserLastLoginParams struct {
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+	ID          uuid.UUID          `json:"id"`
+}
+
+func (q *Queries) UpdateUserLastLogin(ctx context.Context, arg UpdateUserLastLoginParams) error {
+	_, err := q.db.Exec(ctx, updateUserLastLogin, arg.LastLoginAt, arg.ID)
 	return err
 }
@@ -12,6 +12,19 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countProductStockMovements = `-- name: CountProductStockMovements :one
+SELECT COUNT(*)
+FROM stock_movements
+WHERE product_id = $1
+`
+
+func (q *Queries) CountProductStockMovements(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductStockMovements, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (
     code,
@@ -70,9 +83,11 @@ INSERT INTO products (
     sale_price,
     min_stock,
     max_stock,
-    is_active
+    is_active,
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12
 )
 RETURNING id, code, name, created_at
 `
@@ -89,6 +104,7 @@ type CreateProductParams struct {
 	MinStock    pgtype.Numeric `json:"min_stock"`
 	MaxStock    pgtype.Numeric `json:"max_stock"`
 	IsActive    *bool          `json:"is_active"`
+	CreatedBy   pgtype.UUID    `json:"created_by"`
 }
 
 type CreateProductRow struct {
@@ -111,6 +127,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (C
 		arg.MinStock,
 		arg.MaxStock,
 		arg.IsActive,
+		arg.CreatedBy,
 	)
 	var i CreateProductRow
 	err := row.Scan(
@@ -487,7 +504,9 @@ SELECT
     p.max_stock,
     p.is_active,
     p.created_at,
-    p.updated_at
+    p.updated_at,
+    p.created_by,
+    p.updated_by
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 INNER JOIN units_of_measure u ON p.uom_id = u.id
@@ -514,6 +533,8 @@ type GetProductByIDRow struct {
 	IsActive     *bool              `json:"is_active"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy    pgtype.UUID        `json:"created_by"`
+	UpdatedBy    pgtype.UUID        `json:"updated_by"`
 }
 
 func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (GetProductByIDRow, error) {
@@ -537,10 +558,26 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (GetProductB
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
 	)
 	return i, err
 }
 
+const getProductReorderPoint = `-- name: GetProductReorderPoint :one
+SELECT reorder_point
+FROM products
+WHERE id = $1
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) GetProductReorderPoint(ctx context.Context, id uuid.UUID) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, getProductReorderPoint, id)
+	var reorder_point pgtype.Numeric
+	err := row.Scan(&reorder_point)
+	return reorder_point, err
+}
+
 const getStockAdjustmentByID = `-- name: GetStockAdjustmentByID :one
 SELECT 
     sa.id,
@@ -917,6 +954,186 @@ func (q *Queries) ListActiveUoM(ctx context.Context) ([]ListActiveUoMRow, error)
 	return items, nil
 }
 
+const listOpenStockAlerts = `-- name: ListOpenStockAlerts :many
+SELECT
+    a.id,
+    a.product_id,
+    p.code AS product_code,
+    p.name AS product_name,
+    a.reorder_point,
+    (
+        SELECT COALESCE(SUM(sb.quantity), 0)
+        FROM stock_balances sb
+        WHERE sb.product_id = a.product_id
+    )::numeric AS on_hand,
+    a.created_at
+FROM stock_alerts a
+INNER JOIN products p ON a.product_id = p.id
+WHERE a.resolved_at IS NULL
+    AND p.deleted_at IS NULL
+ORDER BY a.created_at DESC
+`
+
+type ListOpenStockAlertsRow struct {
+	ID           uuid.UUID          `json:"id"`
+	ProductID    uuid.UUID          `json:"product_id"`
+	ProductCode  string             `json:"product_code"`
+	ProductName  string             `json:"product_name"`
+	ReorderPoint pgtype.Numeric     `json:"reorder_point"`
+	OnHand       pgtype.Numeric     `json:"on_hand"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListOpenStockAlerts(ctx context.Context) ([]ListOpenStockAlertsRow, error) {
+	rows, err := q.db.Query(ctx, listOpenStockAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenStockAlertsRow
+	for rows.Next() {
+		var i ListOpenStockAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ProductCode,
+			&i.ProductName,
+			&i.ReorderPoint,
+			&i.OnHand,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductStockBalances = `-- name: ListProductStockBalances :many
+SELECT
+    sb.location_id,
+    sl.code as location_code,
+    sl.name as location_name,
+    sb.quantity,
+    sb.reserved_qty,
+    sb.available_qty,
+    sb.last_updated
+FROM stock_balances sb
+INNER JOIN stock_locations sl ON sb.location_id = sl.id
+WHERE sb.product_id = $1
+ORDER BY sl.name
+`
+
+type ListProductStockBalancesRow struct {
+	LocationID   uuid.UUID          `json:"location_id"`
+	LocationCode string             `json:"location_code"`
+	LocationName string             `json:"location_name"`
+	Quantity     pgtype.Numeric     `json:"quantity"`
+	ReservedQty  pgtype.Numeric     `json:"reserved_qty"`
+	AvailableQty pgtype.Numeric     `json:"available_qty"`
+	LastUpdated  pgtype.Timestamptz `json:"last_updated"`
+}
+
+func (q *Queries) ListProductStockBalances(ctx context.Context, productID uuid.UUID) ([]ListProductStockBalancesRow, error) {
+	rows, err := q.db.Query(ctx, listProductStockBalances, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductStockBalancesRow
+	for rows.Next() {
+		var i ListProductStockBalancesRow
+		if err := rows.Scan(
+			&i.LocationID,
+			&i.LocationCode,
+			&i.LocationName,
+			&i.Quantity,
+			&i.ReservedQty,
+			&i.AvailableQty,
+			&i.LastUpdated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductStockMovements = `-- name: ListProductStockMovements :many
+SELECT
+    sm.id,
+    sm.movement_number,
+    sm.location_id,
+    sl.name as location_name,
+    sm.movement_type,
+    sm.quantity,
+    sm.reason,
+    sm.reference,
+    sm.movement_date,
+    sm.created_by
+FROM stock_movements sm
+INNER JOIN stock_locations sl ON sm.location_id = sl.id
+WHERE sm.product_id = $1
+ORDER BY sm.movement_date DESC, sm.created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+type ListProductStockMovementsParams struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	OffsetCount int32     `json:"offset_count"`
+	LimitCount  int32     `json:"limit_count"`
+}
+
+type ListProductStockMovementsRow struct {
+	ID             uuid.UUID          `json:"id"`
+	MovementNumber string             `json:"movement_number"`
+	LocationID     uuid.UUID          `json:"location_id"`
+	LocationName   string             `json:"location_name"`
+	MovementType   string             `json:"movement_type"`
+	Quantity       pgtype.Numeric     `json:"quantity"`
+	Reason         *string            `json:"reason"`
+	Reference      *string            `json:"reference"`
+	MovementDate   pgtype.Timestamptz `json:"movement_date"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+}
+
+func (q *Queries) ListProductStockMovements(ctx context.Context, arg ListProductStockMovementsParams) ([]ListProductStockMovementsRow, error) {
+	rows, err := q.db.Query(ctx, listProductStockMovements, arg.ProductID, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductStockMovementsRow
+	for rows.Next() {
+		var i ListProductStockMovementsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MovementNumber,
+			&i.LocationID,
+			&i.LocationName,
+			&i.MovementType,
+			&i.Quantity,
+			&i.Reason,
+			&i.Reference,
+			&i.MovementDate,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStockAdjustments = `-- name: ListStockAdjustments :many
 SELECT 
     sa.id,
@@ -1162,6 +1379,160 @@ func (q *Queries) ListStockMovements(ctx context.Context, arg ListStockMovements
 	return items, nil
 }
 
+const lockStockBalance = `-- name: LockStockBalance :one
+SELECT quantity
+FROM stock_balances
+WHERE product_id = $1
+    AND location_id = $2
+FOR UPDATE
+`
+
+type LockStockBalanceParams struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	LocationID uuid.UUID `json:"location_id"`
+}
+
+func (q *Queries) LockStockBalance(ctx context.Context, arg LockStockBalanceParams) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, lockStockBalance, arg.ProductID, arg.LocationID)
+	var quantity pgtype.Numeric
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const openStockAlert = `-- name: OpenStockAlert :execrows
+INSERT INTO stock_alerts (
+    product_id,
+    on_hand,
+    reorder_point
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (product_id) WHERE resolved_at IS NULL DO NOTHING
+`
+
+type OpenStockAlertParams struct {
+	ProductID    uuid.UUID      `json:"product_id"`
+	OnHand       pgtype.Numeric `json:"on_hand"`
+	ReorderPoint pgtype.Numeric `json:"reorder_point"`
+}
+
+// 0 row = alert untuk produk ini masih terbuka
+func (q *Queries) OpenStockAlert(ctx context.Context, arg OpenStockAlertParams) (int64, error) {
+	result, err := q.db.Exec(ctx, openStockAlert, arg.ProductID, arg.OnHand, arg.ReorderPoint)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const recordStockMovement = `-- name: RecordStockMovement :one
+INSERT INTO stock_movements (
+    movement_number,
+    product_id,
+    location_id,
+    movement_type,
+    quantity,
+    reason,
+    reference,
+    movement_date,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, NOW(), $8
+)
+RETURNING id, movement_number, product_id, location_id, movement_type, quantity, reference_type, reference_id, movement_date, notes, created_by, created_at, reason, reference
+`
+
+type RecordStockMovementParams struct {
+	MovementNumber string         `json:"movement_number"`
+	ProductID      uuid.UUID      `json:"product_id"`
+	LocationID     uuid.UUID      `json:"location_id"`
+	MovementType   string         `json:"movement_type"`
+	Quantity       pgtype.Numeric `json:"quantity"`
+	Reason         *string        `json:"reason"`
+	Reference      *string        `json:"reference"`
+	CreatedBy      pgtype.UUID    `json:"created_by"`
+}
+
+func (q *Queries) RecordStockMovement(ctx context.Context, arg RecordStockMovementParams) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, recordStockMovement,
+		arg.MovementNumber,
+		arg.ProductID,
+		arg.LocationID,
+		arg.MovementType,
+		arg.Quantity,
+		arg.Reason,
+		arg.Reference,
+		arg.CreatedBy,
+	)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.MovementNumber,
+		&i.ProductID,
+		&i.LocationID,
+		&i.MovementType,
+		&i.Quantity,
+		&i.ReferenceType,
+		&i.ReferenceID,
+		&i.MovementDate,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Reason,
+		&i.Reference,
+	)
+	return i, err
+}
+
+const resolveStockAlert = `-- name: ResolveStockAlert :execrows
+UPDATE stock_alerts
+SET resolved_at = NOW()
+WHERE product_id = $1
+    AND resolved_at IS NULL
+`
+
+func (q *Queries) ResolveStockAlert(ctx context.Context, productID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, resolveStockAlert, productID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setProductReorderPoint = `-- name: SetProductReorderPoint :execrows
+UPDATE products
+SET reorder_point = $1,
+    updated_at = NOW()
+WHERE id = $2
+    AND deleted_at IS NULL
+`
+
+type SetProductReorderPointParams struct {
+	ReorderPoint pgtype.Numeric `json:"reorder_point"`
+	ID           uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) SetProductReorderPoint(ctx context.Context, arg SetProductReorderPointParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setProductReorderPoint, arg.ReorderPoint, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const sumProductOnHand = `-- name: SumProductOnHand :one
+SELECT COALESCE(SUM(quantity), 0)::numeric AS on_hand
+FROM stock_balances
+WHERE product_id = $1
+`
+
+func (q *Queries) SumProductOnHand(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, sumProductOnHand, productID)
+	var on_hand pgtype.Numeric
+	err := row.Scan(&on_hand)
+	return on_hand, err
+}
+
 const updateProduct = `-- name: UpdateProduct :exec
 UPDATE products
 SET name = $2,
@@ -1172,6 +1543,7 @@ SET name = $2,
     min_stock = $7,
     max_stock = $8,
     is_active = $9,
+    updated_by = $10,
     updated_at = NOW()
 WHERE id = $1 
     AND deleted_at IS NULL
@@ -1187,6 +1559,7 @@ type UpdateProductParams struct {
 	MinStock    pgtype.Numeric `json:"min_stock"`
 	MaxStock    pgtype.Numeric `json:"max_stock"`
 	IsActive    *bool          `json:"is_active"`
+	UpdatedBy   pgtype.UUID    `json:"updated_by"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) error {
@@ -1200,6 +1573,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) er
 		arg.MinStock,
 		arg.MaxStock,
 		arg.IsActive,
+		arg.UpdatedBy,
 	)
 	return err
 }
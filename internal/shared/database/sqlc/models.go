@@ -117,21 +117,24 @@ type Payment struct {
 }
 
 type Product struct {
-	ID          uuid.UUID          `json:"id"`
-	Code        string             `json:"code"`
-	Name        string             `json:"name"`
-	Description *string            `json:"description"`
-	CategoryID  pgtype.UUID        `json:"category_id"`
-	UomID       uuid.UUID          `json:"uom_id"`
-	ProductType *string            `json:"product_type"`
-	CostPrice   pgtype.Numeric     `json:"cost_price"`
-	SalePrice   pgtype.Numeric     `json:"sale_price"`
-	MinStock    pgtype.Numeric     `json:"min_stock"`
-	MaxStock    pgtype.Numeric     `json:"max_stock"`
-	IsActive    *bool              `json:"is_active"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	ID           uuid.UUID          `json:"id"`
+	Code         string             `json:"code"`
+	Name         string             `json:"name"`
+	Description  *string            `json:"description"`
+	CategoryID   pgtype.UUID        `json:"category_id"`
+	UomID        uuid.UUID          `json:"uom_id"`
+	ProductType  *string            `json:"product_type"`
+	CostPrice    pgtype.Numeric     `json:"cost_price"`
+	SalePrice    pgtype.Numeric     `json:"sale_price"`
+	MinStock     pgtype.Numeric     `json:"min_stock"`
+	MaxStock     pgtype.Numeric     `json:"max_stock"`
+	IsActive     *bool              `json:"is_active"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	ReorderPoint pgtype.Numeric     `json:"reorder_point"`
+	CreatedBy    pgtype.UUID        `json:"created_by"`
+	UpdatedBy    pgtype.UUID        `json:"updated_by"`
 }
 
 type PurchaseOrder struct {
@@ -187,6 +190,28 @@ type QuotationLine struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+type RbacVersion struct {
+	ID      bool  `json:"id"`
+	Version int64 `json:"version"`
+}
+
+type RefreshToken struct {
+	ID         uuid.UUID          `json:"id"`
+	UserID     uuid.UUID          `json:"user_id"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	ReplacedBy pgtype.UUID        `json:"replaced_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	FamilyID   uuid.UUID          `json:"family_id"`
+}
+
+type RevokedToken struct {
+	Jti       uuid.UUID          `json:"jti"`
+	UserID    uuid.UUID          `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
 type Role struct {
 	ID          uuid.UUID          `json:"id"`
 	Code        string             `json:"code"`
@@ -195,6 +220,9 @@ type Role struct {
 	IsActive    *bool              `json:"is_active"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	UpdatedBy   pgtype.UUID        `json:"updated_by"`
+	Version     int32              `json:"version"`
 }
 
 type RoleMenu struct {
@@ -260,6 +288,15 @@ type StockAdjustmentLine struct {
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 }
 
+type StockAlert struct {
+	ID           uuid.UUID          `json:"id"`
+	ProductID    uuid.UUID          `json:"product_id"`
+	OnHand       pgtype.Numeric     `json:"on_hand"`
+	ReorderPoint pgtype.Numeric     `json:"reorder_point"`
+	ResolvedAt   pgtype.Timestamptz `json:"resolved_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type StockBalance struct {
 	ID           uuid.UUID          `json:"id"`
 	ProductID    uuid.UUID          `json:"product_id"`
@@ -292,6 +329,8 @@ type StockMovement struct {
 	Notes          *string            `json:"notes"`
 	CreatedBy      pgtype.UUID        `json:"created_by"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	Reason         *string            `json:"reason"`
+	Reference      *string            `json:"reference"`
 }
 
 type Supplier struct {
@@ -338,16 +377,26 @@ type UnitsOfMeasure struct {
 }
 
 type User struct {
-	ID           uuid.UUID          `json:"id"`
-	Username     string             `json:"username"`
-	Email        string             `json:"email"`
-	PasswordHash string             `json:"password_hash"`
-	FullName     string             `json:"full_name"`
-	IsActive     *bool              `json:"is_active"`
-	LastLoginAt  pgtype.Timestamptz `json:"last_login_at"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	ID            uuid.UUID          `json:"id"`
+	Username      string             `json:"username"`
+	Email         string             `json:"email"`
+	PasswordHash  string             `json:"password_hash"`
+	FullName      string             `json:"full_name"`
+	IsActive      *bool              `json:"is_active"`
+	LastLoginAt   pgtype.Timestamptz `json:"last_login_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
+	CreatedBy     pgtype.UUID        `json:"created_by"`
+	UpdatedBy     pgtype.UUID        `json:"updated_by"`
+	DeletionDueAt pgtype.Timestamptz `json:"deletion_due_at"`
+}
+
+type UserActivation struct {
+	UserID    uuid.UUID          `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type UserRole struct {
@@ -357,3 +406,39 @@ type UserRole struct {
 	AssignedAt pgtype.Timestamptz `json:"assigned_at"`
 	AssignedBy pgtype.UUID        `json:"assigned_by"`
 }
+
+type Webhook struct {
+	ID         uuid.UUID          `json:"id"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []string           `json:"event_types"`
+	IsActive   bool               `json:"is_active"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WebhookDeadLetter struct {
+	ID        uuid.UUID          `json:"id"`
+	WebhookID pgtype.UUID        `json:"webhook_id"`
+	Url       string             `json:"url"`
+	EventID   uuid.UUID          `json:"event_id"`
+	EventType string             `json:"event_type"`
+	Payload   []byte             `json:"payload"`
+	Attempts  int32              `json:"attempts"`
+	LastError *string            `json:"last_error"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID         uuid.UUID          `json:"id"`
+	WebhookID  pgtype.UUID        `json:"webhook_id"`
+	Url        string             `json:"url"`
+	EventID    uuid.UUID          `json:"event_id"`
+	EventType  string             `json:"event_type"`
+	Attempt    int32              `json:"attempt"`
+	StatusCode *int32             `json:"status_code"`
+	Error      *string            `json:"error"`
+	Succeeded  bool               `json:"succeeded"`
+	DurationMs int32              `json:"duration_ms"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
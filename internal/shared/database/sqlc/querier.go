@@ -8,12 +8,41 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	// token dipakai sekali: row aktivasi dihapus dan user diaktifkan dalam satu
+	// statement; no rows = token tidak dikenal, sudah dipakai, atau expired
+	ActivateUserByToken(ctx context.Context, arg ActivateUserByTokenParams) (uuid.UUID, error)
+	// Baris user tetap ada karena direferensikan created_by/updated_by di
+	// tabel lain; data pribadinya diganti. Cek deletion_due_at diulang di
+	// sini supaya cancel yang datang bersamaan dengan worker tetap menang.
+	AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (int64, error)
+	ApplyCustomerInvoicePayment(ctx context.Context, arg ApplyCustomerInvoicePaymentParams) (ApplyCustomerInvoicePaymentRow, error)
+	// 0 row = user sudah punya role ini
+	AssignRoleIfMissing(ctx context.Context, arg AssignRoleIfMissingParams) (int64, error)
 	AssignRoleToUser(ctx context.Context, arg AssignRoleToUserParams) (AssignRoleToUserRow, error)
+	AssignRoleToUserIfMissing(ctx context.Context, arg AssignRoleToUserIfMissingParams) error
+	// 0 row = user tidak sedang dijadwalkan untuk dihapus
+	CancelUserDeletion(ctx context.Context, id uuid.UUID) (int64, error)
+	CheckCategoryCodeExists(ctx context.Context, code string) (bool, error)
+	// termasuk yang sudah di-soft delete: constraint UNIQUE berlaku untuk semua row
+	CheckCustomerCodeExists(ctx context.Context, code string) (bool, error)
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 	CheckUsernameExists(ctx context.Context, username string) (bool, error)
+	CopyRoleMenus(ctx context.Context, arg CopyRoleMenusParams) error
+	// admin = user aktif yang memegang role 'admin' (seed.RoleAdmin) yang aktif.
+	// remaining: admin selain user_id; is_admin: user_id sendiri admin aktif.
+	CountActiveAdmins(ctx context.Context, userID uuid.UUID) (CountActiveAdminsRow, error)
+	CountCustomers(ctx context.Context, arg CountCustomersParams) (int64, error)
+	CountProductStockMovements(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountProducts(ctx context.Context, search *string) (int64, error)
+	CountRevokedTokens(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+	CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error)
+	CountUsersByRole(ctx context.Context, roleID uuid.UUID) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID pgtype.UUID) (int64, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (CreateCategoryRow, error)
 	CreateCustomer(ctx context.Context, arg CreateCustomerParams) (CreateCustomerRow, error)
 	CreateCustomerInvoice(ctx context.Context, arg CreateCustomerInvoiceParams) (CreateCustomerInvoiceRow, error)
@@ -25,6 +54,7 @@ type Querier interface {
 	CreatePurchaseOrderLine(ctx context.Context, arg CreatePurchaseOrderLineParams) (CreatePurchaseOrderLineRow, error)
 	CreateQuotation(ctx context.Context, arg CreateQuotationParams) (CreateQuotationRow, error)
 	CreateQuotationLine(ctx context.Context, arg CreateQuotationLineParams) (CreateQuotationLineRow, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
 	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
 	CreateSalesOrder(ctx context.Context, arg CreateSalesOrderParams) (CreateSalesOrderRow, error)
 	CreateSalesOrderLine(ctx context.Context, arg CreateSalesOrderLineParams) (CreateSalesOrderLineRow, error)
@@ -36,11 +66,26 @@ type Querier interface {
 	CreateSupplierBill(ctx context.Context, arg CreateSupplierBillParams) (CreateSupplierBillRow, error)
 	CreateUnitOfMeasure(ctx context.Context, arg CreateUnitOfMeasureParams) (CreateUnitOfMeasureRow, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	// register ulang tidak mungkin (email unik), tapi token baru menggantikan
+	// yang lama kalau suatu saat dikirim ulang
+	CreateUserActivation(ctx context.Context, arg CreateUserActivationParams) error
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	CreateWebhookDeadLetter(ctx context.Context, arg CreateWebhookDeadLetterParams) error
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	DeleteCategory(ctx context.Context, id uuid.UUID) (int64, error)
+	// logout: semua refresh token sesi ini (termasuk yang sudah di-rotate)
+	// dihapus, jadi dipakai lagi = invalid token, bukan reuse
+	DeleteRefreshTokenFamily(ctx context.Context, arg DeleteRefreshTokenFamilyParams) error
 	DeleteRole(ctx context.Context, id uuid.UUID) error
+	DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	DeleteUserRoles(ctx context.Context, userID uuid.UUID) error
+	DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error)
 	GetAccountsPayableSummary(ctx context.Context, dollar_1 uuid.UUID) ([]GetAccountsPayableSummaryRow, error)
 	GetAccountsReceivableSummary(ctx context.Context, dollar_1 uuid.UUID) ([]GetAccountsReceivableSummaryRow, error)
 	GetAgingReceivables(ctx context.Context) ([]GetAgingReceivablesRow, error)
 	GetCashFlowSummary(ctx context.Context, arg GetCashFlowSummaryParams) (GetCashFlowSummaryRow, error)
+	// UNION (bukan UNION ALL) supaya data yang sudah terlanjur cyclic tidak loop
+	GetCategoryAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
 	GetCategoryByID(ctx context.Context, id uuid.UUID) (Category, error)
 	GetCustomerByID(ctx context.Context, id uuid.UUID) (GetCustomerByIDRow, error)
 	GetCustomerInvoiceByID(ctx context.Context, id uuid.UUID) (GetCustomerInvoiceByIDRow, error)
@@ -50,12 +95,19 @@ type Querier interface {
 	GetPaymentByID(ctx context.Context, id uuid.UUID) (GetPaymentByIDRow, error)
 	GetProductByCode(ctx context.Context, code string) (GetProductByCodeRow, error)
 	GetProductByID(ctx context.Context, id uuid.UUID) (GetProductByIDRow, error)
+	GetProductReorderPoint(ctx context.Context, id uuid.UUID) (pgtype.Numeric, error)
+	// Bahan ETag GetProfile: updated_at user ditambah counter rbac_version
+	// yang naik setiap role/menu/assignment berubah.
+	GetProfileVersion(ctx context.Context, id uuid.UUID) (GetProfileVersionRow, error)
 	GetPurchaseOrderByID(ctx context.Context, id uuid.UUID) (GetPurchaseOrderByIDRow, error)
 	GetPurchaseOrderLines(ctx context.Context, poID uuid.UUID) ([]GetPurchaseOrderLinesRow, error)
 	GetQuotationByID(ctx context.Context, id uuid.UUID) (GetQuotationByIDRow, error)
 	GetQuotationLines(ctx context.Context, quoteID uuid.UUID) ([]GetQuotationLinesRow, error)
+	GetRefreshToken(ctx context.Context, id uuid.UUID) (GetRefreshTokenRow, error)
 	GetRoleByCode(ctx context.Context, code string) (Role, error)
 	GetRoleByID(ctx context.Context, id uuid.UUID) (Role, error)
+	// Code yang tidak ditemukan tidak muncul; caller membandingkan code-nya.
+	GetRolesByCodes(ctx context.Context, codes []string) ([]Role, error)
 	GetSalesOrderByID(ctx context.Context, id uuid.UUID) (GetSalesOrderByIDRow, error)
 	GetSalesOrderLines(ctx context.Context, soID uuid.UUID) ([]GetSalesOrderLinesRow, error)
 	GetStockAdjustmentByID(ctx context.Context, id uuid.UUID) (GetStockAdjustmentByIDRow, error)
@@ -66,31 +118,102 @@ type Querier interface {
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow, error)
 	GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error)
-	GetUserMenus(ctx context.Context, userID uuid.UUID) ([]GetUserMenusRow, error)
+	GetUserForUpdate(ctx context.Context, id uuid.UUID) (GetUserForUpdateRow, error)
+	GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error)
+	// User, role dan menu dalam satu round-trip untuk GetProfile. Role dan
+	// menu dikirim sebagai array JSON (di-decode di auth_mapper.go);
+	// user tanpa role/menu mendapat '[]'. Urutan menu dibuat total (root
+	// dulu, lalu sort_order, name, code) supaya sidebar tidak berubah-ubah
+	// antar request.
+	GetUserProfile(ctx context.Context, id uuid.UUID) (GetUserProfileRow, error)
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]GetUserRolesRow, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (Webhook, error)
+	GrantRoleMenu(ctx context.Context, arg GrantRoleMenuParams) error
+	// hanya customer yang sudah di-soft delete; yang masih dipakai sales
+	// order/invoice ditolak FK
+	HardDeleteCustomer(ctx context.Context, id uuid.UUID) (int64, error)
+	// Dipakai middleware RequireMenu: true jika salah satu role aktif
+	// punya permission tersebut di menu aktif.
+	HasMenuPermission(ctx context.Context, arg HasMenuPermissionParams) (bool, error)
+	HasPendingActivation(ctx context.Context, userID uuid.UUID) (bool, error)
+	ImportMenu(ctx context.Context, arg ImportMenuParams) (uuid.UUID, error)
+	ImportRole(ctx context.Context, arg ImportRoleParams) (uuid.UUID, error)
+	ImportRoleMenu(ctx context.Context, arg ImportRoleMenuParams) error
+	IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
 	ListActiveCategories(ctx context.Context) ([]ListActiveCategoriesRow, error)
 	ListActiveCustomers(ctx context.Context) ([]ListActiveCustomersRow, error)
 	ListActiveProducts(ctx context.Context, dollar_1 uuid.UUID) ([]ListActiveProductsRow, error)
 	ListActiveStockLocations(ctx context.Context) ([]ListActiveStockLocationsRow, error)
 	ListActiveSuppliers(ctx context.Context) ([]ListActiveSuppliersRow, error)
 	ListActiveUoM(ctx context.Context) ([]ListActiveUoMRow, error)
+	// pencocokan event type dilakukan di Go (webhook.Matches)
+	ListActiveWebhooks(ctx context.Context) ([]Webhook, error)
+	ListCategories(ctx context.Context) ([]Category, error)
 	ListCustomerInvoices(ctx context.Context, arg ListCustomerInvoicesParams) ([]ListCustomerInvoicesRow, error)
+	ListCustomers(ctx context.Context, arg ListCustomersParams) ([]Customer, error)
+	ListExistingUserIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	ListInvoicePayments(ctx context.Context, invoiceID pgtype.UUID) ([]ListInvoicePaymentsRow, error)
+	ListOpenStockAlerts(ctx context.Context) ([]ListOpenStockAlertsRow, error)
 	ListPayments(ctx context.Context, arg ListPaymentsParams) ([]ListPaymentsRow, error)
+	ListProductStockBalances(ctx context.Context, productID uuid.UUID) ([]ListProductStockBalancesRow, error)
+	ListProductStockMovements(ctx context.Context, arg ListProductStockMovementsParams) ([]ListProductStockMovementsRow, error)
+	ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error)
 	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]ListPurchaseOrdersRow, error)
 	ListQuotations(ctx context.Context, arg ListQuotationsParams) ([]ListQuotationsRow, error)
-	ListRoles(ctx context.Context) ([]Role, error)
+	ListRBACGrants(ctx context.Context) ([]ListRBACGrantsRow, error)
+	ListRBACMenus(ctx context.Context) ([]ListRBACMenusRow, error)
+	// Query untuk GET /rbac/export dan POST /rbac/import.
+	// Semua relasi direferensikan lewat code supaya portable antar environment.
+	ListRBACRoles(ctx context.Context) ([]ListRBACRolesRow, error)
+	ListRevokedTokens(ctx context.Context, arg ListRevokedTokensParams) ([]ListRevokedTokensRow, error)
+	// limit_count NULL = semua row
+	ListRoles(ctx context.Context, arg ListRolesParams) ([]Role, error)
 	ListSalesOrders(ctx context.Context, arg ListSalesOrdersParams) ([]ListSalesOrdersRow, error)
 	ListStockAdjustments(ctx context.Context, arg ListStockAdjustmentsParams) ([]ListStockAdjustmentsRow, error)
 	ListStockBalances(ctx context.Context, arg ListStockBalancesParams) ([]ListStockBalancesRow, error)
 	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]ListStockMovementsRow, error)
 	ListSupplierBills(ctx context.Context, arg ListSupplierBillsParams) ([]ListSupplierBillsRow, error)
+	// User yang di-soft delete tidak ikut; urut dari assignment terlama.
+	ListUsersByRole(ctx context.Context, arg ListUsersByRoleParams) ([]ListUsersByRoleRow, error)
+	ListUsersDueForDeletion(ctx context.Context, arg ListUsersDueForDeletionParams) ([]uuid.UUID, error)
+	// keyset pagination by username supaya export tidak perlu OFFSET
+	ListUsersForExport(ctx context.Context, arg ListUsersForExportParams) ([]ListUsersForExportRow, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	// Dipanggil di awal transaksi yang bisa menghabiskan admin aktif (hapus
+	// akun) supaya dua transaksi tidak sama-sama melihat admin lain tersisa.
+	LockAdminRole(ctx context.Context) error
+	// Dipanggil di awal transaksi yang memindahkan parent category supaya dua
+	// perpindahan paralel tidak sama-sama lolos cek cycle (A->B dan B->A).
+	LockCategoryTree(ctx context.Context) error
+	LockCustomerInvoice(ctx context.Context, id uuid.UUID) (LockCustomerInvoiceRow, error)
+	LockStockBalance(ctx context.Context, arg LockStockBalanceParams) (pgtype.Numeric, error)
+	// 0 row = alert untuk produk ini masih terbuka
+	OpenStockAlert(ctx context.Context, arg OpenStockAlertParams) (int64, error)
+	RecordStockMovement(ctx context.Context, arg RecordStockMovementParams) (StockMovement, error)
 	RemoveRoleFromUser(ctx context.Context, arg RemoveRoleFromUserParams) error
+	ResolveStockAlert(ctx context.Context, productID uuid.UUID) (int64, error)
+	RestoreCustomer(ctx context.Context, id uuid.UUID) (int64, error)
+	RevokeAccessToken(ctx context.Context, arg RevokeAccessTokenParams) error
+	// hanya token yang belum di-revoke; 0 row = sudah dipakai (reuse/race)
+	RotateRefreshToken(ctx context.Context, arg RotateRefreshTokenParams) (int64, error)
+	// Jadwal yang sudah ada tidak digeser: mengulang DELETE /auth/account
+	// mengembalikan tanggal yang sama.
+	ScheduleUserDeletion(ctx context.Context, arg ScheduleUserDeletionParams) (pgtype.Timestamptz, error)
+	SetProductReorderPoint(ctx context.Context, arg SetProductReorderPointParams) (int64, error)
+	// Soft delete mengikuti pola internal/shared/softdelete; customer adalah
+	// implementasi referensinya.
+	SoftDeleteCustomer(ctx context.Context, id uuid.UUID) (int64, error)
+	SoftDeleteProduct(ctx context.Context, id uuid.UUID) (int64, error)
+	SumProductOnHand(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (int64, error)
 	UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) error
 	UpdateCustomerInvoicePaidAmount(ctx context.Context, arg UpdateCustomerInvoicePaidAmountParams) error
 	UpdatePOLineReceivedQty(ctx context.Context, arg UpdatePOLineReceivedQtyParams) error
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
 	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) error
 	UpdateQuotationStatus(ctx context.Context, arg UpdateQuotationStatusParams) error
+	// no rows = role tidak ada atau version sudah berubah (update bersamaan)
 	UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error)
 	UpdateRoleStatus(ctx context.Context, arg UpdateRoleStatusParams) error
 	UpdateSOLineDeliveredQty(ctx context.Context, arg UpdateSOLineDeliveredQtyParams) error
@@ -99,7 +222,13 @@ type Querier interface {
 	UpdateStockQuantity(ctx context.Context, arg UpdateStockQuantityParams) error
 	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) error
 	UpdateSupplierBillPaidAmount(ctx context.Context, arg UpdateSupplierBillPaidAmountParams) error
-	UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error)
+	UpdateUserLastLogin(ctx context.Context, arg UpdateUserLastLoginParams) error
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
+	UpsertMenu(ctx context.Context, arg UpsertMenuParams) (Menu, error)
+	// Query untuk cmd/seed: semuanya idempotent (aman dijalankan berulang)
+	// DO UPDATE no-op supaya RETURNING tetap mengembalikan row yang sudah ada
+	UpsertRole(ctx context.Context, arg UpsertRoleParams) (Role, error)
 	UpsertStockBalance(ctx context.Context, arg UpsertStockBalanceParams) (UpsertStockBalanceRow, error)
 }
 
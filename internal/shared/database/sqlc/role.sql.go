@@ -9,26 +9,118 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const assignRoleIfMissing = `-- name: AssignRoleIfMissing :execrows
+INSERT INTO user_roles (
+    user_id,
+    role_id,
+    assigned_by
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, role_id) DO NOTHING
+`
+
+type AssignRoleIfMissingParams struct {
+	UserID     uuid.UUID   `json:"user_id"`
+	RoleID     uuid.UUID   `json:"role_id"`
+	AssignedBy pgtype.UUID `json:"assigned_by"`
+}
+
+// 0 row = user sudah punya role ini
+func (q *Queries) AssignRoleIfMissing(ctx context.Context, arg AssignRoleIfMissingParams) (int64, error) {
+	result, err := q.db.Exec(ctx, assignRoleIfMissing, arg.UserID, arg.RoleID, arg.AssignedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const copyRoleMenus = `-- name: CopyRoleMenus :exec
+INSERT INTO role_menus (
+    role_id,
+    menu_id,
+    can_create,
+    can_read,
+    can_update,
+    can_delete
+)
+SELECT
+    $1::uuid,
+    rm.menu_id,
+    rm.can_create,
+    rm.can_read,
+    rm.can_update,
+    rm.can_delete
+FROM role_menus rm
+WHERE rm.role_id = $2::uuid
+`
+
+type CopyRoleMenusParams struct {
+	TargetRoleID uuid.UUID `json:"target_role_id"`
+	SourceRoleID uuid.UUID `json:"source_role_id"`
+}
+
+func (q *Queries) CopyRoleMenus(ctx context.Context, arg CopyRoleMenusParams) error {
+	_, err := q.db.Exec(ctx, copyRoleMenus, arg.TargetRoleID, arg.SourceRoleID)
+	return err
+}
+
+const countRoleUsers = `-- name: CountRoleUsers :one
+SELECT COUNT(*) FROM user_roles
+WHERE role_id = $1
+`
+
+func (q *Queries) CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countRoleUsers, roleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUsersByRole = `-- name: CountUsersByRole :one
+SELECT COUNT(*)
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+WHERE ur.role_id = $1
+  AND u.deleted_at IS NULL
+`
+
+func (q *Queries) CountUsersByRole(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsersByRole, roleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createRole = `-- name: CreateRole :one
 INSERT INTO roles (
     code,
     name,
-    description
+    description,
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, code, name, description, is_active, created_at, updated_at
+    $1, $2, $3, $4, $4
+) RETURNING id, code, name, description, is_active, created_at, updated_at, created_by, updated_by, version
 `
 
 type CreateRoleParams struct {
-	Code        string  `json:"code"`
-	Name        string  `json:"name"`
-	Description *string `json:"description"`
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	CreatedBy   pgtype.UUID `json:"created_by"`
 }
 
 func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error) {
-	row := q.db.QueryRow(ctx, createRole, arg.Code, arg.Name, arg.Description)
+	row := q.db.QueryRow(ctx, createRole,
+		arg.Code,
+		arg.Name,
+		arg.Description,
+		arg.CreatedBy,
+	)
 	var i Role
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +130,9 @@ func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, e
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.Version,
 	)
 	return i, err
 }
@@ -53,7 +148,7 @@ func (q *Queries) DeleteRole(ctx context.Context, id uuid.UUID) error {
 }
 
 const getRoleByCode = `-- name: GetRoleByCode :one
-SELECT id, code, name, description, is_active, created_at, updated_at FROM roles
+SELECT id, code, name, description, is_active, created_at, updated_at, created_by, updated_by, version FROM roles
 WHERE code = $1 LIMIT 1
 `
 
@@ -68,12 +163,15 @@ func (q *Queries) GetRoleByCode(ctx context.Context, code string) (Role, error)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getRoleByID = `-- name: GetRoleByID :one
-SELECT id, code, name, description, is_active, created_at, updated_at FROM roles
+SELECT id, code, name, description, is_active, created_at, updated_at, created_by, updated_by, version FROM roles
 WHERE id = $1 LIMIT 1
 `
 
@@ -88,17 +186,104 @@ func (q *Queries) GetRoleByID(ctx context.Context, id uuid.UUID) (Role, error) {
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.Version,
 	)
 	return i, err
 }
 
+const getRolesByCodes = `-- name: GetRolesByCodes :many
+SELECT id, code, name, description, is_active, created_at, updated_at, created_by, updated_by, version FROM roles
+WHERE code = ANY($1::text[])
+ORDER BY code
+`
+
+// Code yang tidak ditemukan tidak muncul; caller membandingkan code-nya.
+func (q *Queries) GetRolesByCodes(ctx context.Context, codes []string) ([]Role, error) {
+	rows, err := q.db.Query(ctx, getRolesByCodes, codes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Role
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Description,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExistingUserIDs = `-- name: ListExistingUserIDs :many
+SELECT id FROM users
+WHERE id = ANY($1::uuid[])
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) ListExistingUserIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listExistingUserIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRoles = `-- name: ListRoles :many
-SELECT id, code, name, description, is_active, created_at, updated_at FROM roles
-ORDER BY created_at DESC
+SELECT id, code, name, description, is_active, created_at, updated_at, created_by, updated_by, version FROM roles
+WHERE (
+        $1::text IS NULL
+        OR code ILIKE '%' || $1::text || '%'
+        OR name ILIKE '%' || $1::text || '%'
+    )
+    AND ($2::boolean IS NULL OR is_active = $2::boolean)
+ORDER BY created_at DESC, id
+LIMIT $4::int OFFSET $3::int
 `
 
-func (q *Queries) ListRoles(ctx context.Context) ([]Role, error) {
-	rows, err := q.db.Query(ctx, listRoles)
+type ListRolesParams struct {
+	Search      *string `json:"search"`
+	IsActive    *bool   `json:"is_active"`
+	OffsetCount int32   `json:"offset_count"`
+	LimitCount  *int32  `json:"limit_count"`
+}
+
+// limit_count NULL = semua row
+func (q *Queries) ListRoles(ctx context.Context, arg ListRolesParams) ([]Role, error) {
+	rows, err := q.db.Query(ctx, listRoles,
+		arg.Search,
+		arg.IsActive,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +299,69 @@ func (q *Queries) ListRoles(ctx context.Context) ([]Role, error) {
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByRole = `-- name: ListUsersByRole :many
+SELECT
+    u.id,
+    u.username,
+    u.email,
+    u.full_name,
+    u.is_active,
+    ur.assigned_at
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+WHERE ur.role_id = $1
+  AND u.deleted_at IS NULL
+ORDER BY ur.assigned_at, u.username
+LIMIT $3
+OFFSET $2
+`
+
+type ListUsersByRoleParams struct {
+	RoleID      uuid.UUID `json:"role_id"`
+	OffsetCount int32     `json:"offset_count"`
+	LimitCount  int32     `json:"limit_count"`
+}
+
+type ListUsersByRoleRow struct {
+	ID         uuid.UUID          `json:"id"`
+	Username   string             `json:"username"`
+	Email      string             `json:"email"`
+	FullName   string             `json:"full_name"`
+	IsActive   *bool              `json:"is_active"`
+	AssignedAt pgtype.Timestamptz `json:"assigned_at"`
+}
+
+// User yang di-soft delete tidak ikut; urut dari assignment terlama.
+func (q *Queries) ListUsersByRole(ctx context.Context, arg ListUsersByRoleParams) ([]ListUsersByRoleRow, error) {
+	rows, err := q.db.Query(ctx, listUsersByRole, arg.RoleID, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersByRoleRow
+	for rows.Next() {
+		var i ListUsersByRoleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.FullName,
+			&i.IsActive,
+			&i.AssignedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -131,24 +379,32 @@ SET
     name = $2,
     description = $3,
     is_active = $4,
+    updated_by = $5,
+    version = version + 1,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, code, name, description, is_active, created_at, updated_at
+    AND version = $6
+RETURNING id, code, name, description, is_active, created_at, updated_at, created_by, updated_by, version
 `
 
 type UpdateRoleParams struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description *string   `json:"description"`
-	IsActive    *bool     `json:"is_active"`
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	IsActive    *bool       `json:"is_active"`
+	UpdatedBy   pgtype.UUID `json:"updated_by"`
+	Version     int32       `json:"version"`
 }
 
+// no rows = role tidak ada atau version sudah berubah (update bersamaan)
 func (q *Queries) UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error) {
 	row := q.db.QueryRow(ctx, updateRole,
 		arg.ID,
 		arg.Name,
 		arg.Description,
 		arg.IsActive,
+		arg.UpdatedBy,
+		arg.Version,
 	)
 	var i Role
 	err := row.Scan(
@@ -159,13 +415,16 @@ func (q *Queries) UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, e
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.Version,
 	)
 	return i, err
 }
 
 const updateRoleStatus = `-- name: UpdateRoleStatus :exec
 UPDATE roles
-SET is_active = $2, updated_at = NOW()
+SET is_active = $2, version = version + 1, updated_at = NOW()
 WHERE id = $1
 `
 
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	dbgen "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// TxBeginner is satisfied by *pgxpool.Pool and pgx.Tx (nested = savepoint)
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Transactor runs fn atomically; services depend on this, not on pgx
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(q dbgen.Querier) error) error
+}
+
+// DB wraps the pool for operations spanning several queries
+type DB struct {
+	conn         TxBeginner
+	queryTimeout time.Duration
+}
+
+func NewDB(conn TxBeginner) *DB {
+	return &DB{conn: conn}
+}
+
+// WithQueryTimeout applies WithQueryTimeout to the queries WithTx hands
+// to fn; 0 = tanpa batas per query
+func (d *DB) WithQueryTimeout(timeout time.Duration) *DB {
+	return &DB{conn: d.conn, queryTimeout: timeout}
+}
+
+// WithTx begins a transaction, runs fn with queries bound to it and
+// commits; any error (or panic) from fn rolls everything back
+func (d *DB) WithTx(ctx context.Context, fn func(q dbgen.Querier) error) (err error) {
+	tx, err := d.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(dbgen.New(WithQueryTimeout(tx, d.queryTimeout))); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
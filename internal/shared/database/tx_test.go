@@ -0,0 +1,65 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/database"
+	dbgen "go-mini-erp/internal/shared/database/sqlc"
+)
+
+// Butuh database yang sudah di-migrate: TEST_DB_URL=postgres://...
+func newTestDB(t *testing.T) (*database.DB, *pgxpool.Pool) {
+	t.Helper()
+
+	dbURL := os.Getenv("TEST_DB_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DB_URL not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	return database.NewDB(pool), pool
+}
+
+func TestWithTx_RollbackOnError(t *testing.T) {
+	txDB, pool := newTestDB(t)
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	err := txDB.WithTx(ctx, func(q dbgen.Querier) error {
+		if _, err := q.CreateRole(ctx, dbgen.CreateRoleParams{Code: "tx_rollback", Name: "Rollback"}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	_, err = dbgen.New(pool).GetRoleByCode(ctx, "tx_rollback")
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "role must not survive a rolled back tx")
+}
+
+func TestWithTx_Commit(t *testing.T) {
+	txDB, pool := newTestDB(t)
+	ctx := context.Background()
+	q := dbgen.New(pool)
+
+	err := txDB.WithTx(ctx, func(q dbgen.Querier) error {
+		_, err := q.CreateRole(ctx, dbgen.CreateRoleParams{Code: "tx_commit", Name: "Commit"})
+		return err
+	})
+	require.NoError(t, err)
+
+	role, err := q.GetRoleByCode(ctx, "tx_commit")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = q.DeleteRole(ctx, role.ID) })
+}
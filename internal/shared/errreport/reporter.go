@@ -0,0 +1,35 @@
+package errreport
+
+import (
+	"context"
+	"log"
+)
+
+// Report is a captured failure (panic or unexpected error) with the
+// request context needed to trace it
+type Report struct {
+	Err       error
+	Stack     []byte
+	RequestID string
+	UserID    string
+	Method    string
+	Path      string
+}
+
+// Reporter forwards reports to an error tracking backend
+type Reporter interface {
+	Report(ctx context.Context, r Report)
+}
+
+// NopReporter discards reports (tests / no backend configured)
+type NopReporter struct{}
+
+func (NopReporter) Report(context.Context, Report) {}
+
+// LogReporter writes reports to the standard logger
+type LogReporter struct{}
+
+func (LogReporter) Report(_ context.Context, r Report) {
+	log.Printf("[ERROR] %s %s request_id=%s user_id=%s: %v\n%s",
+		r.Method, r.Path, r.RequestID, r.UserID, r.Err, r.Stack)
+}
@@ -0,0 +1,51 @@
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryReporter sends reports to Sentry
+type SentryReporter struct {
+	hub *sentry.Hub
+}
+
+// NewSentryReporter initializes the Sentry client; environment and
+// release are attached to every event
+func NewSentryReporter(dsn, environment, release string) (*SentryReporter, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:              dsn,
+		Environment:      environment,
+		Release:          release,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("init sentry: %w", err)
+	}
+
+	return &SentryReporter{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+func (s *SentryReporter) Report(_ context.Context, r Report) {
+	hub := s.hub.Clone()
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetTag("request_id", r.RequestID)
+		scope.SetTag("http.method", r.Method)
+		scope.SetTag("http.route", r.Path)
+		if r.UserID != "" {
+			scope.SetUser(sentry.User{ID: r.UserID})
+		}
+		if len(r.Stack) > 0 {
+			scope.SetContext("panic", sentry.Context{"stack": string(r.Stack)})
+		}
+		hub.CaptureException(r.Err)
+	})
+}
+
+// Flush waits for buffered events to be sent, bounded by timeout
+func (s *SentryReporter) Flush(timeout time.Duration) bool {
+	return s.hub.Flush(timeout)
+}
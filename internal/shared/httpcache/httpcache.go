@@ -0,0 +1,85 @@
+package httpcache
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Latest returns the newest updatedAt among items, zero for an empty list
+func Latest[T any](items []T, updatedAt func(T) time.Time) time.Time {
+	var latest time.Time
+	for _, item := range items {
+		if t := updatedAt(item); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// ListETag is the validator of a list without paging: jumlah item plus
+// updated_at terbaru dengan presisi penuh. Last-Modified (presisi detik)
+// tidak melihat hard delete maupun dua edit dalam detik yang sama, jumlah
+// dan nanodetik di sini melihat keduanya. Pakai dengan NotModified.
+func ListETag[T any](items []T, updatedAt func(T) time.Time) string {
+	return fmt.Sprintf(`W/"%d-%d"`, len(items), Latest(items, updatedAt).UnixNano())
+}
+
+// NotModifiedETag sets ETag and answers 304 when one of the request's
+// If-None-Match tags (atau "*") cocok dengan etag; perbandingan weak,
+// jadi W/"x" sama dengan "x". etag kosong = tidak ada header, selalu 200.
+func NotModifiedETag(c *gin.Context, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	c.Header("ETag", etag)
+
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			c.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+/*
+NotModified sets ETag and Last-Modified and answers 304 when the
+request's validators still match; handler cukup return kalau hasilnya
+true.
+
+If-None-Match menang atas If-Modified-Since (RFC 9110 13.2.2): kalau
+klien mengirim ETag, tanggal diabaikan. If-Modified-Since saja tetap
+dilayani untuk klien/proxy yang hanya menyimpan Last-Modified, dengan
+batasan presisi detik dan hard delete yang tidak menggeser updated_at.
+lastModified nol = tanpa Last-Modified.
+*/
+func NotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if !lastModified.IsZero() {
+		// HTTP date hanya presisi detik
+		lastModified = lastModified.UTC().Truncate(time.Second)
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if c.GetHeader("If-None-Match") != "" {
+		return NotModifiedETag(c, etag)
+	}
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil || lastModified.After(since) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
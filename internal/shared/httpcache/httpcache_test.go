@@ -0,0 +1,132 @@
+package httpcache_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/httpcache"
+)
+
+func TestLatest(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []time.Time{base, base.Add(time.Hour), base.Add(time.Minute)}
+
+	identity := func(t time.Time) time.Time { return t }
+	assert.Equal(t, base.Add(time.Hour), httpcache.Latest(items, identity))
+	assert.True(t, httpcache.Latest(nil, identity).IsZero())
+}
+
+func TestListETag(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	identity := func(t time.Time) time.Time { return t }
+	items := []time.Time{base, base.Add(-time.Hour)}
+	etag := httpcache.ListETag(items, identity)
+
+	assert.Equal(t, etag, httpcache.ListETag([]time.Time{base.Add(-time.Hour), base}, identity), "order does not matter")
+	assert.NotEqual(t, etag, httpcache.ListETag(items[:1], identity), "hard delete")
+	assert.NotEqual(t, etag, httpcache.ListETag([]time.Time{base.Add(time.Millisecond), base.Add(-time.Hour)}, identity), "second edit within the same second")
+	assert.Equal(t, `W/"0-`+strconv.FormatInt(time.Time{}.UnixNano(), 10)+`"`, httpcache.ListETag(nil, identity))
+}
+
+func TestNotModifiedETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{"no header", "", http.StatusOK},
+		{"match", `"5-2"`, http.StatusNotModified},
+		{"weak match", `W/"5-2"`, http.StatusNotModified},
+		{"one of many", `"4-1", "5-2"`, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"changed", `"5-1"`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/profile", func(c *gin.Context) {
+				if httpcache.NotModifiedETag(c, `"5-2"`) {
+					return
+				}
+				c.JSON(http.StatusOK, gin.H{})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, `"5-2"`, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lastModified := time.Date(2026, 1, 1, 10, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name            string
+		ifNoneMatch     string
+		ifModifiedSince string
+		want            int
+	}{
+		{"no header", "", "", http.StatusOK},
+		{"etag match", `"5-2"`, "", http.StatusNotModified},
+		{"etag changed", `"5-1"`, "", http.StatusOK},
+		{"date unchanged", "", "Thu, 01 Jan 2026 10:00:00 GMT", http.StatusNotModified},
+		{"date changed", "", "Thu, 01 Jan 2026 09:59:59 GMT", http.StatusOK},
+		{"unparseable date", "", "yesterday", http.StatusOK},
+		// RFC 9110 13.2.2: If-Modified-Since diabaikan kalau ada If-None-Match
+		{"etag changed, date unchanged", `"5-1"`, "Thu, 01 Jan 2026 10:00:00 GMT", http.StatusOK},
+		{"etag match, date changed", `"5-2"`, "Thu, 01 Jan 2026 09:00:00 GMT", http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/items", func(c *gin.Context) {
+				if httpcache.NotModified(c, `"5-2"`, lastModified) {
+					return
+				}
+				c.JSON(http.StatusOK, []string{})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, `"5-2"`, w.Header().Get("ETag"))
+			assert.Equal(t, "Thu, 01 Jan 2026 10:00:00 GMT", w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
+func TestNotModified_ZeroTime(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+	c.Request.Header.Set("If-Modified-Since", "Thu, 01 Jan 2026 10:00:00 GMT")
+
+	assert.False(t, httpcache.NotModified(c, "", time.Time{}))
+	assert.Empty(t, c.Writer.Header().Get("Last-Modified"))
+}
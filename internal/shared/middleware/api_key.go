@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the key of a trusted service client
+const APIKeyHeader = "X-API-Key"
+
+// RequireAPIKey allows only requests whose X-API-Key matches one of keys,
+// for service-to-service endpoints that have no user token
+func RequireAPIKey(keys []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := []byte(c.GetHeader(APIKeyHeader))
+
+		for _, k := range keys {
+			if len(got) > 0 && subtle.ConstantTimeCompare(got, []byte(k)) == 1 {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		c.Abort()
+	}
+}
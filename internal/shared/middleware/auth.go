@@ -1,45 +1,120 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+	"go-mini-erp/internal/shared/util/dbutil"
 )
 
-var jwtSecret = []byte("your-secret-key") // Load from env in production
+var jwtSecret = []byte("your-secret-key") // di-override SetJWTSecret saat startup
+
+// SetJWTSecret sets the secret AuthMiddleware verifies tokens with; must
+// match the one auth.JWTManager signs with
+func SetJWTSecret(secret string) {
+	jwtSecret = []byte(secret)
+}
+
+// RoleSource loads a user's current role codes (auth.RoleCache)
+type RoleSource interface {
+	UserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
+
+var roleSource RoleSource // nil = percaya claim roles di token
+
+// SetRoleSource makes AuthMiddleware take roles from src instead of the
+// token's roles claim; nil restores trusting the claim
+func SetRoleSource(src RoleSource) {
+	roleSource = src
+}
+
+// TokenRevocations reports whether an access token was revoked by logout
+// (auth.RevocationCache). expiresAt is the token's exp; hasil boleh
+// di-cache, tapi tidak lebih lama dari itu.
+type TokenRevocations interface {
+	IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID, expiresAt time.Time) (bool, error)
+}
+
+var tokenRevocations TokenRevocations // nil = deny-list tidak dicek
+
+// SetTokenRevocations makes AuthMiddleware reject tokens whose jti is on
+// the deny-list. Fail closed: token tanpa jti ditolak dan error backend
+// menjadi 500, bukan diloloskan. nil disables the check.
+func SetTokenRevocations(src TokenRevocations) {
+	tokenRevocations = src
+}
+
+var accessTokenCookie string // kosong = hanya header Authorization
+
+// SetAccessTokenCookie makes AuthMiddleware fall back to the named cookie
+// when a request has no Authorization header (browser app); selain
+// GET/HEAD/OPTIONS, request lewat cookie wajib lolos ValidCSRF. ""
+// disables the fallback
+func SetAccessTokenCookie(name string) {
+	accessTokenCookie = name
+}
+
+// AccessTokenCookie returns the cookie name set by SetAccessTokenCookie
+func AccessTokenCookie() string {
+	return accessTokenCookie
+}
 
 type Claims struct {
 	UserID   string   `json:"user_id"`
 	Username string   `json:"username"`
+	Email    string   `json:"email"`
 	Roles    []string `json:"roles"`
+	// TokenType: "access" atau "refresh", sama dengan auth.Claims
+	TokenType string `json:"typ,omitempty"`
+	// SessionID: refresh token family yang diterbitkan bersama token ini
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// tokenTypeAccess is the typ claim of auth.JWTManager access tokens
+const tokenTypeAccess = "access"
+
+// isAccessToken mirrors auth.ParseAccessToken: refresh token (typ lain,
+// atau jti tanpa typ access) ditolak walau ditandatangani secret yang sama
+func (c *Claims) isAccessToken() bool {
+	if c.TokenType != "" && c.TokenType != tokenTypeAccess {
+		return false
+	}
+	return c.ID == "" || c.TokenType == tokenTypeAccess
+}
+
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		tokenString, fromCookie, msg := accessToken(c)
+		if msg != "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
 			c.Abort()
 			return
 		}
-
-		// Extract token from "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-			c.Abort()
+		// cookie dikirim browser otomatis, termasuk dari form situs lain;
+		// request yang mengubah data wajib membawa header CSRF
+		if fromCookie && !isSafeMethod(c.Request.Method) && !ValidCSRF(c) {
+			RespondCSRFFailed(c)
 			return
 		}
 
-		tokenString := parts[1]
-
 		// Parse and validate token
+		// hanya HS256, sama seperti auth.JWTManager; alg none/RS256 ditolak
 		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method %q", token.Header["alg"])
+			}
 			return jwtSecret, nil
-		})
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 		if err != nil || !token.Valid {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
@@ -48,35 +123,121 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		claims, ok := token.Claims.(*Claims)
-		if !ok {
+		if !ok || !claims.isAccessToken() {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Abort()
+			return
+		}
+
+		roles, err := CurrentRoles(c.Request.Context(), claims)
+		switch {
+		case errors.Is(err, errInvalidClaims):
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 			c.Abort()
 			return
+		case errors.Is(err, ErrTokenRevoked):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			c.Abort()
+			return
+		case err != nil:
+			InternalError(c, err)
+			return
 		}
 
 		// Set user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("username", claims.Username)
-		c.Set("roles", claims.Roles)
+		ctxkeys.SetUserID(c, claims.UserID)
+		ctxkeys.SetUsername(c, claims.Username)
+		ctxkeys.SetRoles(c, roles)
+		ctxkeys.SetClaims(c, claims)
+
+		// service layer membaca actor dari context untuk created_by/updated_by
+		if uid, err := uuid.Parse(claims.UserID); err == nil {
+			c.Request = c.Request.WithContext(dbutil.WithActorID(c.Request.Context(), uid))
+		}
 
 		c.Next()
 	}
 }
 
+// ErrTokenRevoked: jti token ada di deny-list (logout)
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+var errInvalidClaims = errors.New("invalid token claims")
+
+// CurrentRoles runs the deny-list and role lookups AuthMiddleware does for
+// claims and returns the caller's current roles. Koneksi yang hidup lebih
+// lama dari satu request (SSE) memanggilnya lagi secara berkala.
+func CurrentRoles(ctx context.Context, claims *Claims) ([]string, error) {
+	if tokenRevocations != nil {
+		jti, err := uuid.Parse(claims.ID)
+		if err != nil {
+			return nil, errInvalidClaims
+		}
+		var exp time.Time
+		if claims.ExpiresAt != nil {
+			exp = claims.ExpiresAt.Time
+		}
+		revoked, err := tokenRevocations.IsAccessTokenRevoked(ctx, jti, exp)
+		if err != nil {
+			return nil, fmt.Errorf("check token revocation: %w", err)
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	if roleSource == nil {
+		return claims.Roles, nil
+	}
+	uid, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, errInvalidClaims
+	}
+	roles, err := roleSource.UserRoles(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("load user roles: %w", err)
+	}
+	return roles, nil
+}
+
+// accessToken takes the token from "Authorization: Bearer <token>" or,
+// when the header is absent, from the access token cookie (fromCookie).
+// Header yang ada tapi formatnya salah ditolak, tidak jatuh ke cookie.
+// msg non-empty means no usable token.
+func accessToken(c *gin.Context) (token string, fromCookie bool, msg string) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		if accessTokenCookie != "" {
+			if cookie, err := c.Cookie(accessTokenCookie); err == nil && cookie != "" {
+				return cookie, true, ""
+			}
+		}
+		return "", false, "Authorization header required"
+	}
+
+	// Extract token from "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", false, "Invalid authorization header format"
+	}
+	return parts[1], false, ""
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(c *gin.Context) string {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		return ""
-	}
-	return userID.(string)
+	return ctxkeys.UserID(c)
 }
 
 // GetRoles extracts roles from context
 func GetRoles(c *gin.Context) []string {
-	roles, exists := c.Get("roles")
-	if !exists {
-		return []string{}
-	}
-	return roles.([]string)
+	return ctxkeys.Roles(c)
+}
+
+// GetClaims returns the verified token claims, nil outside AuthMiddleware
+func GetClaims(c *gin.Context) *Claims {
+	return ctxkeys.Claims[Claims](c)
 }
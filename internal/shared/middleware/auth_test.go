@@ -0,0 +1,302 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+const testJWTSecret = "test-secret-for-auth-middleware-tests"
+
+// roleSourceStub mengembalikan role terkini tanpa cache
+type roleSourceStub struct {
+	roles map[uuid.UUID][]string
+}
+
+func (s *roleSourceStub) UserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return s.roles[userID], nil
+}
+
+func signToken(t *testing.T, userID uuid.UUID, roles []string) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, middleware.Claims{
+		UserID: userID.String(),
+		Roles:  roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	signed, err := token.SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+// newRolesRouter memasang AuthMiddleware dan route yang menulis roles dari context
+func newRolesRouter(t *testing.T, src middleware.RoleSource) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	middleware.SetJWTSecret(testJWTSecret)
+	middleware.SetRoleSource(src)
+	t.Cleanup(func() { middleware.SetRoleSource(nil) })
+
+	router := gin.New()
+	router.GET("/me/roles", middleware.AuthMiddleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, middleware.GetRoles(c))
+	})
+	return router
+}
+
+func getRoles(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me/roles", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_TrustsTokenRoles(t *testing.T) {
+	router := newRolesRouter(t, nil)
+
+	w := getRoles(router, signToken(t, uuid.New(), []string{"admin"}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["admin"]`, w.Body.String())
+}
+
+func TestAuthMiddleware_RolesFromSource(t *testing.T) {
+	userID := uuid.New()
+	src := &roleSourceStub{roles: map[uuid.UUID][]string{userID: {"admin"}}}
+	router := newRolesRouter(t, src)
+
+	token := signToken(t, userID, []string{"admin"})
+
+	w := getRoles(router, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["admin"]`, w.Body.String())
+
+	// admin mencabut role di tengah sesi; token lama masih berisi "admin"
+	src.roles[userID] = []string{"user"}
+
+	w = getRoles(router, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["user"]`, w.Body.String())
+}
+
+func TestAuthMiddleware_RejectsAlgNone(t *testing.T) {
+	router := newRolesRouter(t, nil)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, middleware.Claims{
+		UserID: uuid.NewString(),
+		Roles:  []string{"admin"},
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	w := getRoles(router, token)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+const testAccessCookie = "access_token"
+
+// requestRoles sends header and/or cookie token; string kosong = tidak dikirim
+func requestRoles(router *gin.Engine, headerToken, cookieToken string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me/roles", nil)
+	if headerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+headerToken)
+	}
+	if cookieToken != "" {
+		req.AddCookie(&http.Cookie{Name: testAccessCookie, Value: cookieToken})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_TokenSources(t *testing.T) {
+	headerToken := signToken(t, uuid.New(), []string{"admin"})
+	cookieToken := signToken(t, uuid.New(), []string{"staff"})
+
+	tests := []struct {
+		name         string
+		cookieName   string
+		header       string
+		cookie       string
+		expectedCode int
+		expectedBody string
+	}{
+		{"header only", testAccessCookie, headerToken, "", http.StatusOK, `["admin"]`},
+		{"cookie only", testAccessCookie, "", cookieToken, http.StatusOK, `["staff"]`},
+		{"both present, header wins", testAccessCookie, headerToken, cookieToken, http.StatusOK, `["admin"]`},
+		{"cookie ignored when not configured", "", "", cookieToken, http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newRolesRouter(t, nil)
+			middleware.SetAccessTokenCookie(tt.cookieName)
+			t.Cleanup(func() { middleware.SetAccessTokenCookie("") })
+
+			w := requestRoles(router, tt.header, tt.cookie)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_MalformedHeaderDoesNotFallBack(t *testing.T) {
+	router := newRolesRouter(t, nil)
+	middleware.SetAccessTokenCookie(testAccessCookie)
+	t.Cleanup(func() { middleware.SetAccessTokenCookie("") })
+
+	req := httptest.NewRequest(http.MethodGet, "/me/roles", nil)
+	req.Header.Set("Authorization", "Token abc")
+	req.AddCookie(&http.Cookie{Name: testAccessCookie, Value: signToken(t, uuid.New(), []string{"staff"})})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthMiddleware_CookieWritesRequireCSRF(t *testing.T) {
+	newRolesRouter(t, nil)
+	middleware.SetAccessTokenCookie(testAccessCookie)
+	t.Cleanup(func() { middleware.SetAccessTokenCookie("") })
+
+	router := gin.New()
+	router.Any("/orders", middleware.AuthMiddleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	token := signToken(t, uuid.New(), []string{"staff"})
+
+	tests := []struct {
+		name         string
+		method       string
+		viaHeader    bool
+		csrfCookie   string
+		csrfHeader   string
+		expectedCode int
+	}{
+		{"cookie GET without csrf", http.MethodGet, false, "", "", http.StatusOK},
+		{"cookie POST without csrf", http.MethodPost, false, "", "", http.StatusForbidden},
+		{"cookie DELETE with mismatched csrf", http.MethodDelete, false, "csrf-1", "csrf-2", http.StatusForbidden},
+		{"cookie PUT with matching csrf", http.MethodPut, false, "csrf-1", "csrf-1", http.StatusOK},
+		{"bearer POST without csrf", http.MethodPost, true, "", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/orders", nil)
+			if tt.viaHeader {
+				req.Header.Set("Authorization", "Bearer "+token)
+			} else {
+				req.AddCookie(&http.Cookie{Name: testAccessCookie, Value: token})
+			}
+			if tt.csrfCookie != "" {
+				req.AddCookie(&http.Cookie{Name: middleware.CSRFCookieName, Value: tt.csrfCookie})
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set(middleware.CSRFHeader, tt.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectsRefreshToken(t *testing.T) {
+	router := newRolesRouter(t, nil)
+
+	sign := func(claims middleware.Claims) string {
+		claims.UserID = uuid.NewString()
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour))
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := []struct {
+		name         string
+		claims       middleware.Claims
+		expectedCode int
+	}{
+		{"access token", middleware.Claims{TokenType: "access", RegisteredClaims: jwt.RegisteredClaims{ID: uuid.NewString()}}, http.StatusOK},
+		{"refresh token", middleware.Claims{TokenType: "refresh", RegisteredClaims: jwt.RegisteredClaims{ID: uuid.NewString()}}, http.StatusUnauthorized},
+		{"jti without typ", middleware.Claims{RegisteredClaims: jwt.RegisteredClaims{ID: uuid.NewString()}}, http.StatusUnauthorized},
+		{"unknown typ", middleware.Claims{TokenType: "activation"}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getRoles(router, sign(tt.claims))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+// revocationsStub: jti di revoked ditolak, err non-nil mensimulasikan DB mati
+type revocationsStub struct {
+	revoked map[uuid.UUID]bool
+	err     error
+}
+
+func (s *revocationsStub) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID, expiresAt time.Time) (bool, error) {
+	return s.revoked[jti], s.err
+}
+
+func TestAuthMiddleware_TokenRevocations(t *testing.T) {
+	router := newRolesRouter(t, nil)
+	t.Cleanup(func() { middleware.SetTokenRevocations(nil) })
+
+	revokedJTI := uuid.New()
+	sign := func(jti string) string {
+		claims := middleware.Claims{
+			UserID:           uuid.NewString(),
+			TokenType:        "access",
+			RegisteredClaims: jwt.RegisteredClaims{ID: jti, ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		}
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := []struct {
+		name         string
+		store        *revocationsStub
+		jti          string
+		expectedCode int
+	}{
+		{"active token", &revocationsStub{}, uuid.NewString(), http.StatusOK},
+		{"revoked token", &revocationsStub{revoked: map[uuid.UUID]bool{revokedJTI: true}}, revokedJTI.String(), http.StatusUnauthorized},
+		{"token without jti", &revocationsStub{}, "", http.StatusUnauthorized},
+		{"backend error fails closed", &revocationsStub{err: errors.New("db down")}, uuid.NewString(), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware.SetTokenRevocations(tt.store)
+
+			w := getRoles(router, sign(tt.jti))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
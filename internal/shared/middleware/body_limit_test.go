@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func newBodyLimitRouter(mw gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(mw)
+
+	echo := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.String(http.StatusOK, "%d", len(body))
+	}
+	router.POST("/small", echo)
+	router.POST("/import", echo)
+	return router
+}
+
+func TestMaxBodyBytes_UnderLimit(t *testing.T) {
+	router := newBodyLimitRouter(middleware.MaxBodyBytes(16))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/small", strings.NewReader("hello")))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Body.String())
+}
+
+func TestMaxBodyBytes_OversizedReturns413(t *testing.T) {
+	router := newBodyLimitRouter(middleware.MaxBodyBytes(16))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/small", bytes.NewReader(make([]byte, 1024))))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestMaxBodyBytes_UnknownLengthIsCapped(t *testing.T) {
+	router := newBodyLimitRouter(middleware.MaxBodyBytes(16))
+
+	req := httptest.NewRequest("POST", "/small", bytes.NewReader(make([]byte, 1024)))
+	req.ContentLength = -1
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "request body too large")
+}
+
+func TestMaxBodyBytes_RouteOverride(t *testing.T) {
+	router := newBodyLimitRouter(middleware.MaxBodyBytesWithOverrides(16, map[string]int64{
+		"/import": 4096,
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/import", bytes.NewReader(make([]byte, 1024))))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/small", bytes.NewReader(make([]byte, 1024))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
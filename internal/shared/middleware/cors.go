@@ -1,21 +1,93 @@
 package middleware
 
 import (
+	"errors"
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/config"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
+// ErrCORSWildcardCredentials: "*" plus credentials would let any site make
+// authenticated requests with the user's cookies
+var ErrCORSWildcardCredentials = errors.New(`CORS_ALLOWED_ORIGINS "*" cannot be combined with CORS_ALLOW_CREDENTIALS=true`)
+
+// ValidateCORS rejects configs CORSMiddleware must not run with; dipanggil
+// saat startup
+func ValidateCORS(cfg config.CORSConfig) error {
+	if !cfg.AllowCredentials {
+		return nil
+	}
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			return ErrCORSWildcardCredentials
+		}
+	}
+	return nil
+}
+
+// CORSMiddleware echoes back only allowlisted origins. A "*" entry
+// allows any origin, but never with Access-Control-Allow-Credentials:
+// credentials hanya untuk origin yang tertulis eksplisit (lihat juga
+// ValidateCORS).
+func CORSMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	allowAll := false
+	allowed := make(map[string]struct{}, len(cfg.AllowedOrigins))
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			allowAll = true
+			continue
+		}
+		allowed[strings.TrimRight(o, "/")] = struct{}{}
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+
+		_, listed := allowed[origin]
+		if !listed && !allowAll {
+			if isPreflight(c) {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+		if cfg.AllowCredentials && listed {
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if isPreflight(c) {
+			c.Writer.Header().Set("Access-Control-Allow-Methods", methods)
+			c.Writer.Header().Set("Access-Control-Allow-Headers", headers)
+			c.Writer.Header().Set("Access-Control-Max-Age", "600")
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
+		if exposed != "" {
+			c.Writer.Header().Set("Access-Control-Expose-Headers", exposed)
+		}
+
 		c.Next()
 	}
 }
+
+func isPreflight(c *gin.Context) bool {
+	return c.Request.Method == http.MethodOptions &&
+		c.GetHeader("Access-Control-Request-Method") != ""
+}
@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/config"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func newCORSRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CORSMiddleware(config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+	}))
+	router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	return router
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+
+	w := httptest.NewRecorder()
+	newCORSRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "ETag", w.Header().Get("Access-Control-Expose-Headers"))
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+
+	w := httptest.NewRecorder()
+	newCORSRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	req := httptest.NewRequest("OPTIONS", "/ping", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+
+	w := httptest.NewRecorder()
+	newCORSRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestCORS_PreflightDisallowedOrigin(t *testing.T) {
+	req := httptest.NewRequest("OPTIONS", "/ping", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+
+	w := httptest.NewRecorder()
+	newCORSRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_WildcardNeverAllowsCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CORSMiddleware(config.CORSConfig{
+		AllowedOrigins:   []string{"*", "https://app.example.com"},
+		AllowCredentials: true,
+	}))
+	router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+
+	tests := []struct {
+		origin      string
+		credentials string
+	}{
+		{"https://app.example.com", "true"},
+		{"https://evil.example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/ping", nil)
+			req.Header.Set("Origin", tt.origin)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.credentials, w.Header().Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
+
+func TestValidateCORS(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.CORSConfig
+		wantErr error
+	}{
+		{"wildcard with credentials", config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, middleware.ErrCORSWildcardCredentials},
+		{"wildcard without credentials", config.CORSConfig{AllowedOrigins: []string{"*"}}, nil},
+		{"explicit origins with credentials", config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, middleware.ValidateCORS(tt.cfg), tt.wantErr)
+		})
+	}
+}
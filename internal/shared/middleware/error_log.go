@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+)
+
+var errorLogger *slog.Logger // nil = slog.Default()
+
+// SetErrorLogger sets the logger InternalError writes to
+func SetErrorLogger(logger *slog.Logger) {
+	errorLogger = logger
+}
+
+// StatusClientClosedRequest is nginx's non-standard 499, used when the
+// client went away before the handler finished
+const StatusClientClosedRequest = 499
+
+/*
+InternalError adalah default case semua handleServiceError: error yang
+bukan sentinel dicatat di level error lengkap dengan rantai wrap-nya,
+request id dan route, lalu client hanya menerima 500 generik.
+
+Context yang dibatalkan (client disconnect) menjadi 499 dan context yang
+lewat deadline menjadi 504; keduanya bukan bug server, jadi tidak dicatat
+sebagai error.
+*/
+func InternalError(c *gin.Context, err error) {
+	logger := errorLogger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+
+	attrs := []any{
+		slog.String("error", err.Error()),
+		slog.String("request_id", ctxkeys.RequestID(c)),
+		slog.String("method", c.Request.Method),
+		slog.String("path", path),
+	}
+	for _, a := range userAttrs(c) {
+		attrs = append(attrs, a)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		logger.DebugContext(c.Request.Context(), "request cancelled", attrs...)
+		c.AbortWithStatus(StatusClientClosedRequest)
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.WarnContext(c.Request.Context(), "request timed out", attrs...)
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request timed out"})
+		c.Abort()
+		return
+	}
+
+	attrs = append(attrs, slog.Any("chain", errorChain(err)))
+	logger.ErrorContext(c.Request.Context(), "unexpected error", attrs...)
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	c.Abort()
+}
+
+// errorChain lists every error in err's Unwrap chain, outermost first,
+// as "type: message"
+func errorChain(err error) []string {
+	var chain []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, fmt.Sprintf("%T: %s", err, err.Error()))
+	}
+	return chain
+}
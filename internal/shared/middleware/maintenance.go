@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// MaintenanceSwitch reports whether maintenance mode is on
+type MaintenanceSwitch interface {
+	InMaintenance(ctx context.Context) (bool, error)
+}
+
+// StaticMaintenance is a switch fixed at startup (MAINTENANCE_MODE)
+type StaticMaintenance bool
+
+func (s StaticMaintenance) InMaintenance(context.Context) (bool, error) {
+	return bool(s), nil
+}
+
+// RedisMaintenance is on while its key exists, jadi semua instance bisa
+// di-toggle sekaligus dengan SET/DEL tanpa restart
+type RedisMaintenance struct {
+	client redis.Cmdable
+	key    string
+}
+
+func NewRedisMaintenance(client redis.Cmdable, key string) *RedisMaintenance {
+	return &RedisMaintenance{client: client, key: key}
+}
+
+func (r *RedisMaintenance) InMaintenance(ctx context.Context) (bool, error) {
+	n, err := r.client.Exists(ctx, r.key).Result()
+	if err != nil {
+		return false, fmt.Errorf("maintenance key %s: %w", r.key, err)
+	}
+	return n > 0, nil
+}
+
+// MaintenanceResponse is the 503 body of writes rejected in maintenance
+type MaintenanceResponse struct {
+	Error             string `json:"error" example:"Service under maintenance"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds" example:"300"`
+}
+
+/*
+MaintenanceMode menolak request tulis dengan 503 dan Retry-After selama
+switch aktif. GET, HEAD dan OPTIONS tetap dilayani, termasuk health
+check, jadi client masih bisa membaca data dan probe tidak gagal. Error
+backend switch fail open seperti rate limiter.
+*/
+func MaintenanceMode(sw MaintenanceSwitch, retryAfter time.Duration) gin.HandlerFunc {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		on, err := sw.InMaintenance(c.Request.Context())
+		if err != nil {
+			log.Printf("maintenance switch unavailable, allowing request: %v", err)
+			c.Next()
+			return
+		}
+		if !on {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, MaintenanceResponse{
+			Error:             "Service under maintenance",
+			RetryAfterSeconds: seconds,
+		})
+	}
+}
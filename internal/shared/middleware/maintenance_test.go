@@ -0,0 +1,105 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+type failingSwitch struct{}
+
+func (failingSwitch) InMaintenance(context.Context) (bool, error) {
+	return false, errors.New("redis down")
+}
+
+func newMaintenanceRouter(sw middleware.MaintenanceSwitch) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.MaintenanceMode(sw, 5*time.Minute))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/health", ok)
+	router.GET("/products", ok)
+	router.POST("/products", ok)
+	router.DELETE("/products/:id", ok)
+	return router
+}
+
+func serve(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestMaintenanceMode_BlocksWrites(t *testing.T) {
+	router := newMaintenanceRouter(middleware.StaticMaintenance(true))
+
+	for _, tt := range []struct{ method, path string }{
+		{http.MethodPost, "/products"},
+		{http.MethodDelete, "/products/1"},
+	} {
+		w := serve(router, tt.method, tt.path)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, tt.method)
+		assert.Equal(t, "300", w.Header().Get("Retry-After"))
+
+		var body middleware.MaintenanceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, middleware.MaintenanceResponse{
+			Error:             "Service under maintenance",
+			RetryAfterSeconds: 300,
+		}, body)
+	}
+}
+
+func TestMaintenanceMode_AllowsReads(t *testing.T) {
+	router := newMaintenanceRouter(middleware.StaticMaintenance(true))
+
+	for _, path := range []string{"/products", "/health"} {
+		w := serve(router, http.MethodGet, path)
+
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	}
+}
+
+func TestMaintenanceMode_Off(t *testing.T) {
+	router := newMaintenanceRouter(middleware.StaticMaintenance(false))
+
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodPost, "/products").Code)
+}
+
+func TestMaintenanceMode_SwitchErrorFailsOpen(t *testing.T) {
+	router := newMaintenanceRouter(failingSwitch{})
+
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodPost, "/products").Code)
+}
+
+func TestRedisMaintenance_FollowsKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	router := newMaintenanceRouter(middleware.NewRedisMaintenance(client, "maintenance"))
+
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodPost, "/products").Code)
+
+	require.NoError(t, mr.Set("maintenance", "1"))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(router, http.MethodPost, "/products").Code)
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/products").Code)
+
+	mr.Del("maintenance")
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodPost, "/products").Code)
+}
@@ -0,0 +1,186 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/ratelimit"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, int, time.Duration) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("redis down")
+}
+
+func (failingLimiter) AllowN(context.Context, string, int, int, time.Duration) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("redis down")
+}
+
+func newRateLimitRouter(limiter ratelimit.RateLimiter, limit int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RateLimitMiddleware(limiter, limit, time.Minute, middleware.ClientIPKey))
+	router.GET("/ping", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRateLimitMiddleware_Returns429OverLimit(t *testing.T) {
+	router := newRateLimitRouter(ratelimit.NewMemoryLimiter(), 2)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/ping", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/ping", nil))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	var body middleware.RateLimitedResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, middleware.RateLimitedResponse{
+		Error:             "Too many requests",
+		RetryAfterSeconds: 60,
+		Limit:             2,
+		WindowSeconds:     60,
+	}, body)
+}
+
+func TestRateLimitMiddleware_FailsOpen(t *testing.T) {
+	router := newRateLimitRouter(failingLimiter{}, 1)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/ping", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestClientIPKey_IgnoresForwardedForFromUntrustedPeer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	keyFor := func(trusted []string, xff string) string {
+		router := gin.New()
+		assert.NoError(t, router.SetTrustedProxies(trusted))
+
+		var key string
+		router.GET("/ping", func(c *gin.Context) {
+			key = middleware.ClientIPKey(c)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = "203.0.113.7:41000"
+		if xff != "" {
+			req.Header.Set("X-Forwarded-For", xff)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		return key
+	}
+
+	// TRUSTED_PROXIES kosong: header palsu tetap jatuh ke bucket yang sama
+	assert.Equal(t, "ip:203.0.113.7", keyFor(nil, ""))
+	assert.Equal(t, "ip:203.0.113.7", keyFor(nil, "198.51.100.1"))
+	assert.Equal(t, "ip:203.0.113.7", keyFor(nil, "198.51.100.2"))
+
+	// peer adalah proxy yang dipercaya: IP client diambil dari header
+	assert.Equal(t, "ip:198.51.100.1", keyFor([]string{"203.0.113.0/24"}, "198.51.100.1"))
+}
+
+func TestWeightedRateLimitMiddleware_HeavyRouteDrainsFaster(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.WeightedRateLimitMiddleware(ratelimit.NewMemoryLimiter(), 10, time.Minute,
+		middleware.ClientIPKey, map[string]int{"/export": 5, "/livez": 0}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/export", ok)
+	router.GET("/ping", ok)
+	router.GET("/livez", ok)
+
+	// hitung request yang lolos sebelum 429, tiap route dari IP sendiri
+	allowed := func(path, ip string) int {
+		for n := 0; n < 100; n++ {
+			req := httptest.NewRequest("GET", path, nil)
+			req.RemoteAddr = ip + ":1234"
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code == http.StatusTooManyRequests {
+				return n
+			}
+		}
+		return 100
+	}
+
+	assert.Equal(t, 2, allowed("/export", "10.0.0.1"))
+	assert.Equal(t, 10, allowed("/ping", "10.0.0.2"))
+	assert.Equal(t, 100, allowed("/livez", "10.0.0.3"), "cost 0 is never limited")
+}
+
+func TestWeightedRateLimitMiddleware_SharedBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.WeightedRateLimitMiddleware(ratelimit.NewMemoryLimiter(), 10, time.Minute,
+		middleware.ClientIPKey, map[string]int{"/export": 5}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/export", ok)
+	router.GET("/ping", ok)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	// export memakai 5 dari budget yang sama dengan route murah
+	assert.Equal(t, http.StatusOK, serve("/export").Code)
+	w := serve("/ping")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4", w.Header().Get("X-RateLimit-Remaining"))
+
+	assert.Equal(t, http.StatusTooManyRequests, serve("/export").Code)
+
+	// export yang ditolak tidak memakai budget: sisa 4 masih bisa dipakai
+	w = serve("/ping")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get("X-RateLimit-Remaining"))
+}
+
+func TestValidateRouteCosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		costs   map[string]int
+		budget  int
+		wantErr bool
+	}{
+		{"within budget", map[string]int{"/export": 10, "/livez": 0}, 10, false},
+		{"no costs", nil, 1, false},
+		{"cost above budget", map[string]int{"/export": 11}, 10, true},
+		{"budget below default cost", nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := middleware.ValidateRouteCosts(tt.costs, tt.budget)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
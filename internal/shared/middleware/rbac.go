@@ -1,28 +1,115 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+// MenuAccessChecker looks up role_menus grants; rbac.MenuAccess is the
+// database-backed implementation
+type MenuAccessChecker interface {
+	// MenuForPath returns the menu code guarding a gin route pattern,
+	// "" if none
+	MenuForPath(ctx context.Context, routePath string) (string, error)
+	HasMenuAccess(ctx context.Context, roles []string, menuCode, permission string) (bool, error)
+}
+
+var menuAccess MenuAccessChecker // nil = hanya cek user punya role
+
+// SetMenuAccess sets the checker RequireMenu and RequireMenuForPath
+// consult, called once at startup
+func SetMenuAccess(checker MenuAccessChecker) {
+	menuAccess = checker
+}
+
 func RequireMenu(menuCode string, permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		roles := GetRoles(c)
+		authorizeMenu(c, menuCode, permission)
+	}
+}
 
-		if len(roles) == 0 {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-			c.Abort()
+// RequireMenuForPath is RequireMenu without the per-route arguments: the
+// menu is resolved from the matched route path and the permission from
+// the HTTP method (see PermissionForMethod)
+func RequireMenuForPath() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		permission := PermissionForMethod(c.Request.Method)
+		if permission == "" {
+			denyMenu(c)
 			return
 		}
 
-		// TODO: Query database to check role_menus table
-		// hasAccess := checkMenuAccess(roles, menuCode, permission)
+		if menuAccess == nil {
+			authorizeMenu(c, "", permission)
+			return
+		}
 
-		c.Next()
+		menuCode, err := menuAccess.MenuForPath(c.Request.Context(), c.FullPath())
+		if err != nil {
+			menuCheckFailed(c, err)
+			return
+		}
+		if menuCode == "" {
+			denyMenu(c)
+			return
+		}
+
+		authorizeMenu(c, menuCode, permission)
 	}
 }
 
+// PermissionForMethod maps an HTTP method to a role_menus permission,
+// "" for methods no permission covers
+func PermissionForMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return "read"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	}
+	return ""
+}
+
+func authorizeMenu(c *gin.Context, menuCode, permission string) {
+	roles := GetRoles(c)
+
+	if len(roles) == 0 {
+		denyMenu(c)
+		return
+	}
+
+	if menuAccess != nil {
+		allowed, err := menuAccess.HasMenuAccess(c.Request.Context(), roles, menuCode, permission)
+		if err != nil {
+			menuCheckFailed(c, err)
+			return
+		}
+		if !allowed {
+			denyMenu(c)
+			return
+		}
+	}
+
+	c.Next()
+}
+
+func denyMenu(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	c.Abort()
+}
+
+// gagal cek permission = tolak, jangan fail open
+func menuCheckFailed(c *gin.Context, err error) {
+	InternalError(c, fmt.Errorf("menu access check: %w", err))
+}
+
 // RequireRole checks if user has specific role
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,142 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+// menuAccessStub memberi grant per menu:permission dan mencatat pengecekan terakhir
+type menuAccessStub struct {
+	menus  map[string]string // route path -> menu code
+	grants map[string]bool   // "menu:permission"
+
+	checkedMenu       string
+	checkedPermission string
+}
+
+func (s *menuAccessStub) MenuForPath(ctx context.Context, routePath string) (string, error) {
+	return s.menus[routePath], nil
+}
+
+func (s *menuAccessStub) HasMenuAccess(ctx context.Context, roles []string, menuCode, permission string) (bool, error) {
+	s.checkedMenu, s.checkedPermission = menuCode, permission
+	return s.grants[menuCode+":"+permission], nil
+}
+
+func newMenuRouter(t *testing.T, checker middleware.MenuAccessChecker) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	middleware.SetMenuAccess(checker)
+	t.Cleanup(func() { middleware.SetMenuAccess(nil) })
+
+	router := gin.New()
+	routes := router.Group("/api/v1/products", func(c *gin.Context) {
+		ctxkeys.SetRoles(c, []string{"sales"})
+		c.Next()
+	}, middleware.RequireMenuForPath())
+
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	routes.GET("/:id", ok)
+	routes.POST("", ok)
+	routes.PUT("/:id", ok)
+	routes.PATCH("/:id", ok)
+	routes.DELETE("/:id", ok)
+	routes.OPTIONS("/:id", ok)
+
+	return router
+}
+
+func TestPermissionForMethod(t *testing.T) {
+	tests := map[string]string{
+		http.MethodGet:     "read",
+		http.MethodHead:    "read",
+		http.MethodPost:    "create",
+		http.MethodPut:     "update",
+		http.MethodPatch:   "update",
+		http.MethodDelete:  "delete",
+		http.MethodOptions: "",
+	}
+
+	for method, want := range tests {
+		assert.Equal(t, want, middleware.PermissionForMethod(method), method)
+	}
+}
+
+func TestRequireMenuForPath_InfersPermission(t *testing.T) {
+	tests := []struct {
+		method     string
+		path       string
+		permission string
+	}{
+		{http.MethodGet, "/api/v1/products/1", "read"},
+		{http.MethodPost, "/api/v1/products", "create"},
+		{http.MethodPut, "/api/v1/products/1", "update"},
+		{http.MethodPatch, "/api/v1/products/1", "update"},
+		{http.MethodDelete, "/api/v1/products/1", "delete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			stub := &menuAccessStub{
+				menus: map[string]string{
+					"/api/v1/products":     "products",
+					"/api/v1/products/:id": "products",
+				},
+				grants: map[string]bool{"products:" + tt.permission: true},
+			}
+			router := newMenuRouter(t, stub)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, "products", stub.checkedMenu)
+			assert.Equal(t, tt.permission, stub.checkedPermission)
+		})
+	}
+}
+
+func TestRequireMenuForPath_Denied(t *testing.T) {
+	stub := &menuAccessStub{
+		menus:  map[string]string{"/api/v1/products/:id": "products"},
+		grants: map[string]bool{"products:read": true},
+	}
+	router := newMenuRouter(t, stub)
+
+	tests := []struct {
+		name   string
+		method string
+	}{
+		{"permission not granted", http.MethodDelete},
+		{"method without permission", http.MethodOptions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/api/v1/products/1", nil))
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
+
+func TestRequireMenuForPath_UnknownMenuDenied(t *testing.T) {
+	stub := &menuAccessStub{grants: map[string]bool{":read": true}}
+	router := newMenuRouter(t, stub)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, stub.checkedPermission)
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+	response "go-mini-erp/internal/shared/dto"
+	"go-mini-erp/internal/shared/errreport"
+)
+
+// RecoveryMiddleware replaces gin.Recovery: a panic is forwarded to the
+// reporter (with stack, request id and user id) and the client gets the
+// standard 500 envelope instead of a bare status
+func RecoveryMiddleware(reporter errreport.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// http.ErrAbortHandler = koneksi sengaja diputus, bukan bug
+			if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(rec)
+			}
+
+			err, ok := rec.(error)
+			if !ok {
+				err = fmt.Errorf("panic: %v", rec)
+			}
+
+			path := c.FullPath()
+			if path == "" {
+				path = c.Request.URL.Path
+			}
+
+			reporter.Report(c.Request.Context(), errreport.Report{
+				Err:       err,
+				Stack:     debug.Stack(),
+				RequestID: ctxkeys.RequestID(c),
+				UserID:    ctxkeys.UserID(c),
+				Method:    c.Request.Method,
+				Path:      path,
+			})
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			response.Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error", gin.H{
+				"requestId": ctxkeys.RequestID(c),
+			})
+			c.Abort()
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+	response "go-mini-erp/internal/shared/dto"
+	"go-mini-erp/internal/shared/errreport"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+type captureReporter struct {
+	reports []errreport.Report
+}
+
+func (r *captureReporter) Report(_ context.Context, rep errreport.Report) {
+	r.reports = append(r.reports, rep)
+}
+
+func TestRecoveryMiddleware_ReportsPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reporter := &captureReporter{}
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.RecoveryMiddleware(reporter))
+	router.Use(func(c *gin.Context) {
+		ctxkeys.SetUserID(c, "f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+		c.Next()
+	})
+	router.GET("/boom/:id", func(c *gin.Context) {
+		panic("something broke")
+	})
+
+	req := httptest.NewRequest("GET", "/boom/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var body response.ApiEnvelope
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.False(t, body.Ok)
+	assert.Equal(t, "INTERNAL_ERROR", body.Error["code"])
+
+	require.Len(t, reporter.reports, 1)
+	rep := reporter.reports[0]
+	assert.EqualError(t, rep.Err, "panic: something broke")
+	assert.Equal(t, "req-123", rep.RequestID)
+	assert.Equal(t, "f0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", rep.UserID)
+	assert.Equal(t, "GET", rep.Method)
+	assert.Equal(t, "/boom/:id", rep.Path)
+	assert.Contains(t, string(rep.Stack), "recovery_test.go")
+}
+
+func TestRecoveryMiddleware_NoPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reporter := &captureReporter{}
+
+	router := gin.New()
+	router.Use(middleware.RecoveryMiddleware(reporter))
+	router.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/ok", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, reporter.reports)
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, ctxkeys.RequestID(c))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	assert.NotEmpty(t, w.Body.String())
+	assert.Equal(t, w.Body.String(), w.Header().Get(middleware.RequestIDHeader))
+}
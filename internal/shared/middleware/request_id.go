@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/ctxkeys"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware reuses the inbound X-Request-ID (e.g. from the
+// load balancer) or generates one, and echoes it in the response
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = uuid.NewString()
+		}
+
+		ctxkeys.SetRequestID(c, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
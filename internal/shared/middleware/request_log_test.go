@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+// newLoggedRouter mencatat access log sebagai JSON ke buf
+func newLoggedRouter(t *testing.T, buf *bytes.Buffer) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	middleware.SetJWTSecret(testJWTSecret)
+
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+
+	router := gin.New()
+	router.Use(middleware.RequestLogger(logger), middleware.RequestIDMiddleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/public", ok)
+	router.GET("/private/:id", middleware.AuthMiddleware(), ok)
+	return router
+}
+
+func decodeLogLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	return line
+}
+
+func TestRequestLogger_AuthenticatedUser(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(t, &buf)
+
+	userID := uuid.New()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, middleware.Claims{
+		UserID:   userID.String(),
+		Username: "budi",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	signed, err := token.SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/private/42", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := decodeLogLine(t, &buf)
+	assert.Equal(t, userID.String(), line["user_id"])
+	assert.Equal(t, "budi", line["username"])
+	assert.Equal(t, "/private/:id", line["path"])
+	assert.EqualValues(t, http.StatusNoContent, line["status"])
+	assert.NotEmpty(t, line["request_id"])
+}
+
+func TestRequestLogger_UnauthenticatedOmitsUser(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"public route", "/public", http.StatusNoContent},
+		{"rejected by auth", "/private/42", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			router := newLoggedRouter(t, &buf)
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			line := decodeLogLine(t, &buf)
+			assert.EqualValues(t, tt.status, line["status"])
+			assert.NotContains(t, line, "user_id")
+			assert.NotContains(t, line, "username")
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WriteTimeoutOverrides replaces the server WriteTimeout for routes
+// listed in overrides (keyed by route template, e.g.
+// "/api/v1/users/export"). Durasi 0 menghapus deadline sama sekali.
+func WriteTimeoutOverrides(overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d, ok := overrides[c.FullPath()]; ok {
+			var deadline time.Time
+			if d > 0 {
+				deadline = time.Now().Add(d)
+			}
+			if err := http.NewResponseController(c.Writer).SetWriteDeadline(deadline); err != nil {
+				log.Printf("middleware: set write deadline for %s failed: %v", c.FullPath(), err)
+			}
+		}
+
+		c.Next()
+	}
+}
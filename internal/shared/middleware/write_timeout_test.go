@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func TestWriteTimeoutOverrides(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.WriteTimeoutOverrides(map[string]time.Duration{
+		"/export": time.Second,
+	}))
+
+	// handler lebih lambat dari WriteTimeout server
+	slow := func(c *gin.Context) {
+		time.Sleep(150 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	}
+	router.GET("/export", slow)
+	router.GET("/list", slow)
+
+	srv := httptest.NewUnstartedServer(router)
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/export")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "done", string(body))
+
+	// tanpa override, server memutus koneksi sebelum respons terkirim
+	resp, err = http.Get(srv.URL + "/list")
+	if err == nil {
+		_, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinksQueryParam: ?links=true meminta Links di response list
+const LinksQueryParam = "links"
+
+// Links are ready-made URLs for paging through a list. Next dan Prev
+// kosong di halaman terakhir/pertama.
+type Links struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last"`
+}
+
+/*
+WantsLinks reports whether the client asked for Links, either with
+?links=true or with a links=true parameter on an Accept media type
+(mis. "Accept: application/json; links=true"). Default mati supaya
+response lama tidak berubah.
+*/
+func WantsLinks(c *gin.Context) bool {
+	if v, ok := c.GetQuery(LinksQueryParam); ok {
+		want, err := strconv.ParseBool(v)
+		return err == nil && want
+	}
+
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		if want, err := strconv.ParseBool(params[LinksQueryParam]); err == nil && want {
+			return true
+		}
+	}
+	return false
+}
+
+// LinksFor builds Links for the current request when the client asked
+// for them (see WantsLinks), nil otherwise
+func LinksFor(c *gin.Context, meta Meta) *Links {
+	if !WantsLinks(c) {
+		return nil
+	}
+	links := BuildLinks(c.Request.URL, meta)
+	return &links
+}
+
+// AddLinks sets r.Links for the current request, see LinksFor
+func (r *Response[T]) AddLinks(c *gin.Context) {
+	r.Links = LinksFor(c, r.Meta)
+}
+
+/*
+BuildLinks derives the page URLs from base, keeping its path and every
+query parameter except page and pageSize. URL-nya relatif (path +
+query) supaya tidak bergantung pada Host header atau proxy di depan API.
+List kosong tetap punya satu halaman: first = last = page 1.
+*/
+func BuildLinks(base *url.URL, meta Meta) Links {
+	last := max(meta.TotalPages, 1)
+
+	links := Links{
+		Self:  pageURL(base, meta.Page, meta.PageSize),
+		First: pageURL(base, 1, meta.PageSize),
+		Last:  pageURL(base, last, meta.PageSize),
+	}
+	if meta.Page > 1 {
+		links.Prev = pageURL(base, min(meta.Page-1, last), meta.PageSize)
+	}
+	if meta.Page < last {
+		links.Next = pageURL(base, meta.Page+1, meta.PageSize)
+	}
+	return links
+}
+
+func pageURL(base *url.URL, page, pageSize int) string {
+	q := base.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("pageSize", strconv.Itoa(pageSize))
+
+	u := url.URL{Path: base.Path, RawQuery: q.Encode()}
+	return u.String()
+}
@@ -0,0 +1,102 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/pagination"
+)
+
+func buildLinks(t *testing.T, rawURL string, page int, total int64) pagination.Links {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return pagination.BuildLinks(u, pagination.BuildMeta(total, pagination.NewParams(page, 10)))
+}
+
+func TestBuildLinks_FirstPage(t *testing.T) {
+	links := buildLinks(t, "/api/v1/products?search=kopi&page=1&pageSize=10", 1, 35)
+
+	assert.Equal(t, pagination.Links{
+		Self:  "/api/v1/products?page=1&pageSize=10&search=kopi",
+		First: "/api/v1/products?page=1&pageSize=10&search=kopi",
+		Next:  "/api/v1/products?page=2&pageSize=10&search=kopi",
+		Last:  "/api/v1/products?page=4&pageSize=10&search=kopi",
+	}, links)
+}
+
+func TestBuildLinks_MiddlePage(t *testing.T) {
+	links := buildLinks(t, "/api/v1/products?page=2", 2, 35)
+
+	assert.Equal(t, pagination.Links{
+		Self:  "/api/v1/products?page=2&pageSize=10",
+		First: "/api/v1/products?page=1&pageSize=10",
+		Prev:  "/api/v1/products?page=1&pageSize=10",
+		Next:  "/api/v1/products?page=3&pageSize=10",
+		Last:  "/api/v1/products?page=4&pageSize=10",
+	}, links)
+}
+
+func TestBuildLinks_LastPage(t *testing.T) {
+	links := buildLinks(t, "/api/v1/products?page=4&pageSize=10", 4, 35)
+
+	assert.Equal(t, pagination.Links{
+		Self:  "/api/v1/products?page=4&pageSize=10",
+		First: "/api/v1/products?page=1&pageSize=10",
+		Prev:  "/api/v1/products?page=3&pageSize=10",
+		Last:  "/api/v1/products?page=4&pageSize=10",
+	}, links)
+}
+
+func TestBuildLinks_EmptyAndOutOfRange(t *testing.T) {
+	empty := buildLinks(t, "/items", 1, 0)
+	assert.Equal(t, "/items?page=1&pageSize=10", empty.Last)
+	assert.Empty(t, empty.Prev)
+	assert.Empty(t, empty.Next)
+
+	// halaman di luar jangkauan: prev menunjuk halaman terakhir
+	beyond := buildLinks(t, "/items?page=9", 9, 35)
+	assert.Equal(t, "/items?page=4&pageSize=10", beyond.Prev)
+	assert.Empty(t, beyond.Next)
+}
+
+func TestWantsLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		accept string
+		want   bool
+	}{
+		{"default off", "", "application/json", false},
+		{"query flag", "links=true", "", true},
+		{"query flag false", "links=0", "application/json; links=true", false},
+		{"accept parameter", "", "text/html, application/json; links=true", true},
+		{"invalid accept", "", "application/json; links", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newContext(tt.query)
+			c.Request.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.want, pagination.WantsLinks(c))
+		})
+	}
+}
+
+func TestResponse_AddLinks(t *testing.T) {
+	res := pagination.NewResponse([]string{"a"}, 1, pagination.NewParams(1, 10))
+
+	res.AddLinks(newContext("page=1"))
+	raw, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "links", "tanpa flag response tidak berubah")
+
+	res.AddLinks(newContext("page=1&links=true"))
+	require.NotNil(t, res.Links)
+	assert.Equal(t, "/items?links=true&page=1&pageSize=10", res.Links.Self)
+	assert.Empty(t, res.Links.Next)
+}
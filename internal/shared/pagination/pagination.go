@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultPage     = 1
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Params is paging input parsed from ?page=&pageSize=
+type Params struct {
+	Page     int
+	PageSize int
+}
+
+// Meta is paging info returned alongside list data
+type Meta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"pageSize"`
+	TotalPages int   `json:"totalPages"`
+}
+
+// Response wraps a page of items with its meta; Links hanya terisi
+// kalau client memintanya (lihat AddLinks)
+type Response[T any] struct {
+	Data  []T    `json:"data"`
+	Meta  Meta   `json:"meta"`
+	Links *Links `json:"links,omitempty"`
+}
+
+// ParsePagination reads page & pageSize from query string,
+// falling back to defaults and clamping pageSize to MaxPageSize
+func ParsePagination(c *gin.Context) Params {
+	return NewParams(
+		atoiOrZero(c.Query("page")),
+		atoiOrZero(c.Query("pageSize")),
+	)
+}
+
+// NewParams normalizes raw page values: default untuk nilai < 1, pageSize
+// maksimal MaxPageSize dan page maksimal yang Offset-nya masih muat int32
+func NewParams(page, pageSize int) Params {
+	if page < 1 {
+		page = DefaultPage
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	// page di-clamp supaya Offset muat di int32; halaman sejauh itu
+	// memang kosong
+	if maxPage := math.MaxInt32/pageSize + 1; page > maxPage {
+		page = maxPage
+	}
+
+	return Params{Page: page, PageSize: pageSize}
+}
+
+// Limit is PageSize as int32 for sqlc LIMIT params
+func (p Params) Limit() int32 {
+	return int32(p.PageSize)
+}
+
+// Offset is the row offset as int32 for sqlc OFFSET params
+func (p Params) Offset() int32 {
+	return int32((p.Page - 1) * p.PageSize)
+}
+
+// BuildMeta computes total pages for the given total row count
+func BuildMeta(total int64, params Params) Meta {
+	totalPages := 0
+	if total > 0 && params.PageSize > 0 {
+		totalPages = int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+	}
+
+	return Meta{
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}
+}
+
+// NewResponse builds a Response, never serializing data as null
+func NewResponse[T any](items []T, total int64, params Params) Response[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return Response[T]{
+		Data: items,
+		Meta: BuildMeta(total, params),
+	}
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
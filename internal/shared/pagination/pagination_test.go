@@ -0,0 +1,81 @@
+package pagination_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/pagination"
+)
+
+func newContext(rawQuery string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/items?"+rawQuery, nil)
+	return c
+}
+
+func TestParsePagination_Defaults(t *testing.T) {
+	p := pagination.ParsePagination(newContext(""))
+
+	assert.Equal(t, pagination.DefaultPage, p.Page)
+	assert.Equal(t, pagination.DefaultPageSize, p.PageSize)
+}
+
+func TestParsePagination_Clamping(t *testing.T) {
+	p := pagination.ParsePagination(newContext("page=-3&pageSize=1000"))
+	assert.Equal(t, 1, p.Page)
+	assert.Equal(t, pagination.MaxPageSize, p.PageSize)
+
+	p = pagination.ParsePagination(newContext("page=abc&pageSize=0"))
+	assert.Equal(t, 1, p.Page)
+	assert.Equal(t, pagination.DefaultPageSize, p.PageSize)
+
+	p = pagination.ParsePagination(newContext("page=3&pageSize=25"))
+	assert.Equal(t, 3, p.Page)
+	assert.Equal(t, 25, p.PageSize)
+	assert.Equal(t, int32(25), p.Limit())
+	assert.Equal(t, int32(50), p.Offset())
+}
+
+func TestParsePagination_OffsetFitsInt32(t *testing.T) {
+	tests := []struct {
+		query    string
+		pageSize int
+	}{
+		{"page=9223372036854775807&pageSize=100", 100},
+		{"page=2147483647&pageSize=7", 7},
+		{"page=2147483647", pagination.DefaultPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			p := pagination.ParsePagination(newContext(tt.query))
+
+			assert.Equal(t, tt.pageSize, p.PageSize)
+			assert.GreaterOrEqual(t, p.Offset(), int32(0))
+			assert.Equal(t, int64(p.Page-1)*int64(p.PageSize), int64(p.Offset()))
+		})
+	}
+}
+
+func TestBuildMeta_TotalPages(t *testing.T) {
+	params := pagination.NewParams(1, 10)
+
+	assert.Equal(t, 0, pagination.BuildMeta(0, params).TotalPages)
+	assert.Equal(t, 1, pagination.BuildMeta(1, params).TotalPages)
+	assert.Equal(t, 1, pagination.BuildMeta(10, params).TotalPages)
+	assert.Equal(t, 2, pagination.BuildMeta(11, params).TotalPages)
+	assert.Equal(t, 10, pagination.BuildMeta(100, params).TotalPages)
+}
+
+func TestNewResponse_EmptyData(t *testing.T) {
+	res := pagination.NewResponse[string](nil, 0, pagination.NewParams(1, 10))
+
+	assert.NotNil(t, res.Data)
+	assert.Len(t, res.Data, 0)
+	assert.Equal(t, int64(0), res.Meta.Total)
+	assert.Equal(t, 0, res.Meta.TotalPages)
+}
@@ -0,0 +1,71 @@
+// Package patch implements PATCH-style partial updates.
+package patch
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyUpdate copies every non-nil pointer field of src onto the field of
+// the same name in target. A target field of type T receives the pointed-to
+// value and a *T field receives the pointer itself. Nil fields leave the
+// target untouched, so a null in the request cannot clear a column.
+//
+// src must be a struct whose fields are all pointers; a field without a
+// matching, assignable target field is a programming error and returns an
+// error before target is modified.
+func ApplyUpdate[T any](target *T, src any) error {
+	dst := reflect.ValueOf(target).Elem()
+	if dst.Kind() != reflect.Struct {
+		return fmt.Errorf("patch: target must be a struct, got %s", dst.Kind())
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("patch: src must be a struct, got %s", sv.Kind())
+	}
+
+	type assignment struct {
+		field reflect.Value
+		value reflect.Value
+	}
+	var pending []assignment
+
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Type.Kind() != reflect.Pointer {
+			return fmt.Errorf("patch: src field %s must be a pointer", sf.Name)
+		}
+
+		df := dst.FieldByName(sf.Name)
+		if !df.IsValid() || !df.CanSet() {
+			return fmt.Errorf("patch: target has no settable field %s", sf.Name)
+		}
+
+		v := sv.Field(i)
+		switch {
+		case sf.Type.AssignableTo(df.Type()):
+			if !v.IsNil() {
+				pending = append(pending, assignment{df, v})
+			}
+		case sf.Type.Elem().AssignableTo(df.Type()):
+			if !v.IsNil() {
+				pending = append(pending, assignment{df, v.Elem()})
+			}
+		default:
+			return fmt.Errorf("patch: cannot assign %s to target field %s of type %s", sf.Type, sf.Name, df.Type())
+		}
+	}
+
+	for _, a := range pending {
+		a.field.Set(a.value)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package patch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/patch"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+type record struct {
+	ID          int
+	Name        string
+	Description *string
+	IsActive    *bool
+}
+
+type recordPatch struct {
+	Name        *string
+	Description *string
+	IsActive    *bool
+}
+
+func TestApplyUpdate_Partial(t *testing.T) {
+	target := record{ID: 7, Name: "Sales", Description: dbutil.Ptr("old"), IsActive: dbutil.BoolPtr(true)}
+
+	err := patch.ApplyUpdate(&target, recordPatch{Name: dbutil.Ptr("Sales Team")})
+
+	require.NoError(t, err)
+	assert.Equal(t, record{ID: 7, Name: "Sales Team", Description: dbutil.Ptr("old"), IsActive: dbutil.BoolPtr(true)}, target)
+}
+
+func TestApplyUpdate_Full(t *testing.T) {
+	target := record{ID: 7, Name: "Sales", IsActive: dbutil.BoolPtr(true)}
+
+	err := patch.ApplyUpdate(&target, &recordPatch{
+		Name:        dbutil.Ptr("Finance"),
+		Description: dbutil.Ptr("Handles invoices"),
+		IsActive:    dbutil.BoolPtr(false),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, record{ID: 7, Name: "Finance", Description: dbutil.Ptr("Handles invoices"), IsActive: dbutil.BoolPtr(false)}, target)
+}
+
+func TestApplyUpdate_Empty(t *testing.T) {
+	target := record{ID: 7, Name: "Sales"}
+
+	require.NoError(t, patch.ApplyUpdate(&target, recordPatch{}))
+	assert.Equal(t, record{ID: 7, Name: "Sales"}, target)
+}
+
+func TestApplyUpdate_Mismatch(t *testing.T) {
+	target := record{Name: "Sales"}
+
+	// field yang tidak ada di target
+	err := patch.ApplyUpdate(&target, struct{ Code *string }{Code: dbutil.Ptr("x")})
+	assert.Error(t, err)
+
+	// tipe berbeda; target tidak boleh berubah sebagian
+	err = patch.ApplyUpdate(&target, struct {
+		Name *string
+		ID   *string
+	}{Name: dbutil.Ptr("Finance"), ID: dbutil.Ptr("x")})
+	assert.Error(t, err)
+	assert.Equal(t, "Sales", target.Name)
+
+	// field non-pointer
+	err = patch.ApplyUpdate(&target, struct{ Name string }{Name: "Finance"})
+	assert.Error(t, err)
+}
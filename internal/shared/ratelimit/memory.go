@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryWindow struct {
+	count   int64
+	resetAt time.Time
+}
+
+// MemoryLimiter keeps counters in process; only correct for a single
+// instance (dev / fallback saat Redis tidak ada)
+type MemoryLimiter struct {
+	mu       sync.Mutex
+	windows  map[string]*memoryWindow
+	expiries expiryHeap
+	now      func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		windows: make(map[string]*memoryWindow),
+		now:     time.Now,
+	}
+}
+
+func (m *MemoryLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	return m.AllowN(ctx, key, 1, limit, window)
+}
+
+func (m *MemoryLimiter) AllowN(_ context.Context, key string, cost, limit int, window time.Duration) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	w, ok := m.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		m.evictExpired(now)
+		w = &memoryWindow{resetAt: now.Add(window)}
+		m.windows[key] = w
+		heap.Push(&m.expiries, expiry{key: key, resetAt: w.resetAt})
+	}
+	// hit yang ditolak tidak memakai budget, jadi request mahal yang kena
+	// 429 tidak ikut menguras sisa budget route lain
+	if w.count+int64(cost) > int64(limit) {
+		res := newResult(w.count, limit, w.resetAt.Sub(now))
+		res.Allowed = false
+		return res, nil
+	}
+	w.count += int64(cost)
+
+	return newResult(w.count, limit, w.resetAt.Sub(now)), nil
+}
+
+// evictBatch: paling banyak window yang dibuang per window baru
+const evictBatch = 32
+
+// evictExpired membuang window yang sudah lewat supaya map tidak tumbuh
+// terus. Window diambil dari heap urut resetAt, paling banyak evictBatch
+// per panggilan, jadi biaya di bawah mutex tidak tergantung besar map;
+// tiap window baru membuang lebih banyak daripada yang ditambahkannya.
+func (m *MemoryLimiter) evictExpired(now time.Time) {
+	for i := 0; i < evictBatch && len(m.expiries) > 0; i++ {
+		if now.Before(m.expiries[0].resetAt) {
+			return
+		}
+		e := heap.Pop(&m.expiries).(expiry)
+		// key yang di-Reset atau sudah dapat window baru dilewati
+		if w, ok := m.windows[e.key]; ok && w.resetAt.Equal(e.resetAt) {
+			delete(m.windows, e.key)
+		}
+	}
+}
+
+// expiry is a window's key and resetAt as pushed onto expiryHeap
+type expiry struct {
+	key     string
+	resetAt time.Time
+}
+
+// expiryHeap is a min-heap of expiry by resetAt (container/heap)
+type expiryHeap []expiry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].resetAt.Before(h[j].resetAt) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x any)        { *h = append(*h, x.(expiry)) }
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// Peek reports the key's current window without counting a hit
+func (m *MemoryLimiter) Peek(_ context.Context, key string, limit int) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	w, ok := m.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		return newResult(0, limit, 0), nil
+	}
+
+	return newResult(w.count, limit, w.resetAt.Sub(now)), nil
+}
+
+func (m *MemoryLimiter) Release(_ context.Context, key string, cost int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.windows[key]
+	if !ok || !m.now().Before(w.resetAt) {
+		return nil
+	}
+	w.count = max(w.count-int64(cost), 0)
+	return nil
+}
+
+func (m *MemoryLimiter) Reset(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.windows, key)
+	return nil
+}
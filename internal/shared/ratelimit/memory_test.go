@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiter_WindowResets(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryLimiter()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	res, _ := m.Allow(ctx, "k", 1, time.Minute)
+	assert.True(t, res.Allowed)
+
+	res, _ = m.Allow(ctx, "k", 1, time.Minute)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Minute, res.ResetAfter)
+
+	now = now.Add(time.Minute)
+
+	res, _ = m.Allow(ctx, "k", 1, time.Minute)
+	assert.True(t, res.Allowed)
+	assert.Len(t, m.windows, 1)
+}
+
+func TestMemoryLimiter_PeekAndReset(t *testing.T) {
+	m := NewMemoryLimiter()
+	ctx := context.Background()
+
+	res, _ := m.Peek(ctx, "k", 2)
+	assert.Equal(t, 2, res.Remaining)
+
+	_, _ = m.Allow(ctx, "k", 2, time.Minute)
+	_, _ = m.Allow(ctx, "k", 2, time.Minute)
+
+	// peek tidak menambah hit
+	for i := 0; i < 2; i++ {
+		res, _ = m.Peek(ctx, "k", 2)
+		assert.Equal(t, 0, res.Remaining)
+		assert.True(t, res.Allowed)
+	}
+
+	_ = m.Reset(ctx, "k")
+	res, _ = m.Peek(ctx, "k", 2)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryLimiter_Release(t *testing.T) {
+	m := NewMemoryLimiter()
+	ctx := context.Background()
+
+	_, _ = m.Allow(ctx, "k", 2, time.Minute)
+	_, _ = m.Allow(ctx, "k", 2, time.Minute)
+	res, _ := m.Allow(ctx, "k", 2, time.Minute)
+	assert.False(t, res.Allowed)
+
+	_ = m.Release(ctx, "k", 1)
+	res, _ = m.Allow(ctx, "k", 2, time.Minute)
+	assert.True(t, res.Allowed)
+
+	// tidak turun di bawah nol
+	_ = m.Release(ctx, "k", 5)
+	res, _ = m.Peek(ctx, "k", 2)
+	assert.Equal(t, 2, res.Remaining)
+
+	// key yang tidak ada tidak dibuat
+	_ = m.Release(ctx, "other", 1)
+	assert.NotContains(t, m.windows, "other")
+}
+
+func TestMemoryLimiter_AllowNSpendsCost(t *testing.T) {
+	m := NewMemoryLimiter()
+	ctx := context.Background()
+
+	res, _ := m.AllowN(ctx, "k", 4, 10, time.Minute)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 6, res.Remaining)
+
+	res, _ = m.AllowN(ctx, "k", 6, 10, time.Minute)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res, _ = m.Allow(ctx, "k", 10, time.Minute)
+	assert.False(t, res.Allowed)
+}
+
+func TestMemoryLimiter_RejectedHitIsNotCharged(t *testing.T) {
+	m := NewMemoryLimiter()
+	ctx := context.Background()
+
+	res, _ := m.AllowN(ctx, "k", 6, 10, time.Minute)
+	assert.True(t, res.Allowed)
+
+	// 6 + 6 > 10: ditolak, sisa budget tetap 4
+	res, _ = m.AllowN(ctx, "k", 6, 10, time.Minute)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 4, res.Remaining)
+
+	res, _ = m.AllowN(ctx, "k", 4, 10, time.Minute)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestMemoryLimiter_EvictsExpiredInBatches(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryLimiter()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 10*evictBatch; i++ {
+		_, _ = m.Allow(ctx, fmt.Sprintf("old-%d", i), 1, time.Minute)
+	}
+	now = now.Add(time.Minute)
+
+	// satu window baru hanya membuang evictBatch entry
+	_, _ = m.Allow(ctx, "new-0", 1, time.Minute)
+	assert.Len(t, m.windows, 10*evictBatch+1-evictBatch)
+
+	// window baru berikutnya menyapu sisanya
+	for i := 1; i < 10; i++ {
+		_, _ = m.Allow(ctx, fmt.Sprintf("new-%d", i), 1, time.Minute)
+	}
+	assert.Len(t, m.windows, 10)
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Result is the outcome of a single Allow call
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // sisa waktu sampai window di-reset
+}
+
+// RateLimiter counts hits per key in a fixed window
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+	// AllowN counts one hit weighing cost; limit menjadi budget yang
+	// habis lebih cepat untuk hit yang mahal. Allow = AllowN dengan cost 1.
+	// Hit yang ditolak tidak dihitung: Remaining tetap sisa budget yang
+	// sebenarnya.
+	AllowN(ctx context.Context, key string, cost, limit int, window time.Duration) (Result, error)
+}
+
+// Counter is a RateLimiter whose counters can also be read, given back
+// and cleared without counting a hit. Dipakai login throttle: attempt
+// dipesan dengan Allow sebelum password diverifikasi dan dikembalikan
+// dengan Release kalau ternyata bukan login gagal.
+type Counter interface {
+	RateLimiter
+	Peek(ctx context.Context, key string, limit int) (Result, error)
+	// Release takes back up to cost hits from the key's current window
+	// without touching its expiry; count tidak pernah di bawah nol
+	Release(ctx context.Context, key string, cost int) error
+	Reset(ctx context.Context, key string) error
+}
+
+func newResult(count int64, limit int, ttl time.Duration) Result {
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Result{
+		Allowed:    count <= int64(limit),
+		Limit:      limit,
+		Remaining:  remaining,
+		ResetAfter: ttl,
+	}
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// INCRBY + PEXPIRE atomik: TTL hanya di-set pada hit pertama di window
+// (count == cost). Hit yang akan melewati limit tidak di-INCRBY sama
+// sekali. ARGV[1] = window, ARGV[2] = cost, ARGV[3] = limit; hasil
+// {count, ttl, allowed}
+var incrWindowScript = redis.NewScript(`
+local cost = tonumber(ARGV[2])
+local count = tonumber(redis.call("GET", KEYS[1]) or "0")
+local allowed = 0
+if count + cost <= tonumber(ARGV[3]) then
+	allowed = 1
+	count = redis.call("INCRBY", KEYS[1], cost)
+	if count == cost then
+		redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	end
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+elseif ttl < 0 then
+	ttl = 0
+end
+return {count, ttl, allowed}
+`)
+
+// baca counter tanpa INCR; key tidak ada = count 0
+var peekWindowScript = redis.NewScript(`
+local count = tonumber(redis.call("GET", KEYS[1]) or "0")
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	ttl = 0
+end
+return {count, ttl}
+`)
+
+// DECRBY tanpa turun di bawah nol; TTL window tidak berubah.
+// ARGV[1] = cost
+var releaseWindowScript = redis.NewScript(`
+local count = tonumber(redis.call("GET", KEYS[1]) or "0")
+local n = math.min(count, tonumber(ARGV[1]))
+if n <= 0 then
+	return count
+end
+return redis.call("DECRBY", KEYS[1], n)
+`)
+
+var resetWindowScript = redis.NewScript(`return redis.call("DEL", KEYS[1])`)
+
+// RedisLimiter shares counters across every API instance
+type RedisLimiter struct {
+	client redis.Scripter
+	prefix string
+}
+
+func NewRedisLimiter(client redis.Scripter, prefix string) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix}
+}
+
+func (r *RedisLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	return r.AllowN(ctx, key, 1, limit, window)
+}
+
+func (r *RedisLimiter) AllowN(ctx context.Context, key string, cost, limit int, window time.Duration) (Result, error) {
+	res, err := incrWindowScript.Run(ctx, r.client, []string{r.prefix + key}, window.Milliseconds(), cost, limit).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit %s: %w", key, err)
+	}
+
+	result := newResult(res[0], limit, time.Duration(res[1])*time.Millisecond)
+	result.Allowed = res[2] == 1
+	return result, nil
+}
+
+// Peek reports the key's current window without counting a hit
+func (r *RedisLimiter) Peek(ctx context.Context, key string, limit int) (Result, error) {
+	res, err := peekWindowScript.Run(ctx, r.client, []string{r.prefix + key}).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit peek %s: %w", key, err)
+	}
+
+	return newResult(res[0], limit, time.Duration(res[1])*time.Millisecond), nil
+}
+
+func (r *RedisLimiter) Release(ctx context.Context, key string, cost int) error {
+	if err := releaseWindowScript.Run(ctx, r.client, []string{r.prefix + key}, cost).Err(); err != nil {
+		return fmt.Errorf("rate limit release %s: %w", key, err)
+	}
+	return nil
+}
+
+func (r *RedisLimiter) Reset(ctx context.Context, key string) error {
+	if err := resetWindowScript.Run(ctx, r.client, []string{r.prefix + key}).Err(); err != nil {
+		return fmt.Errorf("rate limit reset %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/ratelimit"
+)
+
+func newRedisLimiter(t *testing.T) (*ratelimit.RedisLimiter, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return ratelimit.NewRedisLimiter(client, "rl:"), mr
+}
+
+func TestRedisLimiter_BlocksOverLimit(t *testing.T) {
+	limiter, _ := newRedisLimiter(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		res, err := limiter.Allow(ctx, "ip:1.2.3.4", 3, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, err := limiter.Allow(ctx, "ip:1.2.3.4", 3, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.InDelta(t, time.Minute, res.ResetAfter, float64(time.Second))
+
+	// key lain punya counter sendiri
+	res, err = limiter.Allow(ctx, "ip:5.6.7.8", 3, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestRedisLimiter_WindowResets(t *testing.T) {
+	limiter, mr := newRedisLimiter(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := limiter.Allow(ctx, "user:1", 2, time.Minute)
+		require.NoError(t, err)
+	}
+	res, _ := limiter.Allow(ctx, "user:1", 2, time.Minute)
+	assert.False(t, res.Allowed)
+
+	mr.FastForward(61 * time.Second)
+
+	res, err := limiter.Allow(ctx, "user:1", 2, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+}
+
+func TestRedisLimiter_SharedAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx := context.Background()
+
+	// dua "instance" API dengan client berbeda ke Redis yang sama
+	a := ratelimit.NewRedisLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "rl:")
+	b := ratelimit.NewRedisLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "rl:")
+
+	_, err := a.Allow(ctx, "k", 2, time.Minute)
+	require.NoError(t, err)
+	_, err = b.Allow(ctx, "k", 2, time.Minute)
+	require.NoError(t, err)
+
+	res, err := a.Allow(ctx, "k", 2, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+}
+
+func TestRedisLimiter_Unavailable(t *testing.T) {
+	limiter, mr := newRedisLimiter(t)
+	mr.Close()
+
+	_, err := limiter.Allow(context.Background(), "k", 1, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestRedisLimiter_PeekAndReset(t *testing.T) {
+	limiter, _ := newRedisLimiter(t)
+	ctx := context.Background()
+
+	res, err := limiter.Peek(ctx, "login:a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Remaining)
+
+	_, err = limiter.Allow(ctx, "login:a", 2, time.Minute)
+	require.NoError(t, err)
+
+	res, err = limiter.Peek(ctx, "login:a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Remaining)
+	assert.Equal(t, time.Minute, res.ResetAfter)
+
+	require.NoError(t, limiter.Reset(ctx, "login:a"))
+	res, err = limiter.Peek(ctx, "login:a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestRedisLimiter_Release(t *testing.T) {
+	limiter, mr := newRedisLimiter(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := limiter.Allow(ctx, "login:a", 2, time.Minute)
+		require.NoError(t, err)
+	}
+	mr.FastForward(10 * time.Second)
+
+	require.NoError(t, limiter.Release(ctx, "login:a", 1))
+	res, err := limiter.Peek(ctx, "login:a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Remaining)
+	// window tidak diperpanjang
+	assert.Equal(t, 50*time.Second, res.ResetAfter)
+
+	require.NoError(t, limiter.Release(ctx, "login:a", 5))
+	res, err = limiter.Peek(ctx, "login:a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Remaining)
+
+	require.NoError(t, limiter.Release(ctx, "login:missing", 1))
+	assert.False(t, mr.Exists("rl:login:missing"))
+}
+
+func TestRedisLimiter_AllowNSpendsCost(t *testing.T) {
+	limiter, mr := newRedisLimiter(t)
+	ctx := context.Background()
+
+	res, err := limiter.AllowN(ctx, "ip:1.2.3.4", 4, 10, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 6, res.Remaining)
+	// TTL di-set pada hit pertama walau cost > 1
+	assert.Equal(t, time.Minute, mr.TTL("rl:ip:1.2.3.4"))
+
+	res, err = limiter.AllowN(ctx, "ip:1.2.3.4", 7, 10, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+}
+
+func TestRedisLimiter_RejectedHitIsNotCharged(t *testing.T) {
+	limiter, mr := newRedisLimiter(t)
+	ctx := context.Background()
+
+	res, err := limiter.AllowN(ctx, "ip:1.2.3.4", 6, 10, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	// 6 + 6 > 10: ditolak tanpa INCRBY
+	res, err = limiter.AllowN(ctx, "ip:1.2.3.4", 6, 10, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 4, res.Remaining)
+	assert.InDelta(t, time.Minute, res.ResetAfter, float64(time.Second))
+	got, _ := mr.Get("rl:ip:1.2.3.4")
+	assert.Equal(t, "6", got)
+
+	res, err = limiter.AllowN(ctx, "ip:1.2.3.4", 4, 10, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	// hit yang ditolak di key baru tidak membuat key
+	res, err = limiter.AllowN(ctx, "ip:5.6.7.8", 11, 10, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.False(t, mr.Exists("rl:ip:5.6.7.8"))
+}
@@ -0,0 +1,102 @@
+/*
+Package softdelete is the shared convention for tables with a deleted_at
+column, supaya tiap modul tidak menulis ulang aturan yang sama:
+
+  - baca: row terhapus disembunyikan, kecuali ?includeDeleted=true
+  - DELETE /x/:id: soft delete (deleted_at = NOW())
+  - POST /x/:id/restore: deleted_at kembali NULL
+  - DELETE /x/:id/permanent: hard delete, hanya untuk row yang sudah
+    di-soft delete dan hanya admin (middleware.RequireRole)
+
+Pola query sqlc (referensi: db/queries/customer.sql, modul customer):
+
+	-- name: ListX :many
+	SELECT ..., deleted_at FROM x
+	WHERE (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL) ...
+
+	-- name: SoftDeleteX :execrows
+	UPDATE x SET deleted_at = NOW(), updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NULL;
+
+	-- name: RestoreX :execrows
+	UPDATE x SET deleted_at = NULL, updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NOT NULL;
+
+	-- name: HardDeleteX :execrows
+	DELETE FROM x WHERE id = $1 AND deleted_at IS NOT NULL;
+
+Ketiga perintah tulis memakai :execrows; hasilnya dilewatkan ke Affected.
+Hard delete yang masih direferensikan tabel lain gagal dengan FK
+violation, lihat Referenced.
+*/
+package softdelete
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// QueryParam: ?includeDeleted=true ikut mengembalikan row terhapus
+const QueryParam = "includeDeleted"
+
+var ErrInvalidIncludeDeleted = errors.New("includeDeleted must be true or false")
+
+// Options selects which rows a read returns; zero value = hanya row aktif
+type Options struct {
+	IncludeDeleted bool
+}
+
+// ParseOptions reads ?includeDeleted; absent = false
+func ParseOptions(c *gin.Context) (Options, error) {
+	v, ok := c.GetQuery(QueryParam)
+	if !ok {
+		return Options{}, nil
+	}
+
+	include, err := strconv.ParseBool(v)
+	if err != nil {
+		return Options{}, ErrInvalidIncludeDeleted
+	}
+	return Options{IncludeDeleted: include}, nil
+}
+
+// Visible reports whether a row with deletedAt is returned under opts
+func (o Options) Visible(deletedAt pgtype.Timestamptz) bool {
+	return o.IncludeDeleted || !deletedAt.Valid
+}
+
+// Filter keeps the items Visible under opts, for rows that were not
+// filtered in SQL (mis. hasil gabungan beberapa query)
+func Filter[T any](items []T, deletedAt func(T) pgtype.Timestamptz, opts Options) []T {
+	kept := make([]T, 0, len(items))
+	for _, item := range items {
+		if opts.Visible(deletedAt(item)) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// Affected turns the result of a SoftDelete/Restore/HardDelete :execrows
+// query into an error: 0 row = notFound (id tidak ada atau statusnya
+// tidak cocok, mis. restore row yang tidak terhapus)
+func Affected(rows int64, err error, notFound error) error {
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// Referenced reports whether err is a foreign key violation, i.e. a hard
+// delete of a row other tables still point to
+func Referenced(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
@@ -0,0 +1,81 @@
+package softdelete_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/softdelete"
+)
+
+func TestParseOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query   string
+		want    softdelete.Options
+		wantErr error
+	}{
+		{"", softdelete.Options{}, nil},
+		{"?includeDeleted=true", softdelete.Options{IncludeDeleted: true}, nil},
+		{"?includeDeleted=1", softdelete.Options{IncludeDeleted: true}, nil},
+		{"?includeDeleted=false", softdelete.Options{}, nil},
+		{"?includeDeleted=maybe", softdelete.Options{}, softdelete.ErrInvalidIncludeDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/customers"+tt.query, nil)
+
+			opts, err := softdelete.ParseOptions(c)
+			assert.Equal(t, tt.want, opts)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+type row struct {
+	code      string
+	deletedAt pgtype.Timestamptz
+}
+
+func TestFilter(t *testing.T) {
+	deleted := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	rows := []row{{"a", pgtype.Timestamptz{}}, {"b", deleted}, {"c", pgtype.Timestamptz{}}}
+	deletedAt := func(r row) pgtype.Timestamptz { return r.deletedAt }
+
+	codes := func(rs []row) []string {
+		out := []string{}
+		for _, r := range rs {
+			out = append(out, r.code)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"a", "c"}, codes(softdelete.Filter(rows, deletedAt, softdelete.Options{})))
+	assert.Equal(t, []string{"a", "b", "c"}, codes(softdelete.Filter(rows, deletedAt, softdelete.Options{IncludeDeleted: true})))
+	assert.Empty(t, softdelete.Filter(nil, deletedAt, softdelete.Options{}))
+}
+
+func TestAffected(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errDB := errors.New("connection reset")
+
+	assert.NoError(t, softdelete.Affected(1, nil, errNotFound))
+	assert.ErrorIs(t, softdelete.Affected(0, nil, errNotFound), errNotFound)
+	assert.ErrorIs(t, softdelete.Affected(0, errDB, errNotFound), errDB)
+}
+
+func TestReferenced(t *testing.T) {
+	assert.True(t, softdelete.Referenced(&pgconn.PgError{Code: "23503"}))
+	assert.False(t, softdelete.Referenced(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, softdelete.Referenced(errors.New("boom")))
+	assert.False(t, softdelete.Referenced(nil))
+}
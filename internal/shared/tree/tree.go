@@ -0,0 +1,50 @@
+// Package tree builds parent/child hierarchies (menus, categories)
+// from flat rows that reference their parent by ID.
+package tree
+
+// Node is one item with its children, in input order
+type Node[T any] struct {
+	Item     T
+	Children []*Node[T]
+}
+
+// Build links items into a forest using key/parent accessors.
+// Items whose parent is nil or not present in items become roots,
+// so a filtered subset (e.g. only permitted menus) still renders.
+// Sibling order follows the order of items.
+func Build[T any, K comparable](items []T, key func(T) K, parent func(T) *K) []*Node[T] {
+	nodes := make(map[K]*Node[T], len(items))
+	for _, item := range items {
+		nodes[key(item)] = &Node[T]{Item: item}
+	}
+
+	roots := make([]*Node[T], 0)
+	for _, item := range items {
+		node := nodes[key(item)]
+
+		p := parent(item)
+		if p == nil || *p == key(item) {
+			roots = append(roots, node)
+			continue
+		}
+
+		if parentNode, ok := nodes[*p]; ok {
+			parentNode.Children = append(parentNode.Children, node)
+			continue
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+// Map converts a forest of T into a forest of U, e.g. into a JSON
+// response type that carries its own Children field.
+func Map[T, U any](nodes []*Node[T], fn func(item T, children []U) U) []U {
+	out := make([]U, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, fn(n.Item, Map(n.Children, fn)))
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/tree"
+)
+
+type item struct {
+	ID     string
+	Parent *string
+}
+
+func ptr(s string) *string { return &s }
+
+func build(items []item) []*tree.Node[item] {
+	return tree.Build(items,
+		func(i item) string { return i.ID },
+		func(i item) *string { return i.Parent },
+	)
+}
+
+func TestBuild(t *testing.T) {
+	roots := build([]item{
+		{ID: "master"},
+		{ID: "products", Parent: ptr("master")},
+		{ID: "settings"},
+		{ID: "customers", Parent: ptr("master")},
+		{ID: "users", Parent: ptr("settings")},
+	})
+
+	require.Len(t, roots, 2)
+	assert.Equal(t, "master", roots[0].Item.ID)
+	require.Len(t, roots[0].Children, 2)
+	assert.Equal(t, "products", roots[0].Children[0].Item.ID)
+	assert.Equal(t, "customers", roots[0].Children[1].Item.ID)
+	assert.Equal(t, "users", roots[1].Children[0].Item.ID)
+}
+
+func TestBuild_ChildBeforeParent(t *testing.T) {
+	roots := build([]item{
+		{ID: "child", Parent: ptr("root")},
+		{ID: "root"},
+	})
+
+	require.Len(t, roots, 1)
+	assert.Equal(t, "root", roots[0].Item.ID)
+	assert.Equal(t, "child", roots[0].Children[0].Item.ID)
+}
+
+func TestBuild_OrphanBecomesRoot(t *testing.T) {
+	roots := build([]item{
+		{ID: "products", Parent: ptr("master")},
+	})
+
+	require.Len(t, roots, 1)
+	assert.Equal(t, "products", roots[0].Item.ID)
+}
+
+func TestMap(t *testing.T) {
+	type out struct {
+		ID       string
+		Children []out
+	}
+
+	roots := build([]item{
+		{ID: "a"},
+		{ID: "b", Parent: ptr("a")},
+	})
+
+	got := tree.Map(roots, func(i item, children []out) out {
+		return out{ID: i.ID, Children: children}
+	})
+
+	assert.Equal(t, []out{{ID: "a", Children: []out{{ID: "b", Children: []out{}}}}}, got)
+}
@@ -0,0 +1,29 @@
+package csvutil
+
+import (
+	"encoding/csv"
+	"strings"
+)
+
+// formulaPrefixes memicu evaluasi formula di Excel/LibreOffice/Sheets
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeFormula prefixes field with ' when a spreadsheet would read it
+// as a formula (=HYPERLINK(...), @SUM(...), ...), so user input in an
+// export opens as plain text
+func EscapeFormula(field string) string {
+	if field != "" && strings.ContainsRune(formulaPrefixes, rune(field[0])) {
+		return "'" + field
+	}
+	return field
+}
+
+// WriteRecord writes record to w with every field passed through
+// EscapeFormula
+func WriteRecord(w *csv.Writer, record []string) error {
+	safe := make([]string, len(record))
+	for i, field := range record {
+		safe[i] = EscapeFormula(field)
+	}
+	return w.Write(safe)
+}
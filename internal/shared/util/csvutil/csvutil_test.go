@@ -0,0 +1,38 @@
+package csvutil_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/util/csvutil"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := map[string]string{
+		`=HYPERLINK("http://evil","x")`: `'=HYPERLINK("http://evil","x")`,
+		"+1+1":                          "'+1+1",
+		"-2+3":                          "'-2+3",
+		"@SUM(A1:A2)":                   "'@SUM(A1:A2)",
+		"\t=1":                          "'\t=1",
+		"\r=1":                          "'\r=1",
+		"budi":                          "budi",
+		"a=b":                           "a=b",
+		"":                              "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, csvutil.EscapeFormula(in), "%q", in)
+	}
+}
+
+func TestWriteRecord(t *testing.T) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	assert.NoError(t, csvutil.WriteRecord(w, []string{"=1+1", "budi", "true"}))
+	w.Flush()
+
+	assert.Equal(t, "'=1+1,budi,true\n", buf.String())
+}
@@ -1,6 +1,7 @@
 package dbutil
 
 import (
+	"context"
 	"database/sql"
 	"strconv"
 	"time"
@@ -10,6 +11,36 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+//
+// =======================
+// GENERIC
+// =======================
+//
+
+// T -> *T
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// *T -> T (default def)
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// ...*T -> *T pertama yang tidak nil dan bukan zero value
+func Coalesce[T comparable](vals ...*T) *T {
+	var zero T
+	for _, v := range vals {
+		if v != nil && *v != zero {
+			return v
+		}
+	}
+	return nil
+}
+
 //
 // =======================
 // UUID
@@ -36,6 +67,33 @@ func UUIDPtrToPgUUID(id *uuid.UUID) pgtype.UUID {
 	}
 }
 
+//
+// =======================
+// ACTOR (created_by / updated_by)
+// =======================
+//
+
+type actorKey struct{}
+
+// ctx + user id -> ctx (diisi AuthMiddleware)
+func WithActorID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, id)
+}
+
+// ctx -> *uuid.UUID (nil jika tidak ada user yang login)
+func ActorID(ctx context.Context) *uuid.UUID {
+	id, ok := ctx.Value(actorKey{}).(uuid.UUID)
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// ctx -> pgtype.UUID (NULL jika tidak ada user yang login)
+func ActorPgUUID(ctx context.Context) pgtype.UUID {
+	return UUIDPtrToPgUUID(ActorID(ctx))
+}
+
 //
 // =======================
 // STRING
@@ -49,10 +107,7 @@ func StringValue(s string) string {
 
 // *string -> string (default "")
 func StringPtrValue(s *string) string {
-	if s == nil {
-		return ""
-	}
-	return *s
+	return Deref(s, "")
 }
 
 // *string -> sql.NullString
@@ -93,15 +148,12 @@ func StringPtrToPgText(s *string) pgtype.Text {
 
 // bool -> *bool
 func BoolPtr(v bool) *bool {
-	return &v
+	return Ptr(v)
 }
 
 // *bool -> bool (default false)
 func BoolPtrValue(b *bool, defaultValue bool) bool {
-	if b == nil {
-		return defaultValue
-	}
-	return *b
+	return Deref(b, defaultValue)
 }
 
 // *bool -> sql.NullBool
@@ -167,6 +219,15 @@ func TimePtrToPgTime(t *time.Time) pgtype.Timestamptz {
 	}
 }
 
+// pgtype.Timestamptz -> *time.Time
+func PgTimeToTimePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// pgtype.Timestamptz -> time.Time (zero jika null)
 func PgTimeValue(t pgtype.Timestamptz) time.Time {
 	if !t.Valid {
 		return time.Time{}
@@ -182,10 +243,7 @@ func PgTimeValue(t pgtype.Timestamptz) time.Time {
 
 // *int32 -> int32
 func Int32PtrValue(i *int32) int32 {
-	if i == nil {
-		return 0
-	}
-	return *i
+	return Deref(i, 0)
 }
 
 // *int32 -> sql.NullInt32
@@ -207,10 +265,7 @@ func Int32PtrToNull(i *int32) sql.NullInt32 {
 
 // *float64 -> float64
 func Float64PtrValue(f *float64) float64 {
-	if f == nil {
-		return 0
-	}
-	return *f
+	return Deref(f, 0)
 }
 
 //
@@ -221,10 +276,7 @@ func Float64PtrValue(f *float64) float64 {
 
 // *decimal.Decimal -> decimal.Decimal
 func DecimalPtrValue(d *decimal.Decimal) decimal.Decimal {
-	if d == nil {
-		return decimal.Zero
-	}
-	return *d
+	return Deref(d, decimal.Zero)
 }
 
 // float64 -> decimal (aman, tapi bukan exact)
@@ -261,6 +313,23 @@ func DecimalToFloat64(d decimal.Decimal) float64 {
 	return f
 }
 
+// decimal -> pgtype.Numeric (kolom DECIMAL di sqlc)
+func DecimalToPgNumeric(d decimal.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:   d.Coefficient(),
+		Exp:   d.Exponent(),
+		Valid: true,
+	}
+}
+
+// pgtype.Numeric -> decimal (zero jika null / NaN)
+func PgNumericToDecimal(n pgtype.Numeric) decimal.Decimal {
+	if !n.Valid || n.NaN || n.Int == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(n.Int, n.Exp)
+}
+
 //
 // =======================
 // DECIMAL -> NULL
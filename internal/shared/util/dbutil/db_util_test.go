@@ -0,0 +1,140 @@
+package dbutil_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+// =======================
+// PTR
+// =======================
+
+func TestPtr(t *testing.T) {
+	s := dbutil.Ptr("hello")
+	assert.Equal(t, "hello", *s)
+
+	i := dbutil.Ptr(int32(42))
+	assert.Equal(t, int32(42), *i)
+
+	id := uuid.New()
+	assert.Equal(t, id, *dbutil.Ptr(id))
+
+	now := time.Now()
+	assert.True(t, now.Equal(*dbutil.Ptr(now)))
+}
+
+func TestPtr_ReturnsCopy(t *testing.T) {
+	v := 10
+	p := dbutil.Ptr(v)
+	*p = 20
+
+	assert.Equal(t, 10, v)
+}
+
+// =======================
+// DEREF
+// =======================
+
+func TestDeref(t *testing.T) {
+	assert.Equal(t, "x", dbutil.Deref(dbutil.Ptr("x"), "default"))
+	assert.Equal(t, "default", dbutil.Deref(nil, "default"))
+
+	assert.Equal(t, int32(7), dbutil.Deref(dbutil.Ptr(int32(7)), 0))
+	assert.Equal(t, int32(0), dbutil.Deref[int32](nil, 0))
+
+	assert.True(t, dbutil.Deref(nil, true))
+	assert.False(t, dbutil.Deref(dbutil.Ptr(false), true))
+
+	d := decimal.RequireFromString("12.50")
+	assert.True(t, d.Equal(dbutil.Deref(&d, decimal.Zero)))
+	assert.True(t, decimal.Zero.Equal(dbutil.Deref(nil, decimal.Zero)))
+}
+
+// =======================
+// COALESCE
+// =======================
+
+func TestCoalesce(t *testing.T) {
+	assert.Nil(t, dbutil.Coalesce[string]())
+	assert.Nil(t, dbutil.Coalesce[string](nil, nil))
+
+	got := dbutil.Coalesce(nil, dbutil.Ptr(""), dbutil.Ptr("b"), dbutil.Ptr("c"))
+	assert.Equal(t, "b", *got)
+
+	n := dbutil.Coalesce(nil, dbutil.Ptr(0), dbutil.Ptr(3))
+	assert.Equal(t, 3, *n)
+
+	id := uuid.New()
+	u := dbutil.Coalesce(dbutil.Ptr(uuid.Nil), &id)
+	assert.Equal(t, id, *u)
+}
+
+// =======================
+// WRAPPERS
+// =======================
+
+func TestLegacyWrappers(t *testing.T) {
+	assert.True(t, *dbutil.BoolPtr(true))
+	assert.False(t, dbutil.BoolPtrValue(nil, false))
+	assert.True(t, dbutil.BoolPtrValue(dbutil.Ptr(true), false))
+
+	assert.Equal(t, "", dbutil.StringPtrValue(nil))
+	assert.Equal(t, "a", dbutil.StringPtrValue(dbutil.Ptr("a")))
+
+	assert.Equal(t, int32(0), dbutil.Int32PtrValue(nil))
+	assert.Equal(t, int32(5), dbutil.Int32PtrValue(dbutil.Ptr(int32(5))))
+
+	assert.Equal(t, float64(0), dbutil.Float64PtrValue(nil))
+	assert.Equal(t, 1.5, dbutil.Float64PtrValue(dbutil.Ptr(1.5)))
+
+	assert.True(t, decimal.Zero.Equal(dbutil.DecimalPtrValue(nil)))
+}
+
+// =======================
+// PG CONVERTERS
+// =======================
+
+func TestPgUUIDToUUIDPtr(t *testing.T) {
+	assert.Nil(t, dbutil.PgUUIDToUUIDPtr(dbutil.UUIDPtrToPgUUID(nil)))
+
+	id := uuid.New()
+	got := dbutil.PgUUIDToUUIDPtr(dbutil.UUIDPtrToPgUUID(&id))
+	assert.Equal(t, id, *got)
+}
+
+func TestPgTimeToTimePtr(t *testing.T) {
+	assert.Nil(t, dbutil.PgTimeToTimePtr(dbutil.TimePtrToPgTime(nil)))
+
+	now := time.Now()
+	got := dbutil.PgTimeToTimePtr(dbutil.TimeToPgTime(now))
+	assert.True(t, now.Equal(*got))
+}
+
+func TestPgNumericRoundTrip(t *testing.T) {
+	for _, v := range []string{"0", "12.50", "-1000.25", "123456789.123"} {
+		d := decimal.RequireFromString(v)
+		got := dbutil.PgNumericToDecimal(dbutil.DecimalToPgNumeric(d))
+		assert.True(t, d.Equal(got), v)
+	}
+
+	assert.True(t, decimal.Zero.Equal(dbutil.PgNumericToDecimal(pgtype.Numeric{})))
+}
+
+func TestActorID(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, dbutil.ActorID(ctx))
+	assert.False(t, dbutil.ActorPgUUID(ctx).Valid)
+
+	id := uuid.New()
+	ctx = dbutil.WithActorID(ctx, id)
+	assert.Equal(t, id, *dbutil.ActorID(ctx))
+	assert.Equal(t, id, uuid.UUID(dbutil.ActorPgUUID(ctx).Bytes))
+}
@@ -0,0 +1,109 @@
+package money
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidAmount = errors.New("invalid money amount")
+
+// minorUnits adalah jumlah digit desimal per currency (ISO 4217).
+// Currency yang tidak terdaftar memakai defaultMinorUnits.
+var minorUnits = map[string]int32{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"SGD": 2,
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+const defaultMinorUnits int32 = 2
+
+// MinorUnits returns the number of decimal places used by currency
+func MinorUnits(currency string) int32 {
+	if units, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return units
+	}
+	return defaultMinorUnits
+}
+
+// FormatMoney renders d as "<CUR> 1,234.56", rounded half-up
+// to the currency's minor-unit precision
+func FormatMoney(d decimal.Decimal, currency string) string {
+	currency = strings.ToUpper(currency)
+	fixed := d.Round(MinorUnits(currency)).StringFixed(MinorUnits(currency))
+
+	sign := ""
+	if strings.HasPrefix(fixed, "-") {
+		sign = "-"
+		fixed = fixed[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(fixed, ".")
+
+	var b strings.Builder
+	b.WriteString(currency)
+	b.WriteByte(' ')
+	b.WriteString(sign)
+	b.WriteString(groupThousands(intPart))
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+
+	return b.String()
+}
+
+// ParseMoney is the inverse of FormatMoney. The currency prefix
+// and thousands separators are optional.
+func ParseMoney(s string, currency string) (decimal.Decimal, error) {
+	currency = strings.ToUpper(currency)
+
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, currency)
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, ",", "")
+
+	if s == "" {
+		return decimal.Zero, ErrInvalidAmount
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, ErrInvalidAmount
+	}
+
+	// tolak presisi melebihi minor unit currency
+	if -d.Exponent() > MinorUnits(currency) && !d.Equal(d.Round(MinorUnits(currency))) {
+		return decimal.Zero, ErrInvalidAmount
+	}
+
+	return d, nil
+}
+
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+
+	return b.String()
+}
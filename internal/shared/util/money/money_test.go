@@ -0,0 +1,58 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/util/money"
+)
+
+func TestFormatMoney_USD(t *testing.T) {
+	assert.Equal(t, "USD 1,234,567.89", money.FormatMoney(decimal.RequireFromString("1234567.891"), "USD"))
+	assert.Equal(t, "USD 0.50", money.FormatMoney(decimal.RequireFromString("0.5"), "usd"))
+	assert.Equal(t, "USD 100.00", money.FormatMoney(decimal.NewFromInt(100), "USD"))
+	assert.Equal(t, "USD 0.13", money.FormatMoney(decimal.RequireFromString("0.125"), "USD"))
+}
+
+func TestFormatMoney_JPY(t *testing.T) {
+	assert.Equal(t, "JPY 1,235", money.FormatMoney(decimal.RequireFromString("1234.5"), "JPY"))
+	assert.Equal(t, "JPY 999", money.FormatMoney(decimal.NewFromInt(999), "JPY"))
+}
+
+func TestFormatMoney_Negative(t *testing.T) {
+	assert.Equal(t, "USD -1,000.25", money.FormatMoney(decimal.RequireFromString("-1000.25"), "USD"))
+	assert.Equal(t, "JPY -12,000", money.FormatMoney(decimal.NewFromInt(-12000), "JPY"))
+}
+
+func TestParseMoney_RoundTrip(t *testing.T) {
+	cases := []struct {
+		amount   string
+		currency string
+	}{
+		{"1234567.89", "USD"},
+		{"-1000.25", "EUR"},
+		{"12000", "JPY"},
+		{"0", "USD"},
+	}
+
+	for _, tc := range cases {
+		d := decimal.RequireFromString(tc.amount)
+
+		got, err := money.ParseMoney(money.FormatMoney(d, tc.currency), tc.currency)
+		assert.NoError(t, err)
+		assert.True(t, d.Equal(got), "%s %s", tc.currency, tc.amount)
+	}
+}
+
+func TestParseMoney_Invalid(t *testing.T) {
+	_, err := money.ParseMoney("", "USD")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	_, err = money.ParseMoney("USD abc", "USD")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	_, err = money.ParseMoney("10.5", "JPY")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
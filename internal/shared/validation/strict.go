@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// UnknownFieldError is returned by ShouldBindStrictJSON for a body field
+// the target struct does not declare
+type UnknownFieldError struct {
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+/*
+ShouldBindStrictJSON is ShouldBindJSON yang menolak field tak dikenal,
+supaya typo seperti "fulname" muncul sebagai error field itu sendiri,
+bukan "fullName is required". Opt-in per handler; hasilnya diteruskan ke
+Response seperti error ShouldBind* lainnya.
+*/
+func ShouldBindStrictJSON(c *gin.Context, obj any) error {
+	if c.Request == nil || c.Request.Body == nil {
+		return errors.New("invalid request")
+	}
+
+	dec := json.NewDecoder(c.Request.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(obj); err != nil {
+		// encoding/json tidak punya tipe error untuk ini, hanya pesan
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return &UnknownFieldError{Field: strings.Trim(field, `"`)}
+		}
+		return err
+	}
+
+	return binding.Validator.ValidateStruct(obj)
+}
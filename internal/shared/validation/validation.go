@@ -0,0 +1,156 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// FieldError is a single failed binding rule, keyed by the JSON field name
+// so UIs can map it straight to a form input.
+type FieldError struct {
+	Field   string `json:"field" example:"password"`
+	Rule    string `json:"rule" example:"min"`
+	Message string `json:"message" example:"password must be at least 6 characters"`
+}
+
+// ErrorResponse is the 400 body for a request that failed to bind.
+// Fields kosong kalau body bukan JSON valid (error parsing, bukan rule).
+type ErrorResponse struct {
+	Error  string       `json:"error" example:"validation failed"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+func init() {
+	// validator melaporkan nama tag json, bukan nama field struct
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonName)
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+// FieldErrors converts validator.ValidationErrors into FieldError values.
+// Returns nil for any other error.
+func FieldErrors(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: message(fe),
+		})
+	}
+	return fields
+}
+
+// Response builds the bad-request body for a ShouldBind* error.
+func Response(err error) ErrorResponse {
+	if fields := FieldErrors(err); fields != nil {
+		return ErrorResponse{Error: "validation failed", Fields: fields}
+	}
+
+	var unknown *UnknownFieldError
+	if errors.As(err, &unknown) {
+		return ErrorResponse{
+			Error: unknown.Error(),
+			Fields: []FieldError{{
+				Field:   unknown.Field,
+				Rule:    "unknown",
+				Message: unknown.Field + " is not a recognized field",
+			}},
+		}
+	}
+
+	return ErrorResponse{Error: err.Error()}
+}
+
+func message(fe validator.FieldError) string {
+	field := fe.Field()
+
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "uuid", "uuid4":
+		return field + " must be a valid UUID"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, fe.Param())
+	case "min", "max", "len":
+		return field + " " + lengthMessage(fe)
+	default:
+		return fmt.Sprintf("%s failed the '%s' rule", field, fe.Tag())
+	}
+}
+
+// lengthMessage: string/slice dihitung panjangnya, angka dibandingkan nilainya
+func lengthMessage(fe validator.FieldError) string {
+	bound := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[fe.Tag()]
+
+	switch fe.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("must be %s %s characters", bound, fe.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("must contain %s %s items", bound, fe.Param())
+	default:
+		return fmt.Sprintf("must be %s %s", bound, fe.Param())
+	}
+}
+
+// NormalizeEmail trims and lowercases an email address so lookups and
+// uniqueness checks do not depend on how the user typed it.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// StripControl removes control characters from free text that is shown
+// back in the UI. Tab dan newline dipertahankan untuk teks multi-baris.
+func StripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// ParamUUID parses path parameter name as a UUID. On failure it writes a
+// 400 ErrorResponse and returns false; the handler should just return.
+func ParamUUID(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid path parameter",
+			Fields: []FieldError{{
+				Field:   name,
+				Rule:    "uuid",
+				Message: name + " must be a valid UUID",
+			}},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
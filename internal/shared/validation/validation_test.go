@@ -0,0 +1,128 @@
+package validation_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/validation"
+)
+
+type sample struct {
+	Name     string   `json:"name" binding:"required"`
+	Quantity int      `json:"quantity" binding:"min=1"`
+	Tags     []string `json:"tags,omitempty" binding:"max=2"`
+	Internal string   `binding:"required"`
+}
+
+func TestFieldErrors(t *testing.T) {
+	err := binding.Validator.ValidateStruct(sample{Tags: []string{"a", "b", "c"}})
+	require.Error(t, err)
+
+	assert.Equal(t, []validation.FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "quantity", Rule: "min", Message: "quantity must be at least 1"},
+		{Field: "tags", Rule: "max", Message: "tags must contain at most 2 items"},
+		// tanpa tag json: nama field struct
+		{Field: "Internal", Rule: "required", Message: "Internal is required"},
+	}, validation.FieldErrors(err))
+}
+
+func TestResponse_NonValidationError(t *testing.T) {
+	res := validation.Response(errors.New("unexpected EOF"))
+
+	assert.Equal(t, "unexpected EOF", res.Error)
+	assert.Nil(t, res.Fields)
+}
+
+func strictContext(body string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	return c
+}
+
+func TestShouldBindStrictJSON(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var s sample
+		err := validation.ShouldBindStrictJSON(strictContext(`{"name":"pen","quantity":2,"Internal":"x"}`), &s)
+
+		require.NoError(t, err)
+		assert.Equal(t, sample{Name: "pen", Quantity: 2, Internal: "x"}, s)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		var s sample
+		err := validation.ShouldBindStrictJSON(strictContext(`{"nmae":"pen","quantity":2}`), &s)
+
+		var unknown *validation.UnknownFieldError
+		require.ErrorAs(t, err, &unknown)
+		assert.Equal(t, "nmae", unknown.Field)
+		assert.Equal(t, validation.ErrorResponse{
+			Error:  `unknown field "nmae"`,
+			Fields: []validation.FieldError{{Field: "nmae", Rule: "unknown", Message: "nmae is not a recognized field"}},
+		}, validation.Response(err))
+	})
+
+	t.Run("rules still apply", func(t *testing.T) {
+		var s sample
+		err := validation.ShouldBindStrictJSON(strictContext(`{"quantity":2,"Internal":"x"}`), &s)
+
+		assert.Equal(t, []validation.FieldError{
+			{Field: "name", Rule: "required", Message: "name is required"},
+		}, validation.FieldErrors(err))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		var s sample
+		err := validation.ShouldBindStrictJSON(strictContext(`{"name":`), &s)
+
+		require.Error(t, err)
+		assert.Nil(t, validation.Response(err).Fields)
+	})
+}
+
+func TestParamUUID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/items/:itemId", func(c *gin.Context) {
+		id, ok := validation.ParamUUID(c, "itemId")
+		if !ok {
+			return
+		}
+		c.String(http.StatusOK, id.String())
+	})
+
+	id := uuid.New()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/"+id.String(), nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, id.String(), w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/123", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"error": "invalid path parameter",
+		"fields": [{"field": "itemId", "rule": "uuid", "message": "itemId must be a valid UUID"}]
+	}`, w.Body.String())
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "user@example.com", validation.NormalizeEmail("  User@Example.COM "))
+	assert.Equal(t, "", validation.NormalizeEmail("   "))
+}
+
+func TestStripControl(t *testing.T) {
+	assert.Equal(t, "Sales team", validation.StripControl("Sales\x00 team\x1b"))
+	assert.Equal(t, "line one\nline two\tend", validation.StripControl("line one\nline two\tend"))
+	assert.Equal(t, "café", validation.StripControl("caf\u0085é"))
+}
@@ -0,0 +1,386 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config for the dispatcher. URLs are static subscribers that receive
+// every event, signed with Secret.
+type Config struct {
+	URLs        []string
+	Secret      string
+	MaxAttempts int
+	BaseDelay   time.Duration
+	Timeout     time.Duration
+	QueueSize   int
+}
+
+// Dispatcher queues events in memory and fans them out from a
+// background worker started with Run. Attempt yang gagal diulang dari
+// antrian retry per subscription, jadi endpoint yang mati tidak menahan
+// event berikutnya. Events still queued when the process stops are lost.
+type Dispatcher struct {
+	cfg    Config
+	store  Store
+	client *http.Client
+	queue  chan Event
+
+	retryMu     sync.Mutex
+	retries     map[string]*retryQueue
+	retryWG     sync.WaitGroup
+	retryClosed bool
+}
+
+// retryQueue is one subscription's pending retries and its worker.
+// Worker berhenti saat antrian kosong, stop ditutup, atau ctx selesai.
+type retryQueue struct {
+	ch   chan retry
+	stop chan struct{}
+}
+
+// retry is a failed delivery waiting for its next attempt
+type retry struct {
+	sub     Subscription
+	event   Event
+	body    []byte
+	attempt int
+	due     time.Time
+}
+
+// NewDispatcher creates a dispatcher; store nil = hanya URL statis,
+// tanpa delivery log
+func NewDispatcher(cfg Config, store Store) *Dispatcher {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = 100
+	}
+
+	return &Dispatcher{
+		cfg:   cfg,
+		store: store,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// redirect tidak diikuti: target sudah divalidasi saat
+			// subscribe, 3xx dianggap attempt gagal
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		queue: make(chan Event, cfg.QueueSize),
+
+		retries: make(map[string]*retryQueue),
+	}
+}
+
+// Publish enqueues event without blocking; kalau antrian penuh event
+// di-drop dan dicatat di log
+func (d *Dispatcher) Publish(ctx context.Context, event Event) {
+	if len(d.cfg.URLs) == 0 && d.store == nil {
+		return
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		log.Printf("webhook: queue full, dropping %s event %s", event.Type, event.ID)
+	}
+}
+
+// Run delivers queued events until ctx is cancelled, then stops the
+// retry workers and waits for them to exit
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			d.stopRetries()
+			return
+		case event := <-d.queue:
+			d.dispatch(ctx, event)
+		}
+	}
+}
+
+// subscribers returns the static URLs plus every stored subscription
+// whose event types match
+func (d *Dispatcher) subscribers(ctx context.Context, eventType string) []Subscription {
+	subs := make([]Subscription, 0, len(d.cfg.URLs))
+	for _, url := range d.cfg.URLs {
+		subs = append(subs, Subscription{URL: url, Secret: d.cfg.Secret, EventTypes: []string{"*"}})
+	}
+
+	if d.store == nil {
+		return subs
+	}
+
+	stored, err := d.store.ActiveSubscriptions(ctx)
+	if err != nil {
+		log.Printf("webhook: load subscriptions failed: %v", err)
+		return subs
+	}
+	for _, sub := range stored {
+		if Matches(sub.EventTypes, eventType) {
+			subs = append(subs, sub)
+		}
+	}
+
+	return subs
+}
+
+// dispatch makes the first attempt to every subscriber in parallel, so a
+// slow endpoint delays the next event by at most Timeout; retry
+// dijadwalkan di antrian subscription masing-masing
+func (d *Dispatcher) dispatch(ctx context.Context, event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("webhook: marshal %s event %s failed: %v", event.Type, event.ID, err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range d.subscribers(ctx, event.Type) {
+		wg.Add(1)
+		go func(sub Subscription) {
+			defer wg.Done()
+			d.attempt(ctx, sub, event, body, 1)
+		}(sub)
+	}
+	wg.Wait()
+}
+
+// attempt posts body to sub once and records the outcome. Kalau gagal,
+// attempt berikutnya dijadwalkan dengan exponential backoff (BaseDelay,
+// 2x, 4x, ...); setelah MaxAttempts event masuk dead letter.
+func (d *Dispatcher) attempt(ctx context.Context, sub Subscription, event Event, body []byte, attempt int) {
+	start := time.Now()
+	status, err := d.post(ctx, sub, event, body)
+
+	d.recordDelivery(ctx, Delivery{
+		SubscriptionID: sub.ID,
+		URL:            sub.URL,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Attempt:        attempt,
+		StatusCode:     status,
+		Error:          errString(err),
+		Succeeded:      err == nil,
+		Duration:       time.Since(start),
+	})
+
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+	if attempt >= d.cfg.MaxAttempts {
+		d.deadLetter(ctx, sub, event, body, attempt, err)
+		return
+	}
+
+	d.scheduleRetry(ctx, retry{
+		sub:     sub,
+		event:   event,
+		body:    body,
+		attempt: attempt + 1,
+		due:     time.Now().Add(d.cfg.BaseDelay << (attempt - 1)),
+	}, err)
+}
+
+// scheduleRetry appends r to its subscription's retry queue, starting
+// the queue's worker on first use. Antrian penuh = event langsung masuk
+// dead letter, bukan hilang diam-diam.
+func (d *Dispatcher) scheduleRetry(ctx context.Context, r retry, lastErr error) {
+	key := retryKey(r.sub)
+
+	// kirim di bawah lock supaya tidak masuk ke antrian yang baru di-reap
+	d.retryMu.Lock()
+	if d.retryClosed {
+		d.retryMu.Unlock()
+		return
+	}
+	queue, ok := d.retries[key]
+	if !ok {
+		queue = &retryQueue{ch: make(chan retry, d.cfg.QueueSize), stop: make(chan struct{})}
+		d.retries[key] = queue
+		d.retryWG.Add(1)
+		go d.runRetries(ctx, key, queue)
+	}
+	queued := true
+	select {
+	case queue.ch <- r:
+	default:
+		queued = false
+	}
+	d.retryMu.Unlock()
+
+	if !queued {
+		log.Printf("webhook: retry queue for %s full", r.sub.URL)
+		d.deadLetter(ctx, r.sub, r.event, r.body, r.attempt-1, lastErr)
+	}
+}
+
+// runRetries works through one subscription's retries in order, waiting
+// for each one's backoff to pass. Exits once the queue drains, when the
+// queue is stopped or when ctx is cancelled.
+func (d *Dispatcher) runRetries(ctx context.Context, key string, queue *retryQueue) {
+	defer d.retryWG.Done()
+
+	for {
+		var r retry
+		select {
+		case <-ctx.Done():
+			return
+		case <-queue.stop:
+			return
+		case r = <-queue.ch:
+		}
+
+		timer := time.NewTimer(time.Until(r.due))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-queue.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		// stop dan timer bisa siap bersamaan
+		select {
+		case <-queue.stop:
+			return
+		default:
+		}
+
+		d.attempt(ctx, r.sub, r.event, r.body, r.attempt)
+
+		if d.reapRetries(key, queue) {
+			return
+		}
+	}
+}
+
+// reapRetries removes queue once it is empty; attempt di atas mungkin
+// baru saja menjadwalkan retry berikutnya ke antrian yang sama
+func (d *Dispatcher) reapRetries(key string, queue *retryQueue) bool {
+	d.retryMu.Lock()
+	defer d.retryMu.Unlock()
+
+	if len(queue.ch) > 0 {
+		return false
+	}
+	if d.retries[key] == queue {
+		delete(d.retries, key)
+	}
+	return true
+}
+
+// CancelRetries drops the pending retries of subscription id, e.g.
+// setelah subscription dihapus atau dinonaktifkan
+func (d *Dispatcher) CancelRetries(id uuid.UUID) {
+	d.retryMu.Lock()
+	defer d.retryMu.Unlock()
+
+	if queue, ok := d.retries[id.String()]; ok {
+		delete(d.retries, id.String())
+		close(queue.stop)
+	}
+}
+
+// stopRetries stops every retry worker and waits for them; retry yang
+// masih antri hilang, sama seperti event di queue utama
+func (d *Dispatcher) stopRetries() {
+	d.retryMu.Lock()
+	d.retryClosed = true
+	for key, queue := range d.retries {
+		delete(d.retries, key)
+		close(queue.stop)
+	}
+	d.retryMu.Unlock()
+
+	d.retryWG.Wait()
+}
+
+// retryKey identifies the retry queue of sub: ID untuk subscription
+// tersimpan, URL untuk URL statis
+func retryKey(sub Subscription) string {
+	if sub.ID != nil {
+		return sub.ID.String()
+	}
+	return sub.URL
+}
+
+func (d *Dispatcher) deadLetter(ctx context.Context, sub Subscription, event Event, body []byte, attempts int, lastErr error) {
+	log.Printf("webhook: %s event %s to %s failed after %d attempts: %v",
+		event.Type, event.ID, sub.URL, attempts, lastErr)
+
+	if d.store == nil {
+		return
+	}
+	if err := d.store.RecordDeadLetter(ctx, DeadLetter{
+		SubscriptionID: sub.ID,
+		URL:            sub.URL,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        body,
+		Attempts:       attempts,
+		LastError:      errString(lastErr),
+	}); err != nil {
+		log.Printf("webhook: record dead letter for event %s failed: %v", event.ID, err)
+	}
+}
+
+func (d *Dispatcher) recordDelivery(ctx context.Context, delivery Delivery) {
+	if d.store == nil {
+		return
+	}
+	if err := d.store.RecordDelivery(ctx, delivery); err != nil {
+		log.Printf("webhook: record delivery for event %s failed: %v", delivery.EventID, err)
+	}
+}
+
+// post returns the response status (0 if none) and an error for
+// anything other than 2xx
+func (d *Dispatcher) post(ctx context.Context, sub Subscription, event Event, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	// timestamp baru per attempt, signature ikut dihitung ulang
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderID, event.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, ts, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func (d *Dispatcher) retryQueues() int {
+	d.retryMu.Lock()
+	defer d.retryMu.Unlock()
+	return len(d.retries)
+}
+
+func TestDispatcher_DrainedRetryQueueIsReaped(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// attempt pertama gagal
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher(Config{
+		URLs:        []string{srv.URL},
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		Timeout:     time.Second,
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	d.Publish(ctx, NewEvent(EventUserCreated, nil))
+
+	assert.Eventually(t, func() bool { return hits.Load() == 2 }, 2*time.Second, 5*time.Millisecond)
+	// worker keluar setelah antrian kosong
+	assert.Eventually(t, func() bool { return d.retryQueues() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestDispatcher_ShutdownStopsRetryWorkers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	subID := uuid.New()
+	d := NewDispatcher(Config{
+		URLs:        []string{srv.URL},
+		MaxAttempts: 3,
+		BaseDelay:   time.Hour,
+		Timeout:     time.Second,
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	d.Publish(ctx, NewEvent(EventUserCreated, nil))
+	assert.Eventually(t, func() bool { return d.retryQueues() == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	assert.Equal(t, 0, d.retryQueues())
+
+	// setelah shutdown tidak ada worker baru
+	d.scheduleRetry(ctx, retry{sub: Subscription{ID: &subID, URL: srv.URL}, attempt: 2}, nil)
+	assert.Equal(t, 0, d.retryQueues())
+}
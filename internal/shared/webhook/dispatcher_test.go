@@ -0,0 +1,367 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/shared/webhook"
+)
+
+func TestDispatcher_RetriesFailingEndpoint(t *testing.T) {
+	var attempts atomic.Int32
+	delivered := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// dua attempt pertama gagal
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+		delivered <- r
+	}))
+	defer srv.Close()
+
+	d := webhook.NewDispatcher(webhook.Config{
+		URLs:        []string{srv.URL},
+		Secret:      "s3cret",
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		Timeout:     time.Second,
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	event := webhook.NewEvent(webhook.EventUserCreated, map[string]string{"username": "budi"})
+	d.Publish(context.Background(), event)
+
+	select {
+	case r := <-delivered:
+		body := <-bodies
+		assert.Equal(t, int32(3), attempts.Load())
+		assert.Equal(t, webhook.EventUserCreated, r.Header.Get(webhook.HeaderEvent))
+		assert.Equal(t, event.ID.String(), r.Header.Get(webhook.HeaderID))
+
+		ts, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.True(t, webhook.Verify("s3cret", ts, body, r.Header.Get(webhook.HeaderSignature)))
+
+		var got webhook.Event
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, event.ID, got.ID)
+		assert.Equal(t, webhook.EventUserCreated, got.Type)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("event not delivered, attempts=%d", attempts.Load())
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	d := webhook.NewDispatcher(webhook.Config{
+		URLs:        []string{srv.URL},
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		Timeout:     time.Second,
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	d.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+
+	assert.Eventually(t, func() bool { return attempts.Load() == 3 }, 2*time.Second, 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestDispatcher_DeadEndpointDoesNotStallOthers(t *testing.T) {
+	var deadHits atomic.Int32
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadHits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer dead.Close()
+
+	var healthyHits atomic.Int32
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthyHits.Add(1)
+	}))
+	defer healthy.Close()
+
+	// backoff satu jam: retry endpoint mati tidak boleh menahan event berikutnya
+	d := webhook.NewDispatcher(webhook.Config{
+		URLs:        []string{dead.URL, healthy.URL},
+		MaxAttempts: 3,
+		BaseDelay:   time.Hour,
+		Timeout:     time.Second,
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	for i := 0; i < 3; i++ {
+		d.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+	}
+
+	assert.Eventually(t, func() bool { return healthyHits.Load() == 3 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(3), deadHits.Load())
+}
+
+func TestDispatcher_PublishDoesNotBlockWhenQueueFull(t *testing.T) {
+	d := webhook.NewDispatcher(webhook.Config{
+		URLs:      []string{"http://127.0.0.1:0"},
+		QueueSize: 1,
+	}, nil)
+
+	// worker tidak dijalankan, antrian langsung penuh
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			d.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full queue")
+	}
+}
+
+// memoryStore mencatat delivery dan dead letter di memory
+type memoryStore struct {
+	mu          sync.Mutex
+	subs        []webhook.Subscription
+	deliveries  []webhook.Delivery
+	deadLetters []webhook.DeadLetter
+}
+
+func (m *memoryStore) ActiveSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	return m.subs, nil
+}
+
+func (m *memoryStore) RecordDelivery(ctx context.Context, d webhook.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deliveries = append(m.deliveries, d)
+	return nil
+}
+
+func (m *memoryStore) RecordDeadLetter(ctx context.Context, dl webhook.DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadLetters = append(m.deadLetters, dl)
+	return nil
+}
+
+func (m *memoryStore) snapshot() ([]webhook.Delivery, []webhook.DeadLetter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]webhook.Delivery(nil), m.deliveries...), append([]webhook.DeadLetter(nil), m.deadLetters...)
+}
+
+func TestDispatcher_FansOutToMatchingSubscriptionsAndLogs(t *testing.T) {
+	var roleHits, invoiceHits atomic.Int32
+	var failures atomic.Int32
+
+	roleSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roleHits.Add(1)
+		// attempt pertama gagal
+		if failures.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer roleSrv.Close()
+
+	invoiceSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		invoiceHits.Add(1)
+	}))
+	defer invoiceSrv.Close()
+
+	roleSubID := uuid.New()
+	store := &memoryStore{subs: []webhook.Subscription{
+		{ID: &roleSubID, URL: roleSrv.URL, Secret: "a", EventTypes: []string{"role.*"}},
+		{ID: idPtr(uuid.New()), URL: invoiceSrv.URL, Secret: "b", EventTypes: []string{webhook.EventInvoicePaid}},
+	}}
+
+	d := webhook.NewDispatcher(webhook.Config{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		Timeout:     time.Second,
+	}, store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	event := webhook.NewEvent(webhook.EventRoleCreated, map[string]string{"code": "sales"})
+	d.Publish(context.Background(), event)
+
+	assert.Eventually(t, func() bool {
+		deliveries, _ := store.snapshot()
+		return len(deliveries) == 2
+	}, 2*time.Second, 5*time.Millisecond)
+
+	deliveries, deadLetters := store.snapshot()
+	assert.Equal(t, int32(2), roleHits.Load())
+	assert.Equal(t, int32(0), invoiceHits.Load())
+	assert.Empty(t, deadLetters)
+
+	assert.Equal(t, 1, deliveries[0].Attempt)
+	assert.Equal(t, http.StatusBadGateway, deliveries[0].StatusCode)
+	assert.False(t, deliveries[0].Succeeded)
+	assert.NotEmpty(t, deliveries[0].Error)
+
+	assert.Equal(t, 2, deliveries[1].Attempt)
+	assert.Equal(t, http.StatusOK, deliveries[1].StatusCode)
+	assert.True(t, deliveries[1].Succeeded)
+	for _, del := range deliveries {
+		assert.Equal(t, &roleSubID, del.SubscriptionID)
+		assert.Equal(t, event.ID, del.EventID)
+		assert.Equal(t, webhook.EventRoleCreated, del.EventType)
+	}
+}
+
+func TestDispatcher_DeadLetterAfterMaxAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	subID := uuid.New()
+	store := &memoryStore{subs: []webhook.Subscription{
+		{ID: &subID, URL: srv.URL, Secret: "a", EventTypes: []string{"*"}},
+	}}
+
+	d := webhook.NewDispatcher(webhook.Config{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		Timeout:     time.Second,
+	}, store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	event := webhook.NewEvent(webhook.EventInvoicePaid, nil)
+	d.Publish(context.Background(), event)
+
+	assert.Eventually(t, func() bool {
+		_, deadLetters := store.snapshot()
+		return len(deadLetters) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	deliveries, deadLetters := store.snapshot()
+	assert.Len(t, deliveries, 2)
+	assert.Equal(t, &subID, deadLetters[0].SubscriptionID)
+	assert.Equal(t, event.ID, deadLetters[0].EventID)
+	assert.Equal(t, 2, deadLetters[0].Attempts)
+	assert.Contains(t, deadLetters[0].LastError, "500")
+
+	var payload webhook.Event
+	require.NoError(t, json.Unmarshal(deadLetters[0].Payload, &payload))
+	assert.Equal(t, event.ID, payload.ID)
+}
+
+func idPtr(id uuid.UUID) *uuid.UUID {
+	return &id
+}
+
+func TestDispatcher_CancelRetriesStopsSubscription(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	subID := uuid.New()
+	store := &memoryStore{subs: []webhook.Subscription{
+		{ID: &subID, URL: srv.URL, Secret: "a", EventTypes: []string{"*"}},
+	}}
+
+	d := webhook.NewDispatcher(webhook.Config{
+		MaxAttempts: 5,
+		BaseDelay:   50 * time.Millisecond,
+		Timeout:     time.Second,
+	}, store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	d.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+	assert.Eventually(t, func() bool { return hits.Load() == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	// subscription dihapus sebelum retry pertama jatuh tempo
+	d.CancelRetries(subID)
+
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, int32(1), hits.Load())
+	_, deadLetters := store.snapshot()
+	assert.Empty(t, deadLetters)
+}
+
+func TestDispatcher_DoesNotFollowRedirects(t *testing.T) {
+	var internalHits atomic.Int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHits.Add(1)
+	}))
+	defer internal.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	subID := uuid.New()
+	store := &memoryStore{subs: []webhook.Subscription{
+		{ID: &subID, URL: redirect.URL, Secret: "a", EventTypes: []string{"*"}},
+	}}
+
+	d := webhook.NewDispatcher(webhook.Config{
+		MaxAttempts: 1,
+		Timeout:     time.Second,
+	}, store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	d.Publish(context.Background(), webhook.NewEvent(webhook.EventUserCreated, nil))
+
+	assert.Eventually(t, func() bool {
+		deliveries, _ := store.snapshot()
+		return len(deliveries) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	deliveries, _ := store.snapshot()
+	assert.Equal(t, http.StatusTemporaryRedirect, deliveries[0].StatusCode)
+	assert.False(t, deliveries[0].Succeeded)
+	assert.Equal(t, int32(0), internalHits.Load())
+}
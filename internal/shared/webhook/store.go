@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription is a delivery target. ID nil = URL statis dari config.
+type Subscription struct {
+	ID         *uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// Delivery is the outcome of a single POST attempt
+type Delivery struct {
+	SubscriptionID *uuid.UUID
+	URL            string
+	EventID        uuid.UUID
+	EventType      string
+	Attempt        int
+	StatusCode     int // 0 = tidak ada response (network error)
+	Error          string
+	Succeeded      bool
+	Duration       time.Duration
+}
+
+// DeadLetter is an event that still failed after MaxAttempts
+type DeadLetter struct {
+	SubscriptionID *uuid.UUID
+	URL            string
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	Attempts       int
+	LastError      string
+}
+
+// Store supplies subscriptions and records delivery outcomes
+type Store interface {
+	ActiveSubscriptions(ctx context.Context) ([]Subscription, error)
+	RecordDelivery(ctx context.Context, d Delivery) error
+	RecordDeadLetter(ctx context.Context, dl DeadLetter) error
+}
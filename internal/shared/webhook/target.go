@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// ErrTargetNotAllowed is returned by ValidateTarget
+var ErrTargetNotAllowed = errors.New("webhook target not allowed")
+
+// host metadata cloud yang tidak tertangkap cek link-local
+var metadataHosts = map[string]bool{
+	"metadata":                 true,
+	"metadata.google.internal": true,
+}
+
+var metadataAddrs = map[netip.Addr]bool{
+	netip.MustParseAddr("fd00:ec2::254"):   true, // AWS IMDS IPv6
+	netip.MustParseAddr("100.100.100.200"): true, // Alibaba Cloud
+}
+
+// ValidateTarget rejects subscription URLs that are not http(s) or that
+// point at loopback, link-local (termasuk 169.254.169.254) or cloud
+// metadata addresses. Hostname dicek apa adanya, tidak di-resolve.
+func ValidateTarget(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrTargetNotAllowed, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme %q", ErrTargetNotAllowed, u.Scheme)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	switch {
+	case host == "":
+		return fmt.Errorf("%w: missing host", ErrTargetNotAllowed)
+	case host == "localhost", strings.HasSuffix(host, ".localhost"), metadataHosts[host]:
+		return fmt.Errorf("%w: host %q", ErrTargetNotAllowed, host)
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && blockedAddr(addr) {
+		return fmt.Errorf("%w: address %s", ErrTargetNotAllowed, host)
+	}
+	return nil
+}
+
+func blockedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	return addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsUnspecified() ||
+		metadataAddrs[addr]
+}
@@ -0,0 +1,129 @@
+// Package webhook delivers domain events to external HTTP endpoints.
+// Payloads are signed with HMAC-SHA256 so receivers can verify them.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types
+const (
+	EventUserCreated     = "user.created"
+	EventUserDeactivated = "user.deactivated"
+	EventRoleCreated     = "role.created"
+	EventRoleUpdated     = "role.updated"
+	EventRoleDeleted     = "role.deleted"
+	EventRoleAssigned    = "role.assigned"
+	EventRoleRemoved     = "role.removed"
+	EventInvoicePaid     = "invoice.paid"
+
+	EventInventoryLowStock = "inventory.low_stock"
+)
+
+// EventTypes lists every event a subscription can ask for
+var EventTypes = []string{
+	EventUserCreated,
+	EventUserDeactivated,
+	EventRoleCreated,
+	EventRoleUpdated,
+	EventRoleDeleted,
+	EventRoleAssigned,
+	EventRoleRemoved,
+	EventInvoicePaid,
+	EventInventoryLowStock,
+}
+
+// Matches reports whether eventType is selected by any of patterns.
+// A pattern is an exact type, "*" for everything, or "<prefix>.*"
+// for every type under prefix (e.g. "user.*").
+func Matches(patterns []string, eventType string) bool {
+	for _, p := range patterns {
+		switch {
+		case p == "*", p == eventType:
+			return true
+		case strings.HasSuffix(p, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(p, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// ValidPattern reports whether p selects at least one known event type
+func ValidPattern(p string) bool {
+	for _, t := range EventTypes {
+		if Matches([]string{p}, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Header yang dikirim bersama setiap delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderID        = "X-Webhook-ID"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Event is the JSON body posted to subscribers
+type Event struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+// NewEvent creates an event with a fresh id
+func NewEvent(eventType string, data any) Event {
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// Publisher enqueues events for delivery. Publish must not block the
+// caller; ctx only covers the enqueue, not the delivery itself.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Nop is a Publisher that drops every event (webhooks disabled)
+type Nop struct{}
+
+func (Nop) Publish(ctx context.Context, event Event) {}
+
+// Sign returns the signature header value for body sent at timestamp:
+// "sha256=" + hex(HMAC-SHA256(secret, "<timestamp>.<body>")).
+// Timestamp ikut di-sign supaya request lama tidak bisa di-replay.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Fanout forwards every event to each publisher in order
+type Fanout []Publisher
+
+func (f Fanout) Publish(ctx context.Context, event Event) {
+	for _, p := range f {
+		p.Publish(ctx, event)
+	}
+}
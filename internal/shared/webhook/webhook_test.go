@@ -0,0 +1,97 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-mini-erp/internal/shared/webhook"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"user.created"}`)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, want, webhook.Sign("s3cret", 1700000000, body))
+}
+
+func TestSign_DependsOnSecretTimestampAndBody(t *testing.T) {
+	body := []byte(`{"a":1}`)
+	sig := webhook.Sign("s3cret", 1700000000, body)
+
+	assert.NotEqual(t, sig, webhook.Sign("other", 1700000000, body))
+	assert.NotEqual(t, sig, webhook.Sign("s3cret", 1700000001, body))
+	assert.NotEqual(t, sig, webhook.Sign("s3cret", 1700000000, []byte(`{"a":2}`)))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"a":1}`)
+	sig := webhook.Sign("s3cret", 1700000000, body)
+
+	assert.True(t, webhook.Verify("s3cret", 1700000000, body, sig))
+	assert.False(t, webhook.Verify("s3cret", 1700000000, body, "sha256=deadbeef"))
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		patterns  []string
+		eventType string
+		want      bool
+	}{
+		{[]string{"user.created"}, "user.created", true},
+		{[]string{"user.created"}, "user.deactivated", false},
+		{[]string{"*"}, "invoice.paid", true},
+		{[]string{"user.*"}, "user.deactivated", true},
+		{[]string{"user.*"}, "role.created", false},
+		{[]string{"user.*"}, "username.changed", false},
+		{[]string{"role.created", "invoice.paid"}, "invoice.paid", true},
+		{nil, "user.created", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, webhook.Matches(tt.patterns, tt.eventType), "%v / %s", tt.patterns, tt.eventType)
+	}
+}
+
+func TestValidPattern(t *testing.T) {
+	assert.True(t, webhook.ValidPattern("role.created"))
+	assert.True(t, webhook.ValidPattern("invoice.*"))
+	assert.True(t, webhook.ValidPattern("*"))
+	assert.True(t, webhook.ValidPattern("role.deleted"))
+	assert.False(t, webhook.ValidPattern("role.archived"))
+	assert.False(t, webhook.ValidPattern("order.*"))
+}
+
+func TestValidateTarget(t *testing.T) {
+	allowed := []string{
+		"https://crm.example.com/hook",
+		"http://10.0.0.5:8080/hook",
+	}
+	for _, raw := range allowed {
+		assert.NoError(t, webhook.ValidateTarget(raw), raw)
+	}
+
+	rejected := []string{
+		"ftp://crm.example.com/hook",
+		"https:///hook",
+		"http://localhost:8080/hook",
+		"http://api.localhost/hook",
+		"http://127.0.0.1/hook",
+		"http://[::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[fe80::1]/hook",
+		"http://[fd00:ec2::254]/hook",
+		"http://metadata.google.internal/computeMetadata/v1",
+	}
+	for _, raw := range rejected {
+		assert.ErrorIs(t, webhook.ValidateTarget(raw), webhook.ErrTargetNotAllowed, raw)
+	}
+}
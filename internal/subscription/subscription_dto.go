@@ -0,0 +1,60 @@
+package subscription
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-mini-erp/internal/shared/pagination"
+)
+
+// EventTypes: exact type ("role.created"), "user.*", atau "*"
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=500"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,required,max=100"`
+	Secret     *string  `json:"secret" binding:"omitempty,min=16,max=255"`
+	IsActive   *bool    `json:"isActive"`
+}
+
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=500"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,required,max=100"`
+	IsActive   *bool    `json:"isActive" binding:"required"`
+}
+
+// WebhookResponse tidak pernah menyertakan secret
+type WebhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	IsActive   bool      `json:"isActive"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// CreateWebhookResponse returns the signing secret once, on creation
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type DeliveryResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventID    uuid.UUID `json:"eventId"`
+	EventType  string    `json:"eventType"`
+	Attempt    int32     `json:"attempt"`
+	StatusCode *int32    `json:"statusCode"`
+	Error      *string   `json:"error"`
+	Succeeded  bool      `json:"succeeded"`
+	DurationMs int32     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ListDeliveriesResponse is pagination.Response[DeliveryResponse]
+// (swag belum bisa membaca generic)
+type ListDeliveriesResponse struct {
+	Data  []DeliveryResponse `json:"data"`
+	Meta  pagination.Meta    `json:"meta"`
+	Links *pagination.Links  `json:"links,omitempty"`
+}
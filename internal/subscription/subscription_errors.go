@@ -0,0 +1,9 @@
+package subscription
+
+import "errors"
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrInvalidEventType = errors.New("unknown event type")
+	ErrURLNotAllowed    = errors.New("webhook url not allowed")
+)
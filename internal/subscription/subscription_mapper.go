@@ -0,0 +1,36 @@
+package subscription
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func mapWebhook(w db.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:         w.ID,
+		URL:        w.Url,
+		EventTypes: w.EventTypes,
+		IsActive:   w.IsActive,
+		CreatedAt:  dbutil.PgTimeValue(w.CreatedAt),
+		UpdatedAt:  dbutil.PgTimeValue(w.UpdatedAt),
+	}
+}
+
+func mapDeliveries(rows []db.WebhookDelivery) []DeliveryResponse {
+	deliveries := make([]DeliveryResponse, 0, len(rows))
+	for _, d := range rows {
+		deliveries = append(deliveries, DeliveryResponse{
+			ID:         d.ID,
+			URL:        d.Url,
+			EventID:    d.EventID,
+			EventType:  d.EventType,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			Succeeded:  d.Succeeded,
+			DurationMs: d.DurationMs,
+			CreatedAt:  dbutil.PgTimeValue(d.CreatedAt),
+		})
+	}
+	return deliveries
+}
@@ -0,0 +1,76 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+//go:generate mockgen -source=subscription_repo.go -destination=mocks/subscription_repository_mock.go -package=mocks
+
+// Repository defines webhook subscription data access contract
+type Repository interface {
+	CreateWebhook(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (db.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]db.Webhook, error)
+	ListActiveWebhooks(ctx context.Context) ([]db.Webhook, error)
+	UpdateWebhook(ctx context.Context, arg db.UpdateWebhookParams) (db.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error)
+
+	CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) error
+	ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID uuid.UUID) (int64, error)
+	CreateWebhookDeadLetter(ctx context.Context, arg db.CreateWebhookDeadLetterParams) error
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates webhook subscription repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) CreateWebhook(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+	return r.q.CreateWebhook(ctx, arg)
+}
+
+func (r *repository) GetWebhookByID(ctx context.Context, id uuid.UUID) (db.Webhook, error) {
+	return r.q.GetWebhookByID(ctx, id)
+}
+
+func (r *repository) ListWebhooks(ctx context.Context) ([]db.Webhook, error) {
+	return r.q.ListWebhooks(ctx)
+}
+
+func (r *repository) ListActiveWebhooks(ctx context.Context) ([]db.Webhook, error) {
+	return r.q.ListActiveWebhooks(ctx)
+}
+
+func (r *repository) UpdateWebhook(ctx context.Context, arg db.UpdateWebhookParams) (db.Webhook, error) {
+	return r.q.UpdateWebhook(ctx, arg)
+}
+
+func (r *repository) DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.DeleteWebhook(ctx, id)
+}
+
+func (r *repository) CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) error {
+	return r.q.CreateWebhookDelivery(ctx, arg)
+}
+
+func (r *repository) ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	return r.q.ListWebhookDeliveries(ctx, arg)
+}
+
+func (r *repository) CountWebhookDeliveries(ctx context.Context, webhookID uuid.UUID) (int64, error) {
+	return r.q.CountWebhookDeliveries(ctx, dbutil.UUIDPtrToPgUUID(&webhookID))
+}
+
+func (r *repository) CreateWebhookDeadLetter(ctx context.Context, arg db.CreateWebhookDeadLetterParams) error {
+	return r.q.CreateWebhookDeadLetter(ctx, arg)
+}
@@ -0,0 +1,20 @@
+package subscription
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/webhooks", middleware.AuthMiddleware(), middleware.RequireRole(seed.RoleAdmin))
+	{
+		routes.POST("", h.CreateWebhook)
+		routes.GET("", h.ListWebhooks)
+		routes.GET("/:id", h.GetWebhook)
+		routes.PUT("/:id", h.UpdateWebhook)
+		routes.DELETE("/:id", h.DeleteWebhook)
+		routes.GET("/:id/deliveries", h.ListDeliveries)
+	}
+}
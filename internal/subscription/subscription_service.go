@@ -0,0 +1,219 @@
+package subscription
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/pagination"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+//go:generate mockgen -source=subscription_service.go -destination=mocks/subscription_service_mock.go -package=mocks
+
+// Service defines webhook subscription business logic
+type Service interface {
+	CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreateWebhookResponse, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context) ([]WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req UpdateWebhookRequest) (*WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, id uuid.UUID, params pagination.Params) (*ListDeliveriesResponse, error)
+}
+
+// Retries cancels the pending deliveries of a subscription; dipenuhi
+// *webhook.Dispatcher
+type Retries interface {
+	CancelRetries(id uuid.UUID)
+}
+
+type service struct {
+	repo    Repository
+	retries Retries
+}
+
+// NewService creates webhook subscription service. retries nil = retry
+// yang sedang antri tetap jalan setelah subscription dihapus.
+func NewService(repo Repository, retries Retries) Service {
+	return &service{repo: repo, retries: retries}
+}
+
+// CreateWebhook stores a subscription. Kalau secret tidak dikirim,
+// dibuatkan random 32 byte; secret hanya dikembalikan di sini.
+func (s *service) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	url, err := validateURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := dbutil.StringPtrValue(req.Secret)
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := s.repo.CreateWebhook(ctx, db.CreateWebhookParams{
+		Url:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   dbutil.BoolPtrValue(req.IsActive, true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateWebhookResponse{
+		WebhookResponse: mapWebhook(w),
+		Secret:          w.Secret,
+	}, nil
+}
+
+func (s *service) GetWebhook(ctx context.Context, id uuid.UUID) (*WebhookResponse, error) {
+	w, err := s.repo.GetWebhookByID(ctx, id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	res := mapWebhook(w)
+	return &res, nil
+}
+
+func (s *service) ListWebhooks(ctx context.Context) ([]WebhookResponse, error) {
+	rows, err := s.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]WebhookResponse, 0, len(rows))
+	for _, w := range rows {
+		webhooks = append(webhooks, mapWebhook(w))
+	}
+	return webhooks, nil
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, id uuid.UUID, req UpdateWebhookRequest) (*WebhookResponse, error) {
+	url, err := validateURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.repo.UpdateWebhook(ctx, db.UpdateWebhookParams{
+		ID:         id,
+		Url:        url,
+		EventTypes: eventTypes,
+		IsActive:   dbutil.BoolPtrValue(req.IsActive, true),
+	})
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	if !w.IsActive {
+		s.cancelRetries(id)
+	}
+
+	res := mapWebhook(w)
+	return &res, nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	rows, err := s.repo.DeleteWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrWebhookNotFound
+	}
+	s.cancelRetries(id)
+	return nil
+}
+
+func (s *service) cancelRetries(id uuid.UUID) {
+	if s.retries != nil {
+		s.retries.CancelRetries(id)
+	}
+}
+
+func (s *service) ListDeliveries(ctx context.Context, id uuid.UUID, params pagination.Params) (*ListDeliveriesResponse, error) {
+	if _, err := s.repo.GetWebhookByID(ctx, id); err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	rows, err := s.repo.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		WebhookID:   dbutil.UUIDPtrToPgUUID(&id),
+		LimitCount:  params.Limit(),
+		OffsetCount: params.Offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountWebhookDeliveries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListDeliveriesResponse{
+		Data: mapDeliveries(rows),
+		Meta: pagination.BuildMeta(total, params),
+	}, nil
+}
+
+// validateURL trims raw and rejects internal targets (loopback,
+// link-local, metadata cloud)
+func validateURL(raw string) (string, error) {
+	url := strings.TrimSpace(raw)
+	if err := webhook.ValidateTarget(url); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrURLNotAllowed, err)
+	}
+	return url, nil
+}
+
+// normalizeEventTypes trims, de-duplicates and validates patterns
+func normalizeEventTypes(patterns []string) ([]string, error) {
+	seen := make(map[string]bool, len(patterns))
+	out := make([]string, 0, len(patterns))
+
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if !webhook.ValidPattern(p) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidEventType, p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+
+	return out, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret failed: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func mapNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
@@ -0,0 +1,136 @@
+package subscription_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/subscription"
+	"go-mini-erp/internal/subscription/mocks"
+)
+
+func TestCreateWebhook_GeneratesSecretAndNormalizesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := subscription.NewService(repo, nil)
+
+	repo.EXPECT().
+		CreateWebhook(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+			assert.Equal(t, "https://crm.example.com/hook", arg.Url)
+			assert.Equal(t, []string{"user.*", "invoice.paid"}, arg.EventTypes)
+			assert.Len(t, arg.Secret, 64)
+			assert.True(t, arg.IsActive)
+			return db.Webhook{ID: uuid.New(), Url: arg.Url, Secret: arg.Secret, EventTypes: arg.EventTypes, IsActive: true}, nil
+		})
+
+	result, err := service.CreateWebhook(context.Background(), subscription.CreateWebhookRequest{
+		URL:        " https://crm.example.com/hook ",
+		EventTypes: []string{"user.*", " invoice.paid", "user.*"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Secret, 64)
+}
+
+func TestCreateWebhook_UnknownEventType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := subscription.NewService(mocks.NewMockRepository(ctrl), nil)
+
+	_, err := service.CreateWebhook(context.Background(), subscription.CreateWebhookRequest{
+		URL:        "https://crm.example.com/hook",
+		EventTypes: []string{"order.shipped"},
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrInvalidEventType)
+}
+
+func TestUpdateWebhook_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := subscription.NewService(repo, nil)
+
+	repo.EXPECT().UpdateWebhook(gomock.Any(), gomock.Any()).Return(db.Webhook{}, pgx.ErrNoRows)
+
+	active := true
+	_, err := service.UpdateWebhook(context.Background(), uuid.New(), subscription.UpdateWebhookRequest{
+		URL:        "https://crm.example.com/hook",
+		EventTypes: []string{"*"},
+		IsActive:   &active,
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrWebhookNotFound)
+}
+
+func TestDeleteWebhook_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	service := subscription.NewService(repo, nil)
+
+	repo.EXPECT().DeleteWebhook(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	assert.ErrorIs(t, service.DeleteWebhook(context.Background(), uuid.New()), subscription.ErrWebhookNotFound)
+}
+
+func TestCreateWebhook_InternalURLRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	// CreateWebhook repo tidak boleh dipanggil
+	service := subscription.NewService(mocks.NewMockRepository(ctrl), nil)
+
+	_, err := service.CreateWebhook(context.Background(), subscription.CreateWebhookRequest{
+		URL:        "http://169.254.169.254/latest/meta-data",
+		EventTypes: []string{"*"},
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrURLNotAllowed)
+}
+
+// retriesStub mencatat subscription yang retry-nya dibatalkan
+type retriesStub struct {
+	cancelled []uuid.UUID
+}
+
+func (r *retriesStub) CancelRetries(id uuid.UUID) {
+	r.cancelled = append(r.cancelled, id)
+}
+
+func TestDeleteWebhook_CancelsPendingRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	retries := &retriesStub{}
+	service := subscription.NewService(repo, retries)
+	id := uuid.New()
+
+	repo.EXPECT().DeleteWebhook(gomock.Any(), id).Return(int64(1), nil)
+
+	assert.NoError(t, service.DeleteWebhook(context.Background(), id))
+	assert.Equal(t, []uuid.UUID{id}, retries.cancelled)
+}
+
+func TestUpdateWebhook_DeactivateCancelsPendingRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	retries := &retriesStub{}
+	service := subscription.NewService(repo, retries)
+	id := uuid.New()
+
+	repo.EXPECT().UpdateWebhook(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.UpdateWebhookParams) (db.Webhook, error) {
+			return db.Webhook{ID: arg.ID, Url: arg.Url, EventTypes: arg.EventTypes, IsActive: arg.IsActive}, nil
+		})
+
+	inactive := false
+	_, err := service.UpdateWebhook(context.Background(), id, subscription.UpdateWebhookRequest{
+		URL:        "https://crm.example.com/hook",
+		EventTypes: []string{"*"},
+		IsActive:   &inactive,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{id}, retries.cancelled)
+}
@@ -0,0 +1,78 @@
+package subscription
+
+import (
+	"context"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+// store adapts Repository to webhook.Store for the dispatcher
+type store struct {
+	repo Repository
+}
+
+// NewStore returns the database-backed webhook.Store
+func NewStore(repo Repository) webhook.Store {
+	return &store{repo: repo}
+}
+
+func (s *store) ActiveSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	rows, err := s.repo.ListActiveWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subs := make([]webhook.Subscription, 0, len(rows))
+	for _, w := range rows {
+		subs = append(subs, webhook.Subscription{
+			ID:         dbutil.Ptr(w.ID),
+			URL:        w.Url,
+			Secret:     w.Secret,
+			EventTypes: w.EventTypes,
+		})
+	}
+	return subs, nil
+}
+
+func (s *store) RecordDelivery(ctx context.Context, d webhook.Delivery) error {
+	var status *int32
+	if d.StatusCode != 0 {
+		status = dbutil.Ptr(int32(d.StatusCode))
+	}
+
+	var errMsg *string
+	if d.Error != "" {
+		errMsg = &d.Error
+	}
+
+	return s.repo.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		WebhookID:  dbutil.UUIDPtrToPgUUID(d.SubscriptionID),
+		Url:        d.URL,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		Attempt:    int32(d.Attempt),
+		StatusCode: status,
+		Error:      errMsg,
+		Succeeded:  d.Succeeded,
+		DurationMs: int32(d.Duration.Milliseconds()),
+	})
+}
+
+func (s *store) RecordDeadLetter(ctx context.Context, dl webhook.DeadLetter) error {
+	var lastErr *string
+	if dl.LastError != "" {
+		lastErr = &dl.LastError
+	}
+
+	return s.repo.CreateWebhookDeadLetter(ctx, db.CreateWebhookDeadLetterParams{
+		WebhookID: dbutil.UUIDPtrToPgUUID(dl.SubscriptionID),
+		Url:       dl.URL,
+		EventID:   dl.EventID,
+		EventType: dl.EventType,
+		Payload:   dl.Payload,
+		Attempts:  int32(dl.Attempts),
+		LastError: lastErr,
+	})
+}
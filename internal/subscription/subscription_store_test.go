@@ -0,0 +1,93 @@
+package subscription_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+	"go-mini-erp/internal/subscription"
+	"go-mini-erp/internal/subscription/mocks"
+)
+
+func TestStore_RecordDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	store := subscription.NewStore(repo)
+
+	subID := uuid.New()
+	eventID := uuid.New()
+
+	repo.EXPECT().CreateWebhookDelivery(gomock.Any(), db.CreateWebhookDeliveryParams{
+		WebhookID:  dbutil.UUIDPtrToPgUUID(&subID),
+		Url:        "https://crm.example.com/hook",
+		EventID:    eventID,
+		EventType:  webhook.EventRoleCreated,
+		Attempt:    2,
+		StatusCode: dbutil.Ptr(int32(502)),
+		Error:      dbutil.Ptr("unexpected status 502"),
+		Succeeded:  false,
+		DurationMs: 120,
+	}).Return(nil)
+
+	err := store.RecordDelivery(context.Background(), webhook.Delivery{
+		SubscriptionID: &subID,
+		URL:            "https://crm.example.com/hook",
+		EventID:        eventID,
+		EventType:      webhook.EventRoleCreated,
+		Attempt:        2,
+		StatusCode:     502,
+		Error:          "unexpected status 502",
+		Duration:       120 * time.Millisecond,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestStore_RecordDelivery_NetworkErrorHasNoStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	store := subscription.NewStore(repo)
+
+	repo.EXPECT().
+		CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateWebhookDeliveryParams) error {
+			assert.Nil(t, arg.StatusCode)
+			assert.False(t, arg.WebhookID.Valid) // URL statis
+			assert.Equal(t, "connection refused", *arg.Error)
+			return nil
+		})
+
+	err := store.RecordDelivery(context.Background(), webhook.Delivery{
+		URL:     "http://127.0.0.1:1",
+		EventID: uuid.New(),
+		Attempt: 1,
+		Error:   "connection refused",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestStore_ActiveSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	store := subscription.NewStore(repo)
+
+	id := uuid.New()
+	repo.EXPECT().ListActiveWebhooks(gomock.Any()).Return([]db.Webhook{
+		{ID: id, Url: "https://a.example.com", Secret: "s", EventTypes: []string{"user.*"}, IsActive: true},
+	}, nil)
+
+	subs, err := store.ActiveSubscriptions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []webhook.Subscription{
+		{ID: &id, URL: "https://a.example.com", Secret: "s", EventTypes: []string{"user.*"}},
+	}, subs)
+}
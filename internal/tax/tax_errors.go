@@ -0,0 +1,8 @@
+package tax
+
+import "errors"
+
+var (
+	ErrRateNotFound = errors.New("tax rate not found")
+	ErrInvalidRate  = errors.New("invalid tax rate")
+)
@@ -0,0 +1,130 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"go-mini-erp/internal/shared/util/money"
+)
+
+//go:generate mockgen -source=tax_service.go -destination=mocks/tax_service_mock.go -package=mocks
+
+// Mode menentukan apakah amount sudah termasuk pajak atau belum
+type Mode string
+
+const (
+	// ModeExclusive: amount adalah harga sebelum pajak, pajak ditambahkan di atasnya
+	ModeExclusive Mode = "exclusive"
+	// ModeInclusive: amount sudah termasuk pajak, pajak diambil dari dalamnya
+	ModeInclusive Mode = "inclusive"
+)
+
+// Any cocok dengan semua region / category saat lookup rate
+const Any = ""
+
+// TaxRate is a rate (0.11 = 11%) applied to a region/category pair.
+// Region or Category set to Any act as a fallback.
+type TaxRate struct {
+	Code     string
+	Region   string
+	Category string
+	Rate     decimal.Decimal
+	Mode     Mode
+	Currency string
+}
+
+// Amounts is an amount split into net, tax and gross
+type Amounts struct {
+	Net   decimal.Decimal
+	Tax   decimal.Decimal
+	Gross decimal.Decimal
+}
+
+// Service defines tax calculation
+type Service interface {
+	RateFor(region, category string) (TaxRate, error)
+	Calculate(amount decimal.Decimal, rate TaxRate) decimal.Decimal
+	Breakdown(amount decimal.Decimal, rate TaxRate) Amounts
+}
+
+type rateKey struct {
+	region   string
+	category string
+}
+
+type service struct {
+	rates map[rateKey]TaxRate
+}
+
+// NewService creates tax service from a rate table. A rate with
+// Region/Category set to Any is used when no exact match exists.
+func NewService(rates ...TaxRate) (Service, error) {
+	table := make(map[rateKey]TaxRate, len(rates))
+
+	for _, r := range rates {
+		if r.Rate.IsNegative() {
+			return nil, fmt.Errorf("%w: %s is negative", ErrInvalidRate, r.Code)
+		}
+		if r.Mode != ModeExclusive && r.Mode != ModeInclusive {
+			return nil, fmt.Errorf("%w: %s has unknown mode %q", ErrInvalidRate, r.Code, r.Mode)
+		}
+
+		key := rateKey{region: r.Region, category: r.Category}
+		if _, dup := table[key]; dup {
+			return nil, fmt.Errorf("%w: duplicate rate for region %q category %q", ErrInvalidRate, r.Region, r.Category)
+		}
+		table[key] = r
+	}
+
+	return &service{rates: table}, nil
+}
+
+// RateFor resolves the most specific rate: region+category,
+// then region only, then category only, then the global default.
+func (s *service) RateFor(region, category string) (TaxRate, error) {
+	candidates := []rateKey{
+		{region, category},
+		{region, Any},
+		{Any, category},
+		{Any, Any},
+	}
+
+	for _, key := range candidates {
+		if r, ok := s.rates[key]; ok {
+			return r, nil
+		}
+	}
+
+	return TaxRate{}, ErrRateNotFound
+}
+
+// Calculate returns the tax portion of amount, rounded half-up
+// (away from zero) to the currency's minor-unit precision.
+//
+// Exclusive: tax = amount * rate
+// Inclusive: tax = amount * rate / (1 + rate)
+func (s *service) Calculate(amount decimal.Decimal, rate TaxRate) decimal.Decimal {
+	places := money.MinorUnits(rate.Currency)
+
+	if rate.Mode == ModeInclusive {
+		divisor := decimal.NewFromInt(1).Add(rate.Rate)
+		return amount.Mul(rate.Rate).DivRound(divisor, places+8).Round(places)
+	}
+
+	return amount.Mul(rate.Rate).Round(places)
+}
+
+// Breakdown splits amount into net/tax/gross so that net + tax == gross
+// always holds after rounding.
+func (s *service) Breakdown(amount decimal.Decimal, rate TaxRate) Amounts {
+	places := money.MinorUnits(rate.Currency)
+	amount = amount.Round(places)
+	tax := s.Calculate(amount, rate)
+
+	if rate.Mode == ModeInclusive {
+		return Amounts{Net: amount.Sub(tax), Tax: tax, Gross: amount}
+	}
+
+	return Amounts{Net: amount, Tax: tax, Gross: amount.Add(tax)}
+}
@@ -0,0 +1,110 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-mini-erp/internal/tax"
+)
+
+var (
+	ppn     = tax.TaxRate{Code: "PPN", Region: "ID", Rate: decimal.RequireFromString("0.11"), Mode: tax.ModeExclusive, Currency: "IDR"}
+	ppnIncl = tax.TaxRate{Code: "PPN-INCL", Rate: decimal.RequireFromString("0.11"), Mode: tax.ModeInclusive, Currency: "IDR"}
+)
+
+func newService(t *testing.T, rates ...tax.TaxRate) tax.Service {
+	t.Helper()
+	svc, err := tax.NewService(rates...)
+	require.NoError(t, err)
+	return svc
+}
+
+func TestCalculate(t *testing.T) {
+	svc := newService(t)
+
+	tenPct := decimal.RequireFromString("0.10")
+	tests := []struct {
+		name   string
+		amount string
+		rate   tax.TaxRate
+		want   string
+	}{
+		{"exclusive", "100000", ppn, "11000"},
+		{"inclusive", "111000", ppnIncl, "11000"},
+		{"exclusive fractional", "19.99", ppn, "2.2"},
+		{"inclusive repeating", "10", ppnIncl, "0.99"},
+		{"exclusive half rounds up", "0.05", tax.TaxRate{Rate: tenPct, Mode: tax.ModeExclusive}, "0.01"},
+		{"exclusive below half rounds down", "0.04", tax.TaxRate{Rate: tenPct, Mode: tax.ModeExclusive}, "0"},
+		{"exclusive negative half rounds away from zero", "-0.05", tax.TaxRate{Rate: tenPct, Mode: tax.ModeExclusive}, "-0.01"},
+		{"inclusive half rounds up", "0.055", tax.TaxRate{Rate: tenPct, Mode: tax.ModeInclusive}, "0.01"},
+		{"zero decimal currency", "105", tax.TaxRate{Rate: tenPct, Mode: tax.ModeExclusive, Currency: "JPY"}, "11"},
+		{"three decimal currency", "1.005", tax.TaxRate{Rate: tenPct, Mode: tax.ModeExclusive, Currency: "KWD"}, "0.101"},
+		{"zero rate", "100", tax.TaxRate{Rate: decimal.Zero, Mode: tax.ModeInclusive}, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := svc.Calculate(decimal.RequireFromString(tt.amount), tt.rate)
+			assert.True(t, decimal.RequireFromString(tt.want).Equal(got), "got %s want %s", got, tt.want)
+		})
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	svc := newService(t)
+
+	excl := svc.Breakdown(decimal.RequireFromString("19.99"), ppn)
+	assert.Equal(t, "19.99", excl.Net.String())
+	assert.Equal(t, "2.2", excl.Tax.String())
+	assert.Equal(t, "22.19", excl.Gross.String())
+
+	// net + tax harus selalu sama dengan gross setelah pembulatan
+	incl := svc.Breakdown(decimal.RequireFromString("10"), ppnIncl)
+	assert.Equal(t, "9.01", incl.Net.String())
+	assert.Equal(t, "0.99", incl.Tax.String())
+	assert.True(t, incl.Net.Add(incl.Tax).Equal(incl.Gross))
+}
+
+func TestRateFor(t *testing.T) {
+	food := tax.TaxRate{Code: "ID-FOOD", Region: "ID", Category: "food", Rate: decimal.Zero, Mode: tax.ModeExclusive}
+	services := tax.TaxRate{Code: "SVC", Category: "services", Rate: decimal.RequireFromString("0.02"), Mode: tax.ModeExclusive}
+	global := tax.TaxRate{Code: "DEFAULT", Rate: decimal.RequireFromString("0.10"), Mode: tax.ModeExclusive}
+
+	svc := newService(t, ppn, food, services, global)
+
+	tests := []struct {
+		region, category, want string
+	}{
+		{"ID", "food", "ID-FOOD"},
+		{"ID", "electronics", "PPN"},
+		{"SG", "services", "SVC"},
+		{"SG", "electronics", "DEFAULT"},
+	}
+
+	for _, tt := range tests {
+		got, err := svc.RateFor(tt.region, tt.category)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got.Code, "%s/%s", tt.region, tt.category)
+	}
+}
+
+func TestRateFor_NotFound(t *testing.T) {
+	svc := newService(t, ppn)
+
+	_, err := svc.RateFor("SG", "food")
+	assert.ErrorIs(t, err, tax.ErrRateNotFound)
+}
+
+func TestNewService_InvalidRates(t *testing.T) {
+	_, err := tax.NewService(tax.TaxRate{Code: "NEG", Rate: decimal.NewFromInt(-1), Mode: tax.ModeExclusive})
+	assert.ErrorIs(t, err, tax.ErrInvalidRate)
+
+	_, err = tax.NewService(tax.TaxRate{Code: "NOMODE", Rate: decimal.Zero})
+	assert.ErrorIs(t, err, tax.ErrInvalidRate)
+
+	_, err = tax.NewService(ppn, ppn)
+	assert.ErrorIs(t, err, tax.ErrInvalidRate)
+}
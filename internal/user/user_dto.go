@@ -0,0 +1,43 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListUsersFilter: ?isActive=true|false & ?role=<role code>
+type ListUsersFilter struct {
+	IsActive *bool
+	RoleCode string
+}
+
+type UserSummary struct {
+	ID          uuid.UUID  `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	FullName    string     `json:"fullName"`
+	IsActive    bool       `json:"isActive"`
+	Roles       []string   `json:"roles"`
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+}
+
+// UpdateUserRequest: field yang tidak dikirim (null) tidak diubah
+type UpdateUserRequest struct {
+	Email    *string `json:"email" binding:"omitempty,email"`
+	FullName *string `json:"fullName" binding:"omitempty,min=1,max=255"`
+	IsActive *bool   `json:"isActive"`
+}
+
+type UserResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	FullName    string     `json:"fullName"`
+	IsActive    bool       `json:"isActive"`
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CreatedBy   *uuid.UUID `json:"createdBy"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	UpdatedBy   *uuid.UUID `json:"updatedBy"`
+}
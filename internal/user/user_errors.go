@@ -0,0 +1,9 @@
+package user
+
+import "errors"
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrEmailExists  = errors.New("email already exists")
+	ErrLastAdmin    = errors.New("cannot deactivate the last active admin")
+)
@@ -0,0 +1,150 @@
+package user
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/shared/middleware"
+	"go-mini-erp/internal/shared/util/csvutil"
+	"go-mini-erp/internal/shared/validation"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+var exportHeader = []string{"username", "email", "full_name", "is_active", "roles", "last_login_at"}
+
+// ExportUsers godoc
+// @Summary Export users as CSV
+// @Description Streams username,email,full_name,is_active,roles,last_login_at. Roles are joined with ";". Admin only.
+// @Tags users
+// @Produce text/csv
+// @Security BearerAuth
+// @Param isActive query bool false "Filter by active flag"
+// @Param role query string false "Filter by role code"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /users/export [get]
+func (h *Handler) ExportUsers(c *gin.Context) {
+	filter, err := parseListUsersFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("users-%s.csv", time.Now().UTC().Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write(exportHeader); err != nil {
+		return
+	}
+
+	err = h.service.ExportUsers(c.Request.Context(), filter, func(batch []UserSummary) error {
+		for _, u := range batch {
+			lastLogin := ""
+			if u.LastLoginAt != nil {
+				lastLogin = u.LastLoginAt.UTC().Format(time.RFC3339)
+			}
+
+			if err := csvutil.WriteRecord(w, []string{
+				u.Username,
+				u.Email,
+				u.FullName,
+				strconv.FormatBool(u.IsActive),
+				strings.Join(u.Roles, ";"),
+				lastLogin,
+			}); err != nil {
+				return err
+			}
+		}
+
+		w.Flush()
+		c.Writer.Flush()
+		return w.Error()
+	})
+	if err != nil {
+		// header sudah terkirim, tidak bisa ganti status; potong response
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	w.Flush()
+}
+
+// UpdateUser godoc
+// @Summary Update user
+// @Description Partial update: only the fields sent are changed. Admin only.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body UpdateUserRequest true "Fields to change"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} validation.ErrorResponse
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/{id} [patch]
+func (h *Handler) UpdateUser(c *gin.Context) {
+	id, ok := validation.ParamUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, validation.Response(err))
+		return
+	}
+
+	res, err := h.service.UpdateUser(c.Request.Context(), id, req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrEmailExists), errors.Is(err, ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		middleware.InternalError(c, err)
+	}
+}
+
+// parseListUsersFilter reads ?isActive= (true/false) and ?role=
+func parseListUsersFilter(c *gin.Context) (ListUsersFilter, error) {
+	filter := ListUsersFilter{RoleCode: c.Query("role")}
+
+	if raw := c.Query("isActive"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.New("isActive must be true or false")
+		}
+		filter.IsActive = &active
+	}
+
+	return filter, nil
+}
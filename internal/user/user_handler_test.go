@@ -0,0 +1,96 @@
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/user"
+	"go-mini-erp/internal/user/mocks"
+)
+
+func newUserRouter(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := user.NewHandler(mockService)
+
+	router := gin.New()
+	router.GET("/users/export", handler.ExportUsers)
+	return router, mockService
+}
+
+func TestExportUsersHandler_CSV(t *testing.T) {
+	router, mockService := newUserRouter(t)
+
+	lastLogin := time.Date(2026, 3, 2, 9, 15, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		ExportUsers(gomock.Any(), user.ListUsersFilter{IsActive: dbutil.BoolPtr(true), RoleCode: "sales"}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ user.ListUsersFilter, fn func([]user.UserSummary) error) error {
+			return fn([]user.UserSummary{
+				{
+					ID:          uuid.New(),
+					Username:    "budi",
+					Email:       "budi@example.com",
+					FullName:    "Budi Santoso",
+					IsActive:    true,
+					Roles:       []string{"admin", "sales"},
+					LastLoginAt: &lastLogin,
+				},
+				{
+					ID:       uuid.New(),
+					Username: "sari",
+					Email:    "sari@example.com",
+					FullName: "Sari, Dewi",
+					IsActive: true,
+					Roles:    []string{"sales"},
+				},
+				{
+					// dari self-registration; tidak boleh jadi formula di spreadsheet
+					ID:       uuid.New(),
+					Username: "=cmd",
+					Email:    "evil@example.com",
+					FullName: `=HYPERLINK("http://evil.example","klik")`,
+					IsActive: true,
+					Roles:    []string{"sales"},
+				},
+			})
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/export?isActive=true&role=sales", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=\"users-")
+
+	lines := strings.Split(strings.TrimRight(w.Body.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"username,email,full_name,is_active,roles,last_login_at",
+		"budi,budi@example.com,Budi Santoso,true,admin;sales,2026-03-02T09:15:00Z",
+		"sari,sari@example.com,\"Sari, Dewi\",true,sales,",
+		"'=cmd,evil@example.com,\"'=HYPERLINK(\"\"http://evil.example\"\",\"\"klik\"\")\",true,sales,",
+	}, lines)
+}
+
+func TestExportUsersHandler_InvalidFilter(t *testing.T) {
+	router, _ := newUserRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/export?isActive=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+)
+
+func mapExportRows(rows []db.ListUsersForExportRow) []UserSummary {
+	users := make([]UserSummary, 0, len(rows))
+
+	for _, u := range rows {
+		users = append(users, UserSummary{
+			ID:          u.ID,
+			Username:    u.Username,
+			Email:       u.Email,
+			FullName:    u.FullName,
+			IsActive:    dbutil.BoolPtrValue(u.IsActive, false),
+			Roles:       u.RoleCodes,
+			LastLoginAt: dbutil.PgTimeToTimePtr(u.LastLoginAt),
+		})
+	}
+
+	return users
+}
+
+func mapUser(u db.UpdateUserRow) *UserResponse {
+	return &UserResponse{
+		ID:          u.ID,
+		Username:    u.Username,
+		Email:       u.Email,
+		FullName:    u.FullName,
+		IsActive:    dbutil.BoolPtrValue(u.IsActive, false),
+		LastLoginAt: dbutil.PgTimeToTimePtr(u.LastLoginAt),
+		CreatedAt:   dbutil.PgTimeValue(u.CreatedAt),
+		CreatedBy:   dbutil.PgUUIDToUUIDPtr(u.CreatedBy),
+		UpdatedAt:   dbutil.PgTimeValue(u.UpdatedAt),
+		UpdatedBy:   dbutil.PgUUIDToUUIDPtr(u.UpdatedBy),
+	}
+}
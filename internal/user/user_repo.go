@@ -0,0 +1,57 @@
+package user
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+)
+
+//go:generate mockgen -source=user_repo.go -destination=mocks/user_repository_mock.go -package=mocks
+
+// Repository defines user management data access contract
+type Repository interface {
+	ListUsersForExport(ctx context.Context, arg db.ListUsersForExportParams) ([]db.ListUsersForExportRow, error)
+	GetUserForUpdate(ctx context.Context, id uuid.UUID) (db.GetUserForUpdateRow, error)
+	UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.UpdateUserRow, error)
+	CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error)
+	// LockAdminRole serializes last-admin checks; hanya berarti di dalam transaksi
+	LockAdminRole(ctx context.Context) error
+
+	// WithQuerier returns a repository bound to q (e.g. a transaction)
+	WithQuerier(q db.Querier) Repository
+}
+
+type repository struct {
+	q db.Querier
+}
+
+// NewRepository creates user repository
+func NewRepository(q db.Querier) Repository {
+	return &repository{q: q}
+}
+
+func (r *repository) ListUsersForExport(ctx context.Context, arg db.ListUsersForExportParams) ([]db.ListUsersForExportRow, error) {
+	return r.q.ListUsersForExport(ctx, arg)
+}
+
+func (r *repository) GetUserForUpdate(ctx context.Context, id uuid.UUID) (db.GetUserForUpdateRow, error) {
+	return r.q.GetUserForUpdate(ctx, id)
+}
+
+func (r *repository) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.UpdateUserRow, error) {
+	return r.q.UpdateUser(ctx, arg)
+}
+
+func (r *repository) CountActiveAdmins(ctx context.Context, userID uuid.UUID) (db.CountActiveAdminsRow, error) {
+	return r.q.CountActiveAdmins(ctx, userID)
+}
+
+func (r *repository) LockAdminRole(ctx context.Context) error {
+	return r.q.LockAdminRole(ctx)
+}
+
+func (r *repository) WithQuerier(q db.Querier) Repository {
+	return &repository{q: q}
+}
@@ -0,0 +1,16 @@
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-mini-erp/internal/seed"
+	"go-mini-erp/internal/shared/middleware"
+)
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	routes := r.Group("/users", middleware.AuthMiddleware())
+	{
+		routes.GET("/export", middleware.RequireRole(seed.RoleAdmin), h.ExportUsers)
+		routes.PATCH("/:id", middleware.RequireRole(seed.RoleAdmin), h.UpdateUser)
+	}
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go-mini-erp/internal/shared/database"
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/patch"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/validation"
+	"go-mini-erp/internal/shared/webhook"
+)
+
+//go:generate mockgen -source=user_service.go -destination=mocks/user_service_mock.go -package=mocks
+
+// exportBatchSize adalah jumlah row per query saat export
+const exportBatchSize int32 = 500
+
+// Service defines user management business logic
+type Service interface {
+	ExportUsers(ctx context.Context, filter ListUsersFilter, fn func(batch []UserSummary) error) error
+	UpdateUser(ctx context.Context, id uuid.UUID, req UpdateUserRequest) (*UserResponse, error)
+}
+
+type service struct {
+	repo   Repository
+	tx     database.Transactor
+	events webhook.Publisher
+}
+
+// NewService creates user service; events nil = webhook tidak dikirim
+func NewService(repo Repository, tx database.Transactor, events webhook.Publisher) Service {
+	if events == nil {
+		events = webhook.Nop{}
+	}
+	return &service{repo: repo, tx: tx, events: events}
+}
+
+// ExportUsers walks the filtered user list in username order, one
+// batch at a time, so the caller can stream without buffering it all.
+func (s *service) ExportUsers(ctx context.Context, filter ListUsersFilter, fn func(batch []UserSummary) error) error {
+	var roleCode *string
+	if code := strings.TrimSpace(filter.RoleCode); code != "" {
+		roleCode = &code
+	}
+
+	var after *string
+	for {
+		rows, err := s.repo.ListUsersForExport(ctx, db.ListUsersForExportParams{
+			IsActive:      filter.IsActive,
+			RoleCode:      roleCode,
+			AfterUsername: after,
+			LimitCount:    exportBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		if err := fn(mapExportRows(rows)); err != nil {
+			return err
+		}
+
+		if int32(len(rows)) < exportBatchSize {
+			return nil
+		}
+		last := rows[len(rows)-1].Username
+		after = &last
+	}
+}
+
+// UpdateUser applies only the fields present in req. Menonaktifkan user
+// mengunci role admin dulu, jadi dua admin yang saling menonaktifkan
+// bersamaan tidak sama-sama lolos cek admin terakhir.
+func (s *service) UpdateUser(ctx context.Context, id uuid.UUID, req UpdateUserRequest) (*UserResponse, error) {
+	var updated db.UpdateUserRow
+	var deactivated bool
+
+	err := s.tx.WithTx(ctx, func(q db.Querier) error {
+		repo := s.repo.WithQuerier(q)
+
+		existing, err := repo.GetUserForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		deactivating := req.IsActive != nil && !*req.IsActive && dbutil.BoolPtrValue(existing.IsActive, false)
+		if deactivating {
+			if err := repo.LockAdminRole(ctx); err != nil {
+				return err
+			}
+			admins, err := repo.CountActiveAdmins(ctx, id)
+			if err != nil {
+				return err
+			}
+			if admins.IsAdmin && admins.Remaining == 0 {
+				return ErrLastAdmin
+			}
+		}
+
+		params := db.UpdateUserParams{
+			ID:        id,
+			Email:     existing.Email,
+			FullName:  existing.FullName,
+			IsActive:  existing.IsActive,
+			UpdatedBy: dbutil.ActorPgUUID(ctx),
+		}
+		if err := patch.ApplyUpdate(&params, req); err != nil {
+			return err
+		}
+		params.Email = validation.NormalizeEmail(params.Email)
+
+		updated, err = repo.UpdateUser(ctx, params)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			if isUniqueViolation(err) {
+				return ErrEmailExists
+			}
+			return fmt.Errorf("update user failed: %w", err)
+		}
+		deactivated = deactivating
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapUser(updated)
+
+	// publish setelah commit supaya subscriber tidak melihat update yang di-rollback
+	if deactivated {
+		s.events.Publish(ctx, webhook.NewEvent(webhook.EventUserDeactivated, res))
+	}
+
+	return res, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
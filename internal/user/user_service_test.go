@@ -0,0 +1,240 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	db "go-mini-erp/internal/shared/database/sqlc"
+	"go-mini-erp/internal/shared/util/dbutil"
+	"go-mini-erp/internal/shared/webhook"
+	"go-mini-erp/internal/user"
+	"go-mini-erp/internal/user/mocks"
+)
+
+// fakeTx menjalankan fn langsung dan mencatat error yang membatalkan tx
+type fakeTx struct {
+	rolledBack bool
+}
+
+func (f *fakeTx) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	err := fn(nil)
+	f.rolledBack = err != nil
+	return err
+}
+
+// publisherStub mencatat event yang di-publish
+type publisherStub struct {
+	events []webhook.Event
+}
+
+func (p *publisherStub) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestExportUsers_KeysetBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(mockRepo, &fakeTx{}, nil)
+
+	first := make([]db.ListUsersForExportRow, 500)
+	for i := range first {
+		first[i] = db.ListUsersForExportRow{ID: uuid.New(), Username: fmt.Sprintf("user%03d", i)}
+	}
+	second := []db.ListUsersForExportRow{{ID: uuid.New(), Username: "zeta", RoleCodes: []string{"admin"}}}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			ListUsersForExport(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.ListUsersForExportParams) ([]db.ListUsersForExportRow, error) {
+				assert.Nil(t, arg.AfterUsername)
+				assert.Equal(t, "admin", *arg.RoleCode)
+				return first, nil
+			}),
+		mockRepo.EXPECT().
+			ListUsersForExport(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.ListUsersForExportParams) ([]db.ListUsersForExportRow, error) {
+				assert.Equal(t, "user499", *arg.AfterUsername)
+				return second, nil
+			}),
+	)
+
+	var total int
+	err := svc.ExportUsers(context.Background(), user.ListUsersFilter{RoleCode: " admin "}, func(batch []user.UserSummary) error {
+		total += len(batch)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 501, total)
+}
+
+func existingUser(id uuid.UUID) db.GetUserForUpdateRow {
+	return db.GetUserForUpdateRow{
+		ID:       id,
+		Username: "jdoe",
+		Email:    "jdoe@example.com",
+		FullName: "John Doe",
+		IsActive: dbutil.BoolPtr(true),
+	}
+}
+
+func TestUpdateUser_Partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(mockRepo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), id).Return(existingUser(id), nil)
+	// hanya fullName yang berubah
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), db.UpdateUserParams{
+		ID:       id,
+		Email:    "jdoe@example.com",
+		FullName: "Johnny Doe",
+		IsActive: dbutil.BoolPtr(true),
+	}).Return(db.UpdateUserRow{ID: id, Username: "jdoe", Email: "jdoe@example.com", FullName: "Johnny Doe", IsActive: dbutil.BoolPtr(true)}, nil)
+
+	res, err := svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{FullName: dbutil.Ptr("Johnny Doe")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Johnny Doe", res.FullName)
+	assert.True(t, res.IsActive)
+}
+
+func TestUpdateUser_Full(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(mockRepo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	want := db.UpdateUserParams{
+		ID:       id,
+		Email:    "john@example.com",
+		FullName: "Johnny Doe",
+		IsActive: dbutil.BoolPtr(false),
+	}
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), id).Return(existingUser(id), nil)
+	mockRepo.EXPECT().LockAdminRole(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CountActiveAdmins(gomock.Any(), id).Return(db.CountActiveAdminsRow{Remaining: 1}, nil)
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), want).
+		Return(db.UpdateUserRow{ID: id, Email: want.Email, FullName: want.FullName, IsActive: want.IsActive}, nil)
+
+	res, err := svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{
+		Email:    dbutil.Ptr("john@example.com"),
+		FullName: dbutil.Ptr("Johnny Doe"),
+		IsActive: dbutil.BoolPtr(false),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "john@example.com", res.Email)
+	assert.False(t, res.IsActive)
+}
+
+func TestUpdateUser_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(mockRepo, &fakeTx{}, nil)
+
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), gomock.Any()).Return(db.GetUserForUpdateRow{}, pgx.ErrNoRows)
+
+	_, err := svc.UpdateUser(context.Background(), uuid.New(), user.UpdateUserRequest{})
+
+	assert.ErrorIs(t, err, user.ErrUserNotFound)
+}
+
+func TestUpdateUser_DeactivateLastAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	tx := &fakeTx{}
+	svc := user.NewService(mockRepo, tx, nil)
+
+	id := uuid.New()
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), id).Return(existingUser(id), nil)
+	// role admin dikunci sebelum dihitung, di dalam transaksi yang sama
+	gomock.InOrder(
+		mockRepo.EXPECT().LockAdminRole(gomock.Any()).Return(nil),
+		mockRepo.EXPECT().CountActiveAdmins(gomock.Any(), id).Return(db.CountActiveAdminsRow{IsAdmin: true, Remaining: 0}, nil),
+	)
+	// UpdateUser tidak boleh dipanggil
+
+	_, err := svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{IsActive: dbutil.BoolPtr(false)})
+
+	assert.ErrorIs(t, err, user.ErrLastAdmin)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestUpdateUser_DeactivateAdminWithAnotherLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(mockRepo, &fakeTx{}, nil)
+
+	id := uuid.New()
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), id).Return(existingUser(id), nil)
+	mockRepo.EXPECT().LockAdminRole(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CountActiveAdmins(gomock.Any(), id).Return(db.CountActiveAdminsRow{IsAdmin: true, Remaining: 1}, nil)
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(db.UpdateUserRow{ID: id}, nil)
+
+	_, err := svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{IsActive: dbutil.BoolPtr(false)})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateUser_DeactivatePublishesEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	svc := user.NewService(mockRepo, &fakeTx{}, events)
+
+	id := uuid.New()
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), id).Return(existingUser(id), nil)
+	mockRepo.EXPECT().LockAdminRole(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CountActiveAdmins(gomock.Any(), id).Return(db.CountActiveAdminsRow{}, nil)
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).
+		Return(db.UpdateUserRow{ID: id, Username: "jdoe", IsActive: dbutil.BoolPtr(false)}, nil)
+
+	_, err := svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{IsActive: dbutil.BoolPtr(false)})
+
+	assert.NoError(t, err)
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, webhook.EventUserDeactivated, events.events[0].Type)
+		assert.Equal(t, id, events.events[0].Data.(*user.UserResponse).ID)
+	}
+}
+
+func TestUpdateUser_NoEventUnlessDeactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockRepository(ctrl)
+	events := &publisherStub{}
+	svc := user.NewService(mockRepo, &fakeTx{}, events)
+
+	id := uuid.New()
+	// user sudah aktif; isActive=true bukan perubahan true→false
+	mockRepo.EXPECT().WithQuerier(gomock.Any()).Return(mockRepo).Times(2)
+	mockRepo.EXPECT().GetUserForUpdate(gomock.Any(), id).Return(existingUser(id), nil).Times(2)
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(db.UpdateUserRow{ID: id}, nil)
+
+	_, err := svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{IsActive: dbutil.BoolPtr(true)})
+	assert.NoError(t, err)
+
+	// deactivation yang gagal (email bentrok) tidak mengirim event
+	mockRepo.EXPECT().LockAdminRole(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CountActiveAdmins(gomock.Any(), id).Return(db.CountActiveAdminsRow{}, nil)
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(db.UpdateUserRow{}, &pgconn.PgError{Code: "23505"})
+
+	_, err = svc.UpdateUser(context.Background(), id, user.UpdateUserRequest{IsActive: dbutil.BoolPtr(false)})
+	assert.ErrorIs(t, err, user.ErrEmailExists)
+
+	assert.Empty(t, events.events)
+}